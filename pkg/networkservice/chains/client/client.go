@@ -27,7 +27,9 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientconn"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clienturl"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connectiontags"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/dial"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/handshake"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/refresh"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/trimpath"
@@ -52,9 +54,11 @@ func NewClient(ctx context.Context, clientOpts ...Option) networkservice.Network
 	return chain.NewNetworkServiceClient(
 		append(
 			[]networkservice.NetworkServiceClient{
-				updatepath.NewClient(opts.name),
+				updatepath.NewClient(opts.name, updatepath.WithIDGenerator(opts.idGenerator)),
 				begin.NewClient(),
 				metadata.NewClient(),
+				handshake.NewClient(handshake.WithCapabilities(opts.capabilities...)),
+				connectiontags.NewClient(),
 				opts.refreshClient,
 				clienturl.NewClient(opts.clientURL),
 				clientconn.NewClient(opts.cc),
@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
 )
 
 type clientOptions struct {
@@ -36,6 +37,8 @@ type clientOptions struct {
 	healClient              networkservice.NetworkServiceClient
 	dialOptions             []grpc.DialOption
 	dialTimeout             time.Duration
+	capabilities            []string
+	idGenerator             idgen.Generator
 }
 
 // Option modifies default client chain values.
@@ -109,3 +112,17 @@ func WithoutRefresh() Option {
 		c.refreshClient = null.NewClient()
 	}
 }
+
+// WithCapabilities sets the capability flags announced to the peers
+func WithCapabilities(capabilities ...string) Option {
+	return func(c *clientOptions) {
+		c.capabilities = capabilities
+	}
+}
+
+// WithIDGenerator sets the generator of the connection and path segment IDs
+func WithIDGenerator(idGenerator idgen.Generator) Option {
+	return func(c *clientOptions) {
+		c.idGenerator = idGenerator
+	}
+}
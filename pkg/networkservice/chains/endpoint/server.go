@@ -34,13 +34,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	admissionserver "github.com/networkservicemesh/sdk/pkg/networkservice/common/admission"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/handshake"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/timeout"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatetoken"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/admission"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
 	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
 	"github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
@@ -66,6 +70,9 @@ type serverOptions struct {
 	authorizeServer                  networkservice.NetworkServiceServer
 	authorizeMonitorConnectionServer networkservice.MonitorConnectionServer
 	additionalFunctionality          []networkservice.NetworkServiceServer
+	admissionLimiter                 *admission.Limiter
+	capabilities                     []string
+	idGenerator                      idgen.Generator
 }
 
 // Option modifies server option value
@@ -105,6 +112,27 @@ func WithAdditionalFunctionality(additionalFunctionality ...networkservice.Netwo
 	}
 }
 
+// WithAdmissionLimiter sets the limiter admitting Requests, Close is never queued behind the waiting Requests
+func WithAdmissionLimiter(limiter *admission.Limiter) Option {
+	return func(o *serverOptions) {
+		o.admissionLimiter = limiter
+	}
+}
+
+// WithCapabilities sets the capability flags announced to the peers
+func WithCapabilities(capabilities ...string) Option {
+	return func(o *serverOptions) {
+		o.capabilities = capabilities
+	}
+}
+
+// WithIDGenerator sets the generator of the connection and path segment IDs
+func WithIDGenerator(idGenerator idgen.Generator) Option {
+	return func(o *serverOptions) {
+		o.idGenerator = idGenerator
+	}
+}
+
 // NewServer - returns a NetworkServiceMesh client as a chain of the standard Client pieces plus whatever
 func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...Option) Endpoint {
 	opts := &serverOptions{
@@ -117,18 +145,27 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	}
 	var mcsPtr networkservice.MonitorConnectionServer
 
+	var servers []networkservice.NetworkServiceServer
+	if opts.admissionLimiter != nil {
+		servers = append(servers, admissionserver.NewServer(opts.admissionLimiter))
+	}
+	servers = append(servers,
+		updatepath.NewServer(opts.name, updatepath.WithIDGenerator(opts.idGenerator)),
+		begin.NewServer(),
+		updatetoken.NewServer(tokenGenerator),
+		opts.authorizeServer,
+		metadata.NewServer(),
+		handshake.NewServer(handshake.WithCapabilities(opts.capabilities...)),
+		timeout.NewServer(ctx),
+		monitor.NewServer(ctx, &mcsPtr),
+		trimpath.NewServer(),
+	)
+
 	rv := &endpoint{}
-	rv.NetworkServiceServer = chain.NewNetworkServiceServer(
-		append([]networkservice.NetworkServiceServer{
-			updatepath.NewServer(opts.name),
-			begin.NewServer(),
-			updatetoken.NewServer(tokenGenerator),
-			opts.authorizeServer,
-			metadata.NewServer(),
-			timeout.NewServer(ctx),
-			monitor.NewServer(ctx, &mcsPtr),
-			trimpath.NewServer(),
-		}, opts.additionalFunctionality...)...)
+	rv.NetworkServiceServer = chain.NewNetworkServiceServer(append(servers, opts.additionalFunctionality...)...)
+	if err := chain.Validate(rv.NetworkServiceServer); err != nil {
+		panic(err.Error())
+	}
 	rv.MonitorConnectionServer = next.NewMonitorConnectionServer(opts.authorizeMonitorConnectionServer, mcsPtr)
 	return rv
 }
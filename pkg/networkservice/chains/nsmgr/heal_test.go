@@ -729,3 +729,113 @@ func testForwarderShouldBeSelectedCorrectlyOnNSMgrRestart(t *testing.T, nodeNum,
 		}, sandbox.GenerateTestToken)
 	}
 }
+
+func TestNSMGRHealEndpoint_Events(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	defer cancel()
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService(t.Name()))
+	require.NoError(t, err)
+
+	nseReg := defaultRegistryEndpoint(nsReg.Name)
+
+	counter := new(count.Server)
+	nse := domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken, counter)
+
+	eventCh := make(chan heal.Event, 10)
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken,
+		nsclient.WithHealClient(heal.NewClient(ctx,
+			heal.WithOnHeal(func(event heal.Event) { eventCh <- event }),
+			heal.WithOnRestore(func(event heal.Event) { eventCh <- event }))))
+
+	conn, err := nsc.Request(ctx, defaultRequest(nsReg.Name))
+	require.NoError(t, err)
+
+	nse.Cancel()
+
+	var healEvent heal.Event
+	select {
+	case healEvent = <-eventCh:
+	case <-ctx.Done():
+		require.FailNow(t, "no heal event received")
+	}
+	require.Equal(t, conn.GetId(), healEvent.ConnectionID)
+	require.Equal(t, heal.ReasonControlPlaneDown, healEvent.Reason)
+	require.Zero(t, healEvent.Attempts)
+
+	nseReg2 := defaultRegistryEndpoint(nsReg.Name)
+	nseReg2.Name += "-2"
+	domain.Nodes[0].NewEndpoint(ctx, nseReg2, sandbox.GenerateTestToken, counter)
+
+	var restoreEvent heal.Event
+	select {
+	case restoreEvent = <-eventCh:
+	case <-ctx.Done():
+		require.FailNow(t, "no heal event received")
+	}
+	require.Equal(t, conn.GetId(), restoreEvent.ConnectionID)
+	require.Equal(t, heal.ReasonControlPlaneDown, restoreEvent.Reason)
+	require.Positive(t, restoreEvent.Attempts)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestNSMGRHealEndpoint_CloseDuringBackoff(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	defer cancel()
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService(t.Name()))
+	require.NoError(t, err)
+
+	nseReg := defaultRegistryEndpoint(nsReg.Name)
+
+	counter := new(count.Server)
+	nse := domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken, counter)
+
+	eventCh := make(chan heal.Event, 10)
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken,
+		nsclient.WithHealClient(heal.NewClient(ctx,
+			heal.WithBackoff(heal.Backoff{InitialInterval: 300 * time.Millisecond, Multiplier: 1}),
+			heal.WithOnHeal(func(event heal.Event) { eventCh <- event }),
+			heal.WithOnRestore(func(event heal.Event) { eventCh <- event }))))
+
+	conn, err := nsc.Request(ctx, defaultRequest(nsReg.Name))
+	require.NoError(t, err)
+
+	nse.Cancel()
+
+	select {
+	case <-eventCh:
+	case <-ctx.Done():
+		require.FailNow(t, "no heal event received")
+	}
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+
+	nseReg2 := defaultRegistryEndpoint(nsReg.Name)
+	nseReg2.Name += "-2"
+	domain.Nodes[0].NewEndpoint(ctx, nseReg2, sandbox.GenerateTestToken, counter)
+
+	// Closed connection is neither re-requested nor reported as restored
+	require.Never(t, func() bool { return len(eventCh) > 0 || counter.Requests() > 1 }, time.Second, tick)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmgr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+func TestNSMGR_LocalBypass(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const bypassedService = "bypassed-ns"
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetRegistryProxySupplier(nil).
+		SetNSMgrProxySupplier(nil).
+		SetNSMgrSupplier(func(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...nsmgr.Option) nsmgr.Nsmgr {
+			return nsmgr.NewServer(ctx, tokenGenerator, append(options, nsmgr.WithLocalBypass(bypassedService))...)
+		}).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+	for _, name := range []string{bypassedService, t.Name()} {
+		nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService(name))
+		require.NoError(t, err)
+		domain.Nodes[0].NewEndpoint(ctx, defaultRegistryEndpoint(nsReg.Name), sandbox.GenerateTestToken)
+	}
+
+	nseCounter := new(count.Server)
+	nseReg := defaultRegistryEndpoint(bypassedService)
+	nseReg.Name = "bypassed-nse"
+	domain.Nodes[0].NewEndpoint(ctx, nseReg, sandbox.GenerateTestToken, nseCounter)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+
+	// The forwarder is skipped for the enabled network service
+	request := defaultRequest(bypassedService)
+	request.Connection.NetworkServiceEndpointName = nseReg.Name
+	conn, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Len(t, conn.GetPath().GetPathSegments(), 3)
+	require.Equal(t, nseReg.Name, conn.GetPath().GetPathSegments()[2].GetName())
+	require.Equal(t, 1, nseCounter.Requests())
+
+	request.Connection = conn.Clone()
+	conn, err = nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Len(t, conn.GetPath().GetPathSegments(), 3)
+	require.Equal(t, 2, nseCounter.Requests())
+	require.Equal(t, 1, nseCounter.UniqueRequests())
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, nseCounter.Closes())
+
+	// Other network services still go via the forwarder
+	conn, err = nsc.Request(ctx, defaultRequest(t.Name()))
+	require.NoError(t, err)
+	require.Len(t, conn.GetPath().GetPathSegments(), 4)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmgr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/nsmgr"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discoverforwarder"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+func TestNSMGR_ForwarderMigration(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	migrator := discoverforwarder.NewMigrator()
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetRegistryProxySupplier(nil).
+		SetNSMgrProxySupplier(nil).
+		SetNSMgrSupplier(func(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...nsmgr.Option) nsmgr.Nsmgr {
+			return nsmgr.NewServer(ctx, tokenGenerator, append(options, nsmgr.WithForwarderMigrator(migrator))...)
+		}).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService(t.Name()))
+	require.NoError(t, err)
+
+	nseCounter := new(count.Server)
+	domain.Nodes[0].NewEndpoint(ctx, defaultRegistryEndpoint(nsReg.Name), sandbox.GenerateTestToken, nseCounter)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+
+	request := defaultRequest(nsReg.Name)
+	conn, err := nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Len(t, conn.GetPath().GetPathSegments(), 4)
+
+	forwarderCounter := new(count.Server)
+	domain.Nodes[0].NewForwarder(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "forwarder-new",
+		NetworkServiceNames: []string{"forwarder"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"forwarder": {Labels: map[string]string{"p2p": "true"}},
+		},
+	}, sandbox.GenerateTestToken, forwarderCounter)
+
+	// Connection is established via the new forwarder before it is closed via the old one
+	require.NoError(t, migrator.Migrate(ctx, conn.GetPath().GetPathSegments()[1].GetId(), "forwarder-new"))
+	require.Equal(t, 1, forwarderCounter.Requests())
+	require.Equal(t, 2, nseCounter.UniqueRequests())
+	require.Equal(t, 1, nseCounter.Closes())
+
+	// Refresh with the path via the old forwarder is sent via the new one
+	request.Connection = conn.Clone()
+	conn, err = nsc.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, "forwarder-new", conn.GetPath().GetPathSegments()[2].GetName())
+	require.Equal(t, 2, forwarderCounter.Requests())
+	require.Equal(t, 1, forwarderCounter.UniqueRequests())
+	require.Equal(t, 2, nseCounter.UniqueRequests())
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, forwarderCounter.Closes())
+	require.Equal(t, 2, nseCounter.UniqueCloses())
+
+	require.Error(t, migrator.Migrate(ctx, conn.GetPath().GetPathSegments()[1].GetId(), "forwarder-new"))
+}
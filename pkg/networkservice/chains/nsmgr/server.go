@@ -38,11 +38,15 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discoverforwarder"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/excludedprefixes"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/localbypass"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/recvfd"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/sendfd"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/metrics"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/recovery"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/serviceacl"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry"
+	registryadmission "github.com/networkservicemesh/sdk/pkg/registry/common/admission"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
 	registryclientinfo "github.com/networkservicemesh/sdk/pkg/registry/common/clientinfo"
@@ -50,13 +54,14 @@ import (
 	registryconnect "github.com/networkservicemesh/sdk/pkg/registry/common/connect"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/dial"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
-	"github.com/networkservicemesh/sdk/pkg/registry/common/localbypass"
+	registrylocalbypass "github.com/networkservicemesh/sdk/pkg/registry/common/localbypass"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
 	registryrecvfd "github.com/networkservicemesh/sdk/pkg/registry/common/recvfd"
 	registrysendfd "github.com/networkservicemesh/sdk/pkg/registry/common/sendfd"
 
 	registryadapter "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/admission"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
@@ -83,6 +88,10 @@ type serverOptions struct {
 	name                             string
 	url                              string
 	forwarderServiceName             string
+	serviceACLOptions                []serviceacl.Option
+	forwarderMigrator                *discoverforwarder.Migrator
+	admissionLimiter                 *admission.Limiter
+	localBypassServices              []string
 }
 
 // Option modifies server option value
@@ -152,6 +161,37 @@ func WithURL(u string) Option {
 	}
 }
 
+// WithServiceACL sets network service ACL checked before the discovery
+func WithServiceACL(serviceACLOptions ...serviceacl.Option) Option {
+	return func(o *serverOptions) {
+		o.serviceACLOptions = serviceACLOptions
+	}
+}
+
+// WithForwarderMigrator sets Migrator used to migrate connections between the node forwarders
+func WithForwarderMigrator(migrator *discoverforwarder.Migrator) Option {
+	return func(o *serverOptions) {
+		o.forwarderMigrator = migrator
+	}
+}
+
+// WithAdmissionLimit limits the number of in-flight Requests and Registers. Close and Unregister are never queued
+// behind them, so resources are released promptly when Nsmgr is saturated. Limit <= 0 means no limit.
+func WithAdmissionLimit(limit int) Option {
+	return func(o *serverOptions) {
+		o.admissionLimiter = admission.NewLimiter(limit)
+	}
+}
+
+// WithLocalBypass enables requesting the local endpoints directly, without the forwarder, for the network services.
+// It should be used only for the network services the endpoints of which support the client mechanisms, e.g. for the
+// purely local composition services.
+func WithLocalBypass(networkServices ...string) Option {
+	return func(o *serverOptions) {
+		o.localBypassServices = networkServices
+	}
+}
+
 var _ Nsmgr = (*nsmgrServer)(nil)
 
 // NewServer - Creates a new Nsmgr
@@ -163,6 +203,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 		authorizeMonitorConnectionServer: authmonitor.NewMonitorConnectionServer(authmonitor.Any()),
 		name:                             "nsmgr-" + uuid.New().String(),
 		forwarderServiceName:             "forwarder",
+		admissionLimiter:                 admission.NewLimiter(0),
 	}
 	for _, opt := range options {
 		opt(opts)
@@ -190,7 +231,7 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	)
 
 	var remoteOrLocalRegistry = chain.NewNetworkServiceEndpointRegistryServer(
-		localbypass.NewNetworkServiceEndpointRegistryServer(opts.url),
+		registrylocalbypass.NewNetworkServiceEndpointRegistryServer(opts.url),
 		registryconnect.NewNetworkServiceEndpointRegistryServer(
 			chain.NewNetworkServiceEndpointRegistryClient(
 				begin.NewNetworkServiceEndpointRegistryClient(),
@@ -207,11 +248,12 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	if opts.regURL == nil {
 		remoteOrLocalRegistry = chain.NewNetworkServiceEndpointRegistryServer(
 			memory.NewNetworkServiceEndpointRegistryServer(),
-			localbypass.NewNetworkServiceEndpointRegistryServer(opts.url),
+			registrylocalbypass.NewNetworkServiceEndpointRegistryServer(opts.url),
 		)
 	}
 
 	var nseRegistry = chain.NewNetworkServiceEndpointRegistryServer(
+		registryadmission.NewNetworkServiceEndpointRegistryServer(opts.admissionLimiter),
 		begin.NewNetworkServiceEndpointRegistryServer(),
 		registryclientinfo.NewNetworkServiceEndpointRegistryServer(),
 		expire.NewNetworkServiceEndpointRegistryServer(ctx, time.Minute),
@@ -223,16 +265,14 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	// Construct Endpoint
 	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator,
 		endpoint.WithName(opts.name),
+		endpoint.WithAdmissionLimiter(opts.admissionLimiter),
 		endpoint.WithAuthorizeServer(opts.authorizeServer),
 		endpoint.WithAuthorizeMonitorConnectionServer(opts.authorizeMonitorConnectionServer),
 		endpoint.WithAdditionalFunctionality(
+			recovery.NewServer(),
 			adapters.NewClientToServer(clientinfo.NewClient()),
-			discoverforwarder.NewServer(
-				registryadapter.NetworkServiceServerToClient(nsRegistry),
-				registryadapter.NetworkServiceEndpointServerToClient(remoteOrLocalRegistry),
-				discoverforwarder.WithForwarderServiceName(opts.forwarderServiceName),
-				discoverforwarder.WithNSMgrURL(opts.url),
-			),
+			serviceacl.NewServer(ctx, opts.serviceACLOptions...),
+			newDiscoverServer(opts, nsRegistry, remoteOrLocalRegistry),
 			excludedprefixes.NewServer(ctx),
 			recvfd.NewServer(), // Receive any files passed
 			metrics.NewServer(),
@@ -260,6 +300,22 @@ func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options
 	return rv
 }
 
+// newDiscoverServer - creates the server selecting either the local endpoint for the local bypass or the forwarder
+func newDiscoverServer(opts *serverOptions, nsRegistry registryapi.NetworkServiceRegistryServer, nseRegistry registryapi.NetworkServiceEndpointRegistryServer) networkservice.NetworkServiceServer {
+	return localbypass.NewServer(
+		registryadapter.NetworkServiceEndpointServerToClient(nseRegistry),
+		discoverforwarder.NewServer(
+			registryadapter.NetworkServiceServerToClient(nsRegistry),
+			registryadapter.NetworkServiceEndpointServerToClient(nseRegistry),
+			discoverforwarder.WithForwarderServiceName(opts.forwarderServiceName),
+			discoverforwarder.WithNSMgrURL(opts.url),
+			discoverforwarder.WithMigrator(opts.forwarderMigrator),
+		),
+		localbypass.WithNSMgrURL(opts.url),
+		localbypass.WithNetworkServices(opts.localBypassServices...),
+	)
+}
+
 func (n *nsmgrServer) Register(s *grpc.Server) {
 	grpcutils.RegisterHealthServices(s, n, n.NetworkServiceEndpointRegistryServer(), n.NetworkServiceRegistryServer())
 	networkservice.RegisterNetworkServiceServer(s, n)
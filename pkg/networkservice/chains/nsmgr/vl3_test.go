@@ -117,6 +117,79 @@ func Test_NSC_ConnectsTo_vl3NSE(t *testing.T) {
 	}
 }
 
+func Test_NSC_ConnectsTo_vl3NSE_SRVAndTXT(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+
+	nsReg, err := nsRegistryClient.Register(ctx, defaultRegistryService("vl3"))
+	require.NoError(t, err)
+
+	nseReg := defaultRegistryEndpoint(nsReg.Name)
+
+	var serverPrefixCh = make(chan *ipam.PrefixResponse, 1)
+	defer close(serverPrefixCh)
+
+	serverPrefixCh <- &ipam.PrefixResponse{Prefix: "10.0.0.1/24"}
+
+	_ = domain.Nodes[0].NewEndpoint(
+		ctx,
+		nseReg,
+		sandbox.GenerateTestToken,
+		vl3.NewServer(ctx, serverPrefixCh),
+		vl3dns.NewServer(ctx,
+			func() net.IP { return net.ParseIP("127.0.0.1") },
+			vl3dns.WithDomainSchemes("{{ index .Labels \"podName\" }}.{{ .NetworkService }}."),
+			vl3dns.WithDNSPort(40053)),
+	)
+
+	resolver := net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, "127.0.0.1:40053")
+		},
+	}
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+
+	req := defaultRequest(nsReg.Name)
+	req.Connection.Labels["podName"] = "nsc"
+	req.Connection.Labels[vl3dns.SRVLabelPrefix+"_http._tcp"] = "8080"
+	req.Connection.Labels[vl3dns.TXTLabelPrefix+"version"] = "1.2"
+
+	resp, err := nsc.Request(ctx, req)
+	require.NoError(t, err)
+
+	_, srvs, err := resolver.LookupSRV(ctx, "http", "tcp", "nsc.vl3")
+	require.NoError(t, err)
+	require.Len(t, srvs, 1)
+	require.Equal(t, "nsc.vl3.", srvs[0].Target)
+	require.Equal(t, uint16(8080), srvs[0].Port)
+
+	txts, err := resolver.LookupTXT(ctx, "nsc.vl3")
+	require.NoError(t, err)
+	require.Equal(t, []string{"version=1.2"}, txts)
+
+	_, err = nsc.Close(ctx, resp)
+	require.NoError(t, err)
+
+	_, _, err = resolver.LookupSRV(ctx, "http", "tcp", "nsc.vl3")
+	require.Error(t, err)
+
+	_, err = resolver.LookupTXT(ctx, "nsc.vl3")
+	require.Error(t, err)
+}
+
 func Test_vl3NSE_ConnectsTo_vl3NSE(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 
@@ -35,8 +35,10 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clusterinfo"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/contextsize"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/interdomainbypass"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/scrublabels"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/swapip"
 	"github.com/networkservicemesh/sdk/pkg/registry"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
@@ -75,6 +77,8 @@ type serverOptions struct {
 	authorizeMonitorConnectionServer networkservice.MonitorConnectionServer
 	dialOptions                      []grpc.DialOption
 	dialTimeout                      time.Duration
+	scrubLabelsOptions               []scrublabels.Option
+	contextSizeOptions               []contextsize.Option
 }
 
 func (s *serverOptions) openMapIPChannel(ctx context.Context) <-chan map[string]string {
@@ -157,7 +161,24 @@ func WithDialTimeout(dialTimeout time.Duration) Option {
 	}
 }
 
+// WithLabelScrubbing sets the policy for scrubbing labels and path details of the connections crossing the domain boundary
+func WithLabelScrubbing(scrubLabelsOptions ...scrublabels.Option) Option {
+	return func(o *serverOptions) {
+		o.scrubLabelsOptions = scrubLabelsOptions
+	}
+}
+
+// WithContextSizeLimits sets the connection context size limits and the compression of the large requests crossing the
+// domain boundary. Default limits are contextsize.DefaultMaxContextSize, contextsize.DefaultMaxExtraContextSize, the
+// compression is disabled.
+func WithContextSizeLimits(contextSizeOptions ...contextsize.Option) Option {
+	return func(o *serverOptions) {
+		o.contextSizeOptions = contextSizeOptions
+	}
+}
+
 // NewServer creates new proxy NSMgr
+// The dials to the remote domains are limited by the diallimit limiter from ctx, if any.
 func NewServer(ctx context.Context, regURL, proxyURL *url.URL, tokenGenerator token.GeneratorFunc, options ...Option) nsmgr.Nsmgr {
 	rv := new(nsmgrProxyServer)
 	opts := &serverOptions{
@@ -200,9 +221,11 @@ func NewServer(ctx context.Context, regURL, proxyURL *url.URL, tokenGenerator to
 		endpoint.WithAuthorizeMonitorConnectionServer(opts.authorizeMonitorConnectionServer),
 		endpoint.WithAdditionalFunctionality(
 			interdomainbypass.NewServer(&interdomainBypassNSEServer, opts.listenOn),
+			contextsize.NewServer(opts.contextSizeOptions...),
 			discover.NewServer(nsClient, nseClient),
 			swapip.NewServer(opts.openMapIPChannel(ctx)),
 			clusterinfo.NewServer(),
+			scrublabels.NewServer(opts.scrubLabelsOptions...),
 			connect.NewServer(
 				client.NewClient(
 					ctx,
@@ -212,6 +235,7 @@ func NewServer(ctx context.Context, regURL, proxyURL *url.URL, tokenGenerator to
 					client.WithoutRefresh(),
 					client.WithAdditionalFunctionality(
 						swapip.NewClient(opts.openMapIPChannel(ctx)),
+						contextsize.NewClient(opts.contextSizeOptions...),
 					),
 				),
 			),
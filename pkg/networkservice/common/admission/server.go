@@ -0,0 +1,58 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission provides a chain element limiting the number of in-flight Requests, Close is never queued
+// behind the waiting Requests so resources are released promptly under the overload.
+package admission
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/admission"
+)
+
+type admissionServer struct {
+	limiter *admission.Limiter
+}
+
+// NewServer - returns a new admission server chain element. It should be placed before begin, so the Requests waiting
+// for admission don't hold the begin executor and the Close for the same connection can proceed.
+// The limiter could be shared with other chain elements to admit their operations together.
+func NewServer(limiter *admission.Limiter) networkservice.NetworkServiceServer {
+	return &admissionServer{
+		limiter: limiter,
+	}
+}
+
+func (s *admissionServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	release, err := s.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *admissionServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	defer s.limiter.AcquirePriority()()
+
+	return next.Server(ctx).Close(ctx, conn)
+}
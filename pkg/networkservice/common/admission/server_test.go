@@ -0,0 +1,101 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/admission"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	admissiontools "github.com/networkservicemesh/sdk/pkg/tools/admission"
+)
+
+type blockingServer struct {
+	blockID string
+	unblock chan struct{}
+}
+
+func (s *blockingServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if request.GetConnection().GetId() == s.blockID {
+		<-s.unblock
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *blockingServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestAdmissionServer_CloseIsNotQueued(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	limiter := admissiontools.NewLimiter(1)
+	blocking := &blockingServer{
+		blockID: "blocked",
+		unblock: make(chan struct{}),
+	}
+	server := chain.NewNetworkServiceServer(
+		admission.NewServer(limiter),
+		begin.NewServer(),
+		blocking,
+	)
+
+	conn, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "established"},
+	})
+	require.NoError(t, err)
+
+	blockedErr := make(chan error, 1)
+	go func() {
+		_, requestErr := server.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "blocked"},
+		})
+		blockedErr <- requestErr
+	}()
+	require.Eventually(t, func() bool { return limiter.InFlight() == 1 }, time.Second, time.Millisecond)
+
+	queuedErr := make(chan error, 1)
+	go func() {
+		_, requestErr := server.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "queued"},
+		})
+		queuedErr <- requestErr
+	}()
+	require.Eventually(t, func() bool { return limiter.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	// Close is processed while the limit is exhausted and a Request is waiting
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, limiter.Waiting())
+
+	close(blocking.unblock)
+	require.NoError(t, <-blockedErr)
+	require.NoError(t, <-queuedErr)
+	require.Equal(t, 0, limiter.InFlight())
+}
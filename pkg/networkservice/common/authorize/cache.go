@@ -0,0 +1,155 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+type decisionKey struct {
+	identity string
+	digest   string
+	version  uint64
+}
+
+type decision struct {
+	err        error
+	expireTime time.Time
+}
+
+// DecisionCache caches authorization decisions by (peer identity, request digest, policy bundle version) for a short
+// TTL. Invalidate should be called on policies reload, WatchPolicyFiles does it for the file policies.
+//
+// Request digest is calculated over the whole path including tokens and their expiration times, so the request with
+// the other token is always evaluated by the policies and never gets the decision made for the previous token. The
+// cache still should not be used together with the replay protection (spiffejwt.ReplayPolicy), because the replayed
+// token gets the cached decision.
+type DecisionCache struct {
+	clock     clock.Clock
+	ttl       time.Duration
+	version   uint64
+	lastSweep time.Time
+	decisions map[decisionKey]*decision
+	mu        sync.Mutex
+}
+
+// NewDecisionCache creates a new DecisionCache with the given TTL
+func NewDecisionCache(ctx context.Context, ttl time.Duration) *DecisionCache {
+	return &DecisionCache{
+		clock:     clock.FromContext(ctx),
+		ttl:       ttl,
+		decisions: make(map[decisionKey]*decision),
+	}
+}
+
+// Invalidate drops all cached decisions and increments the policy bundle version
+func (c *DecisionCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	c.decisions = make(map[decisionKey]*decision)
+}
+
+func (c *DecisionCache) check(ctx context.Context, policies *policiesList, path *networkservice.Path) error {
+	if c == nil {
+		return policies.check(ctx, path)
+	}
+
+	key, ok := c.key(ctx, path)
+	if !ok {
+		return policies.check(ctx, path)
+	}
+
+	c.mu.Lock()
+	now := c.clock.Now()
+	if d, ok := c.decisions[key]; ok {
+		if d.expireTime.After(now) {
+			c.mu.Unlock()
+			return d.err
+		}
+		delete(c.decisions, key)
+	}
+	c.mu.Unlock()
+
+	err := policies.check(ctx, path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep(now)
+	if key.version == c.version {
+		c.decisions[key] = &decision{
+			err:        err,
+			expireTime: now.Add(c.ttl),
+		}
+	}
+
+	return err
+}
+
+// sweep drops expired decisions not more often than once per TTL. Must be called under c.mu.
+func (c *DecisionCache) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < c.ttl {
+		return
+	}
+	c.lastSweep = now
+	for k, d := range c.decisions {
+		if !d.expireTime.After(now) {
+			delete(c.decisions, k)
+		}
+	}
+}
+
+func (c *DecisionCache) key(ctx context.Context, path *networkservice.Path) (decisionKey, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return decisionKey{}, false
+	}
+	cert := opa.ParseX509Cert(p.AuthInfo)
+	if cert == nil {
+		return decisionKey{}, false
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(path)
+	if err != nil {
+		return decisionKey{}, false
+	}
+
+	identity := sha256.Sum256(cert.Raw)
+	digest := sha256.Sum256(data)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return decisionKey{
+		identity: hex.EncodeToString(identity[:]),
+		digest:   hex.EncodeToString(digest[:]),
+		version:  c.version,
+	}, true
+}
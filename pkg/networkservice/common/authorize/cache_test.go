@@ -0,0 +1,170 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+type countPolicy struct {
+	count int
+}
+
+func (p *countPolicy) Check(_ context.Context, _ interface{}) error {
+	p.count++
+	return nil
+}
+
+func withPeerCert(ctx context.Context, raw string) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{Raw: []byte(raw)}},
+			},
+		},
+	})
+}
+
+func newCacheRequest(token string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{
+				Index:        1,
+				PathSegments: []*networkservice.PathSegment{{Name: "nsc", Token: token}, {Name: "nsmgr", Token: token}},
+			},
+		},
+	}
+}
+
+func TestAuthorize_DecisionCache(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	policy := new(countPolicy)
+	cache := authorize.NewDecisionCache(ctx, time.Second)
+	server := authorize.NewServer(authorize.WithPolicies(policy), authorize.WithDecisionCache(cache))
+
+	request := func(ctx context.Context, token string) {
+		_, err := server.Request(ctx, newCacheRequest(token))
+		require.NoError(t, err)
+	}
+
+	request(withPeerCert(ctx, "nsc-1"), "1")
+	request(withPeerCert(ctx, "nsc-1"), "1")
+	require.Equal(t, 1, policy.count)
+
+	// The other token is evaluated by the policies
+	request(withPeerCert(ctx, "nsc-1"), "2")
+	require.Equal(t, 2, policy.count)
+
+	request(withPeerCert(ctx, "nsc-2"), "1")
+	require.Equal(t, 3, policy.count)
+
+	cache.Invalidate()
+	request(withPeerCert(ctx, "nsc-1"), "1")
+	require.Equal(t, 4, policy.count)
+
+	clockMock.Add(time.Second)
+	request(withPeerCert(ctx, "nsc-1"), "1")
+	require.Equal(t, 5, policy.count)
+}
+
+type tokenPolicy struct {
+	valid string
+}
+
+func (p *tokenPolicy) Check(_ context.Context, model interface{}) error {
+	if model.(*networkservice.Path).GetPathSegments()[0].GetToken() != p.valid {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+func TestAuthorize_DecisionCache_Tokens(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cache := authorize.NewDecisionCache(ctx, time.Minute)
+	server := authorize.NewServer(authorize.WithPolicies(&tokenPolicy{valid: "valid"}), authorize.WithDecisionCache(cache))
+
+	ctx = withPeerCert(ctx, "nsc-1")
+
+	_, err := server.Request(ctx, newCacheRequest("valid"))
+	require.NoError(t, err)
+
+	// Forged token doesn't get the cached allow
+	_, err = server.Request(ctx, newCacheRequest("forged"))
+	require.Error(t, err)
+
+	// Cached denial doesn't reject the valid token
+	_, err = server.Request(ctx, newCacheRequest("valid"))
+	require.NoError(t, err)
+}
+
+func TestAuthorize_WatchPolicyFiles(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "allow.rego")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package test\n\ndefault allow = true\n"), os.ModePerm))
+
+	policy := opa.WithPolicyFromFile(path, "allow", opa.True)
+	cache := authorize.NewDecisionCache(ctx, time.Minute)
+	server := authorize.NewServer(authorize.WithPolicies(policy), authorize.WithDecisionCache(cache))
+
+	authorize.WatchPolicyFiles(ctx, cache, policy)
+
+	ctx = withPeerCert(ctx, "nsc-1")
+
+	_, err := server.Request(ctx, newCacheRequest("1"))
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("package test\n\ndefault allow = false\n"), os.ModePerm))
+
+	require.Eventually(t, func() bool {
+		_, err = server.Request(ctx, newCacheRequest("1"))
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
@@ -35,8 +35,9 @@ import (
 )
 
 type authorizeClient struct {
-	policies   policiesList
-	serverPeer atomic.Value
+	policies      policiesList
+	decisionCache *DecisionCache
+	serverPeer    atomic.Value
 }
 
 // NewClient - returns a new authorization networkservicemesh.NetworkServiceClient
@@ -54,7 +55,8 @@ func NewClient(opts ...Option) networkservice.NetworkServiceClient {
 		opt(o)
 	}
 	var result = &authorizeClient{
-		policies: o.policies,
+		policies:      o.policies,
+		decisionCache: o.decisionCache,
 	}
 	return result
 }
@@ -75,7 +77,7 @@ func (a *authorizeClient) Request(ctx context.Context, request *networkservice.N
 		ctx = peer.NewContext(ctx, &p)
 	}
 
-	if err = a.policies.check(ctx, conn.GetPath()); err != nil {
+	if err = a.decisionCache.check(ctx, &a.policies, conn.GetPath()); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -94,7 +96,7 @@ func (a *authorizeClient) Close(ctx context.Context, conn *networkservice.Connec
 	if ok && p != nil {
 		ctx = peer.NewContext(ctx, p)
 	}
-	if err := a.policies.check(ctx, conn.GetPath()); err != nil {
+	if err := a.decisionCache.check(ctx, &a.policies, conn.GetPath()); err != nil {
 		return nil, err
 	}
 
@@ -23,6 +23,7 @@ import "github.com/networkservicemesh/sdk/pkg/tools/spire"
 type options struct {
 	policies              policiesList
 	spiffeIDConnectionMap *spire.SpiffeIDConnectionMap
+	decisionCache         *DecisionCache
 }
 
 // Option is authorization option for network service server
@@ -46,3 +47,10 @@ func WithSpiffeIDConnectionMap(s *spire.SpiffeIDConnectionMap) Option {
 		o.spiffeIDConnectionMap = s
 	}
 }
+
+// WithDecisionCache sets cache for the authorization decisions
+func WithDecisionCache(c *DecisionCache) Option {
+	return func(o *options) {
+		o.decisionCache = c
+	}
+}
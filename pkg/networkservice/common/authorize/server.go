@@ -35,6 +35,7 @@ import (
 type authorizeServer struct {
 	policies              policiesList
 	spiffeIDConnectionMap *spire.SpiffeIDConnectionMap
+	decisionCache         *DecisionCache
 }
 
 // NewServer - returns a new authorization networkservicemesh.NetworkServiceServers
@@ -55,6 +56,7 @@ func NewServer(opts ...Option) networkservice.NetworkServiceServer {
 	var s = &authorizeServer{
 		policies:              o.policies,
 		spiffeIDConnectionMap: o.spiffeIDConnectionMap,
+		decisionCache:         o.decisionCache,
 	}
 	return s
 }
@@ -67,7 +69,7 @@ func (a *authorizeServer) Request(ctx context.Context, request *networkservice.N
 		PathSegments: conn.GetPath().GetPathSegments()[:index+1],
 	}
 	if _, ok := peer.FromContext(ctx); ok {
-		if err := a.policies.check(ctx, leftSide); err != nil {
+		if err := a.decisionCache.check(ctx, &a.policies, leftSide); err != nil {
 			return nil, err
 		}
 	}
@@ -110,7 +112,7 @@ func (a *authorizeServer) Close(ctx context.Context, conn *networkservice.Connec
 		}
 	}
 	if _, ok := peer.FromContext(ctx); ok {
-		if err := a.policies.check(ctx, leftSide); err != nil {
+		if err := a.decisionCache.check(ctx, &a.policies, leftSide); err != nil {
 			return nil, err
 		}
 	}
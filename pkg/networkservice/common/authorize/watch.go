@@ -0,0 +1,57 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorize
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+// WatchPolicyFiles reloads the file policies on the policy file changes and invalidates the decision cache, so the
+// decisions made by the previous policies are not used. The policies created from the source are not watched. The
+// watching stops on ctx done.
+func WatchPolicyFiles(ctx context.Context, cache *DecisionCache, policies ...*opa.AuthorizationPolicy) {
+	for _, policy := range policies {
+		if policy.FilePath() == "" {
+			continue
+		}
+
+		updateCh := fs.WatchFile(ctx, policy.FilePath())
+		// The current file content is already used by the policy
+		<-updateCh
+
+		go func(policy *opa.AuthorizationPolicy) {
+			logger := log.FromContext(ctx).WithField("authorize", "WatchPolicyFiles")
+			for source := range updateCh {
+				// Missing or being written file keeps the current policy
+				if len(source) == 0 {
+					continue
+				}
+				if err := policy.Reload(string(source)); err != nil {
+					logger.Errorf("invalid policy %s: %v", policy.FilePath(), err.Error())
+					continue
+				}
+				if cache != nil {
+					cache.Invalidate()
+				}
+			}
+		}(policy)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Rule describes a failure injected into the selected connections
+type Rule struct {
+	// NetworkService selects connections by the network service, empty value selects all network services
+	NetworkService string
+	// ConnectionID selects connections by the ID, empty value selects all connections
+	ConnectionID string
+	// Latency is a delay added before processing Request and Close
+	Latency time.Duration
+	// RequestError is an error returned from Request instead of calling the next element
+	RequestError error
+	// DropClose sets if Close should be dropped without calling the next element
+	DropClose bool
+}
+
+func (r *Rule) matches(conn *networkservice.Connection) bool {
+	return (r.NetworkService == "" || r.NetworkService == conn.GetNetworkService()) &&
+		(r.ConnectionID == "" || r.ConnectionID == conn.GetId())
+}
+
+// Controller is a runtime API for managing failures injected by the chaos chain elements. Zero value has no rules,
+// so nothing is injected.
+type Controller struct {
+	mu    sync.RWMutex
+	rules map[string]*Rule
+}
+
+// NewController creates a new Controller
+func NewController() *Controller {
+	return new(Controller)
+}
+
+// Set adds or replaces the rule with the given name. nil rule is ignored.
+func (c *Controller) Set(name string, rule *Rule) {
+	if rule == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rules == nil {
+		c.rules = make(map[string]*Rule)
+	}
+	c.rules[name] = rule
+}
+
+// Remove removes the rule with the given name
+func (c *Controller) Remove(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.rules, name)
+}
+
+// Clear removes all rules
+func (c *Controller) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules = nil
+}
+
+// Rules returns a copy of the current rules
+func (c *Controller) Rules() map[string]Rule {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rv := make(map[string]Rule, len(c.rules))
+	for name, rule := range c.rules {
+		rv[name] = *rule
+	}
+	return rv
+}
+
+// match returns the combined rule for the connection or nil if there are no matching rules. Latencies of the matching
+// rules are summed up, RequestError is taken from the first matching rule with the error in the rule names order.
+func (c *Controller) match(conn *networkservice.Connection) *Rule {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.rules) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(c.rules))
+	for name := range c.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rv *Rule
+	for _, name := range names {
+		rule := c.rules[name]
+		if !rule.matches(conn) {
+			continue
+		}
+		if rv == nil {
+			rv = new(Rule)
+		}
+		rv.Latency += rule.Latency
+		rv.DropClose = rv.DropClose || rule.DropClose
+		if rv.RequestError == nil {
+			rv.RequestError = rule.RequestError
+		}
+	}
+	return rv
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos provides chain elements injecting failures (errors, latency, dropped Close calls) into the selected
+// connections for controlled chaos experiments. Failures are managed at runtime with the Controller, elements do
+// nothing while the Controller has no rules.
+package chaos
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type chaosServer struct {
+	controller *Controller
+}
+
+// NewServer creates a new NetworkServiceServer chain element injecting failures managed by the controller
+func NewServer(controller *Controller) networkservice.NetworkServiceServer {
+	return &chaosServer{
+		controller: controller,
+	}
+}
+
+func (s *chaosServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if rule := s.controller.match(request.GetConnection()); rule != nil {
+		if err := delay(ctx, rule); err != nil {
+			return nil, err
+		}
+		if rule.RequestError != nil {
+			log.FromContext(ctx).WithField("chaosServer", "Request").Warnf("injecting error: %s", rule.RequestError.Error())
+			return nil, rule.RequestError
+		}
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *chaosServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if rule := s.controller.match(conn); rule != nil {
+		if err := delay(ctx, rule); err != nil {
+			return nil, err
+		}
+		if rule.DropClose {
+			log.FromContext(ctx).WithField("chaosServer", "Close").Warnf("dropping Close: %s", conn.GetId())
+			return &empty.Empty{}, nil
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func delay(ctx context.Context, rule *Rule) error {
+	if rule.Latency <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.FromContext(ctx).After(rule.Latency):
+		return nil
+	}
+}
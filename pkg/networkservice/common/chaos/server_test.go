@@ -0,0 +1,105 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/chaos"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func TestChaosServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	controller := chaos.NewController()
+	counter := new(count.Server)
+	server := next.NewNetworkServiceServer(chaos.NewServer(controller), counter)
+
+	selected := &networkservice.Connection{Id: "id-1", NetworkService: "ns-1"}
+	other := &networkservice.Connection{Id: "id-2", NetworkService: "ns-2"}
+
+	// No rules
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: selected})
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Requests())
+
+	controller.Set("error", &chaos.Rule{
+		NetworkService: "ns-1",
+		RequestError:   errors.New("injected"),
+		DropClose:      true,
+	})
+
+	_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: selected})
+	require.EqualError(t, err, "injected")
+	_, err = server.Close(ctx, selected)
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Requests())
+	require.Equal(t, 0, counter.Closes())
+
+	_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: other})
+	require.NoError(t, err)
+	require.Equal(t, 2, counter.Requests())
+
+	controller.Set("another-error", &chaos.Rule{
+		RequestError: errors.New("another"),
+	})
+	for i := 0; i < 10; i++ {
+		_, err = server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: selected})
+		require.EqualError(t, err, "another")
+	}
+
+	controller.Clear()
+	controller.Set("latency", &chaos.Rule{
+		ConnectionID: "id-2",
+		Latency:      time.Second,
+	})
+	controller.Set("nil", nil)
+	require.Len(t, controller.Rules(), 1)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := server.Close(ctx, other)
+		errCh <- err
+	}()
+
+	require.Never(t, func() bool { return counter.Closes() > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	// Close may register its timer after any single Add, so keep advancing the clock until it fires
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Second)
+		return counter.Closes() > 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, <-errCh)
+	require.Equal(t, 1, counter.Closes())
+}
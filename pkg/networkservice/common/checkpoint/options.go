@@ -0,0 +1,38 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import "github.com/networkservicemesh/sdk/pkg/tools/paramseal"
+
+// Option is an option pattern for NewStore
+type Option func(s *Store)
+
+// WithCodec sets the codec exporting and importing the metadata values of a chain element. The name identifies the
+// exported values, so it should be the same for the exporting and the importing instances.
+func WithCodec(name string, codec Codec) Option {
+	return func(s *Store) {
+		s.codecs[name] = codec
+	}
+}
+
+// WithSealer sets the sealer encrypting the sensitive mechanism parameters and the metadata values of the exported
+// connections, so the exported state doesn't expose them. The importing instance should use the same key.
+func WithSealer(sealer *paramseal.Sealer) Option {
+	return func(s *Store) {
+		s.sealer = sealer
+	}
+}
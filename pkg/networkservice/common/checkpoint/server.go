@@ -0,0 +1,68 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkpoint provides a chain element keeping the state of the connections in a Store, so control plane
+// components can checkpoint it to the external storage and resume the ownership of the connections after the
+// rescheduling.
+package checkpoint
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type checkpointServer struct {
+	store *Store
+}
+
+// NewServer creates a new checkpoint server keeping the state of the connections in the store. It restores the
+// server metadata of the imported connections, so it should be placed right after the metadata server.
+func NewServer(store *Store) networkservice.NetworkServiceServer {
+	return &checkpointServer{
+		store: store,
+	}
+}
+
+func (s *checkpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	m := metadata.Map(ctx, false)
+	if err := s.store.restore(ctx, request.GetConnection().GetId(), m); err != nil {
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var expirationTime time.Time
+	if expires := conn.GetPrevPathSegment().GetExpires(); expires != nil {
+		expirationTime = expires.AsTime()
+	}
+	s.store.track(conn, expirationTime, m)
+
+	return conn, nil
+}
+
+func (s *checkpointServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.store.untrack(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/checkpoint"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/paramseal"
+)
+
+type interfaceNameKey struct{}
+
+type interfaceNameCodec struct{}
+
+func (interfaceNameCodec) Marshal(m *sync.Map) ([]byte, error) {
+	if v, ok := m.Load(interfaceNameKey{}); ok {
+		return []byte(v.(string)), nil
+	}
+	return nil, nil
+}
+
+func (interfaceNameCodec) Unmarshal(m *sync.Map, data []byte) error {
+	m.Store(interfaceNameKey{}, string(data))
+	return nil
+}
+
+func newRequest(id string, expirationTime time.Time) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Expires: timestamppb.New(expirationTime)},
+					{Name: "nse"},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckpoint_ExportImport(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	store := checkpoint.NewStore(checkpoint.WithCodec("interface-name", interfaceNameCodec{}))
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkpoint.NewServer(store),
+		checkcontext.NewServer(t, func(_ *testing.T, ctx context.Context) {
+			metadata.Map(ctx, false).LoadOrStore(interfaceNameKey{}, "nsm-1")
+		}),
+	)
+
+	expirationTime := clockMock.Now().Add(time.Minute)
+	for _, id := range []string{"1", "2"} {
+		_, err := server.Request(ctx, newRequest(id, expirationTime))
+		require.NoError(t, err)
+	}
+	_, err := server.Close(ctx, newRequest("2", expirationTime).GetConnection())
+	require.NoError(t, err)
+
+	state, err := store.Export(ctx)
+	require.NoError(t, err)
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	imported := new(checkpoint.State)
+	require.NoError(t, json.Unmarshal(data, imported))
+	require.Len(t, imported.Connections, 1)
+	require.Equal(t, "1", imported.Connections[0].Connection.GetId())
+	require.True(t, expirationTime.Equal(imported.Connections[0].ExpirationTime))
+	require.Equal(t, "nsm-1", string(imported.Connections[0].Metadata["interface-name"]))
+
+	// The imported metadata is restored on the next refresh
+	newStore := checkpoint.NewStore(checkpoint.WithCodec("interface-name", interfaceNameCodec{}))
+	newStore.Import(ctx, imported)
+
+	var restored interface{}
+	newServer := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkpoint.NewServer(newStore),
+		checkcontext.NewServer(t, func(_ *testing.T, ctx context.Context) {
+			restored, _ = metadata.Map(ctx, false).Load(interfaceNameKey{})
+		}),
+	)
+
+	// Not refreshed connections are still exported
+	state, err = newStore.Export(ctx)
+	require.NoError(t, err)
+	require.Len(t, state.Connections, 1)
+
+	_, err = newServer.Request(ctx, newRequest("1", expirationTime))
+	require.NoError(t, err)
+	require.Equal(t, "nsm-1", restored)
+}
+
+func TestCheckpoint_ExpiredConnections(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	store := checkpoint.NewStore()
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkpoint.NewServer(store),
+	)
+
+	_, err := server.Request(ctx, newRequest("1", clockMock.Now().Add(time.Minute)))
+	require.NoError(t, err)
+
+	state, err := store.Export(ctx)
+	require.NoError(t, err)
+	require.Len(t, state.Connections, 1)
+
+	clockMock.Add(time.Minute)
+
+	state, err = store.Export(ctx)
+	require.NoError(t, err)
+	require.Empty(t, state.Connections)
+
+	newStore := checkpoint.NewStore()
+	newStore.Import(ctx, &checkpoint.State{
+		Connections: []*checkpoint.Connection{
+			{
+				Connection:     newRequest("1", clockMock.Now()).GetConnection(),
+				ExpirationTime: clockMock.Now(),
+			},
+		},
+	})
+	state, err = newStore.Export(ctx)
+	require.NoError(t, err)
+	require.Empty(t, state.Connections)
+}
+
+func TestCheckpoint_Sealed(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	key := paramseal.StaticKey([]byte("0123456789abcdef0123456789abcdef"))
+	newStore := func(keyProvider paramseal.KeyProvider) *checkpoint.Store {
+		return checkpoint.NewStore(
+			checkpoint.WithCodec("interface-name", interfaceNameCodec{}),
+			checkpoint.WithSealer(paramseal.NewSealer(keyProvider)),
+		)
+	}
+
+	store := newStore(key)
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		checkpoint.NewServer(store),
+		checkcontext.NewServer(t, func(_ *testing.T, ctx context.Context) {
+			metadata.Map(ctx, false).LoadOrStore(interfaceNameKey{}, "nsm-1")
+		}),
+	)
+
+	expirationTime := clockMock.Now().Add(time.Minute)
+	request := newRequest("1", expirationTime)
+	request.GetConnection().Mechanism = &networkservice.Mechanism{
+		Parameters: map[string]string{"privateKey": "private"},
+	}
+	_, err := server.Request(ctx, request)
+	require.NoError(t, err)
+
+	state, err := store.Export(ctx)
+	require.NoError(t, err)
+	require.Len(t, state.Connections, 1)
+	require.NotEqual(t, "nsm-1", string(state.Connections[0].Metadata["interface-name"]))
+	require.NotEqual(t, "private", state.Connections[0].Connection.GetMechanism().GetParameters()["privateKey"])
+
+	for _, tc := range []struct {
+		keyProvider paramseal.KeyProvider
+		restored    bool
+	}{
+		{keyProvider: key, restored: true},
+		{keyProvider: paramseal.RandomKey(), restored: false},
+	} {
+		imported := newStore(tc.keyProvider)
+		imported.Import(ctx, state)
+
+		var restored interface{}
+		_, err = chain.NewNetworkServiceServer(
+			metadata.NewServer(),
+			checkpoint.NewServer(imported),
+			checkcontext.NewServer(t, func(_ *testing.T, ctx context.Context) {
+				restored, _ = metadata.Map(ctx, false).Load(interfaceNameKey{})
+			}),
+		).Request(ctx, newRequest("1", expirationTime))
+		if tc.restored {
+			require.NoError(t, err)
+			require.Equal(t, "nsm-1", restored)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/paramseal"
+)
+
+// Codec exports and imports the metadata values of a chain element
+type Codec interface {
+	// Marshal returns the exported metadata values or nil if there is nothing to export
+	Marshal(m *sync.Map) ([]byte, error)
+	// Unmarshal imports the metadata values exported by Marshal
+	Unmarshal(m *sync.Map, data []byte) error
+}
+
+// Connection is the exported state of the connection
+type Connection struct {
+	Connection     *networkservice.Connection
+	ExpirationTime time.Time
+	// Metadata is the exported metadata values by the codec names
+	Metadata map[string][]byte
+}
+
+type connectionJSON struct {
+	Connection     json.RawMessage   `json:"connection"`
+	ExpirationTime time.Time         `json:"expirationTime"`
+	Metadata       map[string][]byte `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (c *Connection) MarshalJSON() ([]byte, error) {
+	conn, err := protojson.Marshal(c.Connection)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal connection %s", c.Connection.GetId())
+	}
+	return json.Marshal(&connectionJSON{
+		Connection:     conn,
+		ExpirationTime: c.ExpirationTime,
+		Metadata:       c.Metadata,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Connection) UnmarshalJSON(data []byte) error {
+	var v connectionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Wrap(err, "failed to unmarshal connection state")
+	}
+	c.Connection = new(networkservice.Connection)
+	if err := protojson.Unmarshal(v.Connection, c.Connection); err != nil {
+		return errors.Wrap(err, "failed to unmarshal connection")
+	}
+	c.ExpirationTime = v.ExpirationTime
+	c.Metadata = v.Metadata
+	return nil
+}
+
+// State is the exported state of the connections, it can be serialized with encoding/json
+type State struct {
+	Connections []*Connection `json:"connections"`
+}
+
+type entry struct {
+	conn           *networkservice.Connection
+	expirationTime time.Time
+	metadata       *sync.Map
+}
+
+// Store keeps the state of the connections handled by the checkpoint servers, so it can be exported to the external
+// storage and imported by another instance after the rescheduling.
+type Store struct {
+	codecs  map[string]Codec
+	sealer  *paramseal.Sealer
+	entries map[string]*entry
+	pending map[string]*Connection
+	mu      sync.Mutex
+}
+
+// NewStore creates a new Store
+func NewStore(opts ...Option) *Store {
+	s := &Store{
+		codecs:  make(map[string]Codec),
+		entries: make(map[string]*entry),
+		pending: make(map[string]*Connection),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Export returns the state of the established connections and of the imported connections not refreshed yet. Expired
+// connections are not exported.
+func (s *Store) Export(ctx context.Context) (*State, error) {
+	now := clock.FromContext(ctx).Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := new(State)
+	for _, e := range s.entries {
+		if !e.expirationTime.After(now) {
+			continue
+		}
+		c := &Connection{
+			Connection:     e.conn.Clone(),
+			ExpirationTime: e.expirationTime,
+			Metadata:       make(map[string][]byte),
+		}
+		if s.sealer != nil {
+			if err := s.sealer.SealConnection(c.Connection); err != nil {
+				return nil, errors.Wrapf(err, "failed to seal connection %s", e.conn.GetId())
+			}
+		}
+		for name, codec := range s.codecs {
+			data, err := codec.Marshal(e.metadata)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to export %s metadata of connection %s", name, e.conn.GetId())
+			}
+			if data == nil {
+				continue
+			}
+			if s.sealer != nil {
+				if data, err = s.sealer.Seal(data, sealingData(e.conn.GetId(), name)); err != nil {
+					return nil, errors.Wrapf(err, "failed to seal %s metadata of connection %s", name, e.conn.GetId())
+				}
+			}
+			c.Metadata[name] = data
+		}
+		state.Connections = append(state.Connections, c)
+	}
+	for _, c := range s.pending {
+		if c.ExpirationTime.After(now) {
+			state.Connections = append(state.Connections, c)
+		}
+	}
+	return state, nil
+}
+
+// Import imports the state exported by Export. The metadata of the imported connections is restored on their next
+// refresh, the connections expired before it are dropped.
+func (s *Store) Import(ctx context.Context, state *State) {
+	now := clock.FromContext(ctx).Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range state.Connections {
+		if c.ExpirationTime.After(now) {
+			s.pending[c.Connection.GetId()] = c
+		}
+	}
+}
+
+func (s *Store) restore(ctx context.Context, connID string, m *sync.Map) error {
+	s.mu.Lock()
+	c, ok := s.pending[connID]
+	delete(s.pending, connID)
+	s.mu.Unlock()
+
+	if !ok || !c.ExpirationTime.After(clock.FromContext(ctx).Now()) {
+		return nil
+	}
+	for name, data := range c.Metadata {
+		codec, ok := s.codecs[name]
+		if !ok {
+			continue
+		}
+		if s.sealer != nil {
+			var err error
+			if data, err = s.sealer.Open(data, sealingData(connID, name)); err != nil {
+				return errors.Wrapf(err, "failed to open %s metadata of connection %s", name, connID)
+			}
+		}
+		if err := codec.Unmarshal(m, data); err != nil {
+			return errors.Wrapf(err, "failed to import %s metadata of connection %s", name, connID)
+		}
+	}
+	return nil
+}
+
+// sealingData returns the additional data binding the sealed metadata value to the connection and the codec
+func sealingData(connID, name string) []byte {
+	return []byte(connID + "/" + name)
+}
+
+func (s *Store) track(conn *networkservice.Connection, expirationTime time.Time, m *sync.Map) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[conn.GetId()] = &entry{
+		conn:           conn.Clone(),
+		expirationTime: expirationTime,
+		metadata:       m,
+	}
+}
+
+func (s *Store) untrack(connID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, connID)
+	delete(s.pending, connID)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectionstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultInterval = 10 * time.Second
+
+type connectionStatsServer struct {
+	chainCtx context.Context
+	provider Provider
+	interval time.Duration
+}
+
+// NewServer creates a new connectionstats server storing the statistics from the provider into the current path
+// segment on Request and refreshing them periodically (every 10 seconds by default) with the monitor UPDATE events.
+// It should be placed after monitor and updatepath.
+func NewServer(chainCtx context.Context, provider Provider, opts ...Option) networkservice.NetworkServiceServer {
+	s := &connectionStatsServer{
+		chainCtx: chainCtx,
+		provider: provider,
+		interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *connectionStatsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+
+	logger := log.FromContext(ctx).WithField("connectionStatsServer", "Request")
+
+	segment := currentSegment(conn)
+	if segment == nil {
+		return conn, nil
+	}
+	stats, err := s.provider.Statistics(ctx, conn)
+	if err != nil {
+		logger.Warnf("failed to get the statistics of the connection %s: %s", conn.GetId(), err.Error())
+	} else {
+		Store(segment, stats)
+	}
+
+	eventConsumer, ok := monitor.LoadEventConsumer(ctx, false)
+	if !ok {
+		logger.Debug("eventConsumer is not presented")
+		return conn, nil
+	}
+
+	cancelCtx, cancel := context.WithCancel(s.chainCtx)
+	store(ctx, cancel)
+
+	ticker := clock.FromContext(ctx).Ticker(s.interval)
+	go func() {
+		defer ticker.Stop()
+		s.refresh(cancelCtx, ticker, eventConsumer, conn.Clone(), stats, logger)
+	}()
+
+	return conn, nil
+}
+
+func (s *connectionStatsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *connectionStatsServer) refresh(ctx context.Context, ticker clock.Ticker, eventConsumer monitor.EventConsumer,
+	conn *networkservice.Connection, stats *Statistics, logger log.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		newStats, err := s.provider.Statistics(ctx, conn)
+		if err != nil {
+			logger.Warnf("failed to get the statistics of the connection %s: %s", conn.GetId(), err.Error())
+			continue
+		}
+		if stats != nil && *newStats == *stats {
+			continue
+		}
+		stats = newStats
+
+		Store(currentSegment(conn), stats)
+		_ = eventConsumer.Send(&networkservice.ConnectionEvent{
+			Type:        networkservice.ConnectionEventType_UPDATE,
+			Connections: map[string]*networkservice.Connection{conn.GetId(): conn.Clone()},
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectionstats_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connectionstats"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+const interval = time.Second
+
+func TestConnectionStatsServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	var rxBytes uint64
+	provider := connectionstats.ProviderFunc(func(_ context.Context, _ *networkservice.Connection) (*connectionstats.Statistics, error) {
+		return &connectionstats.Statistics{RxBytes: atomic.LoadUint64(&rxBytes), TxPackets: 1}, nil
+	})
+
+	var monitorServer networkservice.MonitorConnectionServer
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		monitor.NewServer(ctx, &monitorServer),
+		updatepath.NewServer("forwarder"),
+		connectionstats.NewServer(ctx, provider, connectionstats.WithInterval(interval)),
+	)
+
+	receiver, err := adapters.NewMonitorServerToClient(monitorServer).MonitorConnections(ctx, new(networkservice.MonitorScopeSelector))
+	require.NoError(t, err)
+	_, err = receiver.Recv()
+	require.NoError(t, err)
+
+	conn, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+
+	stats, ok := connectionstats.Load(conn.GetPath().GetPathSegments()[0])
+	require.True(t, ok)
+	require.Equal(t, &connectionstats.Statistics{TxPackets: 1}, stats)
+	require.Equal(t, "1", conn.GetPath().GetPathSegments()[0].GetMetrics()[connectionstats.TxPacketsKey])
+
+	event, err := receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+
+	atomic.StoreUint64(&rxBytes, 100)
+	clockMock.Add(interval)
+
+	event, err = receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	stats, ok = connectionstats.Load(event.GetConnections()["id"].GetPath().GetPathSegments()[0])
+	require.True(t, ok)
+	require.Equal(t, uint64(100), stats.RxBytes)
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+
+	event, err = receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_DELETE, event.GetType())
+}
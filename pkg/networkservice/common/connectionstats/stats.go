@@ -0,0 +1,104 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connectionstats provides the per-connection traffic statistics populated by the forwarders with a Provider.
+// The statistics are stored in the Metrics of the forwarder path segment, so they are exposed via the monitor events
+// and the metrics chain element without any vendor-specific agents.
+package connectionstats
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Path segment Metrics keys of the statistics
+const (
+	RxBytesKey     = "rx_bytes"
+	TxBytesKey     = "tx_bytes"
+	RxPacketsKey   = "rx_packets"
+	TxPacketsKey   = "tx_packets"
+	RxDroppedKey   = "rx_dropped"
+	TxDroppedKey   = "tx_dropped"
+	statisticsKeys = 6
+)
+
+// Statistics is the connection traffic statistics
+type Statistics struct {
+	RxBytes   uint64
+	TxBytes   uint64
+	RxPackets uint64
+	TxPackets uint64
+	RxDropped uint64
+	TxDropped uint64
+}
+
+// Provider provides the connection statistics, it is implemented by the forwarders
+type Provider interface {
+	Statistics(ctx context.Context, conn *networkservice.Connection) (*Statistics, error)
+}
+
+// ProviderFunc is a function adapter for Provider
+type ProviderFunc func(ctx context.Context, conn *networkservice.Connection) (*Statistics, error)
+
+// Statistics calls f(ctx, conn)
+func (f ProviderFunc) Statistics(ctx context.Context, conn *networkservice.Connection) (*Statistics, error) {
+	return f(ctx, conn)
+}
+
+func (s *Statistics) fields() map[string]*uint64 {
+	return map[string]*uint64{
+		RxBytesKey:   &s.RxBytes,
+		TxBytesKey:   &s.TxBytes,
+		RxPacketsKey: &s.RxPackets,
+		TxPacketsKey: &s.TxPackets,
+		RxDroppedKey: &s.RxDropped,
+		TxDroppedKey: &s.TxDropped,
+	}
+}
+
+// Store merges the statistics into the segment Metrics
+func Store(segment *networkservice.PathSegment, stats *Statistics) {
+	if segment.GetMetrics() == nil {
+		segment.Metrics = make(map[string]string, statisticsKeys)
+	}
+	for k, v := range stats.fields() {
+		segment.GetMetrics()[k] = strconv.FormatUint(*v, 10)
+	}
+}
+
+// Load returns the statistics stored in the segment Metrics, the loaded result reports whether all of them were
+// present
+func Load(segment *networkservice.PathSegment) (*Statistics, bool) {
+	stats := new(Statistics)
+	for k, v := range stats.fields() {
+		value, err := strconv.ParseUint(segment.GetMetrics()[k], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		*v = value
+	}
+	return stats, true
+}
+
+func currentSegment(conn *networkservice.Connection) *networkservice.PathSegment {
+	segments := conn.GetPath().GetPathSegments()
+	if index := int(conn.GetPath().GetIndex()); index < len(segments) {
+		return segments[index]
+	}
+	return nil
+}
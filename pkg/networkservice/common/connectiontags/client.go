@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectiontags
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type tagsKey struct{}
+
+type connectionTagsClient struct{}
+
+// NewClient - returns a new client chain element preserving the connection tags across refresh and heal: the tags
+// set on the first Request are stored and restored on the following Requests and on the returned connections even
+// if some of the downstream components have dropped them.
+// It should be placed after metadata.
+func NewClient() networkservice.NetworkServiceClient {
+	return new(connectionTagsClient)
+}
+
+func (c *connectionTagsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	tags := Get(request.GetConnection())
+	if stored, ok := metadata.Map(ctx, true).Load(tagsKey{}); ok {
+		for k, v := range stored.(map[string]string) {
+			if _, ok := tags[k]; !ok {
+				tags[k] = v
+			}
+		}
+	}
+	setAll(request.GetConnection(), tags)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	setAll(conn, tags)
+	metadata.Map(ctx, true).Store(tagsKey{}, tags)
+
+	return conn, nil
+}
+
+func (c *connectionTagsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectiontags_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/connectiontags"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+// dropContextClient emulates the downstream dropping the connection ExtraContext
+type dropContextClient struct{}
+
+func (c *dropContextClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn := request.GetConnection().Clone()
+	conn.Context = nil
+	return conn, nil
+}
+
+func (c *dropContextClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func TestConnectionTagsClient(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		metadata.NewClient(),
+		connectiontags.NewClient(),
+		checkrequest.NewClient(t, func(t *testing.T, request *networkservice.NetworkServiceRequest) {
+			require.Equal(t, map[string]string{"app-id": "42"}, connectiontags.Get(request.GetConnection()))
+		}),
+		new(dropContextClient),
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	}
+	connectiontags.Set(request.GetConnection(), "app-id", "42")
+	require.Equal(t, "42", request.GetConnection().GetContext().GetExtraContext()[connectiontags.Prefix+"app-id"])
+
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app-id": "42"}, connectiontags.Get(conn))
+
+	// Tags are preserved on refresh even if the connection has lost them
+	conn.Context = nil
+	conn, err = client.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app-id": "42"}, connectiontags.Get(conn))
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+}
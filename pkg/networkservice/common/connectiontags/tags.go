@@ -0,0 +1,63 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connectiontags provides the API for the opaque key/value tags attached by applications to the connection.
+// Tags are stored in the connection ExtraContext under the "tag:" prefixed keys, so they are visible to every NSM
+// component and in the monitor events, letting operational tooling correlate NSM connections with application-level
+// identifiers. The client chain element preserves the tags across refresh and heal.
+package connectiontags
+
+import (
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Prefix is the prefix of the connection ExtraContext keys holding the tags
+const Prefix = "tag:"
+
+// Set sets the tag on the connection
+func Set(conn *networkservice.Connection, key, value string) {
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[Prefix+key] = value
+}
+
+// Delete deletes the tag from the connection
+func Delete(conn *networkservice.Connection, key string) {
+	delete(conn.GetContext().GetExtraContext(), Prefix+key)
+}
+
+// Get returns all the connection tags
+func Get(conn *networkservice.Connection) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range conn.GetContext().GetExtraContext() {
+		if strings.HasPrefix(k, Prefix) {
+			tags[strings.TrimPrefix(k, Prefix)] = v
+		}
+	}
+	return tags
+}
+
+func setAll(conn *networkservice.Connection, tags map[string]string) {
+	for k, v := range tags {
+		Set(conn, k, v)
+	}
+}
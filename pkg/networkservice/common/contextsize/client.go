@@ -0,0 +1,59 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contextsize
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type contextSizeClient struct {
+	*options
+}
+
+// NewClient creates a NetworkServiceClient chain element failing the Requests with the ConnectionContext exceeding
+// the size limits with nsmerrors.ContextTooLarge error before sending them, and compressing the large Requests if
+// WithCompression is set
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	return &contextSizeClient{
+		options: newOptions(opts...),
+	}
+}
+
+func (c *contextSizeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if err := c.check(request.GetConnection().GetContext()); err != nil {
+		return nil, err
+	}
+	if c.compressionThreshold > 0 && proto.Size(request) >= c.compressionThreshold {
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
+	}
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *contextSizeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if c.compressionThreshold > 0 && proto.Size(conn) >= c.compressionThreshold {
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contextsize_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/contextsize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+type callOptionsClient struct {
+	opts []grpc.CallOption
+}
+
+func (c *callOptionsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.opts = opts
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *callOptionsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.opts = opts
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *callOptionsClient) compressed() bool {
+	for _, opt := range c.opts {
+		if compressor, ok := opt.(grpc.CompressorCallOption); ok && compressor.CompressorType == gzip.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func requestWithRoutes(count int) *networkservice.NetworkServiceRequest {
+	ipContext := new(networkservice.IPContext)
+	for i := 0; i < count; i++ {
+		ipContext.DstRoutes = append(ipContext.DstRoutes, &networkservice.Route{
+			Prefix: fmt.Sprintf("10.%d.%d.0/24", i/256, i%256),
+		})
+	}
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Context: &networkservice.ConnectionContext{
+				IpContext: ipContext,
+			},
+		},
+	}
+}
+
+func TestContextSizeServer(t *testing.T) {
+	server := contextsize.NewServer(
+		contextsize.WithMaxContextSize(1000),
+		contextsize.WithMaxExtraContextSize(10),
+	)
+
+	_, err := server.Request(context.Background(), requestWithRoutes(10))
+	require.NoError(t, err)
+
+	_, err = server.Request(context.Background(), requestWithRoutes(100))
+	require.Error(t, err)
+	require.True(t, nsmerrors.Is(err, nsmerrors.ContextTooLarge))
+
+	request := requestWithRoutes(1)
+	request.GetConnection().GetContext().ExtraContext = map[string]string{"key": "long value"}
+	_, err = server.Request(context.Background(), request)
+	require.True(t, nsmerrors.Is(err, nsmerrors.ContextTooLarge))
+}
+
+func TestContextSizeClient_Compression(t *testing.T) {
+	captured := new(callOptionsClient)
+	client := next.NewNetworkServiceClient(
+		contextsize.NewClient(contextsize.WithCompression(1000)),
+		captured,
+	)
+
+	_, err := client.Request(context.Background(), requestWithRoutes(10))
+	require.NoError(t, err)
+	require.False(t, captured.compressed())
+
+	conn, err := client.Request(context.Background(), requestWithRoutes(100))
+	require.NoError(t, err)
+	require.True(t, captured.compressed())
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.True(t, captured.compressed())
+
+	// Limits are checked before sending
+	_, err = client.Request(context.Background(), requestWithRoutes(100000))
+	require.True(t, nsmerrors.Is(err, nsmerrors.ContextTooLarge))
+}
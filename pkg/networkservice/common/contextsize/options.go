@@ -0,0 +1,68 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contextsize
+
+const (
+	// DefaultMaxContextSize is the default limit of the marshaled ConnectionContext size in bytes
+	DefaultMaxContextSize = 1 << 20
+	// DefaultMaxExtraContextSize is the default limit of the ConnectionContext.ExtraContext keys and values total size
+	// in bytes
+	DefaultMaxExtraContextSize = 64 << 10
+)
+
+type options struct {
+	maxContextSize       int
+	maxExtraContextSize  int
+	compressionThreshold int
+}
+
+// Option is an option pattern for NewServer, NewClient
+type Option func(o *options)
+
+// WithMaxContextSize sets the limit of the marshaled ConnectionContext size in bytes, 0 disables the limit
+func WithMaxContextSize(size int) Option {
+	return func(o *options) {
+		o.maxContextSize = size
+	}
+}
+
+// WithMaxExtraContextSize sets the limit of the ConnectionContext.ExtraContext keys and values total size in bytes, 0
+// disables the limit
+func WithMaxExtraContextSize(size int) Option {
+	return func(o *options) {
+		o.maxExtraContextSize = size
+	}
+}
+
+// WithCompression enables the gzip compression of the client requests with the marshaled size of at least threshold
+// bytes. It is ignored by NewServer, the compressed requests are decompressed by gRPC.
+func WithCompression(threshold int) Option {
+	return func(o *options) {
+		o.compressionThreshold = threshold
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		maxContextSize:      DefaultMaxContextSize,
+		maxExtraContextSize: DefaultMaxExtraContextSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package contextsize provides chain elements enforcing the ConnectionContext size limits with the clear errors instead
+// of the gRPC message size failures, and compressing the large requests crossing the domain boundary
+package contextsize
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	// Registers gzip compressor used by the client, so the servers can decompress the requests
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+type contextSizeServer struct {
+	*options
+}
+
+// NewServer creates a NetworkServiceServer chain element rejecting the Requests with the ConnectionContext exceeding
+// the size limits with nsmerrors.ContextTooLarge error
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &contextSizeServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *contextSizeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := s.check(request.GetConnection().GetContext()); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *contextSizeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (o *options) check(connCtx *networkservice.ConnectionContext) error {
+	if connCtx == nil {
+		return nil
+	}
+	if o.maxExtraContextSize > 0 {
+		var size int
+		for k, v := range connCtx.GetExtraContext() {
+			size += len(k) + len(v)
+		}
+		if size > o.maxExtraContextSize {
+			return nsmerrors.Errorf(nsmerrors.ContextTooLarge, "extra context size %d exceeds the limit %d", size, o.maxExtraContextSize)
+		}
+	}
+	if o.maxContextSize > 0 {
+		if size := proto.Size(connCtx); size > o.maxContextSize {
+			return nsmerrors.Errorf(nsmerrors.ContextTooLarge, "connection context size %d exceeds the limit %d (routes: %d, policies: %d)",
+				size, o.maxContextSize,
+				len(connCtx.GetIpContext().GetSrcRoutes())+len(connCtx.GetIpContext().GetDstRoutes()),
+				len(connCtx.GetIpContext().GetPolicies()))
+		}
+	}
+	return nil
+}
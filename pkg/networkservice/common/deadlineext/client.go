@@ -0,0 +1,123 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadlineext
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type deadlineExtClient struct {
+	maxExtension time.Duration
+}
+
+// NewClient - returns a new client chain element announcing the maximum deadline extension in the outgoing gRPC
+// metadata and extending the request deadline by it, so the server could ask for more time instead of failing. It
+// should be placed right before the gRPC call, the elements before it see the original deadline.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := &clientOptions{
+		maxExtension: 15 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &deadlineExtClient{
+		maxExtension: o.maxExtension,
+	}
+}
+
+func (c *deadlineExtClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	extendedCtx, cancel, ok := c.withExtendedDeadline(ctx)
+	if !ok {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	}
+	defer cancel()
+
+	var header grpcmetadata.MD
+	conn, err := next.Client(ctx).Request(extendedCtx, request, append(opts, grpc.Header(&header))...)
+	if extension := extensionFromMD(header); extension > 0 {
+		log.FromContext(ctx).Debugf("request deadline extended by %s", extension)
+	}
+	return conn, err
+}
+
+func (c *deadlineExtClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	extendedCtx, cancel, ok := c.withExtendedDeadline(ctx)
+	if !ok {
+		return next.Client(ctx).Close(ctx, conn, opts...)
+	}
+	defer cancel()
+
+	return next.Client(ctx).Close(extendedCtx, conn, opts...)
+}
+
+// withExtendedDeadline returns a context with the deadline extended by the maximum extension, it is still canceled
+// with ctx but doesn't expire with it
+func (c *deadlineExtClient) withExtendedDeadline(ctx context.Context) (context.Context, context.CancelFunc, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok || c.maxExtension <= 0 {
+		return nil, nil, false
+	}
+
+	clk := clock.FromContext(ctx)
+	extendedCtx, cancel := clk.WithDeadline(
+		extend.WithValuesFromContext(context.Background(), ctx),
+		deadline.Add(c.maxExtension),
+	)
+	go func() {
+		select {
+		case <-extendedCtx.Done():
+		case <-ctx.Done():
+			// ctx is expired with the original deadline, it shouldn't cancel the request
+			if clk.Until(deadline) > 0 {
+				cancel()
+			}
+		}
+	}()
+
+	md, ok := grpcmetadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = grpcmetadata.MD{}
+	} else {
+		md = md.Copy()
+	}
+	md.Set(MaxExtensionKey, c.maxExtension.String())
+
+	return grpcmetadata.NewOutgoingContext(extendedCtx, md), cancel, true
+}
+
+func extensionFromMD(md grpcmetadata.MD) time.Duration {
+	values := md.Get(ExtensionKey)
+	if len(values) == 0 {
+		return 0
+	}
+	extension, err := time.ParseDuration(values[0])
+	if err != nil {
+		return 0
+	}
+	return extension
+}
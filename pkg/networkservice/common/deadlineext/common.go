@@ -0,0 +1,153 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deadlineext provides chain elements negotiating the request deadline extension: the client announces the
+// maximum extension it is ready to wait for and sends the request with the extended deadline, the server keeps the
+// original deadline for the subsequent elements unless some of them ask for more time with Extend.
+package deadlineext
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	// MaxExtensionKey is the gRPC metadata key holding the maximum deadline extension the client is ready to honor
+	MaxExtensionKey = "nsm-deadline-max-extension"
+	// ExtensionKey is the gRPC response header key holding the deadline extension granted by the server
+	ExtensionKey = "nsm-deadline-extension"
+)
+
+type deadlineKey struct{}
+
+// deadlineContext is a context with the deadline which can be moved forward up to the hard deadline
+type deadlineContext struct {
+	context.Context
+	cancel context.CancelFunc
+	clock  clock.Clock
+
+	mu       sync.Mutex
+	initial  time.Time
+	deadline time.Time
+	hard     time.Time
+	timer    clock.Timer
+	expired  bool
+}
+
+func newDeadlineContext(ctx context.Context, deadline, hard time.Time) *deadlineContext {
+	cancelCtx, cancel := context.WithCancel(ctx)
+	dc := &deadlineContext{
+		Context:  cancelCtx,
+		cancel:   cancel,
+		clock:    clock.FromContext(ctx),
+		initial:  deadline,
+		deadline: deadline,
+		hard:     hard,
+	}
+	dc.timer = dc.clock.AfterFunc(dc.clock.Until(deadline), dc.expire)
+	return dc
+}
+
+func (dc *deadlineContext) Deadline() (time.Time, bool) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	return dc.deadline, true
+}
+
+func (dc *deadlineContext) Err() error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if dc.expired {
+		return context.DeadlineExceeded
+	}
+	return dc.Context.Err()
+}
+
+func (dc *deadlineContext) Value(key interface{}) interface{} {
+	if key == (deadlineKey{}) {
+		return dc
+	}
+	return dc.Context.Value(key)
+}
+
+func (dc *deadlineContext) expire() {
+	dc.mu.Lock()
+	// The deadline could be extended while the timer was firing
+	if dc.clock.Now().Before(dc.deadline) {
+		dc.mu.Unlock()
+		return
+	}
+	dc.expired = true
+	dc.mu.Unlock()
+
+	dc.cancel()
+}
+
+func (dc *deadlineContext) extend(d time.Duration) time.Duration {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if dc.expired || dc.Context.Err() != nil {
+		return 0
+	}
+
+	deadline := dc.deadline.Add(d)
+	if deadline.After(dc.hard) {
+		deadline = dc.hard
+	}
+	if !deadline.After(dc.deadline) {
+		return 0
+	}
+	granted := deadline.Sub(dc.deadline)
+
+	dc.timer.Stop()
+	dc.deadline = deadline
+	dc.timer = dc.clock.AfterFunc(dc.clock.Until(deadline), dc.expire)
+
+	return granted
+}
+
+// extension returns the total extension of the deadline
+func (dc *deadlineContext) extension() time.Duration {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	return dc.deadline.Sub(dc.initial)
+}
+
+func (dc *deadlineContext) close() {
+	dc.mu.Lock()
+	dc.timer.Stop()
+	dc.mu.Unlock()
+
+	dc.cancel()
+}
+
+// Extend asks for the request deadline extension by d, the extension is capped by the maximum extension announced by
+// the client. Returns the granted extension, it is 0 if the deadline can't be extended: the client doesn't support the
+// negotiation, the cap is reached or the deadline has already passed. Contexts derived with their own deadlines before
+// the call are not affected, so the elements should extend the deadline first.
+func Extend(ctx context.Context, d time.Duration) time.Duration {
+	if dc, ok := ctx.Value(deadlineKey{}).(*deadlineContext); ok {
+		return dc.extend(d)
+	}
+	return 0
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadlineext_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/deadlineext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	}
+}
+
+// headerServer emulates the remote deadlineext server granting the extension
+type headerServer struct {
+	extension string
+}
+
+func (s *headerServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	for _, opt := range opts {
+		if header, ok := opt.(grpc.HeaderCallOption); ok {
+			*header.HeaderAddr = grpcmetadata.Pairs(deadlineext.ExtensionKey, s.extension)
+		}
+	}
+	return request.GetConnection(), nil
+}
+
+func (s *headerServer) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
+
+func TestDeadlineExtClient(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	requestCtx, requestCancel := clockMock.WithTimeout(ctx, 5*time.Second)
+	defer requestCancel()
+
+	client := chain.NewNetworkServiceClient(
+		deadlineext.NewClient(deadlineext.WithMaxExtension(10*time.Second)),
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			md, ok := grpcmetadata.FromOutgoingContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, []string{"10s"}, md.Get(deadlineext.MaxExtensionKey))
+
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.Equal(t, clockMock.Now().Add(15*time.Second), deadline)
+
+			// The original deadline passes, but the request is still alive
+			clockMock.Add(6 * time.Second)
+			require.Eventually(t, func() bool { return requestCtx.Err() != nil }, time.Second, 10*time.Millisecond)
+			require.NoError(t, ctx.Err())
+		}),
+		&headerServer{extension: "3s"},
+	)
+
+	_, err := client.Request(requestCtx, newRequest())
+	require.NoError(t, err)
+}
+
+func TestDeadlineExtClient_Canceled(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+
+	client := chain.NewNetworkServiceClient(
+		deadlineext.NewClient(),
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			cancel()
+			require.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, 10*time.Millisecond)
+		}),
+	)
+
+	_, err := client.Request(ctx, newRequest())
+	require.NoError(t, err)
+}
+
+func TestDeadlineExtServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	ctx, cancel = clockMock.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	ctx = grpcmetadata.NewIncomingContext(ctx, grpcmetadata.Pairs(deadlineext.MaxExtensionKey, "10s"))
+
+	start := clockMock.Now()
+	server := chain.NewNetworkServiceServer(
+		deadlineext.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.Equal(t, start.Add(5*time.Second), deadline)
+
+			require.Equal(t, 3*time.Second, deadlineext.Extend(ctx, 3*time.Second))
+			deadline, _ = ctx.Deadline()
+			require.Equal(t, start.Add(8*time.Second), deadline)
+
+			clockMock.Add(6 * time.Second)
+			require.NoError(t, ctx.Err())
+
+			clockMock.Add(3 * time.Second)
+			require.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, 10*time.Millisecond)
+			require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+			require.Zero(t, deadlineext.Extend(ctx, time.Second))
+		}),
+	)
+
+	_, err := server.Request(ctx, newRequest())
+	require.NoError(t, err)
+}
+
+func TestDeadlineExtServer_Cap(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	ctx, cancel = clockMock.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	ctx = grpcmetadata.NewIncomingContext(ctx, grpcmetadata.Pairs(deadlineext.MaxExtensionKey, "10s"))
+
+	start := clockMock.Now()
+	server := chain.NewNetworkServiceServer(
+		deadlineext.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			require.Equal(t, 10*time.Second, deadlineext.Extend(ctx, time.Minute))
+			require.Zero(t, deadlineext.Extend(ctx, time.Second))
+
+			deadline, _ := ctx.Deadline()
+			require.Equal(t, start.Add(15*time.Second), deadline)
+		}),
+	)
+
+	_, err := server.Request(ctx, newRequest())
+	require.NoError(t, err)
+}
+
+func TestDeadlineExtServer_NotNegotiated(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	expected, _ := ctx.Deadline()
+	server := chain.NewNetworkServiceServer(
+		deadlineext.NewServer(),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.Equal(t, expected, deadline)
+			require.Zero(t, deadlineext.Extend(ctx, time.Second))
+		}),
+	)
+
+	_, err := server.Request(ctx, newRequest())
+	require.NoError(t, err)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadlineext
+
+import "time"
+
+type clientOptions struct {
+	maxExtension time.Duration
+}
+
+// Option is an option pattern for NewClient
+type Option func(o *clientOptions)
+
+// WithMaxExtension sets the maximum deadline extension the client honors. Default is 15s.
+func WithMaxExtension(maxExtension time.Duration) Option {
+	return func(o *clientOptions) {
+		o.maxExtension = maxExtension
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deadlineext
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type deadlineExtServer struct{}
+
+// NewServer - returns a new server chain element restoring the original request deadline for the subsequent elements
+// if the client announced the maximum deadline extension. The elements can ask for more time with Extend, the granted
+// extension is sent back in the response header.
+func NewServer() networkservice.NetworkServiceServer {
+	return new(deadlineExtServer)
+}
+
+func (s *deadlineExtServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	dc, ok := withOriginalDeadline(ctx)
+	if !ok {
+		return next.Server(ctx).Request(ctx, request)
+	}
+	defer dc.close()
+
+	conn, err := next.Server(dc).Request(dc, request)
+	if extension := dc.extension(); extension > 0 {
+		// Fails if ctx is not the gRPC server one, there is nobody to report to then
+		_ = grpc.SetHeader(ctx, grpcmetadata.Pairs(ExtensionKey, extension.String()))
+	}
+	return conn, err
+}
+
+func (s *deadlineExtServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	dc, ok := withOriginalDeadline(ctx)
+	if !ok {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+	defer dc.close()
+
+	return next.Server(dc).Close(dc, conn)
+}
+
+// withOriginalDeadline returns a context with the deadline shifted back by the maximum extension announced by the
+// client
+func withOriginalDeadline(ctx context.Context) (*deadlineContext, bool) {
+	hard, ok := ctx.Deadline()
+	if !ok {
+		return nil, false
+	}
+	md, ok := grpcmetadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	values := md.Get(MaxExtensionKey)
+	if len(values) == 0 {
+		return nil, false
+	}
+	maxExtension, err := time.ParseDuration(values[0])
+	if err != nil || maxExtension <= 0 {
+		return nil, false
+	}
+	return newDeadlineContext(ctx, hard.Add(-maxExtension), hard), true
+}
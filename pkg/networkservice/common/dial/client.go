@@ -21,6 +21,7 @@ package dial
 
 import (
 	"context"
+	"net/url"
 	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientconn"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/certpin"
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
@@ -72,11 +74,16 @@ func (d *dialClient) Request(ctx context.Context, request *networkservice.Networ
 		return next.Client(ctx).Request(ctx, request, opts...)
 	}
 
+	// If our existing dialer has a different URL and make-before-break is requested, switch to the new URL
+	if oldConn := makeBeforeBreakConn(ctx); oldConn != nil && di.clientURL != nil && di.clientURL.String() != clientURL.String() {
+		return d.switchURL(ctx, di, clientURL, oldConn, request, opts...)
+	}
+
 	// If our existing dialer has a different URL close down the chain
 	if di.clientURL != nil && di.clientURL.String() != clientURL.String() {
 		closeCtx, closeCancel := closeContextFunc()
 		defer closeCancel()
-		err := di.Dial(closeCtx, di.clientURL)
+		err := di.Dial(certpin.WithServerName(closeCtx, di.serverName), di.clientURL)
 		if err != nil {
 			log.FromContext(ctx).Errorf("can not redial to %v, err %v. Deleting clientconn...", grpcutils.URLToTarget(di.clientURL), err)
 			clientconn.Delete(ctx)
@@ -122,3 +129,36 @@ func (d *dialClient) Close(ctx context.Context, conn *networkservice.Connection,
 
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
+
+// switchURL establishes the connection via the clientURL and only then closes the oldConn. The oldConn is closed
+// directly via the old grpc.ClientConn: per connection state of the subsequent chain elements already belongs to the
+// new connection.
+func (d *dialClient) switchURL(ctx context.Context, oldDialer *dialer, clientURL *url.URL, oldConn *networkservice.Connection,
+	request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	closeContextFunc := postpone.ContextWithValues(ctx)
+
+	di := newDialer(d.chainCtx, d.dialTimeout, d.dialOptions...)
+	if err := di.Dial(ctx, clientURL); err != nil {
+		log.FromContext(ctx).Errorf("can not dial to %v, err %v. Keeping the old connection...", grpcutils.URLToTarget(clientURL), err)
+		return nil, err
+	}
+
+	clientconn.Store(ctx, di)
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		_ = di.Close()
+		clientconn.Store(ctx, oldDialer)
+		return nil, err
+	}
+
+	closeCtx, closeCancel := closeContextFunc()
+	defer closeCancel()
+	if err := oldDialer.Dial(certpin.WithServerName(closeCtx, oldDialer.serverName), oldDialer.clientURL); err == nil {
+		if _, err := networkservice.NewNetworkServiceClient(oldDialer).Close(closeCtx, oldConn, opts...); err != nil {
+			log.FromContext(ctx).Warnf("failed to close the old connection via %v: %v", grpcutils.URLToTarget(oldDialer.clientURL), err)
+		}
+	}
+	_ = oldDialer.Close()
+
+	return conn, nil
+}
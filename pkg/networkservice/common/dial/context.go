@@ -0,0 +1,41 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dial
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type makeBeforeBreakKey struct{}
+
+// WithMakeBeforeBreak returns a new context making the dial client to establish the connection via the new client URL
+// before closing the oldConn via the old one, if the client URL has been changed
+func WithMakeBeforeBreak(parent context.Context, oldConn *networkservice.Connection) context.Context {
+	if parent == nil {
+		panic("cannot create context from nil parent")
+	}
+	return context.WithValue(parent, makeBeforeBreakKey{}, oldConn)
+}
+
+func makeBeforeBreakConn(ctx context.Context) *networkservice.Connection {
+	if rv, ok := ctx.Value(makeBeforeBreakKey{}).(*networkservice.Connection); ok {
+		return rv
+	}
+	return nil
+}
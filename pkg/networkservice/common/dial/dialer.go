@@ -25,7 +25,9 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/certpin"
 	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/diallimit"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 )
 
@@ -33,6 +35,7 @@ type dialer struct {
 	ctx            context.Context
 	cleanupContext context.Context
 	clientURL      *url.URL
+	serverName     string
 	cleanupCancel  context.CancelFunc
 	*grpc.ClientConn
 	dialOptions []grpc.DialOption
@@ -56,8 +59,9 @@ func (di *dialer) Dial(ctx context.Context, clientURL *url.URL) error {
 		di.cleanupCancel()
 	}
 
-	// Set the clientURL
+	// Set the clientURL and the name it has been resolved from
 	di.clientURL = clientURL
+	di.serverName = certpin.ServerName(ctx)
 
 	// Setup dialTimeout if needed
 	dialCtx := ctx
@@ -67,7 +71,19 @@ func (di *dialer) Dial(ctx context.Context, clientURL *url.URL) error {
 
 	// Dial
 	target := grpcutils.URLToTarget(di.clientURL)
-	cc, err := grpc.DialContext(dialCtx, target, di.dialOptions...)
+	if limiter := diallimit.FromContext(di.ctx); limiter != nil {
+		release, err := limiter.Acquire(dialCtx, target)
+		if err != nil {
+			return errors.Wrapf(err, "failed to dial %s", target)
+		}
+		defer release()
+	}
+	dialOptions := di.dialOptions
+	if di.serverName != "" {
+		// Handshake with the resolved name, so the peer certificate pins are checked for it and not for the address
+		dialOptions = append(append([]grpc.DialOption(nil), dialOptions...), grpc.WithAuthority(di.serverName))
+	}
+	cc, err := grpc.DialContext(dialCtx, target, dialOptions...)
 	if err != nil {
 		if cc != nil {
 			_ = cc.Close()
@@ -19,14 +19,16 @@
 package discover
 
 import (
+	"context"
+
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
-	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockskew"
 	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
-func matchEndpoint(clockTime clock.Clock, nsLabels map[string]string, ns *registry.NetworkService, nses ...*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
-	validNetworkServiceEndpoints := validateExpirationTime(clockTime, nses)
+func matchEndpoint(ctx context.Context, nsLabels map[string]string, ns *registry.NetworkService, nses ...*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	validNetworkServiceEndpoints := validateExpirationTime(ctx, nses)
 	// Iterate through the matches
 	for _, match := range ns.GetMatches() {
 		// All match source selector labels should be present in the requested labels map
@@ -63,10 +65,10 @@ func matchEndpoint(clockTime clock.Clock, nsLabels map[string]string, ns *regist
 	return validNetworkServiceEndpoints
 }
 
-func validateExpirationTime(clockTime clock.Clock, nses []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+func validateExpirationTime(ctx context.Context, nses []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
 	var validNetworkServiceEndpoints []*registry.NetworkServiceEndpoint
 	for _, nse := range nses {
-		if nse.GetExpirationTime() == nil || nse.GetExpirationTime().AsTime().After(clockTime.Now()) {
+		if nse.GetExpirationTime() == nil || !clockskew.Expired(ctx, nse.GetExpirationTime().AsTime()) {
 			validNetworkServiceEndpoints = append(validNetworkServiceEndpoints, nse)
 		}
 	}
@@ -30,8 +30,8 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
-	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type discoverCandidatesServer struct {
@@ -129,8 +129,6 @@ func (d *discoverCandidatesServer) discoverNetworkServiceEndpoint(ctx context.Co
 }
 
 func (d *discoverCandidatesServer) discoverNetworkServiceEndpoints(ctx context.Context, ns *registry.NetworkService, nsLabels map[string]string) ([]*registry.NetworkServiceEndpoint, error) {
-	clockTime := clock.FromContext(ctx)
-
 	query := &registry.NetworkServiceEndpointQuery{
 		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
 			NetworkServiceNames: []string{ns.Name},
@@ -143,12 +141,12 @@ func (d *discoverCandidatesServer) discoverNetworkServiceEndpoints(ctx context.C
 	}
 	nseList := registry.ReadNetworkServiceEndpointList(nseRespStream)
 
-	result := matchEndpoint(clockTime, nsLabels, ns, nseList...)
+	result := matchEndpoint(ctx, nsLabels, ns, nseList...)
 	if len(result) != 0 {
 		return result, nil
 	}
 
-	return nil, errors.New("network service endpoint candidates not found")
+	return nil, nsmerrors.New(nsmerrors.NoCandidates, "network service endpoint candidates not found")
 }
 
 func (d *discoverCandidatesServer) discoverNetworkService(ctx context.Context, name, payload string) (*registry.NetworkService, error) {
@@ -19,11 +19,15 @@ package discoverforwarder
 import (
 	"context"
 
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 )
 
 type selectedForworderKey struct{}
 
+type migratedSegmentsKey struct{}
+
 func loadForwarderName(ctx context.Context) string {
 	v, ok := metadata.Map(ctx, false).Load(selectedForworderKey{})
 	if !ok {
@@ -35,3 +39,19 @@ func loadForwarderName(ctx context.Context) string {
 func storeForwarderName(ctx context.Context, v string) {
 	metadata.Map(ctx, false).Store(selectedForworderKey{}, v)
 }
+
+func loadMigratedSegments(ctx context.Context) []*networkservice.PathSegment {
+	v, ok := metadata.Map(ctx, false).Load(migratedSegmentsKey{})
+	if !ok {
+		return nil
+	}
+	return v.([]*networkservice.PathSegment)
+}
+
+func storeMigratedSegments(ctx context.Context, v []*networkservice.PathSegment) {
+	metadata.Map(ctx, false).Store(migratedSegmentsKey{}, v)
+}
+
+func deleteMigratedSegments(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(migratedSegmentsKey{})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discoverforwarder
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+)
+
+// MigrationKey is a connection extra context key describing the forwarder migration state in the monitor events
+const MigrationKey = "forwarder-migration"
+
+// Migrator migrates established connections between forwarders on the same node, e.g. during the forwarder upgrade.
+// The connection is established via the new forwarder before it is closed via the old one.
+type Migrator struct {
+	mu        sync.Mutex
+	factories map[string]begin.EventFactory
+	targets   map[string]string
+}
+
+// NewMigrator creates a new Migrator. It should be passed to the discoverforwarder server with WithMigrator.
+func NewMigrator() *Migrator {
+	return &Migrator{
+		factories: make(map[string]begin.EventFactory),
+		targets:   make(map[string]string),
+	}
+}
+
+// Migrate migrates the connection to the forwarder and waits for the migration to complete
+func (m *Migrator) Migrate(ctx context.Context, connectionID, forwarderName string) error {
+	m.mu.Lock()
+	factory, ok := m.factories[connectionID]
+	if !ok {
+		m.mu.Unlock()
+		return errors.Errorf("connection %s is not found", connectionID)
+	}
+	m.targets[connectionID] = forwarderName
+	m.mu.Unlock()
+
+	var err error
+	select {
+	case err = <-factory.Request():
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	m.mu.Lock()
+	_, pending := m.targets[connectionID]
+	delete(m.targets, connectionID)
+	m.mu.Unlock()
+
+	if err == nil && pending {
+		err = errors.Errorf("connection %s has not been migrated", connectionID)
+	}
+	return err
+}
+
+func (m *Migrator) track(ctx context.Context, connectionID string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.factories[connectionID] = begin.FromContext(ctx)
+}
+
+func (m *Migrator) untrack(connectionID string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.factories, connectionID)
+	delete(m.targets, connectionID)
+}
+
+func (m *Migrator) takeTarget(connectionID string) (forwarderName string, ok bool) {
+	if m == nil {
+		return "", false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	forwarderName, ok = m.targets[connectionID]
+	delete(m.targets, connectionID)
+	return forwarderName, ok
+}
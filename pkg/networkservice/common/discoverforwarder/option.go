@@ -16,6 +16,8 @@
 
 package discoverforwarder
 
+import "time"
+
 // Option changes default settings for the discoverForwarderServer
 type Option func(*discoverForwarderServer)
 
@@ -33,3 +35,19 @@ func WithNSMgrURL(nsmgrURL string) Option {
 		d.nsmgrURL = nsmgrURL
 	}
 }
+
+// WithMigrator sets Migrator used to migrate connections between forwarders
+func WithMigrator(migrator *Migrator) Option {
+	return func(d *discoverForwarderServer) {
+		d.migrator = migrator
+	}
+}
+
+// WithScoreHalfLife sets the half life of the forwarder request failures penalty. Forwarders with recent failures
+// are selected last.
+// By default 1 minute
+func WithScoreHalfLife(halfLife time.Duration) Option {
+	return func(d *discoverForwarderServer) {
+		d.scoreHalfLife = halfLife
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discoverforwarder
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultScoreHalfLife        = time.Minute
+	forwarderRequestsMetricName = "forwarder_requests"
+)
+
+type forwarderScore struct {
+	penalty float64
+	updated time.Time
+}
+
+// forwarderScores tracks recent forwarder request failures. Penalty grows by 1 on every failure, halves on every
+// success and decays with the half life, so flapping forwarders are selected last until they become stable.
+type forwarderScores struct {
+	halfLife time.Duration
+	requests syncint64.Counter
+
+	mu     sync.Mutex
+	scores map[string]*forwarderScore
+}
+
+func newForwarderScores(halfLife time.Duration) *forwarderScores {
+	s := &forwarderScores{
+		halfLife: halfLife,
+		scores:   make(map[string]*forwarderScore),
+	}
+	if counter, err := global.Meter("").SyncInt64().Counter(forwarderRequestsMetricName); err == nil {
+		s.requests = counter
+	}
+	return s
+}
+
+// report updates the forwarder penalty with the request result
+func (s *forwarderScores) report(ctx context.Context, forwarderName string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	if s.requests != nil {
+		s.requests.Add(ctx, 1, attribute.String("forwarder", forwarderName), attribute.String("result", result))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock.FromContext(ctx).Now()
+	score := &forwarderScore{
+		penalty: s.penaltyLocked(now, forwarderName),
+		updated: now,
+	}
+	if err != nil {
+		score.penalty++
+	} else {
+		score.penalty /= 2
+	}
+	s.scores[forwarderName] = score
+}
+
+// sort stable sorts forwarders by the penalty
+func (s *forwarderScores) sort(ctx context.Context, nses []*registry.NetworkServiceEndpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock.FromContext(ctx).Now()
+	penalties := make(map[string]float64, len(nses))
+	for _, nse := range nses {
+		penalties[nse.GetName()] = s.penaltyLocked(now, nse.GetName())
+	}
+	sort.SliceStable(nses, func(i, j int) bool {
+		return penalties[nses[i].GetName()] < penalties[nses[j].GetName()]
+	})
+}
+
+func (s *forwarderScores) penaltyLocked(now time.Time, forwarderName string) float64 {
+	score, ok := s.scores[forwarderName]
+	if !ok {
+		return 0
+	}
+	return score.penalty * math.Exp2(-float64(now.Sub(score.updated))/float64(s.halfLife))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discoverforwarder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func names(nses []*registry.NetworkServiceEndpoint) []string {
+	var rv []string
+	for _, nse := range nses {
+		rv = append(rv, nse.GetName())
+	}
+	return rv
+}
+
+func TestForwarderScores(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	scores := newForwarderScores(time.Minute)
+	nses := []*registry.NetworkServiceEndpoint{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	// Flapping forwarder is selected last
+	scores.report(ctx, "a", errors.New("error"))
+	scores.report(ctx, "a", nil)
+	scores.report(ctx, "a", errors.New("error"))
+	scores.report(ctx, "b", errors.New("error"))
+	scores.sort(ctx, nses)
+	require.Equal(t, []string{"c", "b", "a"}, names(nses))
+
+	// Successes reduce the penalty
+	scores.report(ctx, "a", nil)
+	scores.report(ctx, "a", nil)
+	scores.sort(ctx, nses)
+	require.Equal(t, []string{"c", "a", "b"}, names(nses))
+
+	// Penalties decay with time
+	clockMock.Add(time.Hour)
+	scores.report(ctx, "c", errors.New("error"))
+	scores.sort(ctx, nses)
+	require.Equal(t, []string{"a", "b", "c"}, names(nses))
+}
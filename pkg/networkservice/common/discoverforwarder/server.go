@@ -20,16 +20,20 @@ package discoverforwarder
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/dial"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type discoverForwarderServer struct {
@@ -37,6 +41,9 @@ type discoverForwarderServer struct {
 	nsClient             registry.NetworkServiceRegistryClient
 	forwarderServiceName string
 	nsmgrURL             string
+	migrator             *Migrator
+	scoreHalfLife        time.Duration
+	scores               *forwarderScores
 }
 
 // NewServer creates new instance of discoverforwarder networkservice.NetworkServiceServer.
@@ -54,80 +61,102 @@ func NewServer(nsClient registry.NetworkServiceRegistryClient, nseClient registr
 		nseClient:            nseClient,
 		nsClient:             nsClient,
 		forwarderServiceName: "forwarder",
+		scoreHalfLife:        defaultScoreHalfLife,
 	}
 
 	for _, opt := range opts {
 		opt(result)
 	}
+	result.scores = newForwarderScores(result.scoreHalfLife)
 
 	return result
 }
 
 func (d *discoverForwarderServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	var forwarderName = loadForwarderName(ctx)
+	if forwarderName == "" {
+		return d.selectForwarder(ctx, request)
+	}
+	if target, ok := d.migrator.takeTarget(request.GetConnection().GetId()); ok && target != forwarderName {
+		return d.migrate(ctx, request, forwarderName, target)
+	}
+	return d.requestForwarder(ctx, request, forwarderName)
+}
+
+// selectForwarder discovers the forwarders matching the request and requests them one by one until the first success
+func (d *discoverForwarderServer) selectForwarder(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	var logger = log.FromContext(ctx).WithField("discoverForwarderServer", "request")
 
-	if forwarderName == "" {
-		ns, err := d.discoverNetworkService(ctx, request.GetConnection().GetNetworkService(), request.GetConnection().GetPayload())
-		if err != nil {
-			return nil, err
-		}
+	ns, err := d.discoverNetworkService(ctx, request.GetConnection().GetNetworkService(), request.GetConnection().GetPayload())
+	if err != nil {
+		return nil, err
+	}
 
-		stream, err := d.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
-			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
-				NetworkServiceNames: []string{
-					d.forwarderServiceName,
-				},
-				Url: d.nsmgrURL,
+	stream, err := d.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceNames: []string{
+				d.forwarderServiceName,
 			},
-		})
+			Url: d.nsmgrURL,
+		},
+	})
 
-		if err != nil {
-			logger.Errorf("can not open registry nse stream by networkservice. Error: %v", err.Error())
-			return nil, errors.WithStack(err)
-		}
+	if err != nil {
+		logger.Errorf("can not open registry nse stream by networkservice. Error: %v", err.Error())
+		return nil, errors.WithStack(err)
+	}
 
-		nses := d.matchForwarders(request.Connection.GetLabels(), ns, registry.ReadNetworkServiceEndpointList(stream))
+	nses := d.matchForwarders(request.Connection.GetLabels(), ns, registry.ReadNetworkServiceEndpointList(stream))
 
-		if len(nses) == 0 {
-			return nil, errors.New("no candidates found")
-		}
+	if len(nses) == 0 {
+		return nil, nsmerrors.New(nsmerrors.NoCandidates, "no candidates found")
+	}
+
+	d.scores.sort(ctx, nses)
 
-		segments := request.Connection.GetPath().GetPathSegments()
-		if pathIndex := int(request.Connection.GetPath().Index); len(segments) > pathIndex+1 {
-			datapathForwarder := segments[pathIndex+1].Name
-			for i, candidate := range nses {
-				if candidate.Name == datapathForwarder {
-					nses[0], nses[i] = nses[i], nses[0]
-					break
-				}
+	segments := request.Connection.GetPath().GetPathSegments()
+	if pathIndex := int(request.Connection.GetPath().Index); len(segments) > pathIndex+1 {
+		datapathForwarder := segments[pathIndex+1].Name
+		for i, candidate := range nses {
+			if candidate.Name == datapathForwarder {
+				nses[0], nses[i] = nses[i], nses[0]
+				break
 			}
 		}
+	}
 
-		var candidatesErr = errors.New("all forwarders have failed")
+	var candidatesErr = errors.New("all forwarders have failed")
 
-		// TODO: Should we consider about load balancing?
-		// https://github.com/networkservicemesh/sdk/issues/790
-		for i, candidate := range nses {
-			u, err := url.Parse(candidate.Url)
+	// TODO: Should we consider about load balancing?
+	// https://github.com/networkservicemesh/sdk/issues/790
+	for i, candidate := range nses {
+		u, err := url.Parse(candidate.Url)
 
-			if err != nil {
-				logger.Errorf("can not parse forwarder=%v url=%v error=%v", candidate.Name, candidate.Url, err.Error())
-				continue
-			}
+		if err != nil {
+			logger.Errorf("can not parse forwarder=%v url=%v error=%v", candidate.Name, candidate.Url, err.Error())
+			continue
+		}
 
-			resp, err := next.Server(ctx).Request(clienturlctx.WithClientURL(ctx, u), request.Clone())
+		resp, err := next.Server(ctx).Request(clienturlctx.WithClientURL(ctx, u), request.Clone())
+		d.scores.report(ctx, candidate.Name, err)
 
-			if err == nil {
-				storeForwarderName(ctx, candidate.Name)
-				return resp, nil
-			}
-			logger.Errorf("forwarder=%v url=%v returned error=%v", candidate.Name, candidate.Url, err.Error())
-			candidatesErr = errors.Wrapf(candidatesErr, "%v. An error during select forwawrder %v --> %v", i, candidate.Name, err.Error())
+		if err == nil {
+			storeForwarderName(ctx, candidate.Name)
+			d.migrator.track(ctx, resp.GetId())
+			return resp, nil
 		}
-
-		return nil, candidatesErr
+		logger.Errorf("forwarder=%v url=%v returned error=%v", candidate.Name, candidate.Url, err.Error())
+		candidatesErr = errors.Wrapf(candidatesErr, "%v. An error during select forwawrder %v --> %v", i, candidate.Name, err.Error())
 	}
+
+	return nil, candidatesErr
+}
+
+// requestForwarder requests the already selected forwarder
+func (d *discoverForwarderServer) requestForwarder(ctx context.Context, request *networkservice.NetworkServiceRequest, forwarderName string) (*networkservice.Connection, error) {
+	var logger = log.FromContext(ctx).WithField("discoverForwarderServer", "request")
+
+	restoreMigratedPath(ctx, request.GetConnection().GetPath(), forwarderName)
 	stream, err := d.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
 		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
 			Name: forwarderName,
@@ -155,13 +184,110 @@ func (d *discoverForwarderServer) Request(ctx context.Context, request *networks
 	}
 
 	conn, err := next.Server(ctx).Request(clienturlctx.WithClientURL(ctx, u), request)
+	d.scores.report(ctx, forwarderName, err)
 	if err != nil {
 		storeForwarderName(ctx, "")
+		return nil, err
 	}
-	return conn, err
+	d.migrator.track(ctx, conn.GetId())
+	return conn, nil
+}
+
+// migrate establishes the connection via the target forwarder and then closes it via the current one. The current
+// forwarder is kept on failure.
+func (d *discoverForwarderServer) migrate(ctx context.Context, request *networkservice.NetworkServiceRequest, forwarderName, target string) (*networkservice.Connection, error) {
+	var logger = log.FromContext(ctx).WithField("discoverForwarderServer", "migrate")
+
+	stream, err := d.nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: target,
+			Url:  d.nsmgrURL,
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	nses := registry.ReadNetworkServiceEndpointList(stream)
+	if len(nses) == 0 {
+		return nil, errors.Errorf("forwarder %s not found", target)
+	}
+	u, err := url.Parse(nses[0].Url)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	logger.Infof("migrating connection %s from forwarder %s to %s", request.GetConnection().GetId(), forwarderName, target)
+	sendMigrationEvent(ctx, request.GetConnection(), "started", forwarderName, target)
+
+	// Drop the path segments starting from the current forwarder, so the new connection gets new IDs starting from
+	// the target forwarder and doesn't interfere with the old one until it is closed
+	oldConn := request.GetConnection().Clone()
+	request = request.Clone()
+	path := request.GetConnection().GetPath()
+	path.PathSegments = path.GetPathSegments()[:path.GetIndex()+1]
+
+	ctx = dial.WithMakeBeforeBreak(clienturlctx.WithClientURL(ctx, u), oldConn)
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		logger.Errorf("failed to migrate connection %s to forwarder %s: %s", request.GetConnection().GetId(), target, err.Error())
+		sendMigrationEvent(ctx, request.GetConnection(), "failed", forwarderName, target)
+		return nil, err
+	}
+
+	storeForwarderName(ctx, target)
+	storeMigratedSegments(ctx, clonePathSegments(conn.GetPath().GetPathSegments()[conn.GetPath().GetIndex()+1:]))
+	sendMigrationEvent(ctx, conn, "completed", forwarderName, target)
+	return conn, nil
+}
+
+// restoreMigratedPath replaces the path segments starting from the forwarder with the migrated ones, if the client
+// has not received the migrated path yet and refreshes the connection with the path via the old forwarder
+func restoreMigratedPath(ctx context.Context, path *networkservice.Path, forwarderName string) {
+	migrated := loadMigratedSegments(ctx)
+	if migrated == nil {
+		return
+	}
+
+	nextIndex := int(path.GetIndex()) + 1
+	if nextIndex >= len(path.GetPathSegments()) || path.GetPathSegments()[nextIndex].GetName() == forwarderName {
+		deleteMigratedSegments(ctx)
+		return
+	}
+	path.PathSegments = append(path.GetPathSegments()[:nextIndex:nextIndex], clonePathSegments(migrated)...)
+}
+
+func clonePathSegments(segments []*networkservice.PathSegment) []*networkservice.PathSegment {
+	rv := make([]*networkservice.PathSegment, 0, len(segments))
+	for _, segment := range segments {
+		rv = append(rv, segment.Clone())
+	}
+	return rv
+}
+
+func sendMigrationEvent(ctx context.Context, conn *networkservice.Connection, state, from, to string) {
+	eventConsumer, ok := monitor.LoadEventConsumer(ctx, false)
+	if !ok {
+		return
+	}
+
+	conn = conn.Clone()
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[MigrationKey] = state + ": " + from + " -> " + to
+
+	_ = eventConsumer.Send(&networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{conn.GetId(): conn},
+	})
 }
 
 func (d *discoverForwarderServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	d.migrator.untrack(conn.GetId())
+
 	var forwarderName = loadForwarderName(ctx)
 	var logger = log.FromContext(ctx).WithField("discoverForwarderServer", "request")
 
@@ -16,6 +16,8 @@
 
 package excludedprefixes
 
+import "time"
+
 /* These variables set default path to the config file */
 const (
 	// prefixesFile - excluded prefixes file name
@@ -25,3 +27,6 @@ const (
 	// PrefixesFilePathDefault - excluded prefixes file absolute path
 	PrefixesFilePathDefault = nsmConfigDir + "/" + prefixesFile
 )
+
+// RefreshPeriodDefault - default refresh period of the infrastructure prefix providers
+const RefreshPeriodDefault = time.Minute
@@ -16,7 +16,12 @@
 
 package excludedprefixes
 
-import "net/url"
+import (
+	"net/url"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/infraprefixes"
+)
 
 // ServerOption - method for excludedPrefixesServer
 type ServerOption func(server *excludedPrefixesServer)
@@ -28,6 +33,24 @@ func WithConfigPath(s string) ServerOption {
 	}
 }
 
+// WithPrefixProviders - returns method that adds infrastructure prefix providers to excludedPrefixesServer, e.g. the
+// host interfaces prefixes and the cluster CIDRs. The provided prefixes are merged with the config file prefixes and
+// refreshed periodically.
+func WithPrefixProviders(providers ...infraprefixes.Provider) ServerOption {
+	return func(args *excludedPrefixesServer) {
+		args.providers = append(args.providers, providers...)
+	}
+}
+
+// WithRefreshPeriod - returns method that sets the prefix providers refresh period in excludedPrefixesServer
+func WithRefreshPeriod(refreshPeriod time.Duration) ServerOption {
+	return func(args *excludedPrefixesServer) {
+		if refreshPeriod > 0 {
+			args.refreshPeriod = refreshPeriod
+		}
+	}
+}
+
 // ClientOption - method for excludedPrefixesClient
 type ClientOption func(client *excludedPrefixesClient)
 
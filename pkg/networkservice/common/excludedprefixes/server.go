@@ -17,13 +17,14 @@
 // limitations under the License.
 
 // Package excludedprefixes provides a networkservice.NetworkServiceServer chain element that can read excluded prefixes
-// from config map and add them to request to avoid repeated usage.
+// from config map and infrastructure prefix providers and add them to request to avoid repeated usage.
 package excludedprefixes
 
 import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -31,16 +32,24 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/infraprefixes"
 	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type excludedPrefixesServer struct {
-	ctx        context.Context
-	prefixPool atomic.Value
-	once       sync.Once
-	configPath string
+	ctx           context.Context
+	prefixPool    atomic.Value
+	once          sync.Once
+	configPath    string
+	providers     []infraprefixes.Provider
+	refreshPeriod time.Duration
+
+	prefixesMu       sync.Mutex
+	configPrefixes   []string
+	providerPrefixes [][]string
 }
 
 func (eps *excludedPrefixesServer) init(ctx context.Context) {
@@ -48,7 +57,7 @@ func (eps *excludedPrefixesServer) init(ctx context.Context) {
 	eps.prefixPool.Store(zeroPool)
 	updatePrefixes := func(bytes []byte) {
 		if bytes == nil {
-			eps.prefixPool.Store(zeroPool)
+			eps.setConfigPrefixes(ctx, nil)
 		}
 		source := struct {
 			Prefixes []string
@@ -58,12 +67,11 @@ func (eps *excludedPrefixesServer) init(ctx context.Context) {
 			log.FromContext(ctx).Errorf("Can not create unmarshal prefixes, err: %v", err.Error())
 			return
 		}
-		pool, err := ippool.NewPool(source.Prefixes...)
-		if err != nil {
+		if _, err = ippool.NewPool(source.Prefixes...); err != nil {
 			log.FromContext(ctx).Errorf("Can not create prefixpool with prefixes: %+v, err: %v", source.Prefixes, err.Error())
 			return
 		}
-		eps.prefixPool.Store(pool)
+		eps.setConfigPrefixes(ctx, source.Prefixes)
 	}
 	updateCh := fs.WatchFile(eps.ctx, eps.configPath)
 	updatePrefixes(<-updateCh)
@@ -72,6 +80,66 @@ func (eps *excludedPrefixesServer) init(ctx context.Context) {
 			updatePrefixes(update)
 		}
 	}()
+
+	if len(eps.providers) == 0 {
+		return
+	}
+	eps.providerPrefixes = make([][]string, len(eps.providers))
+	eps.refreshProviders(ctx)
+	go func() {
+		ticker := clock.FromContext(eps.ctx).Ticker(eps.refreshPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-eps.ctx.Done():
+				return
+			case <-ticker.C():
+				eps.refreshProviders(ctx)
+			}
+		}
+	}()
+}
+
+func (eps *excludedPrefixesServer) setConfigPrefixes(ctx context.Context, prefixes []string) {
+	eps.prefixesMu.Lock()
+	defer eps.prefixesMu.Unlock()
+
+	eps.configPrefixes = prefixes
+	eps.storePool(ctx)
+}
+
+// refreshProviders requests all the providers for the prefixes. If some provider fails, its previously provided
+// prefixes are kept.
+func (eps *excludedPrefixesServer) refreshProviders(ctx context.Context) {
+	eps.prefixesMu.Lock()
+	defer eps.prefixesMu.Unlock()
+
+	for i, provider := range eps.providers {
+		prefixes, err := provider.Prefixes(eps.ctx)
+		if err != nil {
+			log.FromContext(ctx).Errorf("Can not get prefixes from the provider, err: %v", err.Error())
+			continue
+		}
+		if _, err = ippool.NewPool(prefixes...); err != nil {
+			log.FromContext(ctx).Errorf("Can not create prefixpool with prefixes: %+v, err: %v", prefixes, err.Error())
+			continue
+		}
+		eps.providerPrefixes[i] = prefixes
+	}
+	eps.storePool(ctx)
+}
+
+func (eps *excludedPrefixesServer) storePool(ctx context.Context) {
+	prefixes := append([]string(nil), eps.configPrefixes...)
+	for _, providerPrefixes := range eps.providerPrefixes {
+		prefixes = append(prefixes, providerPrefixes...)
+	}
+	pool, err := ippool.NewPool(prefixes...)
+	if err != nil {
+		log.FromContext(ctx).Errorf("Can not create prefixpool with prefixes: %+v, err: %v", prefixes, err.Error())
+		return
+	}
+	eps.prefixPool.Store(pool)
 }
 
 // Note: request.Connection and Connection.Context should not be nil
@@ -100,12 +168,14 @@ func (eps *excludedPrefixesServer) Close(ctx context.Context, connection *networ
 }
 
 // NewServer -  creates a networkservice.NetworkServiceServer chain element that can read excluded prefixes from config
-// map and add them to request to avoid repeated usage.
+// map and infrastructure prefix providers and add them to request to avoid repeated usage. IPAM chain elements placed
+// after it never allocate addresses from the excluded prefixes.
 // Note: request.Connection and Connection.Context should not be nil when calling Request
 func NewServer(ctx context.Context, setters ...ServerOption) networkservice.NetworkServiceServer {
 	server := &excludedPrefixesServer{
-		configPath: PrefixesFilePathDefault,
-		ctx:        ctx,
+		configPath:    PrefixesFilePathDefault,
+		refreshPeriod: RefreshPeriodDefault,
+		ctx:           ctx,
 	}
 	for _, setter := range setters {
 		setter(server)
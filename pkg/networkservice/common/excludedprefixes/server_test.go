@@ -27,10 +27,12 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
@@ -38,6 +40,9 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/excludedprefixes"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/infraprefixes"
 )
 
 const defaultPrefixesFileName = "excluded_prefixes.yaml"
@@ -191,6 +196,61 @@ func TestFilePrefixesChanged(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPrefixProviders(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	dir := filepath.Join(os.TempDir(), t.Name())
+	defer func() { _ = os.RemoveAll(dir) }()
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+
+	testConfig := strings.Join([]string{"prefixes:", "10.96.0.0/12"}, "\n- ")
+	configPath := filepath.Join(dir, defaultPrefixesFileName)
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(testConfig), os.ModePerm))
+
+	var hostPrefixes atomic.Value
+	hostPrefixes.Store([]string{"192.168.0.0/24"})
+	hostProvider := infraprefixes.ProviderFunc(func(_ context.Context) ([]string, error) {
+		return hostPrefixes.Load().([]string), nil
+	})
+	failingProvider := infraprefixes.ProviderFunc(func(_ context.Context) ([]string, error) {
+		return nil, errors.New("failed")
+	})
+
+	server := chain.NewNetworkServiceServer(excludedprefixes.NewServer(ctx,
+		excludedprefixes.WithConfigPath(configPath),
+		excludedprefixes.WithPrefixProviders(
+			infraprefixes.NewStaticProvider("10.244.0.0/16"),
+			hostProvider,
+			failingProvider,
+		),
+		excludedprefixes.WithRefreshPeriod(time.Minute),
+	))
+
+	req := request()
+	_, err := server.Request(ctx, req)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"10.96.0.0/12", "10.244.0.0/16", "192.168.0.0/24"},
+		req.GetConnection().GetContext().GetIpContext().GetExcludedPrefixes())
+
+	hostPrefixes.Store([]string{"192.168.1.0/24"})
+
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Minute)
+
+		req = request()
+		_, err = server.Request(ctx, req)
+		require.NoError(t, err)
+
+		prefixes := req.GetConnection().GetContext().GetIpContext().GetExcludedPrefixes()
+		sort.Strings(prefixes)
+		return reflect.DeepEqual(prefixes, []string{"10.244.0.0/16", "10.96.0.0/12", "192.168.1.0/24"})
+	}, time.Second, 10*time.Millisecond)
+}
+
 func testWaitForFile(t *testing.T, filePath string) {
 	prefixes := []string{"10.80.0.0/12", "172.16.1.0/24"}
 
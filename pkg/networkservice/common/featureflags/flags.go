@@ -0,0 +1,125 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package featureflags provides the typed API for the request-scoped feature flags carried in the connection
+// ExtraContext under the "feature:" prefixed keys. Chain elements should use the well-known flags declared here or
+// Register their own ones instead of inventing ad-hoc ExtraContext keys, so the flags not known to any element of the
+// chain can be detected and logged by the server chain element.
+package featureflags
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Prefix is the prefix of the connection ExtraContext keys holding the feature flags
+const Prefix = "feature:"
+
+// Flag is a request-scoped feature flag
+type Flag string
+
+// Well-known feature flags
+const (
+	// DryRun asks the chain to validate the Request without allocating any resources
+	DryRun Flag = "dry-run"
+	// DebugTiming asks the chain to report per element Request durations
+	DebugTiming Flag = "debug-timing"
+	// NoHeal asks the chain not to heal the connection
+	NoHeal Flag = "no-heal"
+	// PreferEncrypted asks the chain to prefer encrypted mechanisms
+	PreferEncrypted Flag = "prefer-encrypted"
+)
+
+var registry = struct {
+	sync.RWMutex
+	flags map[Flag]struct{}
+}{
+	flags: map[Flag]struct{}{
+		DryRun:          {},
+		DebugTiming:     {},
+		NoHeal:          {},
+		PreferEncrypted: {},
+	},
+}
+
+// Register registers the flags as known
+func Register(flags ...Flag) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	for _, flag := range flags {
+		registry.flags[flag] = struct{}{}
+	}
+}
+
+// IsRegistered returns true if the flag is registered
+func IsRegistered(flag Flag) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	_, ok := registry.flags[flag]
+	return ok
+}
+
+// Set sets the flag value on the connection
+func Set(conn *networkservice.Connection, flag Flag, enabled bool) {
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[Prefix+string(flag)] = strconv.FormatBool(enabled)
+}
+
+// Enable enables the flag on the connection
+func Enable(conn *networkservice.Connection, flag Flag) {
+	Set(conn, flag, true)
+}
+
+// Delete deletes the flag from the connection
+func Delete(conn *networkservice.Connection, flag Flag) {
+	delete(conn.GetContext().GetExtraContext(), Prefix+string(flag))
+}
+
+// Enabled returns true if the flag is enabled on the connection
+func Enabled(conn *networkservice.Connection, flag Flag) bool {
+	value, ok := conn.GetContext().GetExtraContext()[Prefix+string(flag)]
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
+}
+
+// Unknown returns the sorted list of the connection flags not registered as known
+func Unknown(conn *networkservice.Connection) []Flag {
+	var unknown []Flag
+	for k := range conn.GetContext().GetExtraContext() {
+		if !strings.HasPrefix(k, Prefix) {
+			continue
+		}
+		if flag := Flag(strings.TrimPrefix(k, Prefix)); !IsRegistered(flag) {
+			unknown = append(unknown, flag)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+	return unknown
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflags_test
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/featureflags"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	conn := new(networkservice.Connection)
+	require.False(t, featureflags.Enabled(conn, featureflags.DryRun))
+
+	featureflags.Enable(conn, featureflags.DryRun)
+	featureflags.Set(conn, featureflags.NoHeal, false)
+	require.True(t, featureflags.Enabled(conn, featureflags.DryRun))
+	require.False(t, featureflags.Enabled(conn, featureflags.NoHeal))
+	require.Equal(t, "true", conn.GetContext().GetExtraContext()[featureflags.Prefix+"dry-run"])
+
+	featureflags.Delete(conn, featureflags.DryRun)
+	require.False(t, featureflags.Enabled(conn, featureflags.DryRun))
+
+	conn.GetContext().GetExtraContext()[featureflags.Prefix+"custom-b"] = "true"
+	conn.GetContext().GetExtraContext()[featureflags.Prefix+"custom-a"] = "true"
+	conn.GetContext().GetExtraContext()["not-a-flag"] = "true"
+	require.Equal(t, []featureflags.Flag{"custom-a", "custom-b"}, featureflags.Unknown(conn))
+
+	featureflags.Register("custom-a")
+	require.True(t, featureflags.IsRegistered("custom-a"))
+	require.Equal(t, []featureflags.Flag{"custom-b"}, featureflags.Unknown(conn))
+}
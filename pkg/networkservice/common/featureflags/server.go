@@ -0,0 +1,46 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflags
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type featureFlagsServer struct{}
+
+// NewServer - returns a new server chain element logging the request feature flags not registered as known
+func NewServer() networkservice.NetworkServiceServer {
+	return new(featureFlagsServer)
+}
+
+func (s *featureFlagsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	for _, flag := range Unknown(request.GetConnection()) {
+		log.FromContext(ctx).WithField("featureFlagsServer", "Request").
+			Warnf("unknown feature flag: %s", flag)
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *featureFlagsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handshake
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type handshakeClient struct {
+	own      *Info
+	mismatch *mismatchLogger
+}
+
+// NewClient - returns a new client chain element announcing its version and capabilities in the outgoing gRPC
+// metadata and reading the peer ones from the response header. It should be placed after metadata.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	own := newInfo(opts...)
+	return &handshakeClient{
+		own:      own,
+		mismatch: &mismatchLogger{own: own},
+	}
+}
+
+func (c *handshakeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	var header grpcmetadata.MD
+	conn, err := next.Client(ctx).Request(withOutgoingInfo(ctx, c.own), request, append(opts, grpc.Header(&header))...)
+	if err != nil {
+		return nil, err
+	}
+
+	if peer, ok := fromMD(header); ok {
+		c.mismatch.check(ctx, peer)
+		storePeer(ctx, true, peer)
+	}
+
+	return conn, nil
+}
+
+func (c *handshakeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(withOutgoingInfo(ctx, c.own), conn, opts...)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package handshake provides chain elements exchanging the SDK version and the capability flags with the peer via
+// gRPC metadata, so the elements could gate optional behaviors on the peer capabilities during mixed-version
+// rollouts. Version mismatches are logged once per peer version.
+package handshake
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// VersionKey is the gRPC metadata key holding the SDK version
+	VersionKey = "nsm-sdk-version"
+	// CapabilitiesKey is the gRPC metadata key holding the comma separated capability flags
+	CapabilitiesKey = "nsm-capabilities"
+)
+
+// Version is the SDK version announced to the peers, it can be set on build with
+// -ldflags "-X github.com/networkservicemesh/sdk/pkg/networkservice/common/handshake.Version=<version>"
+var Version = "dev"
+
+// Info is the version and the capabilities of the component
+type Info struct {
+	Version      string
+	Capabilities []string
+}
+
+// Has returns true if the component has the capability
+func (i *Info) Has(capability string) bool {
+	if i == nil {
+		return false
+	}
+	for _, c := range i.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Info) pairs() []string {
+	return []string{VersionKey, i.Version, CapabilitiesKey, strings.Join(i.Capabilities, ",")}
+}
+
+func fromMD(md grpcmetadata.MD) (*Info, bool) {
+	versions := md.Get(VersionKey)
+	if len(versions) == 0 {
+		return nil, false
+	}
+	info := &Info{Version: versions[0]}
+	for _, capabilities := range md.Get(CapabilitiesKey) {
+		for _, c := range strings.Split(capabilities, ",") {
+			if c != "" {
+				info.Capabilities = append(info.Capabilities, c)
+			}
+		}
+	}
+	sort.Strings(info.Capabilities)
+	return info, true
+}
+
+// withOutgoingInfo sets the info to the outgoing gRPC metadata replacing the previously set one
+func withOutgoingInfo(ctx context.Context, info *Info) context.Context {
+	md, ok := grpcmetadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = grpcmetadata.MD{}
+	} else {
+		md = md.Copy()
+	}
+	md.Set(VersionKey, info.Version)
+	md.Set(CapabilitiesKey, strings.Join(info.Capabilities, ","))
+	return grpcmetadata.NewOutgoingContext(ctx, md)
+}
+
+type peerKey struct{}
+
+// Peer returns the version and the capabilities of the peer received for the connection. For the server it is
+// available for the elements after the handshake server, for the client - for the elements before the handshake
+// client after the Request returns.
+func Peer(ctx context.Context, isClient bool) (*Info, bool) {
+	if v, ok := metadata.Map(ctx, isClient).Load(peerKey{}); ok {
+		return v.(*Info), true
+	}
+	return nil, false
+}
+
+func storePeer(ctx context.Context, isClient bool, info *Info) {
+	metadata.Map(ctx, isClient).Store(peerKey{}, info)
+}
+
+// mismatchLogger logs the version mismatch once per peer version
+type mismatchLogger struct {
+	own  *Info
+	seen sync.Map
+}
+
+func (l *mismatchLogger) check(ctx context.Context, peer *Info) {
+	if peer.Version == l.own.Version {
+		return
+	}
+	if _, loaded := l.seen.LoadOrStore(peer.Version, struct{}{}); loaded {
+		return
+	}
+	var missing []string
+	for _, c := range l.own.Capabilities {
+		if !peer.Has(c) {
+			missing = append(missing, c)
+		}
+	}
+	log.FromContext(ctx).Warnf("peer SDK version %s differs from own %s, capabilities missing on peer: %v", peer.Version, l.own.Version, missing)
+}
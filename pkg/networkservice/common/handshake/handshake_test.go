@@ -0,0 +1,122 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handshake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/handshake"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+// headerServer emulates the remote handshake server: checks the outgoing metadata and sets the response header
+type headerServer struct {
+	t *testing.T
+}
+
+func (s *headerServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	md, ok := grpcmetadata.FromOutgoingContext(ctx)
+	require.True(s.t, ok)
+	require.Equal(s.t, []string{"v1.1.0"}, md.Get(handshake.VersionKey))
+	require.Equal(s.t, []string{"a,b"}, md.Get(handshake.CapabilitiesKey))
+
+	for _, opt := range opts {
+		if header, ok := opt.(grpc.HeaderCallOption); ok {
+			*header.HeaderAddr = grpcmetadata.Pairs(handshake.VersionKey, "v1.0.0", handshake.CapabilitiesKey, "a")
+		}
+	}
+	return request.GetConnection(), nil
+}
+
+func (s *headerServer) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
+
+func TestHandshakeClient(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var peer *handshake.Info
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			// Runs before the handshake, peer is not known yet
+			_, ok := handshake.Peer(ctx, true)
+			require.False(t, ok)
+		}),
+		&peerLoader{peer: &peer},
+		handshake.NewClient(handshake.WithVersion("v1.1.0"), handshake.WithCapabilities("a", "b")),
+		&headerServer{t: t},
+	)
+
+	_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "v1.0.0", peer.Version)
+	require.True(t, peer.Has("a"))
+	require.False(t, peer.Has("b"))
+}
+
+// peerLoader loads the peer info after the Request returns
+type peerLoader struct {
+	peer **handshake.Info
+}
+
+func (l *peerLoader) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err == nil {
+		*l.peer, _ = handshake.Peer(ctx, true)
+	}
+	return conn, err
+}
+
+func (l *peerLoader) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func TestHandshakeServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		handshake.NewServer(handshake.WithVersion("v1.1.0")),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			peer, ok := handshake.Peer(ctx, false)
+			require.True(t, ok)
+			require.Equal(t, "v1.0.0", peer.Version)
+			require.Equal(t, []string{"a", "b"}, peer.Capabilities)
+		}),
+	)
+
+	ctx := grpcmetadata.NewIncomingContext(context.Background(),
+		grpcmetadata.Pairs(handshake.VersionKey, "v1.0.0", handshake.CapabilitiesKey, "b,a"))
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id"},
+	})
+	require.NoError(t, err)
+}
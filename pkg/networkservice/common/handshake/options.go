@@ -0,0 +1,44 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handshake
+
+// Option is an option pattern for NewServer, NewClient
+type Option func(o *Info)
+
+// WithVersion sets the announced SDK version. Default is Version.
+func WithVersion(version string) Option {
+	return func(o *Info) {
+		o.Version = version
+	}
+}
+
+// WithCapabilities sets the announced capability flags
+func WithCapabilities(capabilities ...string) Option {
+	return func(o *Info) {
+		o.Capabilities = capabilities
+	}
+}
+
+func newInfo(opts ...Option) *Info {
+	info := &Info{
+		Version: Version,
+	}
+	for _, opt := range opts {
+		opt(info)
+	}
+	return info
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handshake
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type handshakeServer struct {
+	own      *Info
+	mismatch *mismatchLogger
+}
+
+// NewServer - returns a new server chain element reading the peer version and capabilities from the incoming gRPC
+// metadata and announcing its own ones in the response header. It should be placed after metadata.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	own := newInfo(opts...)
+	return &handshakeServer{
+		own:      own,
+		mismatch: &mismatchLogger{own: own},
+	}
+}
+
+func (s *handshakeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if md, ok := grpcmetadata.FromIncomingContext(ctx); ok {
+		if peer, ok := fromMD(md); ok {
+			s.mismatch.check(ctx, peer)
+			storePeer(ctx, false, peer)
+		}
+	}
+	// Fails if ctx is not the gRPC server one (e.g. the refresh events), there is nobody to announce to then
+	_ = grpc.SetHeader(ctx, grpcmetadata.Pairs(s.own.pairs()...))
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *handshakeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heal
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff - policy of the delays between the failed reconnect attempts: the first delay is InitialInterval, each next
+// one is multiplied by Multiplier up to MaxInterval (or up to the max time.Duration if MaxInterval is not set). Every delay is randomized by ±Jitter fraction of it, so the
+// clients of the same endpoint don't reconnect synchronously.
+type Backoff struct {
+	InitialInterval time.Duration
+	Multiplier      float64
+	MaxInterval     time.Duration
+	Jitter          float64
+}
+
+// delay - returns the delay before the attempt following the failed one with the attempt number (starting from 0)
+func (b *Backoff) delay(attempt int) time.Duration {
+	if b == nil || b.InitialInterval <= 0 {
+		return 0
+	}
+
+	maxInterval := float64(math.MaxInt64)
+	if b.MaxInterval > 0 {
+		maxInterval = float64(b.MaxInterval)
+	}
+
+	interval := float64(b.InitialInterval)
+	for i := 0; i < attempt && interval < maxInterval; i++ {
+		interval *= b.Multiplier
+	}
+	interval = math.Min(interval, maxInterval)
+
+	if b.Jitter > 0 {
+		// #nosec G404 - jitter doesn't need a cryptographically secure random
+		interval += interval * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	// float64(math.MaxInt64) is rounded up to 2^63, so the conversion of it overflows
+	if interval >= float64(math.MaxInt64) {
+		return math.MaxInt64
+	}
+	return time.Duration(interval)
+}
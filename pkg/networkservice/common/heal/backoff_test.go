@@ -0,0 +1,61 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	var noBackoff *Backoff
+	require.Zero(t, noBackoff.delay(5))
+
+	backoff := &Backoff{
+		InitialInterval: time.Second,
+		Multiplier:      2,
+		MaxInterval:     10 * time.Second,
+	}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		require.Equal(t, expected, backoff.delay(attempt))
+	}
+
+	backoff.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := backoff.delay(1)
+		require.GreaterOrEqual(t, delay, time.Second)
+		require.LessOrEqual(t, delay, 3*time.Second)
+	}
+}
+
+func TestBackoff_DelayUncapped(t *testing.T) {
+	backoff := &Backoff{
+		InitialInterval: time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+	}
+	for attempt := 0; attempt < 100; attempt++ {
+		require.Greater(t, backoff.delay(attempt), time.Duration(0))
+	}
+	require.GreaterOrEqual(t, backoff.delay(100), time.Duration(math.MaxInt64/2))
+
+	backoff.Jitter = 0
+	require.Equal(t, time.Duration(math.MaxInt64), backoff.delay(100))
+}
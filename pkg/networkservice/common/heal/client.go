@@ -36,6 +36,9 @@ type healClient struct {
 	livenessCheck         LivenessCheck
 	livenessCheckInterval time.Duration
 	livenessCheckTimeout  time.Duration
+	backoff               *Backoff
+	onHeal                EventHandler
+	onRestore             EventHandler
 }
 
 // NewClient - returns a new heal client chain element
@@ -52,6 +55,9 @@ func NewClient(chainCtx context.Context, opts ...Option) networkservice.NetworkS
 		livenessCheck:         o.livenessCheck,
 		livenessCheckInterval: o.livenessCheckInterval,
 		livenessCheckTimeout:  o.livenessCheckTimeout,
+		backoff:               o.backoff,
+		onHeal:                o.onHeal,
+		onRestore:             o.onRestore,
 	}
 }
 
@@ -18,6 +18,7 @@ package heal
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -28,13 +29,22 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
+var closedTimeCh = func() <-chan time.Time {
+	ch := make(chan time.Time)
+	close(ch)
+	return ch
+}()
+
 type eventLoop struct {
 	heal         *healClient
 	eventLoopCtx context.Context
+	healingCtx   context.Context
 	chainCtx     context.Context
+	reconnecting int32
 	conn         *networkservice.Connection
 	eventFactory begin.EventFactory
 	client       networkservice.MonitorConnection_MonitorConnectionsClient
@@ -52,6 +62,9 @@ func newEventLoop(ctx context.Context, cc grpc.ClientConnInterface, conn *networ
 
 	// Create new eventLoopCtx and store its eventLoopCancel
 	eventLoopCtx, eventLoopCancel := context.WithCancel(ctx)
+	// healingCtx is cancelled only by the Request and Close coming not from the healing itself, the healing reconnect
+	// requests cancel eventLoopCtx, but the healing should go on if they fail
+	healingCtx, healingCancel := context.WithCancel(ctx)
 
 	// Create selector to only ask for events related to our Connection
 	selector := &networkservice.MonitorScopeSelector{
@@ -66,6 +79,7 @@ func newEventLoop(ctx context.Context, cc grpc.ClientConnInterface, conn *networ
 	client, err := networkservice.NewMonitorConnectionClient(cc).MonitorConnections(eventLoopCtx, selector)
 	if err != nil {
 		eventLoopCancel()
+		healingCancel()
 		return nil, errors.WithStack(err)
 	}
 
@@ -73,6 +87,7 @@ func newEventLoop(ctx context.Context, cc grpc.ClientConnInterface, conn *networ
 	_, err = client.Recv()
 	if err != nil {
 		eventLoopCancel()
+		healingCancel()
 		return nil, errors.WithStack(err)
 	}
 
@@ -80,6 +95,7 @@ func newEventLoop(ctx context.Context, cc grpc.ClientConnInterface, conn *networ
 	cev := &eventLoop{
 		heal:         heal,
 		eventLoopCtx: eventLoopCtx,
+		healingCtx:   healingCtx,
 		chainCtx:     ctx,
 		conn:         conn,
 		eventFactory: ev,
@@ -88,8 +104,17 @@ func newEventLoop(ctx context.Context, cc grpc.ClientConnInterface, conn *networ
 	}
 
 	// Start the eventLoop
-	go cev.eventLoop()
-	return eventLoopCancel, nil
+	go func() {
+		defer healingCancel()
+		cev.eventLoop()
+	}()
+	return func() {
+		// Request and Close are serialized by begin, so no other Request or Close runs during the reconnect
+		if atomic.LoadInt32(&cev.reconnecting) == 0 {
+			healingCancel()
+		}
+		eventLoopCancel()
+	}, nil
 }
 
 func (cev *eventLoop) monitorCtrlPlane() <-chan struct{} {
@@ -130,8 +155,6 @@ func (cev *eventLoop) monitorCtrlPlane() <-chan struct{} {
 func (cev *eventLoop) eventLoop() {
 	reselect := false
 
-	ctrlPlaneCh := cev.monitorCtrlPlane()
-
 	livenessCheckCtx, livenessCheckCancel := context.WithCancel(context.Background())
 	defer livenessCheckCancel()
 	if cev.heal.livenessCheck != nil {
@@ -144,32 +167,53 @@ func (cev *eventLoop) eventLoop() {
 		reselect = true
 	}
 
+	reason, ok := cev.waitForHealing(livenessCheckCtx)
+	if !ok {
+		return
+	}
+	if reason == ReasonDataPlaneDown {
+		reselect = true
+	}
+
+	cev.enterHealing(reason)
+	cev.reconnect(livenessCheckCtx, reason, reselect)
+}
+
+// waitForHealing - waits for the control or data plane to go down, returns false if the connection is closed
+func (cev *eventLoop) waitForHealing(livenessCheckCtx context.Context) (Reason, bool) {
+	ctrlPlaneCh := cev.monitorCtrlPlane()
+
 	select {
 	case _, ok := <-ctrlPlaneCh:
 		cev.logger.Warnf("Control plane is down")
 		if ok {
 			// Connection closed
-			return
+			return "", false
 		}
 		// Start healing
+		return ReasonControlPlaneDown, true
 	case <-livenessCheckCtx.Done():
 		cev.logger.Warnf("Data plane is down")
-		reselect = true
 		// Start healing
+		return ReasonDataPlaneDown, true
 	case <-cev.chainCtx.Done():
-		return
+		return "", false
 	case <-cev.eventLoopCtx.Done():
-		return
+		return "", false
 	}
+}
 
-	/* Attempts to heal the connection */
-	for {
+// reconnect - attempts to heal the connection until it is healed or closed
+func (cev *eventLoop) reconnect(livenessCheckCtx context.Context, reason Reason, reselect bool) {
+	for attempt := 0; ; attempt++ {
 		select {
 		case <-cev.chainCtx.Done():
 			return
-		default:
+		case <-cev.healingCtx.Done():
+			return
+		case <-cev.backoff(attempt):
 			var options []begin.Option
-			if cev.chainCtx.Err() != nil {
+			if cev.chainCtx.Err() != nil || cev.healingCtx.Err() != nil {
 				return
 			}
 			if !reselect && livenessCheckCtx.Err() != nil {
@@ -180,20 +224,54 @@ func (cev *eventLoop) eventLoop() {
 				cev.logger.Debugf("Reconnect with reselect")
 				options = append(options, begin.WithReselect())
 			}
-			if err := <-cev.eventFactory.Request(options...); err == nil {
+			atomic.StoreInt32(&cev.reconnecting, 1)
+			err, ok := <-cev.eventFactory.Request(options...)
+			atomic.StoreInt32(&cev.reconnecting, 0)
+			if !ok {
+				// Connection is closed
+				return
+			}
+			if err == nil {
+				cev.exitHealing(reason, attempt+1)
 				return
 			}
 		}
 	}
 }
 
+func (cev *eventLoop) enterHealing(reason Reason) {
+	if cev.heal.onHeal != nil {
+		cev.heal.onHeal(Event{ConnectionID: cev.conn.GetId(), Reason: reason})
+	}
+}
+
+func (cev *eventLoop) exitHealing(reason Reason, attempts int) {
+	if cev.heal.onRestore != nil {
+		cev.heal.onRestore(Event{ConnectionID: cev.conn.GetId(), Reason: reason, Attempts: attempts})
+	}
+}
+
+// backoff - returns a channel receiving when the attempt can be made
+func (cev *eventLoop) backoff(attempt int) <-chan time.Time {
+	if attempt == 0 {
+		return closedTimeCh
+	}
+	delay := cev.heal.backoff.delay(attempt - 1)
+	if delay <= 0 {
+		return closedTimeCh
+	}
+	cev.logger.Debugf("Reconnect in %s", delay)
+	return clock.FromContext(cev.chainCtx).After(delay)
+}
+
 func (cev *eventLoop) monitorDataPlane() {
-	ticker := time.NewTicker(cev.heal.livenessCheckInterval)
+	clockTime := clock.FromContext(cev.chainCtx)
+	ticker := clockTime.Ticker(cev.heal.livenessCheckInterval)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-ticker.C:
-			deadlineCtx, deadlineCancel := context.WithDeadline(cev.chainCtx, time.Now().Add(cev.heal.livenessCheckTimeout))
+		case <-ticker.C():
+			deadlineCtx, deadlineCancel := clockTime.WithTimeout(cev.chainCtx, cev.heal.livenessCheckTimeout)
 			alive := cev.heal.livenessCheck(deadlineCtx, cev.conn)
 			deadlineCancel()
 
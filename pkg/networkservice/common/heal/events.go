@@ -0,0 +1,41 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heal
+
+// Reason - reason of the connection healing
+type Reason string
+
+const (
+	// ReasonControlPlaneDown - the control plane of the connection is down
+	ReasonControlPlaneDown Reason = "control-plane-down"
+	// ReasonDataPlaneDown - the data plane liveness check of the connection has failed
+	ReasonDataPlaneDown Reason = "data-plane-down"
+)
+
+// Event - connection healing event
+type Event struct {
+	// ConnectionID - ID of the healed connection
+	ConnectionID string
+	// Reason - reason of the healing
+	Reason Reason
+	// Attempts - number of the reconnect attempts made, it is 0 when the connection enters healing
+	Attempts int
+}
+
+// EventHandler - handler of the healing events. It is called synchronously from the healing loop, so it should not
+// block.
+type EventHandler func(event Event)
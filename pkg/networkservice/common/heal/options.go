@@ -35,6 +35,9 @@ type options struct {
 	livenessCheck         LivenessCheck
 	livenessCheckInterval time.Duration
 	livenessCheckTimeout  time.Duration
+	backoff               *Backoff
+	onHeal                EventHandler
+	onRestore             EventHandler
 }
 
 // Option - option for heal.NewClient() chain element
@@ -60,3 +63,32 @@ func WithLivenessCheckTimeout(livenessCheckTimeout time.Duration) Option {
 		o.livenessCheckTimeout = livenessCheckTimeout
 	}
 }
+
+// WithLivenessProbeRegistry - sets the data plane liveness checker selecting the probe by the connection mechanism type
+func WithLivenessProbeRegistry(registry *LivenessProbeRegistry) Option {
+	return func(o *options) {
+		o.livenessCheck = registry.Check
+	}
+}
+
+// WithBackoff - sets the policy of the delays between the failed reconnect attempts. By default the attempts are made
+// without any delay.
+func WithBackoff(backoff Backoff) Option {
+	return func(o *options) {
+		o.backoff = &backoff
+	}
+}
+
+// WithOnHeal - sets the handler called when the connection enters healing
+func WithOnHeal(onHeal EventHandler) Option {
+	return func(o *options) {
+		o.onHeal = onHeal
+	}
+}
+
+// WithOnRestore - sets the handler called when the connection is restored by healing
+func WithOnRestore(onRestore EventHandler) Option {
+	return func(o *options) {
+		o.onRestore = onRestore
+	}
+}
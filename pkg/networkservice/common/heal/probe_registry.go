@@ -0,0 +1,71 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// LivenessProbeRegistry - selects the data plane liveness check by the connection mechanism type
+type LivenessProbeRegistry struct {
+	mu           sync.RWMutex
+	probes       map[string]LivenessCheck
+	defaultProbe LivenessCheck
+}
+
+// NewLivenessProbeRegistry - returns a new empty LivenessProbeRegistry
+func NewLivenessProbeRegistry() *LivenessProbeRegistry {
+	return &LivenessProbeRegistry{
+		probes: make(map[string]LivenessCheck),
+	}
+}
+
+// Register - registers the probe for the mechanism type
+func (r *LivenessProbeRegistry) Register(mechanismType string, probe LivenessCheck) *LivenessProbeRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.probes[mechanismType] = probe
+	return r
+}
+
+// SetDefault - sets the probe used for the mechanism types with no registered probe
+func (r *LivenessProbeRegistry) SetDefault(probe LivenessCheck) *LivenessProbeRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.defaultProbe = probe
+	return r
+}
+
+// Check - checks conn with the probe registered for its mechanism type. Connections with no probe are 'live'.
+func (r *LivenessProbeRegistry) Check(deadlineCtx context.Context, conn *networkservice.Connection) bool {
+	r.mu.RLock()
+	probe, ok := r.probes[conn.GetMechanism().GetType()]
+	if !ok {
+		probe = r.defaultProbe
+	}
+	r.mu.RUnlock()
+
+	if probe == nil {
+		return true
+	}
+	return probe(deadlineCtx, conn)
+}
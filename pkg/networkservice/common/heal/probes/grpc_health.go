@@ -0,0 +1,58 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
+)
+
+// GRPCHealth returns a probe checking that the gRPC health service on the port of the connection destination IP
+// addresses reports the service as serving. Insecure credentials are used if no dial options are passed.
+func GRPCHealth(port uint16, service string, dialOptions ...grpc.DialOption) heal.LivenessCheck {
+	if len(dialOptions) == 0 {
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	return func(deadlineCtx context.Context, conn *networkservice.Connection) bool {
+		return checkDstIPs(deadlineCtx, conn, func(ctx context.Context, ip net.IP) error {
+			cc, err := grpc.DialContext(ctx, net.JoinHostPort(ip.String(), strconv.Itoa(int(port))), dialOptions...)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = cc.Close() }()
+
+			resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service},
+				grpc.WaitForReady(true))
+			if err != nil {
+				return err
+			}
+			if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+				return errors.Errorf("service %q is %s", service, resp.GetStatus())
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"sync/atomic"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
+)
+
+const (
+	protocolICMP     = 1
+	protocolICMPv6   = 58
+	maxICMPReplySize = 1500
+)
+
+var icmpSeq uint32
+
+// ICMP returns a probe checking that the connection destination IP addresses reply to ICMP echo requests.
+// Unprivileged ICMP sockets are used, so on Linux the process group should be allowed by net.ipv4.ping_group_range.
+func ICMP() heal.LivenessCheck {
+	return func(deadlineCtx context.Context, conn *networkservice.Connection) bool {
+		return checkDstIPs(deadlineCtx, conn, ping)
+	}
+}
+
+func ping(ctx context.Context, ip net.IP) error {
+	network, protocol, echoType, replyType := "udp4", protocolICMP, icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply)
+	if ip.To4() == nil {
+		network, protocol, echoType, replyType = "udp6", protocolICMPv6, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+
+	pc, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen %s", network)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		_ = pc.Close()
+	}()
+
+	seq := int(atomic.AddUint32(&icmpSeq, 1) & 0xffff)
+	data := []byte("nsm-liveness-probe")
+	if err := sendEcho(pc, ip, echoType, seq, data); err != nil {
+		return err
+	}
+
+	buf := make([]byte, maxICMPReplySize)
+	for {
+		n, peer, err := pc.ReadFrom(buf)
+		if err != nil {
+			return errors.Wrapf(err, "no ICMP echo reply from %s", ip)
+		}
+		if udpAddr, ok := peer.(*net.UDPAddr); ok && udpAddr.IP.Equal(ip) && isEchoReply(buf[:n], protocol, replyType, seq, data) {
+			return nil
+		}
+	}
+}
+
+func sendEcho(pc net.PacketConn, ip net.IP, echoType icmp.Type, seq int, data []byte) error {
+	request, err := (&icmp.Message{
+		Type: echoType,
+		Body: &icmp.Echo{ID: os.Getpid() & 0xffff, Seq: seq, Data: data},
+	}).Marshal(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ICMP echo request")
+	}
+	if _, err = pc.WriteTo(request, &net.UDPAddr{IP: ip}); err != nil {
+		return errors.Wrapf(err, "failed to send ICMP echo request to %s", ip)
+	}
+	return nil
+}
+
+// isEchoReply - checks that msg is a reply to the echo request with the given seq and data
+func isEchoReply(msg []byte, protocol int, replyType icmp.Type, seq int, data []byte) bool {
+	reply, err := icmp.ParseMessage(protocol, msg)
+	if err != nil || reply.Type != replyType {
+		return false
+	}
+	// The ID is rewritten by the kernel for the unprivileged ICMP sockets, so only Seq and Data are checked
+	echo, ok := reply.Body.(*icmp.Echo)
+	return ok && echo.Seq == seq && bytes.Equal(echo.Data, data)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probes provides data plane liveness probes checking the destination IP addresses of the connection. They
+// are intended to be registered per mechanism type in heal.LivenessProbeRegistry.
+// ARP probes require raw sockets bound to the connection interface, so they are provided by the forwarders.
+package probes
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// checkDstIPs returns true if check succeeds for all the connection destination IP addresses
+func checkDstIPs(ctx context.Context, conn *networkservice.Connection, check func(ctx context.Context, ip net.IP) error) bool {
+	dstIPs := conn.GetContext().GetIpContext().GetDstIpAddrs()
+	if len(dstIPs) == 0 {
+		return true
+	}
+
+	var wg sync.WaitGroup
+	var failed int32
+	for _, cidr := range dstIPs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return false
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if check(ctx, ip) != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return atomic.LoadInt32(&failed) == 0
+}
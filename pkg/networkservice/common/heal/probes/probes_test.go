@@ -0,0 +1,115 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"golang.org/x/net/icmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal/probes"
+)
+
+func newConn(mechanismType string) *networkservice.Connection {
+	return &networkservice.Connection{
+		Mechanism: &networkservice.Mechanism{Type: mechanismType},
+		Context: &networkservice.ConnectionContext{
+			IpContext: &networkservice.IPContext{
+				DstIpAddrs: []string{"127.0.0.1/32"},
+			},
+		},
+	}
+}
+
+func listen(t *testing.T) (net.Listener, uint16) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return l, uint16(l.Addr().(*net.TCPAddr).Port)
+}
+
+func check(probe heal.LivenessCheck, conn *networkservice.Connection) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return probe(ctx, conn)
+}
+
+func TestTCPConnect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	l, port := listen(t)
+	require.True(t, check(probes.TCPConnect(port), newConn(kernel.MECHANISM)))
+
+	require.NoError(t, l.Close())
+	require.False(t, check(probes.TCPConnect(port), newConn(kernel.MECHANISM)))
+}
+
+func TestGRPCHealth(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	l, port := listen(t)
+	healthServer := health.NewServer()
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	healthServer.SetServingStatus("nse", grpc_health_v1.HealthCheckResponse_SERVING)
+	require.True(t, check(probes.GRPCHealth(port, "nse"), newConn(kernel.MECHANISM)))
+
+	healthServer.SetServingStatus("nse", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	require.False(t, check(probes.GRPCHealth(port, "nse"), newConn(kernel.MECHANISM)))
+}
+
+func TestICMP(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	pc, err := icmp.ListenPacket("udp4", "")
+	if err != nil {
+		t.Skipf("unprivileged ICMP sockets are not allowed: %s", err.Error())
+	}
+	_ = pc.Close()
+
+	require.True(t, check(probes.ICMP(), newConn(kernel.MECHANISM)))
+}
+
+func TestLivenessProbeRegistry(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	dead := func(context.Context, *networkservice.Connection) bool { return false }
+
+	registry := heal.NewLivenessProbeRegistry()
+	require.True(t, check(registry.Check, newConn(kernel.MECHANISM)))
+
+	registry.Register(kernel.MECHANISM, dead)
+	require.False(t, check(registry.Check, newConn(kernel.MECHANISM)))
+	require.True(t, check(registry.Check, newConn(vxlan.MECHANISM)))
+
+	registry.SetDefault(dead)
+	require.False(t, check(registry.Check, newConn(vxlan.MECHANISM)))
+}
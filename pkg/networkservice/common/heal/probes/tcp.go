@@ -0,0 +1,42 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
+)
+
+// TCPConnect returns a probe checking that the TCP connection to the port of the connection destination IP addresses
+// can be established
+func TCPConnect(port uint16) heal.LivenessCheck {
+	return func(deadlineCtx context.Context, conn *networkservice.Connection) bool {
+		return checkDstIPs(deadlineCtx, conn, func(ctx context.Context, ip net.IP) error {
+			var dialer net.Dialer
+			c, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), strconv.Itoa(int(port))))
+			if err != nil {
+				return err
+			}
+			return c.Close()
+		})
+	}
+}
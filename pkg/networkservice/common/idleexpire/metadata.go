@@ -0,0 +1,46 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idleexpire
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type (
+	cancelKey      struct{}
+	establishedKey struct{}
+)
+
+func storeCancel(ctx context.Context, cancel context.CancelFunc) {
+	metadata.Map(ctx, false).Store(cancelKey{}, cancel)
+}
+
+func loadAndDeleteCancel(ctx context.Context) (context.CancelFunc, bool) {
+	v, ok := metadata.Map(ctx, false).LoadAndDelete(cancelKey{})
+	if !ok {
+		return nil, false
+	}
+	return v.(context.CancelFunc), true
+}
+
+func loadOrStoreEstablished(ctx context.Context, established time.Time) time.Time {
+	v, _ := metadata.Map(ctx, false).LoadOrStore(establishedKey{}, established)
+	return v.(time.Time)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idleexpire
+
+import "time"
+
+// Option is an option pattern for NewServer
+type Option func(s *idleExpireServer)
+
+// WithIdleTimeout sets the period of no activity after which the connection is closed
+// By default 10 minutes
+func WithIdleTimeout(idleTimeout time.Duration) Option {
+	return func(s *idleExpireServer) {
+		s.idleTimeout = idleTimeout
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idleexpire provides a chain element closing the connections idle for too long.
+// Control-plane refreshes are not a reliable liveness signal: an intermediate NSMgr keeps refreshing the tokens of the
+// connections whose NSC has vanished without Close. So the connection activity is queried from the pluggable
+// ActivitySource (e.g. datapath counters) and the connection is closed if it has no activity for the idle timeout,
+// reclaiming IPAM and interface resources.
+package idleexpire
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultIdleTimeout = 10 * time.Minute
+
+// ActivitySource provides the time of the last connection activity
+type ActivitySource interface {
+	// LastActivity returns the time of the last connection activity or zero time if there was no activity
+	LastActivity(ctx context.Context, conn *networkservice.Connection) (time.Time, error)
+}
+
+// ActivitySourceFunc is a function adapter for ActivitySource
+type ActivitySourceFunc func(ctx context.Context, conn *networkservice.Connection) (time.Time, error)
+
+// LastActivity calls f(ctx, conn)
+func (f ActivitySourceFunc) LastActivity(ctx context.Context, conn *networkservice.Connection) (time.Time, error) {
+	return f(ctx, conn)
+}
+
+type idleExpireServer struct {
+	chainCtx    context.Context
+	source      ActivitySource
+	idleTimeout time.Duration
+}
+
+// NewServer creates a new idleexpire server closing the connections having no activity reported by the source for
+// the idle timeout (10 minutes by default). The connection establishment is treated as its first activity, following
+// refreshes are not.
+// It should be placed after begin and metadata.
+func NewServer(chainCtx context.Context, source ActivitySource, opts ...Option) networkservice.NetworkServiceServer {
+	s := &idleExpireServer{
+		chainCtx:    chainCtx,
+		source:      source,
+		idleTimeout: defaultIdleTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *idleExpireServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cancel, ok := loadAndDeleteCancel(ctx); ok {
+		cancel()
+	}
+
+	clk := clock.FromContext(ctx)
+	established := loadOrStoreEstablished(ctx, clk.Now())
+
+	cancelCtx, cancel := context.WithCancel(s.chainCtx)
+	storeCancel(ctx, cancel)
+
+	timer := clk.Timer(s.idleTimeout - clk.Since(established))
+	go s.watch(cancelCtx, begin.FromContext(ctx), conn.Clone(), established, timer, clk,
+		log.FromContext(ctx).WithField("idleExpireServer", "watch"))
+
+	return conn, nil
+}
+
+func (s *idleExpireServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if cancel, ok := loadAndDeleteCancel(ctx); ok {
+		cancel()
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *idleExpireServer) watch(ctx context.Context, eventFactory begin.EventFactory, conn *networkservice.Connection,
+	lastActivity time.Time, timer clock.Timer, clk clock.Clock, logger log.Logger) {
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+
+		activity, err := s.source.LastActivity(ctx, conn)
+		switch {
+		case err != nil:
+			logger.Warnf("failed to get the last activity of the connection %s: %s", conn.GetId(), err.Error())
+		case activity.After(lastActivity):
+			lastActivity = activity
+		}
+
+		idle := clk.Since(lastActivity)
+		if idle >= s.idleTimeout {
+			logger.Warnf("connection %s is idle for %s, closing it", conn.GetId(), idle)
+			eventFactory.Close(begin.CancelContext(ctx))
+			return
+		}
+		timer.Reset(s.idleTimeout - idle)
+	}
+}
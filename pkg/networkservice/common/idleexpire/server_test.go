@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idleexpire_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/idleexpire"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+const idleTimeout = time.Minute
+
+type activitySource struct {
+	mu           sync.Mutex
+	lastActivity time.Time
+}
+
+func (s *activitySource) set(lastActivity time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastActivity = lastActivity
+}
+
+func (s *activitySource) LastActivity(_ context.Context, _ *networkservice.Connection) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastActivity, nil
+}
+
+func newServer(ctx context.Context, source idleexpire.ActivitySource, counter *count.Server) networkservice.NetworkServiceServer {
+	return chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		idleexpire.NewServer(ctx, source, idleexpire.WithIdleTimeout(idleTimeout)),
+		counter,
+	)
+}
+
+// waitClosed moves the clock forward until the connection is closed and returns the elapsed time
+func waitClosed(t *testing.T, clockMock *clockmock.Mock, counter *count.Server) time.Duration {
+	start := clockMock.Now()
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Second)
+		return counter.Closes() == 1
+	}, time.Second, time.Millisecond)
+	return clockMock.Since(start)
+}
+
+func TestIdleExpireServer_Activity(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	source := new(activitySource)
+	counter := new(count.Server)
+	server := newServer(ctx, source, counter)
+
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: &networkservice.Connection{Id: "id"}})
+	require.NoError(t, err)
+
+	clockMock.Add(idleTimeout / 2)
+	source.set(clockMock.Now())
+
+	require.GreaterOrEqual(t, waitClosed(t, clockMock, counter), idleTimeout)
+}
+
+func TestIdleExpireServer_RefreshIsNotActivity(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	counter := new(count.Server)
+	server := newServer(ctx, new(activitySource), counter)
+
+	request := &networkservice.NetworkServiceRequest{Connection: &networkservice.Connection{Id: "id"}}
+	conn, err := server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+
+	clockMock.Add(idleTimeout / 2)
+	request.Connection = conn
+	_, err = server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+
+	require.Less(t, waitClosed(t, clockMock, counter), idleTimeout)
+}
+
+func TestIdleExpireServer_Close(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	counter := new(count.Server)
+	server := newServer(ctx, new(activitySource), counter)
+
+	conn, err := server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: &networkservice.Connection{Id: "id"}})
+	require.NoError(t, err)
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+
+	clockMock.Add(2 * idleTimeout)
+	require.Never(t, func() bool { return counter.Closes() > 1 }, time.Millisecond*100, time.Millisecond*10)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type leaseClient struct {
+	chainCtx context.Context
+	interval time.Duration
+}
+
+// NewClient creates a new NetworkServiceClient chain element renewing the leases of the connections until they are
+// closed. It should be placed after dial.
+func NewClient(chainCtx context.Context, opts ...ClientOption) networkservice.NetworkServiceClient {
+	c := &leaseClient{
+		chainCtx: chainCtx,
+		interval: defaultRenewInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *leaseClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, ok := clientconn.Load(ctx)
+	if !ok {
+		return conn, nil
+	}
+
+	renewCtx, cancel := context.WithCancel(extend.WithValuesFromContext(c.chainCtx, ctx))
+	store(ctx, cancel)
+
+	go c.renew(renewCtx, NewLeaseClient(cc), conn.GetNextPathSegment().GetId(), log.FromContext(ctx).WithField("leaseClient", "renew"))
+
+	return conn, nil
+}
+
+func (c *leaseClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// renew renews the lease until the ctx is canceled or the server reports it has no lease for the connection
+func (c *leaseClient) renew(ctx context.Context, leaseClient LeaseClient, id string, logger log.Logger) {
+	ticker := clock.FromContext(ctx).Ticker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		if _, err := leaseClient.Renew(ctx, &networkservice.Connection{Id: id}); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if code := status.Code(err); code == codes.NotFound || code == codes.Unimplemented {
+				logger.Infof("connection %s has no lease: %s", id, err.Error())
+				return
+			}
+			logger.Warnf("failed to renew the lease of the connection %s: %s", id, err.Error())
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lease provides chain elements binding the connections of the designated (e.g. interdomain) clients to
+// time-bound leases.
+// The server side element closes the connection with all its resources once its lease expires, so the lease must be
+// actively renewed with the lightweight Renew call of the lease service. Unlike the token expiration, the lease
+// doesn't depend on the refresh period chosen by the client, so it provides stricter resource control for the
+// cross-domain tenants.
+// The client side element renews the leases of its connections until they are closed.
+package lease
+
+import (
+	"time"
+)
+
+const (
+	defaultDuration      = time.Minute
+	defaultRenewInterval = defaultDuration / 3
+)
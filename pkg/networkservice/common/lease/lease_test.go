@@ -0,0 +1,170 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/lease"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+func newRequest(ns string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "conn-id",
+			NetworkService: ns,
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Id: "nsc-id"},
+					{Id: "conn-id"},
+				},
+			},
+		},
+	}
+}
+
+func TestLeaseServer_Expire(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	leaseServer := lease.NewServer(ctx, lease.WithDuration(time.Minute))
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		leaseServer,
+		counter,
+	)
+
+	conn, err := server.Request(ctx, newRequest("ns@domain"))
+	require.NoError(t, err)
+
+	clockMock.Add(40 * time.Second)
+	_, err = leaseServer.Renew(ctx, &networkservice.Connection{Id: conn.GetId()})
+	require.NoError(t, err)
+
+	clockMock.Add(40 * time.Second)
+	require.Never(t, func() bool { return counter.Closes() > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	clockMock.Add(30 * time.Second)
+	require.Eventually(t, func() bool { return counter.Closes() == 1 }, time.Second, 10*time.Millisecond)
+
+	_, err = leaseServer.Renew(ctx, &networkservice.Connection{Id: conn.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestLeaseServer_NotSelected(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	leaseServer := lease.NewServer(ctx)
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		leaseServer,
+		counter,
+	)
+
+	conn, err := server.Request(ctx, newRequest("ns"))
+	require.NoError(t, err)
+
+	_, err = leaseServer.Renew(ctx, &networkservice.Connection{Id: conn.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	clockMock.Add(time.Hour)
+	require.Never(t, func() bool { return counter.Closes() > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestLeaseClient_Renew(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leaseServer := lease.NewServer(ctx, lease.WithDuration(500*time.Millisecond))
+	counter := new(count.Server)
+
+	grpcServer := grpc.NewServer()
+	networkservice.RegisterNetworkServiceServer(grpcServer, chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		leaseServer,
+		counter,
+	))
+	lease.RegisterLeaseServer(grpcServer, leaseServer)
+
+	listenOn := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	select {
+	case serveErr := <-grpcutils.ListenAndServe(ctx, listenOn, grpcServer):
+		require.NoError(t, serveErr)
+	default:
+	}
+
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(listenOn), grpc.WithBlock(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		metadata.NewClient(),
+		clientconn.NewClient(cc),
+		lease.NewClient(ctx, lease.WithRenewInterval(100*time.Millisecond)),
+		networkservice.NewNetworkServiceClient(cc),
+	)
+
+	conn, err := client.Request(ctx, newRequest("ns@domain"))
+	require.NoError(t, err)
+
+	// The client renews the lease, so the connection outlives it
+	require.Never(t, func() bool { return counter.Closes() > 0 }, 1500*time.Millisecond, 50*time.Millisecond)
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Closes())
+
+	// Without renewals the lease expires
+	_, err = networkservice.NewNetworkServiceClient(cc).Request(ctx, newRequest("ns@domain"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return counter.Closes() == 2 }, 2*time.Second, 50*time.Millisecond)
+}
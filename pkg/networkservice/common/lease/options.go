@@ -0,0 +1,53 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Option is an option pattern for NewServer
+type Option func(s *Server)
+
+// WithDuration sets the lease duration. Default value = 1 minute.
+func WithDuration(duration time.Duration) Option {
+	return func(s *Server) {
+		s.duration = duration
+	}
+}
+
+// WithSelector sets a function selecting the connections bound to the leases. Default selector selects the
+// interdomain connections.
+func WithSelector(selector func(ctx context.Context, conn *networkservice.Connection) bool) Option {
+	return func(s *Server) {
+		s.selector = selector
+	}
+}
+
+// ClientOption is an option pattern for NewClient
+type ClientOption func(c *leaseClient)
+
+// WithRenewInterval sets the interval between the lease renewals. It should be several times less than the server
+// lease duration. Default value = 20 seconds.
+func WithRenewInterval(interval time.Duration) ClientOption {
+	return func(c *leaseClient) {
+		c.interval = interval
+	}
+}
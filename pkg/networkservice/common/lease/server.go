@@ -0,0 +1,137 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Server is a NetworkServiceServer chain element closing the selected connections once their leases expire. It also
+// implements LeaseServer, so it should be registered with RegisterLeaseServer on the same gRPC server.
+type Server struct {
+	chainCtx context.Context
+	duration time.Duration
+	selector func(ctx context.Context, conn *networkservice.Connection) bool
+
+	mu     sync.Mutex
+	leases map[string]*lease
+}
+
+type lease struct {
+	timer  clock.Timer
+	cancel context.CancelFunc
+}
+
+// NewServer creates a new lease Server
+func NewServer(chainCtx context.Context, opts ...Option) *Server {
+	s := &Server{
+		chainCtx: chainCtx,
+		duration: defaultDuration,
+		selector: func(_ context.Context, conn *networkservice.Connection) bool {
+			return interdomain.Is(conn.GetNetworkService())
+		},
+		leases: make(map[string]*lease),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Request starts the lease of the selected connection or extends it on refresh
+func (s *Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.selector(ctx, conn) {
+		s.remove(conn.GetId())
+		return conn, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.leases[conn.GetId()]; ok {
+		// If the lease has already expired, the connection is going to be closed right after this Request
+		if l.timer.Stop() {
+			l.timer.Reset(s.duration)
+		}
+		return conn, nil
+	}
+
+	id := conn.GetId()
+	eventFactory := begin.FromContext(ctx)
+	logger := log.FromContext(ctx).WithField("leaseServer", "Request")
+
+	cancelCtx, cancel := context.WithCancel(s.chainCtx)
+	s.leases[id] = &lease{
+		timer: clock.FromContext(ctx).AfterFunc(s.duration, func() {
+			logger.Warnf("lease of the connection %s has expired, closing the connection", id)
+			eventFactory.Close(begin.CancelContext(cancelCtx))
+		}),
+		cancel: cancel,
+	}
+
+	return conn, nil
+}
+
+// Close releases the lease of the connection
+func (s *Server) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.remove(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// Renew extends the lease of the connection with the conn.Id. It fails with codes.NotFound if the connection has no
+// lease or the lease has already expired.
+func (s *Server) Renew(_ context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.leases[conn.GetId()]
+	if !ok || !l.timer.Stop() {
+		return nil, status.Errorf(codes.NotFound, "no active lease for the connection: %s", conn.GetId())
+	}
+	l.timer.Reset(s.duration)
+
+	return &empty.Empty{}, nil
+}
+
+func (s *Server) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.leases[id]; ok {
+		l.timer.Stop()
+		l.cancel()
+		delete(s.leases, id)
+	}
+}
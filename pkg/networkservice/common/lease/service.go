@@ -0,0 +1,94 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+)
+
+const renewMethod = "/lease.Lease/Renew"
+
+// LeaseServer is the server API of the lease service
+type LeaseServer interface {
+	// Renew extends the lease of the connection with the conn.Id
+	Renew(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error)
+}
+
+// LeaseClient is the client API of the lease service
+type LeaseClient interface {
+	// Renew extends the lease of the connection with the conn.Id
+	Renew(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type leaseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewLeaseClient creates a new LeaseClient
+func NewLeaseClient(cc grpc.ClientConnInterface) LeaseClient {
+	return &leaseServiceClient{
+		cc: cc,
+	}
+}
+
+func (c *leaseServiceClient) Renew(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	if err := c.cc.Invoke(ctx, renewMethod, conn, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegisterLeaseServer registers the LeaseServer in the gRPC server
+func RegisterLeaseServer(s grpc.ServiceRegistrar, srv LeaseServer) {
+	s.RegisterService(&leaseServiceDesc, srv)
+}
+
+func renewHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(networkservice.Connection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: renewMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).Renew(ctx, req.(*networkservice.Connection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// leaseServiceDesc describes the lease service reusing the networkservice messages, so no separate proto is needed
+var leaseServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lease.Lease",
+	HandlerType: (*LeaseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Renew",
+			Handler:    renewHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localbypass
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type bypassedKey struct{}
+
+func loadBypassed(ctx context.Context) bool {
+	_, ok := metadata.Map(ctx, false).Load(bypassedKey{})
+	return ok
+}
+
+func storeBypassed(ctx context.Context) {
+	metadata.Map(ctx, false).Store(bypassedKey{}, struct{}{})
+}
+
+func deleteBypassed(ctx context.Context) {
+	metadata.Map(ctx, false).Delete(bypassedKey{})
+}
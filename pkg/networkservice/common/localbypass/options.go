@@ -0,0 +1,36 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localbypass
+
+// Option changes default settings for the localBypassServer
+type Option func(*localBypassServer)
+
+// WithNSMgrURL sets URL of the NSMgr the local endpoints are registered on
+func WithNSMgrURL(nsmgrURL string) Option {
+	return func(s *localBypassServer) {
+		s.nsmgrURL = nsmgrURL
+	}
+}
+
+// WithNetworkServices enables the forwarder bypass for the network services
+func WithNetworkServices(networkServices ...string) Option {
+	return func(s *localBypassServer) {
+		for _, ns := range networkServices {
+			s.networkServices[ns] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package localbypass provides a chain element short-circuiting the forwarder for the connections to the endpoints
+// registered on the same NSMgr. It is enabled explicitly per network service, because the forwarder is skipped
+// and so the mechanism requested by the client is passed to the endpoint as is.
+package localbypass
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type localBypassServer struct {
+	nseClient       registry.NetworkServiceEndpointRegistryClient
+	forwarderServer networkservice.NetworkServiceServer
+	nsmgrURL        string
+	networkServices map[string]struct{}
+}
+
+// NewServer creates a new localbypass server. For the enabled network services it requests the endpoint directly if
+// it is registered on the NSMgr with nsmgrURL, all other requests are passed to the forwarderServer.
+// Requires not nil nseClient.
+// Requires not nil forwarderServer.
+func NewServer(nseClient registry.NetworkServiceEndpointRegistryClient, forwarderServer networkservice.NetworkServiceServer, opts ...Option) networkservice.NetworkServiceServer {
+	if nseClient == nil {
+		panic("nseClient can not be nil")
+	}
+	if forwarderServer == nil {
+		panic("forwarderServer can not be nil")
+	}
+
+	s := &localBypassServer{
+		nseClient:       nseClient,
+		forwarderServer: forwarderServer,
+		networkServices: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *localBypassServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if _, ok := s.networkServices[request.GetConnection().GetNetworkService()]; !ok {
+		return s.forwarderServer.Request(ctx, request)
+	}
+
+	// Connection established via the forwarder is kept via the forwarder on refresh
+	path := request.GetConnection().GetPath()
+	if !loadBypassed(ctx) && len(path.GetPathSegments()) > int(path.GetIndex())+1 {
+		return s.forwarderServer.Request(ctx, request)
+	}
+
+	nse, err := s.findLocalEndpoint(ctx, request.GetConnection())
+	if err != nil {
+		return nil, err
+	}
+	if nse == nil {
+		// The endpoint is not local or is not local anymore, so the connection goes via the forwarder
+		deleteBypassed(ctx)
+		return s.forwarderServer.Request(ctx, request)
+	}
+
+	u, err := url.Parse(nse.GetUrl())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse url %s of the endpoint %s", nse.GetUrl(), nse.GetName())
+	}
+
+	log.FromContext(ctx).WithField("localBypassServer", "Request").Debugf("bypassing the forwarder to the local endpoint %s", nse.GetName())
+
+	request.GetConnection().NetworkServiceEndpointName = nse.GetName()
+	conn, err := next.Server(ctx).Request(clienturlctx.WithClientURL(ctx, u), request)
+	if err != nil {
+		return nil, err
+	}
+	storeBypassed(ctx)
+	return conn, nil
+}
+
+func (s *localBypassServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if !loadBypassed(ctx) {
+		return s.forwarderServer.Close(ctx, conn)
+	}
+
+	nse, err := s.findLocalEndpoint(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if nse == nil {
+		return nil, errors.Errorf("local endpoint %s is not found", conn.GetNetworkServiceEndpointName())
+	}
+
+	u, err := url.Parse(nse.GetUrl())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse url %s of the endpoint %s", nse.GetUrl(), nse.GetName())
+	}
+	return next.Server(ctx).Close(clienturlctx.WithClientURL(ctx, u), conn)
+}
+
+// findLocalEndpoint returns the local endpoint providing the connection network service, or nil if there are no such
+// endpoints. If the connection has the endpoint name selected, only this endpoint is looked for.
+func (s *localBypassServer) findLocalEndpoint(ctx context.Context, conn *networkservice.Connection) (*registry.NetworkServiceEndpoint, error) {
+	query := &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name:                conn.GetNetworkServiceEndpointName(),
+			NetworkServiceNames: []string{conn.GetNetworkService()},
+			Url:                 s.nsmgrURL,
+		},
+	}
+
+	stream, err := s.nseClient.Find(ctx, query)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		if nse.GetUrl() != s.nsmgrURL {
+			return nse, nil
+		}
+	}
+	return nil, nil
+}
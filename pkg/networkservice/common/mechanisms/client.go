@@ -25,6 +25,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type mechanismsClient struct {
@@ -64,7 +65,7 @@ func (mc *mechanismsClient) Request(ctx context.Context, request *networkservice
 			err = errors.Wrap(err, respErr.Error())
 		}
 	}
-	return nil, err
+	return nil, nsmerrors.WithClass(err, nsmerrors.MechanismUnsupported)
 }
 
 func (mc *mechanismsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
@@ -16,7 +16,7 @@
 
 package mechanisms
 
-import "github.com/pkg/errors"
+import "github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 
-var errCannotSupportMech = errors.New("cannot support any of the requested mechanism")
-var errUnsupportedMech = errors.New("unsupported mechanism")
+var errCannotSupportMech = nsmerrors.New(nsmerrors.MechanismUnsupported, "cannot support any of the requested mechanism")
+var errUnsupportedMech = nsmerrors.New(nsmerrors.MechanismUnsupported, "unsupported mechanism")
@@ -0,0 +1,119 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netnswatch provides a chain element validating the network namespaces referenced by the mechanisms and
+// closing the connections when their network namespace disappears, so the client is notified and can heal.
+package netnswatch
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/netns"
+)
+
+const defaultInterval = time.Second
+
+type netnsWatchServer struct {
+	chainCtx context.Context
+	interval time.Duration
+}
+
+// NewServer creates a new netnswatch server. It should be placed after recvfd, so the received netns files are
+// referenced by the file:// URLs.
+func NewServer(chainCtx context.Context, opts ...Option) networkservice.NetworkServiceServer {
+	s := &netnsWatchServer{
+		chainCtx: chainCtx,
+		interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *netnsWatchServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	for _, mechanism := range append(request.GetMechanismPreferences(), request.GetConnection().GetMechanism()) {
+		if netnsURL := fileNetNSURL(mechanism); netnsURL != "" {
+			if err := netns.Validate(netnsURL); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+
+	netnsURL := fileNetNSURL(conn.GetMechanism())
+	if netnsURL == "" {
+		return conn, nil
+	}
+
+	cancelCtx, cancel := context.WithCancel(s.chainCtx)
+	goneCh, err := netns.Watch(cancelCtx, netnsURL, s.interval)
+	if err != nil {
+		cancel()
+		log.FromContext(ctx).WithField("netnsWatchServer", "Request").Warnf("failed to watch %s: %s", netnsURL, err.Error())
+		return conn, nil
+	}
+	store(ctx, cancel)
+
+	eventFactory := begin.FromContext(ctx)
+	logger := log.FromContext(ctx).WithField("netnsWatchServer", "watch")
+	go func() {
+		select {
+		case <-cancelCtx.Done():
+		case <-goneCh:
+			logger.Warnf("network namespace %s is gone, closing the connection", netnsURL)
+			eventFactory.Close(begin.CancelContext(cancelCtx))
+		}
+	}()
+
+	return conn, nil
+}
+
+func (s *netnsWatchServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// fileNetNSURL returns the file:// netns URL of the mechanism or "" if there is no such
+func fileNetNSURL(mechanism *networkservice.Mechanism) string {
+	netnsURL := mechanism.GetParameters()[common.InodeURL]
+	if netnsURL == "" {
+		return ""
+	}
+	if u, err := url.Parse(netnsURL); err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return netnsURL
+}
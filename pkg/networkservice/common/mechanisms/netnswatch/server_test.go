@@ -0,0 +1,99 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netnswatch_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/netnswatch"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+func newRequest(netnsPath string) *networkservice.NetworkServiceRequest {
+	mechanism := kernelmech.ToMechanism(kernelmech.New((&url.URL{Scheme: "file", Path: netnsPath}).String()))
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "id",
+			Mechanism: &networkservice.Mechanism{
+				Cls:        cls.LOCAL,
+				Type:       kernelmech.MECHANISM,
+				Parameters: mechanism.GetParameters(),
+			},
+		},
+	}
+}
+
+func TestNetNSWatchServer_CloseOnGone(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "net")
+	require.NoError(t, os.Symlink("/proc/self/ns/net", path))
+
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		netnswatch.NewServer(ctx, netnswatch.WithInterval(time.Millisecond)),
+		counter,
+	)
+
+	_, err := server.Request(ctx, newRequest(path))
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(path))
+	require.Eventually(t, func() bool { return counter.Closes() == 1 }, time.Second, time.Millisecond)
+}
+
+func TestNetNSWatchServer_Dangling(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counter := new(count.Server)
+	server := chain.NewNetworkServiceServer(
+		begin.NewServer(),
+		metadata.NewServer(),
+		netnswatch.NewServer(ctx),
+		counter,
+	)
+
+	_, err := server.Request(ctx, newRequest(filepath.Join(t.TempDir(), "missing")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "doesn't exist")
+	require.Equal(t, 0, counter.Requests())
+}
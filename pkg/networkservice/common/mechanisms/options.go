@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mechanisms
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// RankingKey is the connection ExtraContext key holding the ranking of the offered mechanisms made by the cost-based
+// selection, e.g. "KERNEL:1,VXLAN:5". It is intended for diagnostics only.
+const RankingKey = "mechanism-ranking"
+
+// CostFunc returns the cost of the offered mechanism for the request, the cheapest mechanism is selected. Error
+// excludes the mechanism from the selection (e.g. encryption is required, but the mechanism doesn't provide it, or
+// the hardware needed for the mechanism is not available).
+type CostFunc func(ctx context.Context, request *networkservice.NetworkServiceRequest, mechanism *networkservice.Mechanism) (float64, error)
+
+// SumCosts returns a CostFunc summing the costs returned by costFuncs, error returned by any of them excludes the
+// mechanism
+func SumCosts(costFuncs ...CostFunc) CostFunc {
+	return func(ctx context.Context, request *networkservice.NetworkServiceRequest, mechanism *networkservice.Mechanism) (float64, error) {
+		var sum float64
+		for _, costFunc := range costFuncs {
+			cost, err := costFunc(ctx, request, mechanism)
+			if err != nil {
+				return 0, err
+			}
+			sum += cost
+		}
+		return sum, nil
+	}
+}
+
+// Option is an option pattern for NewServer
+type Option func(ms *mechanismsServer)
+
+// WithCostFunc makes the server select the cheapest of the offered mechanisms according to costFunc instead of the
+// first supported one. Mechanisms with equal costs keep the client preference order. The ranking is returned in the
+// connection ExtraContext under RankingKey.
+func WithCostFunc(costFunc CostFunc) Option {
+	return func(ms *mechanismsServer) {
+		ms.costFunc = costFunc
+	}
+}
+
+type rankedMechanism struct {
+	mechanism *networkservice.Mechanism
+	cost      float64
+}
+
+func storeRanking(conn *networkservice.Connection, ranked []*rankedMechanism) {
+	var ranking []string
+	for _, r := range ranked {
+		ranking = append(ranking, r.mechanism.GetType()+":"+strconv.FormatFloat(r.cost, 'g', -1, 64))
+	}
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[RankingKey] = strings.Join(ranking, ",")
+}
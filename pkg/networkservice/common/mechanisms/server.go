@@ -22,16 +22,20 @@ package mechanisms
 
 import (
 	"context"
+	"sort"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type mechanismsServer struct {
 	mechanisms map[string]networkservice.NetworkServiceServer // key is Mechanism.Type
+	costFunc   CostFunc
 }
 
 // NewServer - returns new NetworkServiceServer chain element that will attempt to meet the request.MechanismPreferences using
@@ -40,10 +44,14 @@ type mechanismsServer struct {
 //                   key:    mechanismType
 //                   value:  NetworkServiceServer that only handles the work for the specified mechanismType
 //                           Note: Supplied NetworkServiceServer elements should not call next.Server(ctx).{Request,Close} themselves
-func NewServer(mechanisms map[string]networkservice.NetworkServiceServer) networkservice.NetworkServiceServer {
+//             - opts - server options
+func NewServer(mechanisms map[string]networkservice.NetworkServiceServer, opts ...Option) networkservice.NetworkServiceServer {
 	rv := &mechanismsServer{
 		mechanisms: make(map[string]networkservice.NetworkServiceServer),
 	}
+	for _, opt := range opts {
+		opt(rv)
+	}
 	for mechanismType, server := range mechanisms {
 		// We wrap in a chain here to make sure that if the 'server' is calling next.Server(ctx) it doesn't
 		// skips past returning here.
@@ -60,6 +68,9 @@ func (ms *mechanismsServer) Request(ctx context.Context, request *networkservice
 		}
 		return nil, errUnsupportedMech
 	}
+	if ms.costFunc != nil {
+		return ms.requestByCost(ctx, request)
+	}
 	var err = errCannotSupportMech
 	for _, mechanism := range request.GetMechanismPreferences() {
 		srv, ok := ms.mechanisms[mechanism.GetType()]
@@ -74,7 +85,39 @@ func (ms *mechanismsServer) Request(ctx context.Context, request *networkservice
 			err = errors.Wrap(err, respErr.Error())
 		}
 	}
-	return nil, err
+	return nil, nsmerrors.WithClass(err, nsmerrors.MechanismUnsupported)
+}
+
+func (ms *mechanismsServer) requestByCost(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	var err = errCannotSupportMech
+	var ranked []*rankedMechanism
+	for _, mechanism := range request.GetMechanismPreferences() {
+		if _, ok := ms.mechanisms[mechanism.GetType()]; !ok {
+			continue
+		}
+		cost, costErr := ms.costFunc(ctx, request, mechanism)
+		if costErr != nil {
+			log.FromContext(ctx).Debugf("mechanism %s is excluded: %s", mechanism.GetType(), costErr.Error())
+			err = errors.Wrap(err, costErr.Error())
+			continue
+		}
+		ranked = append(ranked, &rankedMechanism{mechanism: mechanism, cost: cost})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].cost < ranked[j].cost
+	})
+
+	for _, r := range ranked {
+		req := request.Clone()
+		req.GetConnection().Mechanism = r.mechanism
+		resp, respErr := ms.mechanisms[r.mechanism.GetType()].Request(ctx, req)
+		if respErr == nil {
+			storeRanking(resp, ranked)
+			return resp, nil
+		}
+		err = errors.Wrap(err, respErr.Error())
+	}
+	return nil, nsmerrors.WithClass(err, nsmerrors.MechanismUnsupported)
 }
 
 func (ms *mechanismsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
@@ -236,3 +236,32 @@ func TestDontCallNextByItself(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(ch))
 }
+
+func TestCostBasedSelection(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	costs := map[string]float64{
+		kernel.MECHANISM: 1,
+		srv6.MECHANISM:   1,
+		vxlan.MECHANISM:  5,
+	}
+	server := chain.NewNetworkServiceServer(mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
+		memif.MECHANISM:  null.NewServer(),
+		kernel.MECHANISM: injecterror.NewServer(),
+		srv6.MECHANISM:   null.NewServer(),
+		vxlan.MECHANISM:  null.NewServer(),
+	}, mechanisms.WithCostFunc(mechanisms.SumCosts(
+		func(_ context.Context, _ *networkservice.NetworkServiceRequest, mechanism *networkservice.Mechanism) (float64, error) {
+			if mechanism.GetType() == memif.MECHANISM {
+				return 0, errors.New("no hardware")
+			}
+			return costs[mechanism.GetType()], nil
+		},
+	))))
+
+	// memif is excluded, kernel is the cheapest but fails, so srv6 having the same cost is selected
+	conn, err := server.Request(context.Background(), request())
+	require.NoError(t, err)
+	require.Equal(t, srv6.MECHANISM, conn.GetMechanism().GetType())
+	require.Equal(t, "KERNEL:1,SRV6:1,VXLAN:5", conn.GetContext().GetExtraContext()[mechanisms.RankingKey])
+}
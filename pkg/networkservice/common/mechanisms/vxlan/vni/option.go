@@ -18,6 +18,11 @@
 
 package vni
 
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+	"github.com/networkservicemesh/sdk/pkg/tools/tunnelid"
+)
+
 // Option is an option pattern for vni server/client
 type Option func(o *vniOpions)
 
@@ -30,6 +35,24 @@ func WithTunnelPort(tunnelPort uint16) Option {
 	}
 }
 
+// WithAllocator sets the VNI allocator, e.g. the persistent one or the one shared with other chain elements. Default
+// is an in-memory allocator owned by the server.
+func WithAllocator(allocator *tunnelid.Allocator) Option {
+	return func(o *vniOpions) {
+		o.allocator = allocator
+	}
+}
+
+// WithTenantQuota sets the quota limiting the number of VNIs per tenant. The tenant is taken from the context, so the
+// tenancy server should be placed before. VNIs are unique per remote IP for all the tenants, so the allocator is shared.
+func WithTenantQuota(quota *tenancy.Quota) Option {
+	return func(o *vniOpions) {
+		o.quota = quota
+	}
+}
+
 type vniOpions struct {
 	tunnelPort uint16
+	allocator  *tunnelid.Allocator
+	quota      *tenancy.Quota
 }
@@ -21,7 +21,6 @@ package vni
 import (
 	"context"
 	"net"
-	"sync"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
@@ -32,14 +31,15 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+	"github.com/networkservicemesh/sdk/pkg/tools/tunnelid"
 )
 
 type vniServer struct {
 	tunnelIP   net.IP
 	tunnelPort uint16
-
-	// This map stores all generated VNIs
-	sync.Map
+	allocator  *tunnelid.Allocator
+	quota      *tenancy.Quota
 }
 
 // NewServer - set the DstIP *and* VNI for the vxlan mechanism
@@ -50,10 +50,16 @@ func NewServer(tunnelIP net.IP, options ...Option) networkservice.NetworkService
 	for _, opt := range options {
 		opt(opts)
 	}
+	if opts.allocator == nil {
+		// In-memory allocator can't fail
+		opts.allocator, _ = tunnelid.NewAllocator(tunnelid.VXLAN)
+	}
 
 	return &vniServer{
 		tunnelIP:   tunnelIP,
 		tunnelPort: opts.tunnelPort,
+		allocator:  opts.allocator,
+		quota:      opts.quota,
 	}
 }
 
@@ -70,76 +76,101 @@ func (v *vniServer) Request(ctx context.Context, request *networkservice.Network
 
 	logger.WithField("mechanism.DstIP", mechanism.DstIP()).WithField("mechanism.DstPort", mechanism.DstPort()).Debugf("set mechanism dst")
 
-	k := vniKey{
-		srcIPString: mechanism.SrcIP().String(),
-		vni:         mechanism.VNI(),
-	}
+	owner := request.GetConnection().GetId()
+	tenant := tenancy.FromContext(ctx)
 
+	var loaded bool
+	var err error
 	// If we already have a VNI, make sure we remember it, and go on
-	if k.vni != 0 && mechanism.SrcIP() != nil {
-		_, _ = v.Map.LoadOrStore(k, &k)
-		_, loaded := loadOrStore(ctx, metadata.IsClient(v), k.vni)
+	if mechanism.VNI() != 0 && mechanism.SrcIP() != nil {
+		loaded, err = v.reuse(ctx, mechanism, owner, tenant, logger)
+	} else {
+		loaded, err = v.allocate(ctx, mechanism, owner, tenant, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !loaded {
+		delete(ctx, metadata.IsClient(v))
+		_ = v.allocator.Release(mechanism.SrcIP(), mechanism.VNI(), owner)
+		v.quota.Release(tenant)
 
-		logger.WithField("vni", k.vni).Debugf("loadOrStore vni in metadata")
+		logger.WithField("vni", mechanism.VNI()).Errorf("error returned from request, deleting vni. err=%v", err.Error())
+	}
+	return conn, err
+}
 
-		conn, err := next.Server(ctx).Request(ctx, request)
-		if err != nil && !loaded {
-			delete(ctx, metadata.IsClient(v))
-			v.Map.Delete(k)
+// reuse - remembers and reserves the VNI already set in the mechanism, returns true if it was already stored in metadata
+func (v *vniServer) reuse(ctx context.Context, mechanism *vxlan.Mechanism, owner, tenant string, logger log.Logger) (bool, error) {
+	srcIP, vni := mechanism.SrcIP(), mechanism.VNI()
 
-			logger.WithField("vni", k.vni).Errorf("error returned from request, deleting vni. err=%v", err.Error())
+	_, loaded := loadOrStore(ctx, metadata.IsClient(v), vni)
+	if !loaded {
+		if err := v.quota.Acquire(tenant); err != nil {
+			delete(ctx, metadata.IsClient(v))
+			return false, err
+		}
+	}
+	if err := v.allocator.Reserve(srcIP, vni, owner); err != nil {
+		if !loaded {
+			delete(ctx, metadata.IsClient(v))
+			v.quota.Release(tenant)
 		}
-		return conn, err
+		return false, err
 	}
 
-	vni, loaded := load(ctx, metadata.IsClient(v))
-	if loaded {
+	logger.WithField("vni", vni).Debugf("loadOrStore vni in metadata")
+	return loaded, nil
+}
+
+// allocate - sets the VNI stored in metadata or allocates a new one, returns true if it was already stored in metadata
+func (v *vniServer) allocate(ctx context.Context, mechanism *vxlan.Mechanism, owner, tenant string, logger log.Logger) (bool, error) {
+	if vni, loaded := load(ctx, metadata.IsClient(v)); loaded {
 		mechanism.SetVNI(vni)
 		logger.WithField("vni", vni).Debugf("vni loaded from metadata")
-	} else {
-		for {
-			// Generate a random VNI (appropriately odd or even)
-			var err error
-			k.vni, err = mechanism.GenerateRandomVNI()
-			if err != nil {
-				return nil, err
-			}
-			// If its not one already in use, set it and we are good to go
-			if _, ok := v.Map.LoadOrStore(k, &k); !ok {
-				mechanism.SetVNI(k.vni)
-				store(ctx, metadata.IsClient(v), k.vni)
-				logger.WithField("vni", k.vni).Debugf("vni generated and stored in metadata")
-				break
-			}
-		}
+		return true, nil
 	}
 
-	conn, err := next.Server(ctx).Request(ctx, request)
-	if err != nil && !loaded {
-		delete(ctx, metadata.IsClient(v))
-		v.Map.Delete(k)
-
-		logger.WithField("vniKey.vni", k.vni).Errorf("error returned from request, deleting vni. err=%v", err.Error())
+	// Validates that both srcIP and dstIP are set
+	if _, err := mechanism.GenerateRandomVNI(); err != nil {
+		return false, err
 	}
-	return conn, err
+	if err := v.quota.Acquire(tenant); err != nil {
+		return false, err
+	}
+	// Allocate a VNI appropriately odd or even
+	even := mechanism.EvenVNI()
+	vni, err := v.allocator.Allocate(mechanism.SrcIP(), owner, func(id uint32) bool {
+		return (id%2 == 0) == even
+	})
+	if err != nil {
+		v.quota.Release(tenant)
+		return false, err
+	}
+	mechanism.SetVNI(vni)
+	store(ctx, metadata.IsClient(v), vni)
+	logger.WithField("vni", vni).Debugf("vni generated and stored in metadata")
+	return false, nil
 }
 
 func (v *vniServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
 	if mechanism := vxlan.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		k := vniKey{
-			srcIPString: mechanism.SrcIP().String(),
-			vni:         mechanism.VNI(),
-		}
-
-		if k.vni != 0 && mechanism.SrcIP() != nil {
+		if vni := mechanism.VNI(); vni != 0 && mechanism.SrcIP() != nil {
+			if _, ok := load(ctx, metadata.IsClient(v)); ok {
+				v.quota.Release(tenancy.FromContext(ctx))
+			}
 			delete(ctx, metadata.IsClient(v))
-			v.Map.LoadAndDelete(k)
+			if err := v.allocator.Release(mechanism.SrcIP(), vni, conn.GetId()); err != nil {
+				log.FromContext(ctx).WithField("VNIserver", "close").Errorf("failed to release vni: %s", err.Error())
+			}
 
 			log.FromContext(ctx).
 				WithField("VNIserver", "close").
-				WithField("vniKey.srcIPString", k.srcIPString).
-				WithField("vniKey.vni", k.vni).
-				Debugf("vniKey deleted")
+				WithField("srcIP", mechanism.SrcIP()).
+				WithField("vni", vni).
+				Debugf("vni released")
 		}
 	}
 	return next.Server(ctx).Close(ctx, conn)
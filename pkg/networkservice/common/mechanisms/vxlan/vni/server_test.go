@@ -25,12 +25,15 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanisms/vxlan/vni"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+	"github.com/networkservicemesh/sdk/pkg/tools/tunnelid"
 )
 
 func TestVNIServer(t *testing.T) {
@@ -99,3 +102,86 @@ func TestVNIServerNonVxLAN(t *testing.T) {
 	assert.NotNil(t, conn)
 	assert.Nil(t, vxlan.ToMechanism(conn.GetMechanism()))
 }
+
+func TestVNIServerConflict(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	allocator, err := tunnelid.NewAllocator(tunnelid.VXLAN)
+	assert.NoError(t, err)
+
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vni.NewServer(net.ParseIP("192.0.2.2"), vni.WithAllocator(allocator)),
+	)
+
+	newRequest := func(id string) *networkservice.NetworkServiceRequest {
+		request := &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Mechanism: &networkservice.Mechanism{
+					Cls:  cls.REMOTE,
+					Type: vxlan.MECHANISM,
+				},
+			},
+		}
+		vxlan.ToMechanism(request.GetConnection().GetMechanism()).SetSrcIP(net.ParseIP("192.0.2.1")).SetVNI(42)
+		return request
+	}
+
+	conn, err := server.Request(context.Background(), newRequest("conn-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(42), vxlan.ToMechanism(conn.GetMechanism()).VNI())
+
+	// The same VNI for the same remote can't be used by another connection
+	_, err = server.Request(context.Background(), newRequest("conn-2"))
+	assert.True(t, errors.Is(err, tunnelid.ErrConflict))
+
+	_, err = server.Close(context.Background(), conn)
+	assert.NoError(t, err)
+	_, err = server.Request(context.Background(), newRequest("conn-2"))
+	assert.NoError(t, err)
+}
+
+func TestVNIServerTenantQuota(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	quota := tenancy.NewQuota(tenancy.WithDefaultLimit(1))
+	server := next.NewNetworkServiceServer(
+		metadata.NewServer(),
+		vni.NewServer(net.ParseIP("192.0.2.2"), vni.WithTenantQuota(quota)),
+	)
+
+	newRequest := func(id string) *networkservice.NetworkServiceRequest {
+		request := &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{
+				Id: id,
+				Mechanism: &networkservice.Mechanism{
+					Cls:  cls.REMOTE,
+					Type: vxlan.MECHANISM,
+				},
+			},
+		}
+		vxlan.ToMechanism(request.GetConnection().GetMechanism()).SetSrcIP(net.ParseIP("192.0.2.1"))
+		return request
+	}
+	tenantA := tenancy.WithTenant(context.Background(), "tenant-a")
+	tenantB := tenancy.WithTenant(context.Background(), "tenant-b")
+
+	conn, err := server.Request(tenantA, newRequest("conn-1"))
+	assert.NoError(t, err)
+
+	// Refresh doesn't take another VNI
+	conn, err = server.Request(tenantA, &networkservice.NetworkServiceRequest{Connection: conn})
+	assert.NoError(t, err)
+
+	_, err = server.Request(tenantA, newRequest("conn-2"))
+	assert.True(t, errors.Is(err, tenancy.ErrQuotaExceeded))
+
+	_, err = server.Request(tenantB, newRequest("conn-3"))
+	assert.NoError(t, err)
+
+	_, err = server.Close(tenantA, conn)
+	assert.NoError(t, err)
+	_, err = server.Request(tenantA, newRequest("conn-2"))
+	assert.NoError(t, err)
+}
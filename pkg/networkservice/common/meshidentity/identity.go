@@ -0,0 +1,55 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package meshidentity provides a chain element exchanging the connection identities with an external service mesh,
+// so the policies spanning NSM and the service mesh can reference consistent identities.
+// Established connections are exported to the pluggable workload API with the client SPIFFE ID and the connection
+// labels, external identity attributes of the client are imported into the connection labels.
+package meshidentity
+
+import (
+	"context"
+)
+
+// Identity is an identity of the established NSM connection
+type Identity struct {
+	ConnectionID   string
+	SpiffeID       string
+	NetworkService string
+	Labels         map[string]string
+}
+
+// Exporter exports the connection identities to an external workload API
+type Exporter interface {
+	// Export creates or updates the identity, it is called on every successful Request including refreshes
+	Export(ctx context.Context, identity *Identity) error
+	// Remove removes the identity of the closed connection
+	Remove(ctx context.Context, connectionID string) error
+}
+
+// Importer imports the external identity attributes of the client
+type Importer interface {
+	// Import returns the external identity attributes of the client with the given SPIFFE ID
+	Import(ctx context.Context, spiffeID string) (map[string]string, error)
+}
+
+// ImporterFunc is a function adapter for Importer
+type ImporterFunc func(ctx context.Context, spiffeID string) (map[string]string, error)
+
+// Import calls f(ctx, spiffeID)
+func (f ImporterFunc) Import(ctx context.Context, spiffeID string) (map[string]string, error) {
+	return f(ctx, spiffeID)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meshidentity
+
+const defaultLabelPrefix = "mesh/"
+
+type options struct {
+	exporter    Exporter
+	importer    Importer
+	labelPrefix string
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithExporter sets the exporter of the established connection identities
+func WithExporter(exporter Exporter) Option {
+	return func(o *options) {
+		o.exporter = exporter
+	}
+}
+
+// WithImporter sets the importer of the external client identities
+func WithImporter(importer Importer) Option {
+	return func(o *options) {
+		o.importer = importer
+	}
+}
+
+// WithLabelPrefix sets the prefix of the imported connection labels
+// Default: "mesh/"
+func WithLabelPrefix(prefix string) Option {
+	return func(o *options) {
+		o.labelPrefix = prefix
+	}
+}
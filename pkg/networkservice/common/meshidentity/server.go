@@ -0,0 +1,125 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meshidentity
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+)
+
+type meshIdentityServer struct {
+	*options
+}
+
+// NewServer creates a NetworkServiceServer chain element importing the external client identity into the connection
+// labels prefixed with the label prefix before passing the Request further and exporting the established connection
+// identity after it. Import failures fail the Request, export failures are only logged because the identity is
+// exported again on the next refresh.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		labelPrefix: defaultLabelPrefix,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &meshIdentityServer{
+		options: o,
+	}
+}
+
+func (s *meshIdentityServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	spiffeID := clientID(ctx, request.GetConnection().GetPath())
+
+	if s.importer != nil && spiffeID != "" {
+		attributes, err := s.importer.Import(ctx, spiffeID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to import the external identity of %s", spiffeID)
+		}
+		s.setLabels(request.GetConnection(), attributes)
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.exporter != nil {
+		identity := &Identity{
+			ConnectionID:   conn.GetId(),
+			SpiffeID:       spiffeID,
+			NetworkService: conn.GetNetworkService(),
+			Labels:         conn.GetLabels(),
+		}
+		if exportErr := s.exporter.Export(ctx, identity); exportErr != nil {
+			log.FromContext(ctx).Errorf("failed to export the identity of the connection %s: %v", conn.GetId(), exportErr.Error())
+		}
+	}
+
+	return conn, nil
+}
+
+func (s *meshIdentityServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if s.exporter != nil {
+		if err := s.exporter.Remove(ctx, conn.GetId()); err != nil {
+			log.FromContext(ctx).Errorf("failed to remove the identity of the connection %s: %v", conn.GetId(), err.Error())
+		}
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// setLabels replaces the previously imported labels, so the attributes removed from the external identity don't stay
+// on the connection after refreshes
+func (s *meshIdentityServer) setLabels(conn *networkservice.Connection, attributes map[string]string) {
+	labels := make(map[string]string, len(conn.GetLabels())+len(attributes))
+	for k, v := range conn.GetLabels() {
+		if !strings.HasPrefix(k, s.labelPrefix) {
+			labels[k] = v
+		}
+	}
+	for k, v := range attributes {
+		labels[s.labelPrefix+k] = v
+	}
+	conn.Labels = labels
+}
+
+// clientID returns the client identity: the subject of the first path segment token or the peer SPIFFE ID if the
+// request comes directly from the client without a token
+func clientID(ctx context.Context, path *networkservice.Path) string {
+	if segments := path.GetPathSegments(); len(segments) > 0 && segments[0].GetToken() != "" {
+		claims := new(jwt.RegisteredClaims)
+		if _, _, err := new(jwt.Parser).ParseUnverified(segments[0].GetToken(), claims); err != nil {
+			return ""
+		}
+		return claims.Subject
+	}
+	if path.GetIndex() != 0 {
+		return ""
+	}
+	if spiffeID, err := spire.SpiffeIDFromContext(ctx); err == nil {
+		return spiffeID.String()
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meshidentity_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/meshidentity"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffepeer"
+)
+
+type testExporter struct {
+	mu         sync.Mutex
+	identities map[string]*meshidentity.Identity
+	err        error
+}
+
+func (e *testExporter) Export(_ context.Context, identity *meshidentity.Identity) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return e.err
+	}
+	e.identities[identity.ConnectionID] = identity
+	return nil
+}
+
+func (e *testExporter) Remove(_ context.Context, connectionID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.identities, connectionID)
+	return nil
+}
+
+func newRequest(t *testing.T, subject string) *networkservice.NetworkServiceRequest {
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{Subject: subject}).SignedString([]byte("key"))
+	require.NoError(t, err)
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:             "conn-id",
+			NetworkService: "ns",
+			Labels:         map[string]string{"app": "nsc"},
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Token: tok},
+					{Name: "nsmgr"},
+				},
+			},
+		},
+	}
+}
+
+func TestMeshIdentityServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	attributes := map[string]string{"sa": "default/nsc", "trust": "high"}
+	exporter := &testExporter{identities: make(map[string]*meshidentity.Identity)}
+	server := meshidentity.NewServer(
+		meshidentity.WithExporter(exporter),
+		meshidentity.WithImporter(meshidentity.ImporterFunc(func(_ context.Context, spiffeID string) (map[string]string, error) {
+			require.Equal(t, "spiffe://test.com/nsc", spiffeID)
+			return attributes, nil
+		})),
+	)
+
+	conn, err := server.Request(context.Background(), newRequest(t, "spiffe://test.com/nsc"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "nsc", "mesh/sa": "default/nsc", "mesh/trust": "high"}, conn.GetLabels())
+	require.Equal(t, &meshidentity.Identity{
+		ConnectionID:   "conn-id",
+		SpiffeID:       "spiffe://test.com/nsc",
+		NetworkService: "ns",
+		Labels:         conn.GetLabels(),
+	}, exporter.identities["conn-id"])
+
+	// Refresh drops the attributes removed from the external identity
+	attributes = map[string]string{"sa": "default/nsc"}
+	request := newRequest(t, "spiffe://test.com/nsc")
+	request.Connection = conn
+	conn, err = server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "nsc", "mesh/sa": "default/nsc"}, conn.GetLabels())
+	require.Equal(t, conn.GetLabels(), exporter.identities["conn-id"].Labels)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Empty(t, exporter.identities)
+}
+
+func TestMeshIdentityServer_PeerIdentity(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	exporter := &testExporter{identities: make(map[string]*meshidentity.Identity)}
+	server := meshidentity.NewServer(
+		meshidentity.WithExporter(exporter),
+		meshidentity.WithImporter(meshidentity.ImporterFunc(func(_ context.Context, spiffeID string) (map[string]string, error) {
+			return map[string]string{"id": spiffeID}, nil
+		})),
+		meshidentity.WithLabelPrefix("istio."),
+	)
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:   "conn-id",
+			Path: &networkservice.Path{PathSegments: []*networkservice.PathSegment{{Name: "nsc"}}},
+		},
+	}
+	conn, err := server.Request(spiffepeer.NewContext(context.Background(), "spiffe://test.com/nsc"), request)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"istio.id": "spiffe://test.com/nsc"}, conn.GetLabels())
+	require.Equal(t, "spiffe://test.com/nsc", exporter.identities["conn-id"].SpiffeID)
+}
+
+func TestMeshIdentityServer_Errors(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := meshidentity.NewServer(
+		meshidentity.WithImporter(meshidentity.ImporterFunc(func(context.Context, string) (map[string]string, error) {
+			return nil, errors.New("workload API is not available")
+		})),
+	)
+	_, err := server.Request(context.Background(), newRequest(t, "spiffe://test.com/nsc"))
+	require.Error(t, err)
+
+	// Export failures don't fail the Request
+	server = meshidentity.NewServer(
+		meshidentity.WithExporter(&testExporter{err: errors.New("workload API is not available")}),
+	)
+	_, err = server.Request(context.Background(), newRequest(t, "spiffe://test.com/nsc"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+// batcher merges the events of the same type sent to the filter and flushes them when the filter is idle for the
+// window or the batch is full. All its methods should be called in the filter executor.
+type batcher struct {
+	clock   clock.Clock
+	window  time.Duration
+	maxSize int
+	filter  *monitorFilter
+
+	pending    *networkservice.ConnectionEvent
+	timer      clock.Timer
+	generation uint64
+	err        error
+}
+
+func newBatcher(clockTime clock.Clock, window time.Duration, maxSize int, filter *monitorFilter) *batcher {
+	return &batcher{
+		clock:   clockTime,
+		window:  window,
+		maxSize: maxSize,
+		filter:  filter,
+	}
+}
+
+func (b *batcher) push(event *networkservice.ConnectionEvent) error {
+	// Error of the flush on idle
+	if b.err != nil {
+		return b.err
+	}
+
+	if b.pending != nil && b.pending.GetType() != event.GetType() {
+		if err := b.flush(); err != nil {
+			return err
+		}
+	}
+	if b.pending == nil {
+		b.pending = &networkservice.ConnectionEvent{
+			Type:        event.GetType(),
+			Connections: make(map[string]*networkservice.Connection),
+		}
+	}
+	for id, conn := range event.GetConnections() {
+		b.pending.Connections[id] = conn
+	}
+
+	if len(b.pending.GetConnections()) >= b.maxSize {
+		return b.flush()
+	}
+
+	b.stopTimer()
+	generation := b.generation
+	b.timer = b.clock.AfterFunc(b.window, func() {
+		b.filter.executor.AsyncExec(func() {
+			// The batch has already been flushed or the timer has been reset
+			if generation != b.generation {
+				return
+			}
+			b.err = b.flush()
+		})
+	})
+	return nil
+}
+
+func (b *batcher) flush() error {
+	b.stopTimer()
+	if b.pending == nil {
+		return nil
+	}
+	event := b.pending
+	b.pending = nil
+	return b.filter.Send(event)
+}
+
+func (b *batcher) stopTimer() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.generation++
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func startBatchMonitor(ctx context.Context, t *testing.T, opts ...monitor.Option) (networkservice.NetworkServiceServer, <-chan *networkservice.ConnectionEvent) {
+	var monitorServer networkservice.MonitorConnectionServer
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		monitor.NewServer(ctx, &monitorServer, opts...),
+	)
+
+	receiver, err := adapters.NewMonitorServerToClient(monitorServer).MonitorConnections(ctx, new(networkservice.MonitorScopeSelector))
+	require.NoError(t, err)
+
+	event, err := receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+
+	eventCh := make(chan *networkservice.ConnectionEvent, 10)
+	go func() {
+		defer close(eventCh)
+		for {
+			event, err := receiver.Recv()
+			if err != nil {
+				return
+			}
+			eventCh <- event
+		}
+	}()
+
+	return server, eventCh
+}
+
+func request(ctx context.Context, t *testing.T, server networkservice.NetworkServiceServer, id string) *networkservice.Connection {
+	conn, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: id}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return conn
+}
+
+func TestMonitorServer_Batch(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server, eventCh := startBatchMonitor(ctx, t, monitor.WithBatchWindow(100*time.Millisecond))
+
+	conns := make(map[string]*networkservice.Connection)
+	for _, id := range []string{"a", "b", "c"} {
+		conns[id] = request(ctx, t, server, id)
+	}
+
+	var event *networkservice.ConnectionEvent
+	require.Eventually(t, func() bool {
+		clockMock.Add(100 * time.Millisecond)
+		select {
+		case event = <-eventCh:
+			return true
+		default:
+			return false
+		}
+	}, time.Second/2, 10*time.Millisecond)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	require.Len(t, event.GetConnections(), 3)
+
+	// Events of the different types are not merged
+	_, err := server.Close(ctx, conns["a"])
+	require.NoError(t, err)
+	request(ctx, t, server, "d")
+
+	select {
+	case event = <-eventCh:
+	case <-ctx.Done():
+		require.FailNow(t, "no DELETE event")
+	}
+	require.Equal(t, networkservice.ConnectionEventType_DELETE, event.GetType())
+	require.Len(t, event.GetConnections(), 1)
+	require.Contains(t, event.GetConnections(), "a")
+
+	require.Eventually(t, func() bool {
+		clockMock.Add(100 * time.Millisecond)
+		select {
+		case event = <-eventCh:
+			return true
+		default:
+			return false
+		}
+	}, time.Second/2, 10*time.Millisecond)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	require.Len(t, event.GetConnections(), 1)
+	require.Contains(t, event.GetConnections(), "d")
+}
+
+func TestMonitorServer_BatchMaxSize(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server, eventCh := startBatchMonitor(ctx, t, monitor.WithBatchWindow(time.Hour), monitor.WithMaxBatchSize(2))
+
+	request(ctx, t, server, "a")
+	request(ctx, t, server, "b")
+	request(ctx, t, server, "c")
+
+	select {
+	case event := <-eventCh:
+		require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+		require.Len(t, event.GetConnections(), 2)
+	case <-ctx.Done():
+		require.FailNow(t, "no batched event")
+	}
+
+	select {
+	case <-eventCh:
+		require.FailNow(t, "unexpected event")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
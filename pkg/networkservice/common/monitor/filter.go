@@ -26,6 +26,7 @@ import (
 type monitorFilter struct {
 	selector *networkservice.MonitorScopeSelector
 	executor serialize.Executor
+	batcher  *batcher
 
 	networkservice.MonitorConnection_MonitorConnectionsServer
 }
@@ -48,3 +49,12 @@ func (m *monitorFilter) Send(event *networkservice.ConnectionEvent) error {
 	}
 	return nil
 }
+
+// Push - sends the event or adds it to the batch if the batch event delivery is enabled.
+// Should be called in the filter executor.
+func (m *monitorFilter) Push(event *networkservice.ConnectionEvent) error {
+	if m.batcher == nil {
+		return m.Send(event)
+	}
+	return m.batcher.push(event)
+}
@@ -22,18 +22,22 @@ import (
 	"github.com/edwarnicke/serialize"
 	"github.com/google/uuid"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type monitorConnectionServer struct {
 	chainCtx    context.Context
+	opts        *options
 	connections map[string]*networkservice.Connection
 	filters     map[string]*monitorFilter
 	executor    serialize.Executor
 }
 
-func newMonitorConnectionServer(chainCtx context.Context) networkservice.MonitorConnectionServer {
+func newMonitorConnectionServer(chainCtx context.Context, opts *options) networkservice.MonitorConnectionServer {
 	return &monitorConnectionServer{
 		chainCtx:    chainCtx,
+		opts:        opts,
 		connections: make(map[string]*networkservice.Connection),
 		filters:     make(map[string]*monitorFilter),
 	}
@@ -42,6 +46,9 @@ func newMonitorConnectionServer(chainCtx context.Context) networkservice.Monitor
 func (m *monitorConnectionServer) MonitorConnections(selector *networkservice.MonitorScopeSelector, srv networkservice.MonitorConnection_MonitorConnectionsServer) error {
 	m.executor.AsyncExec(func() {
 		filter := newMonitorFilter(selector, srv)
+		if m.opts.batchWindow > 0 {
+			filter.batcher = newBatcher(clock.FromContext(m.chainCtx), m.opts.batchWindow, m.opts.maxBatchSize, filter)
+		}
 		m.filters[uuid.New().String()] = filter
 
 		connections := networkservice.FilterMapOnManagerScopeSelector(m.connections, selector)
@@ -92,7 +99,7 @@ func (m *monitorConnectionServer) Send(event *networkservice.ConnectionEvent) (_
 						delete(m.filters, id)
 					})
 				default:
-					err = filter.Send(e)
+					err = filter.Push(e)
 				}
 				if err != nil {
 					m.executor.AsyncExec(func() {
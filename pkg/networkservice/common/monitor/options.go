@@ -0,0 +1,51 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"time"
+)
+
+// DefaultMaxBatchSize is the default maximum number of connections in the batched event
+const DefaultMaxBatchSize = 1000
+
+type options struct {
+	batchWindow  time.Duration
+	maxBatchSize int
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithBatchWindow enables the batch event delivery: events of the same type are merged into a single event sent when
+// there are no new events for the window. Events of the different types are never merged, so the order of the
+// connection updates and deletes is kept.
+func WithBatchWindow(window time.Duration) Option {
+	return func(o *options) {
+		o.batchWindow = window
+	}
+}
+
+// WithMaxBatchSize sets the maximum number of connections in the batched event, the batch is sent immediately when it
+// is reached. Default: DefaultMaxBatchSize
+func WithMaxBatchSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.maxBatchSize = size
+		}
+	}
+}
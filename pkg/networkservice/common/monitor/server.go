@@ -49,8 +49,15 @@ type monitorServer struct {
 //                        networkservice.MonitorConnectionServer that can be used either standalone or in a
 //                        networkservice.MonitorConnectionServer chain
 //             chainCtx - context for lifecycle management
-func NewServer(chainCtx context.Context, monitorServerPtr *networkservice.MonitorConnectionServer) networkservice.NetworkServiceServer {
-	*monitorServerPtr = newMonitorConnectionServer(chainCtx)
+//             opts - batch event delivery options
+func NewServer(chainCtx context.Context, monitorServerPtr *networkservice.MonitorConnectionServer, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		maxBatchSize: DefaultMaxBatchSize,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	*monitorServerPtr = newMonitorConnectionServer(chainCtx, o)
 	return &monitorServer{
 		chainCtx:                chainCtx,
 		MonitorConnectionServer: *monitorServerPtr,
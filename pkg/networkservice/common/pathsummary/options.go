@@ -0,0 +1,29 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathsummary
+
+import "net/url"
+
+// Option is an option pattern for NewServer
+type Option func(s *pathSummaryServer)
+
+// WithURL sets the URL of the server, it is shown in the server path segment of the Summary
+func WithURL(u *url.URL) Option {
+	return func(s *pathSummaryServer) {
+		s.url = u
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathsummary
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type pathSummaryServer struct {
+	url *url.URL
+}
+
+// NewServer creates a NetworkServiceServer chain element storing the path Summary in the returned connection extra
+// context. It should be placed after the monitor server, so the monitor events carry the Summary.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	s := new(pathSummaryServer)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *pathSummaryServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if s.url != nil {
+		if segment := request.GetConnection().GetCurrentPathSegment(); segment != nil {
+			if segment.Metrics == nil {
+				segment.Metrics = make(map[string]string)
+			}
+			segment.Metrics[URLMetricKey] = s.url.String()
+		}
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if storeErr := store(conn); storeErr != nil {
+		log.FromContext(ctx).WithField("pathSummaryServer", "Request").Warnf("%v", storeErr.Error())
+	}
+	return conn, nil
+}
+
+func (s *pathSummaryServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathsummary_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/pathsummary"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+func TestPathSummaryServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	expires := time.Date(2022, 1, 1, 0, 10, 0, 0, time.UTC)
+
+	var monitorServer networkservice.MonitorConnectionServer
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		monitor.NewServer(ctx, &monitorServer),
+		pathsummary.NewServer(pathsummary.WithURL(&url.URL{Scheme: "tcp", Host: "10.0.0.1:5001"})),
+	)
+
+	receiver, err := adapters.NewMonitorServerToClient(monitorServer).MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Id: "nsmgr-id"}},
+	})
+	require.NoError(t, err)
+	_, err = receiver.Recv()
+	require.NoError(t, err)
+
+	conn, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "nsmgr-id",
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Id: "nsc-id"},
+					{Name: "nsmgr", Id: "nsmgr-id", Expires: timestamppb.New(expires)},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	event, err := receiver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+
+	summary, err := pathsummary.FromConnection(event.GetConnections()[conn.GetId()])
+	require.NoError(t, err)
+	require.Equal(t, &pathsummary.Summary{
+		Index: 1,
+		Segments: []*pathsummary.Segment{
+			{Name: "nsc", ID: "nsc-id"},
+			{Name: "nsmgr", ID: "nsmgr-id", URL: "tcp://10.0.0.1:5001", Expires: &expires},
+		},
+	}, summary)
+	require.Equal(t, "nsc -> nsmgr (tcp://10.0.0.1:5001, expires 2022-01-01T00:10:00Z)", summary.String())
+
+	_, err = pathsummary.FromConnection(&networkservice.Connection{})
+	require.Error(t, err)
+}
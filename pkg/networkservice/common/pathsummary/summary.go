@@ -0,0 +1,123 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathsummary provides a chain element precomputing a normalized, human-readable summary of the connection
+// path (segment names, URLs, token expirations), so the monitor events carry it and UIs can render end-to-end paths
+// without interpreting the path themselves.
+package pathsummary
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Key is a connection extra context key of the JSON encoded Summary
+	Key = "path-summary"
+	// URLMetricKey is a path segment metrics key of the segment owner URL
+	URLMetricKey = "url"
+)
+
+// Segment is a summary of the path segment
+type Segment struct {
+	Name    string     `json:"name"`
+	ID      string     `json:"id"`
+	URL     string     `json:"url,omitempty"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// Summary is a summary of the connection path
+type Summary struct {
+	// Index is the index of the segment of the summary producer
+	Index    int        `json:"index"`
+	Segments []*Segment `json:"segments"`
+}
+
+// New creates a Summary of the path
+func New(path *networkservice.Path) *Summary {
+	s := &Summary{
+		Index:    int(path.GetIndex()),
+		Segments: make([]*Segment, 0, len(path.GetPathSegments())),
+	}
+	for _, segment := range path.GetPathSegments() {
+		summarySegment := &Segment{
+			Name: segment.GetName(),
+			ID:   segment.GetId(),
+			URL:  segment.GetMetrics()[URLMetricKey],
+		}
+		if segment.GetExpires() != nil {
+			expires := segment.GetExpires().AsTime().UTC()
+			summarySegment.Expires = &expires
+		}
+		s.Segments = append(s.Segments, summarySegment)
+	}
+	return s
+}
+
+// FromConnection returns the Summary stored in the connection extra context
+func FromConnection(conn *networkservice.Connection) (*Summary, error) {
+	value, ok := conn.GetContext().GetExtraContext()[Key]
+	if !ok {
+		return nil, errors.Errorf("no path summary in the connection %s", conn.GetId())
+	}
+	s := new(Summary)
+	if err := json.Unmarshal([]byte(value), s); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode path summary of the connection %s", conn.GetId())
+	}
+	return s, nil
+}
+
+// String returns the human-readable path, e.g.:
+//
+//	nsc -> nsmgr (tcp://10.0.0.1:5001, expires 2022-01-01T00:10:00Z) -> nse (tcp://10.0.0.2:5002)
+func (s *Summary) String() string {
+	parts := make([]string, 0, len(s.Segments))
+	for _, segment := range s.Segments {
+		var details []string
+		if segment.URL != "" {
+			details = append(details, segment.URL)
+		}
+		if segment.Expires != nil {
+			details = append(details, fmt.Sprintf("expires %s", segment.Expires.Format(time.RFC3339)))
+		}
+		if len(details) == 0 {
+			parts = append(parts, segment.Name)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", segment.Name, strings.Join(details, ", ")))
+	}
+	return strings.Join(parts, " -> ")
+}
+
+func store(conn *networkservice.Connection) error {
+	value, err := json.Marshal(New(conn.GetPath()))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode path summary")
+	}
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+	conn.GetContext().GetExtraContext()[Key] = string(value)
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package payloadvalidation provides a chain element rejecting the Requests with the payload types not supported by
+// the deployment or not passing the payload type validator.
+package payloadvalidation
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/payloadtypes"
+)
+
+type payloadValidationServer struct {
+	types *payloadtypes.Registry
+}
+
+// NewServer creates a NetworkServiceServer chain element validating the Request payload with the payload types
+// registry. It should be placed after discover, so the payload of the Network Service is already set to the Request.
+func NewServer(types *payloadtypes.Registry) networkservice.NetworkServiceServer {
+	return &payloadValidationServer{
+		types: types,
+	}
+}
+
+func (s *payloadValidationServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := s.types.Validate(ctx, request.GetConnection()); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *payloadValidationServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
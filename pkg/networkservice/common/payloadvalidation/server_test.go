@@ -0,0 +1,53 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadvalidation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/payloadvalidation"
+	"github.com/networkservicemesh/sdk/pkg/tools/payloadtypes"
+)
+
+func TestPayloadValidationServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	types := payloadtypes.NewRegistry()
+	require.NoError(t, types.Register("L2TP", nil))
+
+	server := payloadvalidation.NewServer(types)
+
+	for _, payloadType := range []string{"", payload.Ethernet, "L2TP"} {
+		_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Payload: payloadType},
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Payload: "MPLS"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+type options struct {
+	closeOnPanic bool
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithCloseOnPanic sets if the connection should be closed by the downstream elements after a panic in Request, so
+// partially allocated resources are cleaned up
+func WithCloseOnPanic(closeOnPanic bool) Option {
+	return func(o *options) {
+		o.closeOnPanic = closeOnPanic
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recovery provides a chain element recovering panics from the downstream elements, so one buggy chain element
+// can't crash the whole process.
+package recovery
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const panicsMetricName = "recovered_panics"
+
+type recoveryServer struct {
+	panics       syncint64.Counter
+	closeOnPanic bool
+}
+
+// NewServer creates a new NetworkServiceServer chain element converting panics from the downstream elements to
+// INTERNAL errors. Panic stacks are written to the logger from the Request/Close ctx and every panic increments the
+// "recovered_panics" counter.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &recoveryServer{
+		closeOnPanic: o.closeOnPanic,
+	}
+	if counter, err := global.Meter("").SyncInt64().Counter(panicsMetricName); err == nil {
+		s.panics = counter
+	}
+	return s
+}
+
+func (s *recoveryServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (conn *networkservice.Connection, err error) {
+	defer func() {
+		if recoverErr := s.recover(ctx, "Request", recover()); recoverErr != nil {
+			conn, err = nil, recoverErr
+			if s.closeOnPanic {
+				_, _ = s.Close(ctx, request.GetConnection())
+			}
+		}
+	}()
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *recoveryServer) Close(ctx context.Context, conn *networkservice.Connection) (_ *empty.Empty, err error) {
+	defer func() {
+		if recoverErr := s.recover(ctx, "Close", recover()); recoverErr != nil {
+			err = recoverErr
+		}
+	}()
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *recoveryServer) recover(ctx context.Context, operation string, r interface{}) error {
+	if r == nil {
+		return nil
+	}
+
+	log.FromContext(ctx).WithField("recoveryServer", "recover").Errorf("recovered panic in %s: %v\n%s", operation, r, debug.Stack())
+	if s.panics != nil {
+		s.panics.Add(ctx, 1, attribute.String("operation", operation))
+	}
+	return status.Errorf(codes.Internal, "recovered panic in %s: %v", operation, r)
+}
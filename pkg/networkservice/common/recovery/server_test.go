@@ -0,0 +1,96 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/recovery"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+)
+
+func TestRecoveryServer_Request(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	counter := new(count.Server)
+	server := next.NewNetworkServiceServer(
+		recovery.NewServer(recovery.WithCloseOnPanic(true)),
+		counter,
+		checkrequest.NewServer(t, func(*testing.T, *networkservice.NetworkServiceRequest) {
+			panic("request panic")
+		}),
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.Nil(t, conn)
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Contains(t, err.Error(), "request panic")
+	require.Equal(t, 1, counter.Closes())
+}
+
+func TestRecoveryServer_Error(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceServer(
+		recovery.NewServer(),
+		injecterror.NewServer(),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.Error(t, err)
+	require.NotEqual(t, codes.Internal, status.Code(err))
+
+	_, err = server.Close(context.Background(), &networkservice.Connection{})
+	require.Error(t, err)
+}
+
+func TestRecoveryServer_RequestLogger(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var buff bytes.Buffer
+	logrus.SetOutput(&buff)
+	defer logrus.SetOutput(os.Stderr)
+
+	server := next.NewNetworkServiceServer(
+		recovery.NewServer(),
+		checkrequest.NewServer(t, func(*testing.T, *networkservice.NetworkServiceRequest) {
+			panic("request panic")
+		}),
+	)
+
+	ctx := log.WithLog(context.Background(), logruslogger.New(context.Background(), logrus.Fields{"requestID": "request-1"}))
+	_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	require.Contains(t, buff.String(), "request-1")
+	require.Contains(t, buff.String(), "recovered panic in Request: request panic")
+}
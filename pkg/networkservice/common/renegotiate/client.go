@@ -0,0 +1,126 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renegotiate
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type renegotiateClient struct {
+	chainCtx context.Context
+}
+
+// NewClient creates a new NetworkServiceClient chain element re-Requesting the connection once the NSE asks to
+// renegotiate it. It should be placed after dial.
+func NewClient(chainCtx context.Context) networkservice.NetworkServiceClient {
+	return &renegotiateClient{
+		chainCtx: chainCtx,
+	}
+}
+
+func (c *renegotiateClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, ok := clientconn.Load(ctx)
+	if !ok {
+		return conn, nil
+	}
+	eventFactory := begin.FromContext(ctx)
+	if eventFactory == nil {
+		return conn, nil
+	}
+
+	logger := log.FromContext(ctx).WithField("renegotiateClient", "Request")
+
+	watchCtx, cancel := context.WithCancel(extend.WithValuesFromContext(c.chainCtx, ctx))
+	selector := &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{
+			{
+				Id:   conn.GetCurrentPathSegment().GetId(),
+				Name: conn.GetCurrentPathSegment().GetName(),
+			},
+		},
+	}
+	monitorClient, err := networkservice.NewMonitorConnectionClient(cc).MonitorConnections(watchCtx, selector)
+	if err != nil {
+		cancel()
+		logger.Warnf("failed to monitor the connection %s: %s", conn.GetId(), err.Error())
+		return conn, nil
+	}
+	// Wait for the initial state transfer, so the renegotiation requested right after the Request is not missed
+	initialEvent, err := monitorClient.Recv()
+	if err != nil {
+		cancel()
+		logger.Warnf("failed to monitor the connection %s: %s", conn.GetId(), err.Error())
+		return conn, nil
+	}
+	store(ctx, cancel)
+
+	go watch(monitorClient, initialEvent, eventFactory, conn.Clone(), logger)
+
+	return conn, nil
+}
+
+func (c *renegotiateClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if cancel, ok := loadAndDelete(ctx); ok {
+		cancel()
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// watch re-Requests the connection once its generation changes. The re-Request cancels the watch and starts a new
+// one, so watch handles only a single renegotiation.
+func watch(monitorClient networkservice.MonitorConnection_MonitorConnectionsClient, event *networkservice.ConnectionEvent,
+	eventFactory begin.EventFactory, conn *networkservice.Connection, logger log.Logger) {
+	current := generation(conn)
+	index := int(conn.GetPath().GetIndex())
+	for {
+		for _, eventConn := range event.GetConnections() {
+			segments := eventConn.GetPath().GetPathSegments()
+			if len(segments) <= index || segments[index].GetId() != conn.GetId() {
+				continue
+			}
+			if g := generation(eventConn); g != "" && g != current {
+				logger.Infof("renegotiating the connection %s", conn.GetId())
+				eventFactory.Request()
+				return
+			}
+		}
+
+		var err error
+		if event, err = monitorClient.Recv(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package renegotiate provides chain elements allowing an NSE to ask its clients to re-Request the existing
+// connections, so the changed connection parameters (e.g. IP renumbering, new routes) are applied without waiting for
+// the next refresh.
+// The NSE side Server marks the NSE path segment with a new generation and sends the monitor UPDATE event, the client
+// side element watches the monitor events of the connection and re-Requests it once the generation changes.
+package renegotiate
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// MetricKey is a path segment metrics key of the renegotiation generation
+const MetricKey = "renegotiate"
+
+// generation returns the renegotiation generation of the connection stored in the NSE (last) path segment
+func generation(conn *networkservice.Connection) string {
+	segments := conn.GetPath().GetPathSegments()
+	if len(segments) == 0 {
+		return ""
+	}
+	return segments[len(segments)-1].GetMetrics()[MetricKey]
+}
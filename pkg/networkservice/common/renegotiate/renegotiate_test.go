@@ -0,0 +1,102 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renegotiate_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/renegotiate"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkresponse"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func TestRenegotiate(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(2).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsReg, err := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken).Register(ctx, &registry.NetworkService{
+		Name: "ns",
+	})
+	require.NoError(t, err)
+
+	renegotiateServer := renegotiate.NewServer()
+	counter := new(count.Server)
+	domain.Nodes[1].NewEndpoint(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{nsReg.GetName()},
+	}, sandbox.GenerateTestToken, renegotiateServer, counter)
+
+	// Counts the completed NSC Requests including the renegotiation ones
+	var responses int32
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken,
+		client.WithAdditionalFunctionality(
+			checkresponse.NewClient(t, func(*testing.T, *networkservice.Connection) {
+				atomic.AddInt32(&responses, 1)
+			}),
+			renegotiate.NewClient(ctx),
+		),
+	)
+	renegotiated := func(expected int32) func() bool {
+		return func() bool { return atomic.LoadInt32(&responses) == expected }
+	}
+
+	conn, err := nsc.Request(ctx, &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{{Cls: cls.LOCAL, Type: kernelmech.MECHANISM}},
+		Connection:           &networkservice.Connection{Id: "1", NetworkService: nsReg.GetName()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.Requests())
+	require.Equal(t, int32(1), atomic.LoadInt32(&responses))
+
+	nseConnID := conn.GetPath().GetPathSegments()[len(conn.GetPath().GetPathSegments())-1].GetId()
+	require.Error(t, renegotiateServer.Renegotiate("unknown"))
+
+	require.NoError(t, renegotiateServer.Renegotiate(nseConnID))
+	require.Eventually(t, renegotiated(2), time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, counter.Requests())
+
+	require.NoError(t, renegotiateServer.RenegotiateAll())
+	require.Eventually(t, renegotiated(3), time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, counter.Requests())
+
+	// The renegotiated connection is not renegotiated again
+	require.Never(t, func() bool { return counter.Requests() > 3 }, 200*time.Millisecond, 10*time.Millisecond)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Error(t, renegotiateServer.Renegotiate(nseConnID))
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package renegotiate
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+)
+
+type connectionInfo struct {
+	conn          *networkservice.Connection
+	eventConsumer monitor.EventConsumer
+	generation    uint64
+}
+
+// Server is a NSE side NetworkServiceServer chain element sending the renegotiation requests to the clients. It
+// should be placed after monitor and updatepath.
+type Server struct {
+	mu          sync.Mutex
+	connections map[string]*connectionInfo
+}
+
+// NewServer creates a new renegotiate Server
+func NewServer() *Server {
+	return &Server{
+		connections: make(map[string]*connectionInfo),
+	}
+}
+
+// Request implements networkservice.NetworkServiceServer
+func (s *Server) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	eventConsumer, ok := monitor.LoadEventConsumer(ctx, false)
+	if !ok {
+		return conn, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.connections[conn.GetId()]
+	if !ok {
+		info = new(connectionInfo)
+		s.connections[conn.GetId()] = info
+	}
+	// Keep the generation on the connection, so the client doesn't renegotiate it again
+	if info.generation > 0 {
+		setGeneration(conn, info.generation)
+	}
+	info.conn = conn.Clone()
+	info.eventConsumer = eventConsumer
+
+	return conn, nil
+}
+
+// Close implements networkservice.NetworkServiceServer
+func (s *Server) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mu.Lock()
+	delete(s.connections, conn.GetId())
+	s.mu.Unlock()
+
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// Renegotiate asks the client of the connection to re-Request it
+func (s *Server) Renegotiate(connectionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.connections[connectionID]
+	if !ok {
+		return errors.Errorf("connection %s is not found", connectionID)
+	}
+	return info.renegotiate()
+}
+
+// RenegotiateAll asks the clients of all the connections to re-Request them
+func (s *Server) RenegotiateAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for id, info := range s.connections {
+		if renegotiateErr := info.renegotiate(); renegotiateErr != nil {
+			err = errors.Wrapf(renegotiateErr, "failed to renegotiate the connection %s", id)
+		}
+	}
+	return err
+}
+
+func (i *connectionInfo) renegotiate() error {
+	i.generation++
+	setGeneration(i.conn, i.generation)
+
+	return i.eventConsumer.Send(&networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{i.conn.GetId(): i.conn.Clone()},
+	})
+}
+
+func setGeneration(conn *networkservice.Connection, generation uint64) {
+	segment := conn.GetCurrentPathSegment()
+	if segment == nil {
+		return
+	}
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	segment.Metrics[MetricKey] = strconv.FormatUint(generation, 10)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundrobin
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/drain"
+)
+
+// Option is an option pattern for NewServer
+type Option func(s *selectEndpointServer)
+
+// WithDrainTracker sets the tracker of the endpoints with the pending unregistration, such endpoints are not selected
+// unless all the candidates are draining
+func WithDrainTracker(tracker *drain.Tracker) Option {
+	return func(s *selectEndpointServer) {
+		s.drainTracker = tracker
+	}
+}
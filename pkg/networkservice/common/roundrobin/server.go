@@ -31,18 +31,25 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/discover"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/drain"
 )
 
 type selectEndpointServer struct {
-	selector *roundRobinSelector
+	selector     *roundRobinSelector
+	drainTracker *drain.Tracker
 }
 
 // NewServer - provides a NetworkServiceServer chain element that round robins among candidates provided by
-// discover.Candidate(ctx) in the context.
-func NewServer() networkservice.NetworkServiceServer {
-	return &selectEndpointServer{
+// discover.Candidate(ctx) in the context. Cordoned candidates and candidates with the pending unregistration are
+// skipped unless all the candidates are draining.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	s := &selectEndpointServer{
 		selector: newRoundRobinSelector(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *selectEndpointServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -50,13 +57,14 @@ func (s *selectEndpointServer) Request(ctx context.Context, request *networkserv
 		return next.Server(ctx).Request(ctx, request)
 	}
 	candidates := discover.Candidates(ctx)
+	endpoints := drain.Available(s.drainTracker, candidates.Endpoints)
 
 	var candidatesErr = errors.New("all candidates have failed")
 
-	for i := 0; i < len(candidates.Endpoints); i++ {
-		endpoint := s.selector.selectEndpoint(candidates.NetworkService, candidates.Endpoints)
+	for i := 0; i < len(endpoints); i++ {
+		endpoint := s.selector.selectEndpoint(candidates.NetworkService, endpoints)
 		if endpoint == nil {
-			return nil, errors.Errorf("failed to select endpoint for Network Service: %v %v", candidates.NetworkService, candidates.Endpoints)
+			return nil, errors.Errorf("failed to select endpoint for Network Service: %v %v", candidates.NetworkService, endpoints)
 		}
 		u, err := url.Parse(endpoint.Url)
 		if err != nil {
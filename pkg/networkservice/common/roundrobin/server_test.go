@@ -32,11 +32,13 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/drain"
 )
 
 const (
 	nse1 = "nse-1"
 	nse2 = "nse-2"
+	nse3 = "nse-3"
 	ns   = "ns"
 )
 
@@ -86,3 +88,63 @@ func TestSelectEndpointServer_CleanRequest(t *testing.T) {
 	require.Equal(t, nse2, conn.NetworkServiceEndpointName)
 	require.Equal(t, map[string]string{nse2: nse2}, conn.Labels)
 }
+
+func TestSelectEndpointServer_Drain(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	tracker := drain.NewTracker(context.Background())
+
+	var selected []string
+	s := next.NewNetworkServiceServer(
+		roundrobin.NewServer(roundrobin.WithDrainTracker(tracker)),
+		checkrequest.NewServer(t, func(_ *testing.T, request *networkservice.NetworkServiceRequest) {
+			selected = append(selected, request.GetConnection().GetNetworkServiceEndpointName())
+		}),
+	)
+
+	cordoned := &registry.NetworkServiceEndpoint{
+		Name:                nse1,
+		Url:                 "unix://" + nse1,
+		NetworkServiceNames: []string{ns},
+	}
+	drain.Cordon(cordoned)
+	endpoints := []*registry.NetworkServiceEndpoint{
+		cordoned,
+		{
+			Name:                nse2,
+			Url:                 "unix://" + nse2,
+			NetworkServiceNames: []string{ns},
+		},
+		{
+			Name:                nse3,
+			Url:                 "unix://" + nse3,
+			NetworkServiceNames: []string{ns},
+		},
+	}
+	tracker.Unregistering(nse2)
+
+	ctx := discover.WithCandidates(context.Background(), endpoints, &registry.NetworkService{Name: ns})
+	for i := 0; i < 3; i++ {
+		_, err := s.Request(ctx, &networkservice.NetworkServiceRequest{Connection: &networkservice.Connection{}})
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{nse3, nse3, nse3}, selected)
+
+	// All the candidates are draining, so they are selected anyway
+	selected = nil
+	ctx = discover.WithCandidates(context.Background(), endpoints[:2], &registry.NetworkService{Name: ns})
+	for i := 0; i < 2; i++ {
+		_, err := s.Request(ctx, &networkservice.NetworkServiceRequest{Connection: &networkservice.Connection{}})
+		require.NoError(t, err)
+	}
+	require.ElementsMatch(t, []string{nse1, nse2}, selected)
+
+	// Registered again
+	selected = nil
+	tracker.Registered(nse2)
+	for i := 0; i < 2; i++ {
+		_, err := s.Request(ctx, &networkservice.NetworkServiceRequest{Connection: &networkservice.Connection{}})
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{nse2, nse2}, selected)
+}
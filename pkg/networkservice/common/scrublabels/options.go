@@ -0,0 +1,52 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scrublabels
+
+type options struct {
+	remove           map[string]struct{}
+	rewrite          map[string]string
+	scrubPathDetails bool
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithRemovedLabels sets label keys which are removed from the connections crossing the domain boundary
+func WithRemovedLabels(keys ...string) Option {
+	return func(o *options) {
+		for _, key := range keys {
+			o.remove[key] = struct{}{}
+		}
+	}
+}
+
+// WithRewrittenLabels sets label values which replace the original values of the connections crossing the domain boundary
+func WithRewrittenLabels(labels map[string]string) Option {
+	return func(o *options) {
+		for k, v := range labels {
+			o.rewrite[k] = v
+		}
+	}
+}
+
+// WithPathDetailsScrubbed enables removing of the local path segments metrics and masking of the previous path segments
+// names and IDs of the connections crossing the domain boundary
+func WithPathDetailsScrubbed() Option {
+	return func(o *options) {
+		o.scrubPathDetails = true
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scrublabels provides a chain element that strips or rewrites internal labels and path details of the
+// connections crossing the domain boundary, so the internal topology is not leaked to the foreign clusters.
+package scrublabels
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
+)
+
+type scrubLabelsServer struct {
+	*options
+}
+
+// NewServer creates a NetworkServiceServer chain element that applies the configured scrubbing policy to the
+// interdomain Requests/Closes before passing them to the foreign domain. The passed request and connection are never
+// modified, original labels and path details are restored on the response.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		remove:  make(map[string]struct{}),
+		rewrite: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &scrubLabelsServer{
+		options: o,
+	}
+}
+
+func (s *scrubLabelsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if !crossesDomain(request.GetConnection()) {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	// Scrub a clone, so the upstream elements keep the original request
+	original := request.GetConnection()
+	request = request.Clone()
+	s.scrub(request.GetConnection())
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.restore(conn, original)
+
+	return conn, nil
+}
+
+func (s *scrubLabelsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if !crossesDomain(conn) {
+		return next.Server(ctx).Close(ctx, conn)
+	}
+
+	conn = conn.Clone()
+	s.scrub(conn)
+
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *scrubLabelsServer) scrub(conn *networkservice.Connection) {
+	if conn == nil {
+		return
+	}
+
+	if len(s.remove) > 0 || len(s.rewrite) > 0 {
+		scrubbed := make(map[string]string, len(conn.GetLabels()))
+		for k, v := range conn.GetLabels() {
+			if _, ok := s.remove[k]; ok {
+				continue
+			}
+			if rewritten, ok := s.rewrite[k]; ok {
+				v = rewritten
+			}
+			scrubbed[k] = v
+		}
+		conn.Labels = scrubbed
+	}
+
+	if s.scrubPathDetails {
+		index := int(conn.GetPath().GetIndex())
+		segments := conn.GetPath().GetPathSegments()
+		for i := 0; i < len(segments) && i <= index; i++ {
+			segments[i].Metrics = nil
+			// The current segment identifies the connection for the next hop, so only the previous ones are masked
+			if i < index {
+				segments[i].Name = mask(segments[i].GetName())
+				segments[i].Id = mask(segments[i].GetId())
+			}
+		}
+	}
+}
+
+func (s *scrubLabelsServer) restore(conn, original *networkservice.Connection) {
+	conn.Labels = original.GetLabels()
+
+	if s.scrubPathDetails {
+		segments := conn.GetPath().GetPathSegments()
+		originalSegments := original.GetPath().GetPathSegments()
+		for i := 0; i < len(segments) && i < len(originalSegments) && i <= int(original.GetPath().GetIndex()); i++ {
+			segments[i].Name = originalSegments[i].GetName()
+			segments[i].Id = originalSegments[i].GetId()
+			segments[i].Metrics = originalSegments[i].GetMetrics()
+		}
+	}
+}
+
+// mask returns a stable opaque replacement of the value, so the foreign domain sees the same values on refreshes
+func mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+func crossesDomain(conn *networkservice.Connection) bool {
+	return interdomain.Is(conn.GetNetworkService()) || interdomain.Is(conn.GetNetworkServiceEndpointName())
+}
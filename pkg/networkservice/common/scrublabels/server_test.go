@@ -0,0 +1,92 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scrublabels_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/scrublabels"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
+)
+
+func newRequest(ns string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: ns,
+			Labels:         map[string]string{"app": "nsc", "node": "node-1", "cluster": "internal"},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Id: "nsc-id", Metrics: map[string]string{"node": "node-1"}},
+					{Name: "nsmgr-proxy", Id: "nsmgr-proxy-id", Metrics: map[string]string{"node": "node-2"}},
+				},
+				Index: 1,
+			},
+		},
+	}
+}
+
+func TestScrubLabelsServer_Interdomain(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := chain.NewNetworkServiceServer(
+		scrublabels.NewServer(
+			scrublabels.WithRemovedLabels("node"),
+			scrublabels.WithRewrittenLabels(map[string]string{"cluster": "public"}),
+			scrublabels.WithPathDetailsScrubbed(),
+		),
+		checkrequest.NewServer(t, func(t *testing.T, r *networkservice.NetworkServiceRequest) {
+			require.Equal(t, map[string]string{"app": "nsc", "cluster": "public"}, r.GetConnection().GetLabels())
+			segments := r.GetConnection().GetPath().GetPathSegments()
+			for _, segment := range segments {
+				require.Nil(t, segment.GetMetrics())
+			}
+			require.NotEqual(t, "nsc", segments[0].GetName())
+			require.NotEqual(t, "nsc-id", segments[0].GetId())
+			require.Equal(t, "nsmgr-proxy-id", segments[1].GetId())
+		}),
+	)
+
+	request := newRequest("ns@domain")
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, newRequest("").GetConnection().GetLabels(), conn.GetLabels())
+	require.Equal(t, "node-2", conn.GetPath().GetPathSegments()[1].GetMetrics()["node"])
+	require.Equal(t, "nsc-id", conn.GetPath().GetPathSegments()[0].GetId())
+
+	// The passed request is not modified
+	require.Equal(t, newRequest("ns@domain").String(), request.String())
+}
+
+func TestScrubLabelsServer_Local(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := chain.NewNetworkServiceServer(
+		scrublabels.NewServer(scrublabels.WithRemovedLabels("node")),
+		checkrequest.NewServer(t, func(t *testing.T, r *networkservice.NetworkServiceRequest) {
+			require.Equal(t, "node-1", r.GetConnection().GetLabels()["node"])
+		}),
+	)
+
+	_, err := server.Request(context.Background(), newRequest("ns"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceacl
+
+import "github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+
+type options struct {
+	aclFilePath string
+	policies    []authorize.Policy
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithACLFilePath sets path to the YAML file mapping client SPIFFE IDs to the network services they may request:
+//
+//	spiffe://example.org/ns/default/sa/nsc:
+//	  - my-networkservice
+//	"*":
+//	  - public-networkservice
+//
+// File is watched for changes.
+func WithACLFilePath(aclFilePath string) Option {
+	return func(o *options) {
+		o.aclFilePath = aclFilePath
+	}
+}
+
+// WithPolicies sets policies checking Input with the client SPIFFE ID and requested network service
+func WithPolicies(policies ...authorize.Policy) Option {
+	return func(o *options) {
+		o.policies = policies
+	}
+}
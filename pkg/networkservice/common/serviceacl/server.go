@@ -0,0 +1,166 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package serviceacl provides a chain element enforcing per network service ACLs before the discovery, so clients
+// requesting not allowed network services are rejected with PERMISSION_DENIED before any dialing occurs.
+package serviceacl
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"gopkg.in/yaml.v2"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+)
+
+const anyID = "*"
+
+// Input is an input passed to the policies
+type Input struct {
+	SpiffeID       string `json:"spiffe_id"`
+	NetworkService string `json:"network_service"`
+}
+
+type acl map[string][]string
+
+func (a acl) allowed(spiffeID, networkService string) bool {
+	for _, id := range []string{spiffeID, anyID} {
+		for _, ns := range a[id] {
+			if ns == networkService || ns == anyID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type serviceACLServer struct {
+	acl      *atomic.Value
+	policies []authorize.Policy
+}
+
+// NewServer creates a new NetworkServiceServer chain element checking that the client identity is allowed to request
+// the network service. Without ACL file and policies all requests are allowed.
+func NewServer(ctx context.Context, opts ...Option) networkservice.NetworkServiceServer {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &serviceACLServer{
+		acl:      new(atomic.Value),
+		policies: o.policies,
+	}
+
+	if o.aclFilePath != "" {
+		logger := log.FromContext(ctx).WithField("serviceACLServer", o.aclFilePath)
+
+		// Until the ACL is successfully loaded, all requests are denied
+		s.acl.Store(make(acl))
+		if data, err := ioutil.ReadFile(filepath.Clean(o.aclFilePath)); err != nil {
+			logger.Errorf("failed to read network service ACL: %v", err.Error())
+		} else {
+			s.load(logger, data)
+		}
+
+		updateCh := fs.WatchFile(ctx, o.aclFilePath)
+		go func() {
+			for data := range updateCh {
+				if data == nil {
+					logger.Warn("network service ACL file is removed, keeping the previous ACL")
+					continue
+				}
+				s.load(logger, data)
+			}
+		}()
+	}
+
+	return s
+}
+
+func (s *serviceACLServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	if err := s.check(ctx, request.GetConnection()); err != nil {
+		return nil, err
+	}
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *serviceACLServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *serviceACLServer) check(ctx context.Context, conn *networkservice.Connection) error {
+	a, hasACL := s.acl.Load().(acl)
+	if !hasACL && len(s.policies) == 0 {
+		return nil
+	}
+
+	networkService := conn.GetNetworkService()
+	input := &Input{
+		SpiffeID:       clientID(ctx, conn.GetPath()),
+		NetworkService: networkService,
+	}
+
+	if hasACL && !a.allowed(input.SpiffeID, networkService) {
+		return nsmerrors.Errorf(nsmerrors.PolicyDenied, "%q is not allowed to request network service %q", input.SpiffeID, networkService)
+	}
+	for _, policy := range s.policies {
+		if err := policy.Check(ctx, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clientID returns the client identity: the subject of the first path segment token or the peer SPIFFE ID if the
+// request comes directly from the client without a token
+func clientID(ctx context.Context, path *networkservice.Path) string {
+	if token := path.GetPathSegments(); len(token) > 0 && token[0].GetToken() != "" {
+		claims := new(jwt.RegisteredClaims)
+		if _, _, err := new(jwt.Parser).ParseUnverified(token[0].GetToken(), claims); err != nil {
+			return ""
+		}
+		return claims.Subject
+	}
+	if path.GetIndex() != 0 {
+		return ""
+	}
+	if spiffeID, err := spire.SpiffeIDFromContext(ctx); err == nil {
+		return spiffeID.String()
+	}
+	return ""
+}
+
+// load parses the ACL and stores it, keeps the previous ACL on parse failure
+func (s *serviceACLServer) load(logger log.Logger, data []byte) {
+	rv := make(acl)
+	if err := yaml.Unmarshal(data, &rv); err != nil {
+		logger.Errorf("failed to parse network service ACL, keeping the previous ACL: %v", err.Error())
+		return
+	}
+	s.acl.Store(rv)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceacl_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/serviceacl"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffepeer"
+)
+
+func request(ns string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{NetworkService: ns},
+	}
+}
+
+func remoteRequest(t *testing.T, ns, clientID string) *networkservice.NetworkServiceRequest {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{Subject: clientID}).SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			NetworkService: ns,
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Token: token},
+					{Name: "nsmgr"},
+				},
+			},
+		},
+	}
+}
+
+func TestServiceACLServer_File(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "acl.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+spiffe://test.com/nsc:
+  - private-ns
+"*":
+  - public-ns
+`), os.ModePerm))
+
+	server := serviceacl.NewServer(ctx, serviceacl.WithACLFilePath(path))
+
+	nscCtx := spiffepeer.NewContext(ctx, "spiffe://test.com/nsc")
+	otherCtx := spiffepeer.NewContext(ctx, "spiffe://test.com/other")
+
+	_, err := server.Request(nscCtx, request("private-ns"))
+	require.NoError(t, err)
+	_, err = server.Request(nscCtx, request("public-ns"))
+	require.NoError(t, err)
+
+	_, err = server.Request(otherCtx, request("public-ns"))
+	require.NoError(t, err)
+	_, err = server.Request(otherCtx, request("private-ns"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServiceACLServer_Policy(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	policy := opa.WithPolicyFromSource(`
+		package test
+
+		default allow = false
+
+		allow {
+			input.spiffe_id == "spiffe://test.com/nsc"
+			input.network_service == "private-ns"
+		}
+`, "allow", opa.True)
+
+	server := serviceacl.NewServer(context.Background(), serviceacl.WithPolicies(policy))
+
+	_, err := server.Request(spiffepeer.NewContext(context.Background(), "spiffe://test.com/nsc"), request("private-ns"))
+	require.NoError(t, err)
+	_, err = server.Request(spiffepeer.NewContext(context.Background(), "spiffe://test.com/other"), request("private-ns"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = serviceacl.NewServer(context.Background()).Request(context.Background(), request("private-ns"))
+	require.NoError(t, err)
+}
+
+func TestServiceACLServer_RemoteHop(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "acl.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+spiffe://test.com/nsc:
+  - private-ns
+`), os.ModePerm))
+
+	server := serviceacl.NewServer(ctx, serviceacl.WithACLFilePath(path))
+
+	// The peer is the previous NSMgr, the client identity is taken from the path
+	nsmgrCtx := spiffepeer.NewContext(ctx, "spiffe://test.com/nsmgr")
+
+	_, err := server.Request(nsmgrCtx, remoteRequest(t, "private-ns", "spiffe://test.com/nsc"))
+	require.NoError(t, err)
+	_, err = server.Request(nsmgrCtx, remoteRequest(t, "private-ns", "spiffe://test.com/other"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestServiceACLServer_InvalidUpdate(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "acl.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+"*":
+  - public-ns
+`), os.ModePerm))
+
+	server := serviceacl.NewServer(ctx, serviceacl.WithACLFilePath(path))
+	nscCtx := spiffepeer.NewContext(ctx, "spiffe://test.com/nsc")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`not: [valid`), os.ModePerm))
+
+	require.Never(t, func() bool {
+		_, err := server.Request(nscCtx, request("public-ns"))
+		return err != nil
+	}, 200*time.Millisecond, 10*time.Millisecond)
+}
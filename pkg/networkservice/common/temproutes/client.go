@@ -0,0 +1,216 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package temproutes provides a client chain element installing the temporary alternate routes to the IPContext while
+// the connection is being healed and removing them once the connection is restored or the routes TTL expires.
+package temproutes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// MarkPrefix is the prefix of the ConnectionContext.ExtraContext keys marking the temporary routes. The value is the
+// route expiration time in RFC3339 format.
+const MarkPrefix = "temp-route:"
+
+const (
+	srcSide = "src:"
+	dstSide = "dst:"
+)
+
+type tempRoutesClient struct {
+	tracker   *Tracker
+	srcRoutes []*networkservice.Route
+	dstRoutes []*networkservice.Route
+	ttl       time.Duration
+}
+
+// NewClient creates a new NetworkServiceClient chain element installing the temporary routes while tracker reports
+// the connection is being healed. It should be placed after the heal client.
+func NewClient(tracker *Tracker, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		ttl: DefaultTTL,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &tempRoutesClient{
+		tracker:   tracker,
+		srcRoutes: o.srcRoutes,
+		dstRoutes: o.dstRoutes,
+		ttl:       o.ttl,
+	}
+}
+
+func (c *tempRoutesClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetIpContext() == nil {
+		conn.Context.IpContext = &networkservice.IPContext{}
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.Context.ExtraContext = make(map[string]string)
+	}
+
+	now := clock.FromContext(ctx).Now()
+	healing := c.tracker.Healing(conn.GetId())
+
+	ipCtx := conn.GetContext().GetIpContext()
+	ipCtx.SrcRoutes = c.update(ctx, conn.GetContext().GetExtraContext(), ipCtx.GetSrcRoutes(), c.srcRoutes, srcSide, healing, now)
+	ipCtx.DstRoutes = c.update(ctx, conn.GetContext().GetExtraContext(), ipCtx.GetDstRoutes(), c.dstRoutes, dstSide, healing, now)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.scheduleCleanup(ctx, conn)
+
+	return conn, nil
+}
+
+func (c *tempRoutesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if timer, loaded := loadAndDelete(ctx, metadata.IsClient(c)); loaded {
+		timer.Stop()
+	}
+	c.tracker.setCleanup(conn.GetId(), nil)
+
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+// update installs the temporary routes missing in the current routes while healing, removes the expired ones and
+// removes all of them when the connection is not being healed anymore. The expired routes marks are kept until the
+// end of healing, so the routes are not installed again.
+func (c *tempRoutesClient) update(
+	ctx context.Context,
+	extraCtx map[string]string,
+	current, routes []*networkservice.Route,
+	side string,
+	healing bool,
+	now time.Time,
+) []*networkservice.Route {
+	for _, route := range routes {
+		markKey := MarkPrefix + side + route.GetPrefix()
+		expires, marked := parseMark(extraCtx, markKey)
+
+		switch {
+		case !healing:
+			if marked {
+				current = removeRoute(current, route)
+				delete(extraCtx, markKey)
+				log.FromContext(ctx).WithField("tempRoutesClient", "Request").Debugf("removed temporary route %s", route)
+			}
+		case !marked:
+			if containsPrefix(current, route.GetPrefix()) {
+				continue
+			}
+			current = append(current, proto.Clone(route).(*networkservice.Route))
+			extraCtx[markKey] = now.Add(c.ttl).Format(time.RFC3339Nano)
+			log.FromContext(ctx).WithField("tempRoutesClient", "Request").Debugf("installed temporary route %s", route)
+		case !now.Before(expires):
+			current = removeRoute(current, route)
+		}
+	}
+	return current
+}
+
+// scheduleCleanup schedules the request removing the temporary routes on the earliest routes expiration and on the
+// connection restore
+func (c *tempRoutesClient) scheduleCleanup(ctx context.Context, conn *networkservice.Connection) {
+	if timer, loaded := loadAndDelete(ctx, metadata.IsClient(c)); loaded {
+		timer.Stop()
+	}
+
+	clockTime := clock.FromContext(ctx)
+	now := clockTime.Now()
+
+	var marked bool
+	var nextExpires time.Time
+	for markKey := range conn.GetContext().GetExtraContext() {
+		expires, ok := parseMark(conn.GetContext().GetExtraContext(), markKey)
+		if !ok {
+			continue
+		}
+		marked = true
+		if expires.After(now) && (nextExpires.IsZero() || expires.Before(nextExpires)) {
+			nextExpires = expires
+		}
+	}
+
+	if !marked {
+		c.tracker.setCleanup(conn.GetId(), nil)
+		return
+	}
+
+	eventFactory := begin.FromContext(ctx)
+	cleanup := func() {
+		eventFactory.Request()
+	}
+	c.tracker.setCleanup(conn.GetId(), cleanup)
+	if !nextExpires.IsZero() {
+		store(ctx, metadata.IsClient(c), clockTime.AfterFunc(nextExpires.Sub(now), cleanup))
+	}
+}
+
+func parseMark(extraCtx map[string]string, markKey string) (time.Time, bool) {
+	if !strings.HasPrefix(markKey, MarkPrefix) {
+		return time.Time{}, false
+	}
+	value, ok := extraCtx[markKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	expires, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+func containsPrefix(routes []*networkservice.Route, prefix string) bool {
+	for _, route := range routes {
+		if route.GetPrefix() == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func removeRoute(routes []*networkservice.Route, route *networkservice.Route) []*networkservice.Route {
+	for i := range routes {
+		if routes[i].GetPrefix() == route.GetPrefix() && routes[i].GetNextHop() == route.GetNextHop() {
+			return append(routes[:i], routes[i+1:]...)
+		}
+	}
+	return routes
+}
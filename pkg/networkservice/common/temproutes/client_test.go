@@ -0,0 +1,168 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temproutes_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/temproutes"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injectclock"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+type captureClient struct {
+	mu        sync.Mutex
+	srcRoutes []*networkservice.Route
+}
+
+func (c *captureClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	c.mu.Lock()
+	c.srcRoutes = nil
+	for _, route := range request.GetConnection().GetContext().GetIpContext().GetSrcRoutes() {
+		c.srcRoutes = append(c.srcRoutes, proto.Clone(route).(*networkservice.Route))
+	}
+	c.mu.Unlock()
+	return next.Client(ctx).Request(ctx, request, opts...)
+}
+
+func (c *captureClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
+
+func (c *captureClient) prefixes() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var prefixes []string
+	for _, route := range c.srcRoutes {
+		prefixes = append(prefixes, route.GetPrefix())
+	}
+	return prefixes
+}
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: "conn-id",
+			Context: &networkservice.ConnectionContext{
+				IpContext: &networkservice.IPContext{
+					SrcRoutes: []*networkservice.Route{{Prefix: "10.0.0.0/24"}},
+				},
+			},
+		},
+	}
+}
+
+func TestTempRoutesClient_Restore(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tracker := temproutes.NewTracker()
+	capture := new(captureClient)
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		metadata.NewClient(),
+		temproutes.NewClient(tracker, temproutes.WithSrcRoutes(&networkservice.Route{Prefix: "10.1.0.0/24", NextHop: "10.1.0.1"})),
+		capture,
+	)
+
+	conn, err := client.Request(ctx, newRequest())
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/24"}, capture.prefixes())
+
+	tracker.OnHeal(heal.Event{ConnectionID: conn.GetId(), Reason: heal.ReasonDataPlaneDown})
+	require.True(t, tracker.Healing(conn.GetId()))
+
+	conn, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/24", "10.1.0.0/24"}, capture.prefixes())
+	require.Contains(t, conn.GetContext().GetExtraContext(), temproutes.MarkPrefix+"src:10.1.0.0/24")
+
+	tracker.OnRestore(heal.Event{ConnectionID: conn.GetId(), Reason: heal.ReasonDataPlaneDown, Attempts: 1})
+	require.False(t, tracker.Healing(conn.GetId()))
+	require.Eventually(t, func() bool {
+		prefixes := capture.prefixes()
+		return len(prefixes) == 1 && prefixes[0] == "10.0.0.0/24"
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestTempRoutesClient_TTL(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+
+	tracker := temproutes.NewTracker()
+	capture := new(captureClient)
+	client := chain.NewNetworkServiceClient(
+		begin.NewClient(),
+		metadata.NewClient(),
+		injectclock.NewClient(clockMock),
+		temproutes.NewClient(tracker,
+			temproutes.WithSrcRoutes(
+				&networkservice.Route{Prefix: "10.0.0.0/24", NextHop: "10.0.0.1"},
+				&networkservice.Route{Prefix: "10.1.0.0/24", NextHop: "10.1.0.1"},
+			),
+			temproutes.WithTTL(time.Minute),
+		),
+		capture,
+	)
+
+	tracker.OnHeal(heal.Event{ConnectionID: "conn-id", Reason: heal.ReasonControlPlaneDown})
+
+	// 10.0.0.0/24 is already routed by the primary path, so it is not installed
+	conn, err := client.Request(ctx, newRequest())
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/24", "10.1.0.0/24"}, capture.prefixes())
+	require.Len(t, conn.GetContext().GetIpContext().GetSrcRoutes(), 2)
+
+	clockMock.Add(time.Minute)
+	require.Eventually(t, func() bool {
+		return len(capture.prefixes()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Expired routes are not installed again during the same healing
+	_, err = client.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/24"}, capture.prefixes())
+
+	_, err = client.Close(ctx, conn)
+	require.NoError(t, err)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temproutes
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// DefaultTTL is the default lifetime of the temporary routes
+const DefaultTTL = time.Minute
+
+type options struct {
+	srcRoutes []*networkservice.Route
+	dstRoutes []*networkservice.Route
+	ttl       time.Duration
+}
+
+// Option is an option pattern for NewClient
+type Option func(o *options)
+
+// WithSrcRoutes sets the temporary routes installed to the IPContext.SrcRoutes during healing
+func WithSrcRoutes(routes ...*networkservice.Route) Option {
+	return func(o *options) {
+		o.srcRoutes = append(o.srcRoutes, routes...)
+	}
+}
+
+// WithDstRoutes sets the temporary routes installed to the IPContext.DstRoutes during healing
+func WithDstRoutes(routes ...*networkservice.Route) Option {
+	return func(o *options) {
+		o.dstRoutes = append(o.dstRoutes, routes...)
+	}
+}
+
+// WithTTL sets the lifetime of the temporary routes. The routes are removed after it even if the connection is still
+// being healed. Default: DefaultTTL
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		if ttl > 0 {
+			o.ttl = ttl
+		}
+	}
+}
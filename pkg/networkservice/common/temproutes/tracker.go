@@ -0,0 +1,83 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temproutes
+
+import (
+	"sync"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/heal"
+)
+
+// Tracker tracks the connections being healed. It should be set as the heal.NewClient event handlers:
+//
+//	heal.NewClient(ctx,
+//		heal.WithOnHeal(tracker.OnHeal),
+//		heal.WithOnRestore(tracker.OnRestore),
+//	)
+type Tracker struct {
+	mu       sync.Mutex
+	healing  map[string]struct{}
+	cleanups map[string]func()
+}
+
+// NewTracker creates a new Tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		healing:  make(map[string]struct{}),
+		cleanups: make(map[string]func()),
+	}
+}
+
+// OnHeal marks the connection as being healed, so the temporary routes are installed on the next requests
+func (t *Tracker) OnHeal(event heal.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.healing[event.ConnectionID] = struct{}{}
+}
+
+// OnRestore marks the connection as restored and triggers the temporary routes cleanup
+func (t *Tracker) OnRestore(event heal.Event) {
+	t.mu.Lock()
+	delete(t.healing, event.ConnectionID)
+	cleanup := t.cleanups[event.ConnectionID]
+	t.mu.Unlock()
+
+	if cleanup != nil {
+		cleanup()
+	}
+}
+
+// Healing returns true if the connection is being healed
+func (t *Tracker) Healing(connID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.healing[connID]
+	return ok
+}
+
+func (t *Tracker) setCleanup(connID string, cleanup func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if cleanup == nil {
+		delete(t.cleanups, connID)
+		return
+	}
+	t.cleanups[connID] = cleanup
+}
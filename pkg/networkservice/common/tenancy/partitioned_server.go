@@ -0,0 +1,110 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+// ServerFactory creates the chain element instance owning the resources of the tenant
+type ServerFactory func(tenant string) networkservice.NetworkServiceServer
+
+type partitionedServer struct {
+	factory ServerFactory
+	quota   *tenancy.Quota
+
+	mu          sync.Mutex
+	instances   map[string]networkservice.NetworkServiceServer
+	connections map[string]string
+}
+
+// NewPartitionedServer creates a NetworkServiceServer chain element passing the Requests and Closes to the tenant
+// own instance of the chain element created by factory, so the resources (e.g. IPAM pools) are partitioned per
+// tenant. The tenant is taken from the context, so NewServer should be placed before.
+func NewPartitionedServer(factory ServerFactory, opts ...Option) networkservice.NetworkServiceServer {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &partitionedServer{
+		factory:     factory,
+		quota:       o.quota,
+		instances:   make(map[string]networkservice.NetworkServiceServer),
+		connections: make(map[string]string),
+	}
+}
+
+func (s *partitionedServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	tenant := tenancy.FromContext(ctx)
+	id := request.GetConnection().GetId()
+
+	s.mu.Lock()
+	current, loaded := s.connections[id]
+	if loaded && current != tenant {
+		s.mu.Unlock()
+		return nil, errors.Errorf("connection %s belongs to another tenant", id)
+	}
+	if !loaded {
+		if err := s.quota.Acquire(tenant); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		s.connections[id] = tenant
+	}
+	instance := s.instanceLocked(tenant)
+	s.mu.Unlock()
+
+	conn, err := instance.Request(ctx, request)
+	if err != nil && !loaded {
+		s.mu.Lock()
+		delete(s.connections, id)
+		s.quota.Release(tenant)
+		s.mu.Unlock()
+	}
+	return conn, err
+}
+
+func (s *partitionedServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mu.Lock()
+	tenant, ok := s.connections[conn.GetId()]
+	if ok {
+		delete(s.connections, conn.GetId())
+		s.quota.Release(tenant)
+	} else {
+		tenant = tenancy.FromContext(ctx)
+	}
+	instance := s.instanceLocked(tenant)
+	s.mu.Unlock()
+
+	return instance.Close(ctx, conn)
+}
+
+func (s *partitionedServer) instanceLocked(tenant string) networkservice.NetworkServiceServer {
+	instance, ok := s.instances[tenant]
+	if !ok {
+		instance = s.factory(tenant)
+		s.instances[tenant] = instance
+	}
+	return instance
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenancy provides chain elements deriving the connection tenant and partitioning the resources of the
+// following chain elements per tenant, e.g. each tenant gets its own IPAM pools:
+//
+//	chain.NewNetworkServiceServer(
+//		tenancy.NewServer(resolverOpts...),
+//		tenancy.NewPartitionedServer(func(string) networkservice.NetworkServiceServer {
+//			return point2pointipam.NewServer(prefix)
+//		}, tenancy.WithQuota(quota)),
+//		...
+//	)
+package tenancy
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+type tenancyServer struct {
+	resolver *tenancy.Resolver
+}
+
+// NewServer creates a NetworkServiceServer chain element deriving the tenant from the peer identity or the connection
+// labels and storing it in the context for the following chain elements. The tenant is derived on the first Request
+// and is kept for the connection lifetime, so the connection can't be moved to another tenant by relabeling.
+func NewServer(opts ...tenancy.ResolverOption) networkservice.NetworkServiceServer {
+	return &tenancyServer{
+		resolver: tenancy.NewResolver(opts...),
+	}
+}
+
+func (s *tenancyServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	tenant, loaded := loadOrStore(ctx, metadata.IsClient(s), s.resolver.Resolve(ctx, request.GetConnection().GetLabels()))
+	if !loaded {
+		log.FromContext(ctx).WithField("tenancyServer", "Request").Debugf("tenant: %q", tenant)
+	}
+
+	conn, err := next.Server(ctx).Request(tenancy.WithTenant(ctx, tenant), request)
+	if err != nil && !loaded {
+		del(ctx, metadata.IsClient(s))
+	}
+	return conn, err
+}
+
+func (s *tenancyServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	tenant, ok := loadAndDelete(ctx, metadata.IsClient(s))
+	if !ok {
+		tenant = s.resolver.Resolve(ctx, conn.GetLabels())
+	}
+	return next.Server(ctx).Close(tenancy.WithTenant(ctx, tenant), conn)
+}
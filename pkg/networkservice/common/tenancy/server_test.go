@@ -0,0 +1,109 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/tenancy"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/point2pointipam"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	tenancytools "github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+func newRequest(id, tenant string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     id,
+			Labels: map[string]string{tenancytools.LabelKey: tenant},
+		},
+	}
+}
+
+func TestPartitionedServer_IPAM(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	_, prefix, err := net.ParseCIDR("192.168.0.0/24")
+	require.NoError(t, err)
+
+	quota := tenancytools.NewQuota(tenancytools.WithDefaultLimit(1))
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		tenancy.NewServer(),
+		tenancy.NewPartitionedServer(func(string) networkservice.NetworkServiceServer {
+			return point2pointipam.NewServer(prefix)
+		}, tenancy.WithQuota(quota)),
+	)
+
+	connA, err := server.Request(context.Background(), newRequest("conn-a", "tenant-a"))
+	require.NoError(t, err)
+	connB, err := server.Request(context.Background(), newRequest("conn-b", "tenant-b"))
+	require.NoError(t, err)
+
+	// The tenants have independent address spaces
+	require.Equal(t, connA.GetContext().GetIpContext().GetSrcIpAddrs(), connB.GetContext().GetIpContext().GetSrcIpAddrs())
+
+	// Refresh doesn't take the quota, relabeling doesn't move the connection to another tenant
+	connA.Labels[tenancytools.LabelKey] = "tenant-b"
+	connA, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: connA})
+	require.NoError(t, err)
+	require.Equal(t, 1, quota.Used("tenant-a"))
+	require.Equal(t, 1, quota.Used("tenant-b"))
+
+	_, err = server.Request(context.Background(), newRequest("conn-a-2", "tenant-a"))
+	require.True(t, errors.Is(err, tenancytools.ErrQuotaExceeded))
+
+	_, err = server.Close(context.Background(), connA)
+	require.NoError(t, err)
+	require.Equal(t, 0, quota.Used("tenant-a"))
+
+	_, err = server.Request(context.Background(), newRequest("conn-a-2", "tenant-a"))
+	require.NoError(t, err)
+}
+
+func TestTenancyServer_Context(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var tenant string
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		tenancy.NewServer(tenancytools.WithLabelKey("team")),
+		checkcontext.NewServer(t, func(t *testing.T, ctx context.Context) {
+			tenant = tenancytools.FromContext(ctx)
+		}),
+	)
+
+	request := newRequest("conn", "ignored")
+	request.GetConnection().GetLabels()["team"] = "tenant-a"
+	conn, err := server.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a", tenant)
+
+	tenant = ""
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, "tenant-a", tenant)
+}
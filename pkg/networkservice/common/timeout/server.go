@@ -31,6 +31,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockskew"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
@@ -67,7 +68,7 @@ func (s *timeoutServer) Request(ctx context.Context, request *networkservice.Net
 	store(ctx, metadata.IsClient(s), cancel)
 	eventFactory := begin.FromContext(ctx)
 	timeClock := clock.FromContext(ctx)
-	afterCh := timeClock.After(timeClock.Until(expirationTime))
+	afterCh := timeClock.After(clockskew.Until(ctx, expirationTime))
 	go func(cancelCtx context.Context, afterCh <-chan time.Time) {
 		select {
 		case <-cancelCtx.Done():
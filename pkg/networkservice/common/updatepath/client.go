@@ -25,18 +25,30 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
 )
 
 type updatePathClient struct {
-	name string
+	name       string
+	generateID idgen.Generator
 }
 
 // NewClient - creates a new updatePath client to update connection path.
 //             name - name of the client
+//             opts - WithIDGenerator sets the connection and path segment IDs generation strategy
 //
 // Workflow are documented in common.go
-func NewClient(name string) networkservice.NetworkServiceClient {
-	return &updatePathClient{name: name}
+func NewClient(name string, opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		generateID: idgen.UUID(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &updatePathClient{
+		name:       name,
+		generateID: o.generateID,
+	}
 }
 
 func (i *updatePathClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (conn *networkservice.Connection, err error) {
@@ -45,7 +57,7 @@ func (i *updatePathClient) Request(ctx context.Context, request *networkservice.
 	}
 
 	var index uint32
-	request.Connection, index, err = updatePath(request.Connection, i.name)
+	request.Connection, index, err = updatePath(request.Connection, i.name, i.generateID)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +74,7 @@ func (i *updatePathClient) Request(ctx context.Context, request *networkservice.
 }
 
 func (i *updatePathClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (_ *empty.Empty, err error) {
-	conn, _, err = updatePath(conn, i.name)
+	conn, _, err = updatePath(conn, i.name, i.generateID)
 	if err != nil {
 		return nil, err
 	}
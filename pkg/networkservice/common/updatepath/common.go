@@ -17,10 +17,11 @@
 package updatepath
 
 import (
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
 )
 
 /*
@@ -34,7 +35,7 @@ import (
 		2.2 if no next path segment available, it will add one more path segment and generate new Id, update connection.Id.
 		2.3 if path has next segment available and next name is segmentName, take Id from next path segment.
 */
-func updatePath(conn *networkservice.Connection, segmentName string) (*networkservice.Connection, uint32, error) {
+func updatePath(conn *networkservice.Connection, segmentName string, generateID idgen.Generator) (*networkservice.Connection, uint32, error) {
 	if conn == nil {
 		return nil, 0, errors.New("updatePath cannot be called with a nil conn")
 	}
@@ -47,7 +48,7 @@ func updatePath(conn *networkservice.Connection, segmentName string) (*networkse
 		conn.Path.Index = 0
 		if conn.Id == "" {
 			// Generate new ID for connection and segment.
-			conn.Id = uuid.New().String()
+			conn.Id = generateID(nil, segmentName)
 		}
 		// Add current segment to list
 		conn.Path.PathSegments = append(conn.Path.PathSegments, &networkservice.PathSegment{
@@ -83,7 +84,7 @@ func updatePath(conn *networkservice.Connection, segmentName string) (*networkse
 	if nextIndex < len(path.GetPathSegments()) && path.GetPathSegments()[nextIndex].Name != segmentName {
 		// 2.1 path has next segment available, but next name is not equal to segmentName
 		path.PathSegments[nextIndex].Name = segmentName
-		path.PathSegments[nextIndex].Id = uuid.New().String()
+		path.PathSegments[nextIndex].Id = generateID(path.PathSegments[path.Index], segmentName)
 	}
 
 	// Increment index to be accurate to current chain element
@@ -91,7 +92,7 @@ func updatePath(conn *networkservice.Connection, segmentName string) (*networkse
 
 	if int(conn.Path.Index) >= len(path.GetPathSegments()) {
 		// 2.2 no next path segment available
-		conn.Id = uuid.New().String()
+		conn.Id = generateID(path.PathSegments[conn.Path.Index-1], segmentName)
 		path.PathSegments = append(path.PathSegments, &networkservice.PathSegment{
 			Name: segmentName,
 			Id:   conn.Id,
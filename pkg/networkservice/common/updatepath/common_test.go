@@ -18,8 +18,10 @@ package updatepath_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
@@ -28,6 +30,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkrequest"
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
 )
 
 const (
@@ -265,7 +268,9 @@ func TestUpdatePath(t *testing.T) {
 	for i := range samples {
 		sample := samples[i]
 		t.Run("TestNewServer_"+sample.name, func(t *testing.T) {
-			sample.test(t, updatepath.NewServer)
+			sample.test(t, func(name string) networkservice.NetworkServiceServer {
+				return updatepath.NewServer(name)
+			})
 		})
 	}
 	for i := range samples {
@@ -277,3 +282,25 @@ func TestUpdatePath(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatePath_IDGenerator(t *testing.T) {
+	generateID := idgen.Hash(uuid.NameSpaceURL, idgen.WithPrefix("site-a/", idgen.UUID()))
+
+	server := next.NewNetworkServiceServer(
+		updatepath.NewServer("nsc", updatepath.WithIDGenerator(generateID)),
+		updatepath.NewServer("nsmgr", updatepath.WithIDGenerator(generateID)),
+	)
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{})
+	require.NoError(t, err)
+	require.Len(t, conn.GetPath().GetPathSegments(), 2)
+	require.True(t, strings.HasPrefix(conn.GetId(), "site-a/"))
+
+	// The same path gets the same IDs
+	nsmgrID := conn.GetPath().GetPathSegments()[1].GetId()
+	conn.GetPath().GetPathSegments()[1].Id = ""
+	conn.GetPath().GetPathSegments()[1].Name = ""
+	conn, err = server.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, nsmgrID, conn.GetPath().GetPathSegments()[1].GetId())
+}
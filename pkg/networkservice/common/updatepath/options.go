@@ -0,0 +1,37 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package updatepath
+
+import (
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
+)
+
+type options struct {
+	generateID idgen.Generator
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithIDGenerator sets the generator of the new connection and path segment IDs. Default is idgen.UUID.
+func WithIDGenerator(generateID idgen.Generator) Option {
+	return func(o *options) {
+		if generateID != nil {
+			o.generateID = generateID
+		}
+	}
+}
@@ -27,18 +27,30 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
 )
 
 type updatePathServer struct {
-	name string
+	name       string
+	generateID idgen.Generator
 }
 
 // NewServer - creates a new updatePath client to update connection path.
 //             name - name of the client
+//             opts - WithIDGenerator sets the connection and path segment IDs generation strategy
 //
 // Workflow are documented in common.go
-func NewServer(name string) networkservice.NetworkServiceServer {
-	return &updatePathServer{name: name}
+func NewServer(name string, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		generateID: idgen.UUID(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &updatePathServer{
+		name:       name,
+		generateID: o.generateID,
+	}
 }
 
 func (i *updatePathServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (conn *networkservice.Connection, err error) {
@@ -47,7 +59,7 @@ func (i *updatePathServer) Request(ctx context.Context, request *networkservice.
 	}
 
 	var index uint32
-	request.Connection, index, err = updatePath(request.Connection, i.name)
+	request.Connection, index, err = updatePath(request.Connection, i.name, i.generateID)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +76,7 @@ func (i *updatePathServer) Request(ctx context.Context, request *networkservice.
 }
 
 func (i *updatePathServer) Close(ctx context.Context, conn *networkservice.Connection) (_ *empty.Empty, err error) {
-	conn, _, err = updatePath(conn, i.name)
+	conn, _, err = updatePath(conn, i.name, i.generateID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,83 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchdog provides a chain element reporting Requests which are in flight for too long, attributing the stall
+// to the deepest pending chain element.
+package watchdog
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/inflight"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	defaultThreshold = 15 * time.Second
+	maxStackDumpSize = 1 << 20
+)
+
+type watchdogServer struct {
+	logger    log.Logger
+	threshold time.Duration
+	stackDump bool
+}
+
+// NewServer creates a new NetworkServiceServer chain element logging Requests which are in flight longer than the
+// threshold (15s by default) together with the deepest pending chain element and optionally goroutine stacks.
+// Reports are written to the logger from ctx, so they are not lost when tracing is disabled.
+func NewServer(ctx context.Context, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		threshold: defaultThreshold,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &watchdogServer{
+		logger:    log.FromContext(ctx).WithField("watchdogServer", "Request"),
+		threshold: o.threshold,
+		stackDump: o.stackDump,
+	}
+}
+
+func (s *watchdogServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	ctx, stack := inflight.WithStack(ctx)
+	connID := request.GetConnection().GetId()
+	timer := clock.FromContext(ctx).AfterFunc(s.threshold, func() {
+		s.logger.Warnf("request %s is in flight for more than %s, stalled in: %s [%s]",
+			connID, s.threshold, stack.Deepest(), strings.Join(stack.Elements(), " -> "))
+		if s.stackDump {
+			buf := make([]byte, maxStackDumpSize)
+			s.logger.Warnf("goroutine stacks:\n%s", buf[:runtime.Stack(buf, true)])
+		}
+	})
+	defer timer.Stop()
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *watchdogServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchdog_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/watchdog"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+type stuckServer struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *stuckServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	close(s.entered)
+	<-s.release
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *stuckServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func TestWatchdogServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	buf := new(syncBuffer)
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	stuck := &stuckServer{
+		entered: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	server := chain.NewNetworkServiceServer(
+		watchdog.NewServer(ctx, watchdog.WithThreshold(time.Second), watchdog.WithStackDump(true)),
+		stuck,
+	)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := server.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "conn-id"},
+		})
+		errCh <- err
+	}()
+
+	<-stuck.entered
+	clockMock.Add(time.Second)
+
+	require.Eventually(t, func() bool {
+		return bytes.Contains([]byte(buf.String()), []byte("goroutine stacks"))
+	}, time.Second, 10*time.Millisecond)
+	require.Contains(t, buf.String(), "request conn-id is in flight for more than 1s, stalled in:")
+	require.Contains(t, buf.String(), "stuckServer.Request")
+
+	close(stuck.release)
+	require.NoError(t, <-errCh)
+}
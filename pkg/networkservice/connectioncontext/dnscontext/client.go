@@ -27,6 +27,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"google.golang.org/grpc"
 
+	"github.com/networkservicemesh/sdk/pkg/networkservice/connectioncontext/dnscontext/dnsconfiglifetime"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
@@ -42,12 +44,14 @@ type dnsContextClient struct {
 	dnsConfigsMap          *dnsconfig.Map
 }
 
-// NewClient creates a new DNS client chain component. Setups all DNS traffic to the localhost. Monitors DNS configs from connections.
+// NewClient creates a new DNS client chain component. Setups all DNS traffic to the localhost. Monitors DNS configs from connections
+// and stores them into the DNS configs map for the connection lifetime.
 func NewClient(options ...DNSOption) networkservice.NetworkServiceClient {
 	var c = &dnsContextClient{
 		chainContext:        context.Background(),
 		defaultNameServerIP: "127.0.0.1",
 		resolveConfigPath:   "/etc/resolv.conf",
+		dnsConfigsMap:       new(dnsconfig.Map),
 	}
 	for _, o := range options {
 		o.apply(c)
@@ -56,7 +60,10 @@ func NewClient(options ...DNSOption) networkservice.NetworkServiceClient {
 	c.storedResolvConfigPath = c.resolveConfigPath + ".restore"
 	c.initialize()
 
-	return c
+	return chain.NewNetworkServiceClient(
+		c,
+		dnsconfiglifetime.NewClient(c.dnsConfigsMap),
+	)
 }
 
 func (c *dnsContextClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
@@ -76,23 +83,10 @@ func (c *dnsContextClient) Request(ctx context.Context, request *networkservice.
 		request.GetConnection().GetContext().GetDnsContext().Configs = append(request.GetConnection().GetContext().GetDnsContext().Configs, c.resolvconfDNSConfig)
 	}
 
-	rv, err := next.Client(ctx).Request(ctx, request, opts...)
-	if err != nil {
-		return nil, err
-	}
-
-	var configs []*networkservice.DNSConfig
-	if rv.GetContext().GetDnsContext() != nil {
-		configs = rv.GetContext().GetDnsContext().GetConfigs()
-	}
-
-	c.dnsConfigsMap.Store(rv.Id, configs)
-
-	return rv, err
+	return next.Client(ctx).Request(ctx, request, opts...)
 }
 
 func (c *dnsContextClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	c.dnsConfigsMap.Delete(conn.Id)
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
 
@@ -0,0 +1,56 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsconfiglifetime
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+)
+
+type dnsConfigLifetimeClient struct {
+	*lifetime
+}
+
+// NewClient creates a new NetworkServiceClient chain element storing DNS configs received from the server into
+// configs until the connections are closed or expired
+func NewClient(configs *dnsconfig.Map) networkservice.NetworkServiceClient {
+	return &dnsConfigLifetimeClient{
+		lifetime: newLifetime(configs),
+	}
+}
+
+func (c *dnsConfigLifetimeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.update(ctx, conn.GetId(), conn.GetContext().GetDnsContext().GetConfigs(), expirationTime(conn.GetCurrentPathSegment()))
+
+	return conn, nil
+}
+
+func (c *dnsConfigLifetimeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.release(conn.GetId())
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnsconfiglifetime provides chain elements tying DNS configs stored in dnsconfig.Map to the connection
+// lifecycle: configs are added on successful Request, updated on refresh and removed on Close or connection
+// expiration. Identical configs supplied by multiple connections are stored once and removed with the last of them.
+package dnsconfiglifetime
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+)
+
+type connEntry struct {
+	keys  []string
+	timer clock.Timer
+}
+
+type lifetime struct {
+	configs *dnsconfig.Map
+
+	mu    sync.Mutex
+	refs  map[string]int
+	conns map[string]*connEntry
+}
+
+func newLifetime(configs *dnsconfig.Map) *lifetime {
+	if configs == nil {
+		panic("configs cannot be nil")
+	}
+	return &lifetime{
+		configs: configs,
+		refs:    make(map[string]int),
+		conns:   make(map[string]*connEntry),
+	}
+}
+
+// update replaces configs stored for the connection and (re)arms the connection expiration timer
+func (l *lifetime) update(ctx context.Context, connID string, configs []*networkservice.DNSConfig, expires *time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var keys []string
+	for _, config := range configs {
+		if config == nil {
+			continue
+		}
+		key := configKey(config)
+		if l.refs[key]++; l.refs[key] == 1 {
+			l.configs.Store(key, []*networkservice.DNSConfig{config})
+		}
+		keys = append(keys, key)
+	}
+
+	l.releaseLocked(connID)
+
+	entry := &connEntry{keys: keys}
+	if expires != nil {
+		timeClock := clock.FromContext(ctx)
+		entry.timer = timeClock.AfterFunc(timeClock.Until(*expires), func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			if l.conns[connID] == entry {
+				l.releaseLocked(connID)
+			}
+		})
+	}
+	l.conns[connID] = entry
+}
+
+// release removes configs stored for the connection
+func (l *lifetime) release(connID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.releaseLocked(connID)
+}
+
+func (l *lifetime) releaseLocked(connID string) {
+	entry, ok := l.conns[connID]
+	if !ok {
+		return
+	}
+	delete(l.conns, connID)
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	for _, key := range entry.keys {
+		if l.refs[key]--; l.refs[key] == 0 {
+			delete(l.refs, key)
+			l.configs.Delete(key)
+		}
+	}
+}
+
+// configKey returns the same key for the configs with the same DNS servers and search domains
+func configKey(config *networkservice.DNSConfig) string {
+	servers := append([]string(nil), config.GetDnsServerIps()...)
+	sort.Strings(servers)
+	return strings.Join(servers, ",") + "|" + strings.Join(config.GetSearchDomains(), ",")
+}
+
+func expirationTime(segment *networkservice.PathSegment) *time.Time {
+	if segment.GetExpires() == nil {
+		return nil
+	}
+	expires := segment.GetExpires().AsTime()
+	return &expires
+}
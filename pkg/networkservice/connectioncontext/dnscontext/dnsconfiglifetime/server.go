@@ -0,0 +1,55 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsconfiglifetime
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+)
+
+type dnsConfigLifetimeServer struct {
+	*lifetime
+}
+
+// NewServer creates a new NetworkServiceServer chain element storing DNS configs of the client connections into
+// configs until the connections are closed or expired
+func NewServer(configs *dnsconfig.Map) networkservice.NetworkServiceServer {
+	return &dnsConfigLifetimeServer{
+		lifetime: newLifetime(configs),
+	}
+}
+
+func (s *dnsConfigLifetimeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.update(ctx, conn.GetId(), conn.GetContext().GetDnsContext().GetConfigs(), expirationTime(conn.GetPrevPathSegment()))
+
+	return conn, nil
+}
+
+func (s *dnsConfigLifetimeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.release(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
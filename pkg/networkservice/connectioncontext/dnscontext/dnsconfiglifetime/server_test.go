@@ -0,0 +1,135 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsconfiglifetime_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/connectioncontext/dnscontext/dnsconfiglifetime"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+)
+
+const expireTimeout = time.Minute
+
+func newRequest(id string, expires time.Time, configs ...*networkservice.DNSConfig) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id: id,
+			Context: &networkservice.ConnectionContext{
+				DnsContext: &networkservice.DNSContext{Configs: configs},
+			},
+			Path: &networkservice.Path{
+				Index: 1,
+				PathSegments: []*networkservice.PathSegment{
+					{Name: "nsc", Expires: timestamppb.New(expires)},
+					{Name: "nse"},
+				},
+			},
+		},
+	}
+}
+
+func servers(configs *dnsconfig.Map) []string {
+	var rv []string
+	configs.Range(func(_ string, value []*networkservice.DNSConfig) bool {
+		for _, config := range value {
+			rv = append(rv, config.GetDnsServerIps()...)
+		}
+		return true
+	})
+	return rv
+}
+
+func TestDNSConfigLifetimeServer_RefCount(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+	expires := clockMock.Now().Add(expireTimeout)
+
+	configs := new(dnsconfig.Map)
+	server := dnsconfiglifetime.NewServer(configs)
+
+	shared := &networkservice.DNSConfig{DnsServerIps: []string{"1.1.1.1", "2.2.2.2"}, SearchDomains: []string{"nsm"}}
+
+	conn1, err := server.Request(ctx, newRequest("1", expires, shared))
+	require.NoError(t, err)
+	conn2, err := server.Request(ctx, newRequest("2", expires, &networkservice.DNSConfig{
+		DnsServerIps:  []string{"2.2.2.2", "1.1.1.1"},
+		SearchDomains: []string{"nsm"},
+	}))
+	require.NoError(t, err)
+
+	// Identical configs are stored once
+	require.ElementsMatch(t, []string{"1.1.1.1", "2.2.2.2"}, servers(configs))
+
+	// Refresh replaces the connection configs
+	_, err = server.Request(ctx, newRequest("1", expires, &networkservice.DNSConfig{DnsServerIps: []string{"3.3.3.3"}}))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}, servers(configs))
+
+	_, err = server.Close(ctx, conn2)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"3.3.3.3"}, servers(configs))
+
+	_, err = server.Close(ctx, conn1)
+	require.NoError(t, err)
+	require.Empty(t, servers(configs))
+}
+
+func TestDNSConfigLifetimeServer_Expire(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	configs := new(dnsconfig.Map)
+	server := dnsconfiglifetime.NewServer(configs)
+
+	config := &networkservice.DNSConfig{DnsServerIps: []string{"1.1.1.1"}}
+
+	_, err := server.Request(ctx, newRequest("1", clockMock.Now().Add(expireTimeout), config))
+	require.NoError(t, err)
+
+	// Refresh moves the expiration
+	clockMock.Add(expireTimeout / 2)
+	_, err = server.Request(ctx, newRequest("1", clockMock.Now().Add(expireTimeout), config))
+	require.NoError(t, err)
+
+	clockMock.Add(expireTimeout / 2)
+	require.Equal(t, []string{"1.1.1.1"}, servers(configs))
+
+	clockMock.Add(expireTimeout / 2)
+	require.Eventually(t, func() bool {
+		return len(servers(configs)) == 0
+	}, time.Second, 10*time.Millisecond)
+}
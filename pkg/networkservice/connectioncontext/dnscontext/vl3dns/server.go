@@ -21,10 +21,13 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/miekg/dns"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
@@ -40,8 +43,19 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
 )
 
+const (
+	// SRVLabelPrefix is the prefix of the client labels publishing the SRV records: "dns-srv/_http._tcp": "8080"
+	// publishes "_http._tcp.<client name>" record with the "<client name>" target and 8080 port
+	SRVLabelPrefix = "dns-srv/"
+	// TXTLabelPrefix is the prefix of the client labels publishing the TXT records: "dns-txt/version": "1.2"
+	// publishes "version=1.2" string in "<client name>" TXT record
+	TXTLabelPrefix = "dns-txt/"
+)
+
 type vl3DNSServer struct {
 	dnsServerRecords      memory.Map
+	dnsServerSRVRecords   memory.SRVMap
+	dnsServerTXTRecords   memory.TXTMap
 	dnsConfigs            *dnsconfig.Map
 	domainSchemeTemplates []*template.Template
 	dnsPort               int
@@ -52,6 +66,8 @@ type vl3DNSServer struct {
 
 type clientDNSNameKey struct{}
 
+type clientSRVNamesKey struct{}
+
 // NewServer creates a new vl3dns netwrokservice server.
 // It starts dns server on the passed port/url. By default listens ":53".
 // By default is using fanout dns handler to connect to other vl3 nses.
@@ -59,10 +75,12 @@ type clientDNSNameKey struct{}
 // opts confugre vl3dns networkservice instance with specific behavior.
 func NewServer(chanCtx context.Context, getDNSServerIP func() net.IP, opts ...Option) networkservice.NetworkServiceServer {
 	var result = &vl3DNSServer{
-		dnsPort:           53,
-		listenAndServeDNS: dnsutils.ListenAndServe,
-		getDNSServerIP:    getDNSServerIP,
-		dnsConfigs:        new(dnsconfig.Map),
+		dnsPort: 53,
+		listenAndServeDNS: func(ctx context.Context, handler dnsutils.Handler, listenOn string) {
+			dnsutils.ListenAndServe(ctx, handler, listenOn)
+		},
+		getDNSServerIP: getDNSServerIP,
+		dnsConfigs:     new(dnsconfig.Map),
 	}
 
 	for _, opt := range opts {
@@ -74,7 +92,10 @@ func NewServer(chanCtx context.Context, getDNSServerIP func() net.IP, opts ...Op
 			dnsconfigs.NewDNSHandler(result.dnsConfigs),
 			noloop.NewDNSHandler(),
 			norecursion.NewDNSHandler(),
-			memory.NewDNSHandler(&result.dnsServerRecords),
+			memory.NewDNSHandler(&result.dnsServerRecords,
+				memory.WithSRVRecords(&result.dnsServerSRVRecords),
+				memory.WithTXTRecords(&result.dnsServerTXTRecords),
+			),
 			fanout.NewDNSHandler(fanout.WithDefaultDNSPort(uint16(result.dnsPort))),
 		)
 	}
@@ -103,6 +124,7 @@ func (n *vl3DNSServer) Request(ctx context.Context, request *networkservice.Netw
 		if !compareStringSlices(previousNames, recordNames) {
 			for _, prevName := range previousNames {
 				n.dnsServerRecords.Delete(prevName)
+				n.dnsServerTXTRecords.Delete(prevName)
 			}
 		}
 	}
@@ -115,6 +137,7 @@ func (n *vl3DNSServer) Request(ctx context.Context, request *networkservice.Netw
 
 		metadata.Map(ctx, false).Store(clientDNSNameKey{}, recordNames)
 	}
+	n.storeSRVAndTXTRecords(ctx, request.GetConnection(), recordNames, len(ips) > 0)
 
 	resp, err := next.Server(ctx).Request(ctx, request)
 
@@ -145,6 +168,12 @@ func (n *vl3DNSServer) Close(ctx context.Context, conn *networkservice.Connectio
 		var names = v.([]string)
 		for _, name := range names {
 			n.dnsServerRecords.Delete(name)
+			n.dnsServerTXTRecords.Delete(name)
+		}
+	}
+	if v, ok := metadata.Map(ctx, false).LoadAndDelete(clientSRVNamesKey{}); ok {
+		for _, name := range v.([]string) {
+			n.dnsServerSRVRecords.Delete(name)
 		}
 	}
 
@@ -165,6 +194,51 @@ func (n *vl3DNSServer) addDNSContext(c *networkservice.Connection) (added string
 	return "", false
 }
 
+// storeSRVAndTXTRecords publishes the SRV and TXT records set by the client labels for the client names and removes
+// the previously published ones which are not set anymore
+func (n *vl3DNSServer) storeSRVAndTXTRecords(ctx context.Context, c *networkservice.Connection, recordNames []string, hasIPs bool) {
+	var srvNames, txt []string
+	if hasIPs {
+		for key, value := range c.GetLabels() {
+			switch {
+			case strings.HasPrefix(key, SRVLabelPrefix):
+				port, err := strconv.ParseUint(value, 10, 16)
+				if err != nil {
+					continue
+				}
+				service := strings.TrimPrefix(key, SRVLabelPrefix)
+				for _, recordName := range recordNames {
+					srvName := dns.Fqdn(service + "." + recordName)
+					n.dnsServerSRVRecords.Store(srvName, []*dns.SRV{{Port: uint16(port), Target: recordName}})
+					srvNames = append(srvNames, srvName)
+				}
+			case strings.HasPrefix(key, TXTLabelPrefix):
+				txt = append(txt, strings.TrimPrefix(key, TXTLabelPrefix)+"="+value)
+			}
+		}
+	}
+	sort.Strings(txt)
+
+	for _, recordName := range recordNames {
+		if len(txt) > 0 {
+			n.dnsServerTXTRecords.Store(recordName, txt)
+		} else {
+			n.dnsServerTXTRecords.Delete(recordName)
+		}
+	}
+
+	if v, ok := metadata.Map(ctx, false).LoadAndDelete(clientSRVNamesKey{}); ok {
+		for _, prevName := range v.([]string) {
+			if !containsString(srvNames, prevName) {
+				n.dnsServerSRVRecords.Delete(prevName)
+			}
+		}
+	}
+	if len(srvNames) > 0 {
+		metadata.Map(ctx, false).Store(clientSRVNamesKey{}, srvNames)
+	}
+}
+
 func (n *vl3DNSServer) buildSrcDNSRecords(c *networkservice.Connection) ([]string, error) {
 	var result []string
 	for _, templ := range n.domainSchemeTemplates {
@@ -189,6 +263,15 @@ func compareStringSlices(a, b []string) bool {
 	return true
 }
 
+func containsString(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 func withinPrefix(ipAddr, prefix string) bool {
 	_, ipNet, err := net.ParseCIDR(prefix)
 	if err != nil {
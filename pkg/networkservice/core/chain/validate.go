@@ -0,0 +1,131 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+type chainElement struct{}
+
+// networkservicePkg is the path of the sdk networkservice packages
+var networkservicePkg = path.Dir(path.Dir(reflect.TypeOf(chainElement{}).PkgPath()))
+
+var (
+	updatePathPkg  = path.Join(networkservicePkg, "common", "updatepath")
+	beginPkg       = path.Join(networkservicePkg, "common", "begin")
+	updateTokenPkg = path.Join(networkservicePkg, "common", "updatetoken")
+	authorizePkg   = path.Join(networkservicePkg, "common", "authorize")
+	timeoutPkg     = path.Join(networkservicePkg, "common", "timeout")
+)
+
+// singletonPkgs are the packages of the elements storing per connection state, so they shouldn't be used more than once
+// in the same chain
+var singletonPkgs = []string{
+	updatePathPkg,
+	beginPkg,
+	updateTokenPkg,
+	authorizePkg,
+	timeoutPkg,
+	path.Join(networkservicePkg, "common", "monitor"),
+	path.Join(networkservicePkg, "common", "trimpath"),
+	path.Join(networkservicePkg, "utils", "metadata"),
+}
+
+// Validate checks the server chain for the common misconfigurations. It should be used on the application startup for
+// the complete chains, so the misconfigured application fails fast instead of misbehaving at runtime.
+func Validate(server networkservice.NetworkServiceServer) error {
+	elements := flatten(server)
+
+	var problems []string
+	if indexOf(elements, updatePathPkg) < 0 {
+		problems = append(problems, "updatepath is missing, so the path and the connection IDs are not maintained")
+	}
+	if timeoutIndex := indexOf(elements, timeoutPkg); timeoutIndex >= 0 {
+		if beginIndex := indexOf(elements, beginPkg); beginIndex < 0 || beginIndex > timeoutIndex {
+			problems = append(problems, "timeout is used without begin before it, so the expiration Close is not serialized with the refresh Requests")
+		}
+	}
+	if authorizeIndex, updateTokenIndex := indexOf(elements, authorizePkg), indexOf(elements, updateTokenPkg); authorizeIndex >= 0 && authorizeIndex < updateTokenIndex {
+		problems = append(problems, "authorize is used before updatetoken, so the policies check the path with the outdated tokens")
+	}
+	for _, pkg := range singletonPkgs {
+		if count := countOf(elements, pkg); count > 1 {
+			problems = append(problems, path.Base(pkg)+" is used more than once, so the connection state is handled twice")
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("invalid chain: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// flatten returns the chain elements, the nested chains are replaced with their elements
+func flatten(server networkservice.NetworkServiceServer) []networkservice.NetworkServiceServer {
+	switch s := server.(type) {
+	case interface {
+		Elements() []networkservice.NetworkServiceServer
+	}:
+		var rv []networkservice.NetworkServiceServer
+		for _, element := range s.Elements() {
+			rv = append(rv, flatten(element)...)
+		}
+		return rv
+	case interface {
+		Unwrap() networkservice.NetworkServiceServer
+	}:
+		return flatten(s.Unwrap())
+	default:
+		return []networkservice.NetworkServiceServer{server}
+	}
+}
+
+func pkgOf(server networkservice.NetworkServiceServer) string {
+	t := reflect.TypeOf(server)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.PkgPath()
+}
+
+func indexOf(elements []networkservice.NetworkServiceServer, pkg string) int {
+	for i, element := range elements {
+		if pkgOf(element) == pkg {
+			return i
+		}
+	}
+	return -1
+}
+
+func countOf(elements []networkservice.NetworkServiceServer, pkg string) (count int) {
+	for _, element := range elements {
+		if pkgOf(element) == pkg {
+			count++
+		}
+	}
+	return count
+}
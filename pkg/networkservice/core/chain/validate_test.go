@@ -0,0 +1,77 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/timeout"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatepath"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/updatetoken"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func TestValidate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, chain.Validate(chain.NewNetworkServiceServer(
+		updatepath.NewServer("nsmgr"),
+		begin.NewServer(),
+		updatetoken.NewServer(sandbox.GenerateTestToken),
+		authorize.NewServer(),
+		chain.NewNetworkServiceServer(
+			metadata.NewServer(),
+			timeout.NewServer(ctx),
+		),
+	)))
+
+	for name, server := range map[string]networkservice.NetworkServiceServer{
+		"updatepath is missing": chain.NewNetworkServiceServer(
+			begin.NewServer(),
+		),
+		"timeout is used without begin": chain.NewNetworkServiceServer(
+			updatepath.NewServer("nsmgr"),
+			timeout.NewServer(ctx),
+			begin.NewServer(),
+		),
+		"authorize is used before updatetoken": chain.NewNetworkServiceServer(
+			updatepath.NewServer("nsmgr"),
+			authorize.NewServer(),
+			updatetoken.NewServer(sandbox.GenerateTestToken),
+		),
+		"begin is used more than once": chain.NewNetworkServiceServer(
+			updatepath.NewServer("nsmgr"),
+			begin.NewServer(),
+			chain.NewNetworkServiceServer(
+				begin.NewServer(),
+			),
+		),
+	} {
+		err := chain.Validate(server)
+		require.Error(t, err, name)
+		require.Contains(t, err.Error(), name)
+	}
+}
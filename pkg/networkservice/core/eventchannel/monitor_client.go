@@ -35,6 +35,10 @@ type monitorConnectionClient struct {
 	once           sync.Once
 	eventCh        <-chan *networkservice.ConnectionEvent
 	fanoutEventChs []chan *networkservice.ConnectionEvent
+	selectors      []*networkservice.MonitorScopeSelector
+	filter         bool
+	bufferSize     int
+	overflowPolicy OverflowPolicy
 	updateExecutor serialize.Executor
 }
 
@@ -42,33 +46,31 @@ type monitorConnectionClient struct {
 //                              eventCh - channel that provides events to feed the Recv function
 //                                        when an event is sent on the eventCh, all networkservice.MonitorConnection_MonitorConnectionsClient
 //                                        returned from calling MonitorConnections receive the event.
-//                              Note: Does not perform filtering basedon MonitorScopeSelector
-func NewMonitorConnectionClient(eventCh <-chan *networkservice.ConnectionEvent) networkservice.MonitorConnectionClient {
-	return &monitorConnectionClient{
-		eventCh: eventCh,
+//                              Note: Does not perform filtering based on MonitorScopeSelector unless
+//                                    WithSelectorFiltering option is passed
+//                              Note: Blocks on a full subscriber buffer unless another policy is set with
+//                                    WithOverflowPolicy option
+func NewMonitorConnectionClient(eventCh <-chan *networkservice.ConnectionEvent, options ...MonitorConnectionClientOption) networkservice.MonitorConnectionClient {
+	rv := &monitorConnectionClient{
+		eventCh:    eventCh,
+		bufferSize: defaultBufferSize,
 	}
+	for _, o := range options {
+		o.apply(rv)
+	}
+	return rv
 }
 
-func (m *monitorConnectionClient) MonitorConnections(ctx context.Context, _ *networkservice.MonitorScopeSelector, _ ...grpc.CallOption) (networkservice.MonitorConnection_MonitorConnectionsClient, error) {
-	fanoutEventCh := make(chan *networkservice.ConnectionEvent, 100)
+func (m *monitorConnectionClient) MonitorConnections(ctx context.Context, selector *networkservice.MonitorScopeSelector, _ ...grpc.CallOption) (networkservice.MonitorConnection_MonitorConnectionsClient, error) {
+	fanoutEventCh := make(chan *networkservice.ConnectionEvent, m.bufferSize)
 	m.updateExecutor.AsyncExec(func() {
 		m.once.Do(m.eventLoop)
 		m.fanoutEventChs = append(m.fanoutEventChs, fanoutEventCh)
+		m.selectors = append(m.selectors, selector)
 		go func() {
 			<-ctx.Done()
 			m.updateExecutor.AsyncExec(func() {
-				if len(m.fanoutEventChs) == 0 {
-					return
-				}
-				var newFanoutEventChs []chan *networkservice.ConnectionEvent
-				for _, ch := range m.fanoutEventChs {
-					if ch == fanoutEventCh {
-						close(fanoutEventCh)
-						continue
-					}
-					newFanoutEventChs = append(newFanoutEventChs, ch)
-				}
-				m.fanoutEventChs = newFanoutEventChs
+				m.remove(fanoutEventCh)
 			})
 		}()
 	})
@@ -80,8 +82,18 @@ func (m *monitorConnectionClient) eventLoop() {
 		for event := range m.eventCh {
 			e := event
 			m.updateExecutor.AsyncExec(func() {
-				for _, fanoutEventCh := range m.fanoutEventChs {
-					fanoutEventCh <- e
+				var disconnected []chan *networkservice.ConnectionEvent
+				for i, fanoutEventCh := range m.fanoutEventChs {
+					filteredEvent, ok := e, true
+					if m.filter {
+						filteredEvent, ok = filterEvent(e, m.selectors[i])
+					}
+					if ok && !m.overflowPolicy.send(fanoutEventCh, filteredEvent) {
+						disconnected = append(disconnected, fanoutEventCh)
+					}
+				}
+				for _, fanoutEventCh := range disconnected {
+					m.remove(fanoutEventCh)
 				}
 			})
 		}
@@ -90,6 +102,23 @@ func (m *monitorConnectionClient) eventLoop() {
 				close(fanoutEventCh)
 			}
 			m.fanoutEventChs = []chan *networkservice.ConnectionEvent{}
+			m.selectors = nil
 		})
 	}()
 }
+
+// remove closes the subscriber channel and removes it from the fanout, does nothing if it is already removed
+func (m *monitorConnectionClient) remove(fanoutEventCh chan *networkservice.ConnectionEvent) {
+	var newFanoutEventChs []chan *networkservice.ConnectionEvent
+	var newSelectors []*networkservice.MonitorScopeSelector
+	for i, ch := range m.fanoutEventChs {
+		if ch == fanoutEventCh {
+			close(fanoutEventCh)
+			continue
+		}
+		newFanoutEventChs = append(newFanoutEventChs, ch)
+		newSelectors = append(newSelectors, m.selectors[i])
+	}
+	m.fanoutEventChs = newFanoutEventChs
+	m.selectors = newSelectors
+}
@@ -68,3 +68,116 @@ func TestNewMonitorConnectionClient_MonitorConnections(t *testing.T) {
 	assert.NotNil(t, err)
 	close(eventCh)
 }
+
+func TestNewMonitorConnectionClient_SelectorFiltering(t *testing.T) {
+	eventCh := make(chan *networkservice.ConnectionEvent, 10)
+	client := eventchannel.NewMonitorConnectionClient(eventCh, eventchannel.WithSelectorFiltering())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all, err := client.MonitorConnections(ctx, nil)
+	assert.Nil(t, err)
+	filtered, err := client.MonitorConnections(ctx, &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsmgr-1"}},
+	})
+	assert.Nil(t, err)
+
+	newConn := func(id string, names ...string) *networkservice.Connection {
+		conn := &networkservice.Connection{Id: id, Path: &networkservice.Path{}}
+		for _, name := range names {
+			conn.Path.PathSegments = append(conn.Path.PathSegments, &networkservice.PathSegment{Name: name})
+		}
+		return conn
+	}
+	eventCh <- &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: map[string]*networkservice.Connection{"2": newConn("2", "nsc", "nsmgr-2")},
+	}
+	eventCh <- &networkservice.ConnectionEvent{
+		Type: networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{
+			"1": newConn("1", "nsc", "nsmgr-1"),
+			"2": newConn("2", "nsc", "nsmgr-2"),
+		},
+	}
+	eventCh <- &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_DELETE,
+		Connections: map[string]*networkservice.Connection{"2": newConn("2", "nsc", "nsmgr-2")},
+	}
+
+	for _, expected := range []int{1, 2, 1} {
+		event, recvErr := all.Recv()
+		assert.Nil(t, recvErr)
+		assert.Len(t, event.GetConnections(), expected)
+	}
+
+	// INITIAL_STATE_TRANSFER is sent even if empty, the not matching DELETE is dropped
+	event, err := filtered.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	assert.Empty(t, event.GetConnections())
+
+	event, err = filtered.Recv()
+	assert.Nil(t, err)
+	assert.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	assert.Len(t, event.GetConnections(), 1)
+	assert.NotNil(t, event.GetConnections()["1"])
+
+	close(eventCh)
+	_, err = filtered.Recv()
+	assert.NotNil(t, err)
+}
+
+func TestNewMonitorConnectionClient_OverflowPolicy(t *testing.T) {
+	for name, sample := range map[string]struct {
+		policy   eventchannel.OverflowPolicy
+		expected []string
+		closed   bool
+	}{
+		"DropOldest": {policy: eventchannel.OverflowDropOldest, expected: []string{"3", "4"}},
+		"DropNewest": {policy: eventchannel.OverflowDropNewest, expected: []string{"0", "1"}},
+		"Disconnect": {policy: eventchannel.OverflowDisconnect, expected: []string{"0", "1"}, closed: true},
+	} {
+		sample := sample
+		t.Run(name, func(t *testing.T) {
+			eventCh := make(chan *networkservice.ConnectionEvent)
+			defer close(eventCh)
+
+			client := eventchannel.NewMonitorConnectionClient(eventCh,
+				eventchannel.WithBufferSize(2),
+				eventchannel.WithOverflowPolicy(sample.policy),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The stuck receiver is subscribed first, so it is served before the fast one on each event
+			stuck, err := client.MonitorConnections(ctx, nil)
+			assert.Nil(t, err)
+			fast, err := client.MonitorConnections(ctx, nil)
+			assert.Nil(t, err)
+
+			for i := 0; i < 5; i++ {
+				id := fmt.Sprintf("%d", i)
+				eventCh <- &networkservice.ConnectionEvent{
+					Type:        networkservice.ConnectionEventType_UPDATE,
+					Connections: map[string]*networkservice.Connection{id: {Id: id}},
+				}
+				event, recvErr := fast.Recv()
+				assert.Nil(t, recvErr)
+				assert.NotNil(t, event.GetConnections()[id])
+			}
+
+			for _, id := range sample.expected {
+				event, recvErr := stuck.Recv()
+				assert.Nil(t, recvErr)
+				assert.NotNil(t, event.GetConnections()[id])
+			}
+			if sample.closed {
+				_, err = stuck.Recv()
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
@@ -33,6 +33,8 @@ type monitorConnectionServer struct {
 	selectors []*networkservice.MonitorScopeSelector
 	executor  serialize.Executor
 	connectCh chan<- int
+	// connections is the last known state of the connections, it is nil if the initial state transfer is disabled
+	connections map[string]*networkservice.Connection
 }
 
 // NewMonitorServer - returns a networkservice.MonitorConnectionServer
@@ -58,6 +60,13 @@ func (m *monitorConnectionServer) MonitorConnections(selector *networkservice.Mo
 		m.executor.AsyncExec(func() {
 			m.servers = append(m.servers, srv)
 			m.selectors = append(m.selectors, selector)
+			if m.connections != nil {
+				// TODO - figure out what if any error handling to do here
+				_ = srv.Send(&networkservice.ConnectionEvent{
+					Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+					Connections: networkservice.FilterMapOnManagerScopeSelector(m.connections, selector),
+				})
+			}
 			if m.connectCh != nil {
 				m.connectCh <- len(m.servers)
 			}
@@ -87,14 +96,16 @@ func (m *monitorConnectionServer) eventLoop() {
 		for event := range m.eventCh {
 			e := event
 			m.executor.AsyncExec(func() {
-				for i, srv := range m.servers {
-					filteredEvent := &networkservice.ConnectionEvent{
-						Type:        e.Type,
-						Connections: networkservice.FilterMapOnManagerScopeSelector(e.GetConnections(), m.selectors[i]),
-					}
-					if filteredEvent.Type == networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER || len(filteredEvent.GetConnections()) > 0 {
-						// TODO - figure out what if any error handling to do here
-						_ = srv.Send(filteredEvent)
+				events := []*networkservice.ConnectionEvent{e}
+				if m.connections != nil {
+					events = m.store(e)
+				}
+				for _, event := range events {
+					for i, srv := range m.servers {
+						if filteredEvent, ok := filterEvent(event, m.selectors[i]); ok {
+							// TODO - figure out what if any error handling to do here
+							_ = srv.Send(filteredEvent)
+						}
 					}
 				}
 			})
@@ -104,3 +115,55 @@ func (m *monitorConnectionServer) eventLoop() {
 		})
 	}()
 }
+
+// store updates the last known state of the connections, returns the events which should be sent to the subscribers.
+// INITIAL_STATE_TRANSFER replaces the state, so it is sent as DELETE for the dropped connections and UPDATE for the
+// rest, because the subscribers have already received the initial state on subscription.
+func (m *monitorConnectionServer) store(event *networkservice.ConnectionEvent) []*networkservice.ConnectionEvent {
+	switch event.GetType() {
+	case networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER:
+		dropped := make(map[string]*networkservice.Connection)
+		for id, conn := range m.connections {
+			if _, ok := event.GetConnections()[id]; !ok {
+				dropped[id] = conn
+			}
+		}
+		m.connections = make(map[string]*networkservice.Connection, len(event.GetConnections()))
+		for id, conn := range event.GetConnections() {
+			m.connections[id] = conn.Clone()
+		}
+
+		var events []*networkservice.ConnectionEvent
+		if len(dropped) > 0 {
+			events = append(events, &networkservice.ConnectionEvent{
+				Type:        networkservice.ConnectionEventType_DELETE,
+				Connections: dropped,
+			})
+		}
+		if len(event.GetConnections()) > 0 {
+			events = append(events, &networkservice.ConnectionEvent{
+				Type:        networkservice.ConnectionEventType_UPDATE,
+				Connections: event.GetConnections(),
+			})
+		}
+		return events
+	case networkservice.ConnectionEventType_DELETE:
+		for id := range event.GetConnections() {
+			delete(m.connections, id)
+		}
+	default:
+		for id, conn := range event.GetConnections() {
+			m.connections[id] = conn.Clone()
+		}
+	}
+	return []*networkservice.ConnectionEvent{event}
+}
+
+// filterEvent returns the event with the connections matching the selector and true if the event should be sent
+func filterEvent(event *networkservice.ConnectionEvent, selector *networkservice.MonitorScopeSelector) (*networkservice.ConnectionEvent, bool) {
+	filteredEvent := &networkservice.ConnectionEvent{
+		Type:        event.GetType(),
+		Connections: networkservice.FilterMapOnManagerScopeSelector(event.GetConnections(), selector),
+	}
+	return filteredEvent, filteredEvent.GetType() == networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER || len(filteredEvent.GetConnections()) > 0
+}
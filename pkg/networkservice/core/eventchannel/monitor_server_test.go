@@ -134,3 +134,105 @@ func requireConnectionCount(t *testing.T, expected int, ch <-chan int) {
 		}
 	}
 }
+
+func TestMonitorConnectionServer_InitialStateTransfer(t *testing.T) {
+	eventCh := make(chan *networkservice.ConnectionEvent, numEvents)
+	defer close(eventCh)
+
+	server := eventchannel.NewMonitorServer(eventCh, eventchannel.WithInitialStateTransfer())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor := func(selector *networkservice.MonitorScopeSelector) <-chan *networkservice.ConnectionEvent {
+		senderEventCh := make(chan *networkservice.ConnectionEvent, numEvents)
+		go func() {
+			_ = server.MonitorConnections(selector, eventchannel.NewMonitorConnectionMonitorConnectionsServer(ctx, senderEventCh))
+		}()
+		return senderEventCh
+	}
+	newConn := func(id, nsmgr string) *networkservice.Connection {
+		return &networkservice.Connection{
+			Id:   id,
+			Path: &networkservice.Path{PathSegments: []*networkservice.PathSegment{{Name: "nsc"}, {Name: nsmgr}}},
+		}
+	}
+
+	first := monitor(nil)
+	event := <-first
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	require.Empty(t, event.GetConnections())
+
+	// The source INITIAL_STATE_TRANSFER replaces the state and is sent as UPDATE
+	eventCh <- &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: map[string]*networkservice.Connection{"4": newConn("4", "nsmgr-1")},
+	}
+	eventCh <- &networkservice.ConnectionEvent{
+		Type: networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{
+			"1": newConn("1", "nsmgr-1"),
+			"2": newConn("2", "nsmgr-1"),
+			"3": newConn("3", "nsmgr-2"),
+		},
+	}
+	eventCh <- &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_DELETE,
+		Connections: map[string]*networkservice.Connection{"2": newConn("2", "nsmgr-1")},
+	}
+	event = <-first
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+	require.NotNil(t, event.GetConnections()["4"])
+	require.Equal(t, networkservice.ConnectionEventType_UPDATE, (<-first).GetType())
+	require.Equal(t, networkservice.ConnectionEventType_DELETE, (<-first).GetType())
+
+	second := monitor(&networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsmgr-1"}},
+	})
+	event = <-second
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	require.Len(t, event.GetConnections(), 2)
+	require.NotNil(t, event.GetConnections()["1"])
+	require.NotNil(t, event.GetConnections()["4"])
+
+	eventCh <- &networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{"5": newConn("5", "nsmgr-1")},
+	}
+	require.NotNil(t, (<-first).GetConnections()["5"])
+	require.NotNil(t, (<-second).GetConnections()["5"])
+
+	// The connections missing in the new source INITIAL_STATE_TRANSFER are deleted
+	eventCh <- &networkservice.ConnectionEvent{
+		Type: networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER,
+		Connections: map[string]*networkservice.Connection{
+			"1": newConn("1", "nsmgr-1"),
+			"6": newConn("6", "nsmgr-2"),
+		},
+	}
+	for _, tc := range []struct {
+		ch      <-chan *networkservice.ConnectionEvent
+		deleted []string
+		updated []string
+	}{
+		{ch: first, deleted: []string{"3", "4", "5"}, updated: []string{"1", "6"}},
+		{ch: second, deleted: []string{"4", "5"}, updated: []string{"1"}},
+	} {
+		event = <-tc.ch
+		require.Equal(t, networkservice.ConnectionEventType_DELETE, event.GetType())
+		require.Len(t, event.GetConnections(), len(tc.deleted))
+		for _, id := range tc.deleted {
+			require.NotNil(t, event.GetConnections()[id])
+		}
+		event = <-tc.ch
+		require.Equal(t, networkservice.ConnectionEventType_UPDATE, event.GetType())
+		require.Len(t, event.GetConnections(), len(tc.updated))
+		for _, id := range tc.updated {
+			require.NotNil(t, event.GetConnections()[id])
+		}
+	}
+
+	event = <-monitor(nil)
+	require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+	require.Len(t, event.GetConnections(), 2)
+}
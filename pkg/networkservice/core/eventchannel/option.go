@@ -17,6 +17,10 @@
 // Package eventchannel provides API for creating monitoring components  via golang channels
 package eventchannel
 
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
 // MonitorConnectionServerOption applies specific parameters for MonitorConnectionServer
 type MonitorConnectionServerOption interface {
 	apply(s *monitorConnectionServer)
@@ -34,3 +38,49 @@ func WithConnectChannel(connectCh chan<- int) MonitorConnectionServerOption {
 		s.connectCh = connectCh
 	})
 }
+
+// WithInitialStateTransfer enables for MonitorConnectionServer retaining of the last known state of the connections:
+// the state is sent to each new subscriber as INITIAL_STATE_TRANSFER event like monitor.Server does, the received
+// INITIAL_STATE_TRANSFER events replace the state and are sent to the current subscribers as DELETE for the dropped
+// connections and UPDATE for the rest
+func WithInitialStateTransfer() MonitorConnectionServerOption {
+	return monitorConnectionServerOptionFunc(func(s *monitorConnectionServer) {
+		s.connections = make(map[string]*networkservice.Connection)
+	})
+}
+
+// MonitorConnectionClientOption applies specific parameters for MonitorConnectionClient
+type MonitorConnectionClientOption interface {
+	apply(c *monitorConnectionClient)
+}
+
+type monitorConnectionClientOptionFunc func(*monitorConnectionClient)
+
+func (f monitorConnectionClientOptionFunc) apply(c *monitorConnectionClient) {
+	f(c)
+}
+
+// WithSelectorFiltering enables for MonitorConnectionClient filtering of the events by the MonitorScopeSelector passed
+// to MonitorConnections: only the connections with the path segments matching the selector are sent to the receiver,
+// events left without connections are dropped except of INITIAL_STATE_TRANSFER
+func WithSelectorFiltering() MonitorConnectionClientOption {
+	return monitorConnectionClientOptionFunc(func(c *monitorConnectionClient) {
+		c.filter = true
+	})
+}
+
+// WithBufferSize sets for MonitorConnectionClient the buffer size of each subscriber
+// Default: 100
+func WithBufferSize(size int) MonitorConnectionClientOption {
+	return monitorConnectionClientOptionFunc(func(c *monitorConnectionClient) {
+		c.bufferSize = size
+	})
+}
+
+// WithOverflowPolicy sets for MonitorConnectionClient the policy applied when the subscriber buffer is full
+// Default: OverflowBlock
+func WithOverflowPolicy(policy OverflowPolicy) MonitorConnectionClientOption {
+	return monitorConnectionClientOptionFunc(func(c *monitorConnectionClient) {
+		c.overflowPolicy = policy
+	})
+}
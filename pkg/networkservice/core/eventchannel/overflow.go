@@ -0,0 +1,74 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventchannel
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+const defaultBufferSize = 100
+
+// OverflowPolicy defines what MonitorConnectionClient does with an event when the subscriber buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the event loop until the subscriber reads the buffered events, so a stuck subscriber stalls
+	// all the others
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered event to store the new one
+	OverflowDropOldest
+	// OverflowDropNewest drops the new event
+	OverflowDropNewest
+	// OverflowDisconnect closes the subscriber channel, so the subscriber receives the buffered events and then an error
+	OverflowDisconnect
+)
+
+// send sends the event to the subscriber channel according to the policy, returns false if the subscriber should be
+// disconnected
+func (p OverflowPolicy) send(ch chan *networkservice.ConnectionEvent, event *networkservice.ConnectionEvent) bool {
+	switch p {
+	case OverflowDropOldest:
+		for {
+			select {
+			case ch <- event:
+				return true
+			default:
+			}
+			// The subscriber can concurrently read the buffered events, so the channel can be already empty here
+			select {
+			case <-ch:
+			default:
+			}
+		}
+	case OverflowDropNewest:
+		select {
+		case ch <- event:
+		default:
+		}
+		return true
+	case OverflowDisconnect:
+		select {
+		case ch <- event:
+			return true
+		default:
+			return false
+		}
+	default:
+		ch <- event
+		return true
+	}
+}
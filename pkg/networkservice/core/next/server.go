@@ -65,6 +65,11 @@ func (n *nextServer) Close(ctx context.Context, conn *networkservice.Connection)
 	return server.Close(ctx, conn)
 }
 
+// Elements returns the chained servers, it is used to inspect the chain
+func (n *nextServer) Elements() []networkservice.NetworkServiceServer {
+	return n.servers[n.index:]
+}
+
 func (n *nextServer) getServerAndContext(ctx context.Context) (networkservice.NetworkServiceServer, context.Context) {
 	nextParent := n.nextParent
 	if n.index == 0 {
@@ -20,6 +20,7 @@ package trace
 
 import (
 	"context"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
@@ -27,6 +28,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/inflight"
+	"github.com/networkservicemesh/sdk/pkg/tools/provenance"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
@@ -49,11 +52,15 @@ func (t *beginTraceClient) Request(ctx context.Context, request *networkservice.
 	operation := typeutils.GetFuncName(t.traced, "Request")
 	ctx, finish := withLog(ctx, operation, request.GetConnection().GetId())
 	defer finish()
+	defer inflight.Enter(ctx, operation)()
 
 	logRequest(ctx, request, "request")
 	// Actually call the next
-	rv, err := t.traced.Request(ctx, request, opts...)
+	rv, err := timeRequest(ctx, operation, request.GetConnection(), func(ctx context.Context) (*networkservice.Connection, error) {
+		return t.traced.Request(ctx, request, opts...)
+	})
 	if err != nil {
+		err = provenance.Wrap(err, strings.TrimSuffix(operation, ".Request"), "Request", request.GetConnection().GetPath().GetIndex())
 		return nil, logError(ctx, err, operation)
 	}
 	logResponse(ctx, rv, "request")
@@ -69,6 +76,7 @@ func (t *beginTraceClient) Close(ctx context.Context, conn *networkservice.Conne
 	logRequest(ctx, conn, "close")
 	rv, err := t.traced.Close(ctx, conn, opts...)
 	if err != nil {
+		err = provenance.Wrap(err, strings.TrimSuffix(operation, ".Close"), "Close", conn.GetPath().GetIndex())
 		return nil, logError(ctx, err, operation)
 	}
 	logResponse(ctx, conn, "close")
@@ -78,7 +86,9 @@ func (t *beginTraceClient) Close(ctx context.Context, conn *networkservice.Conne
 
 func (t *endTraceClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
 	logRequest(ctx, request, "request")
-	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	conn, err := timeDownstream(ctx, func() (*networkservice.Connection, error) {
+		return next.Client(ctx).Request(ctx, request, opts...)
+	})
 	logResponse(ctx, conn, "request")
 	return conn, err
 }
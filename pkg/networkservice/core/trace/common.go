@@ -31,6 +31,7 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
 )
 
 func logRequest(ctx context.Context, request proto.Message, prefixes ...string) {
@@ -38,7 +39,11 @@ func logRequest(ctx context.Context, request proto.Message, prefixes ...string)
 	diffMsg := strings.Join(append(prefixes, "diff"), "-")
 
 	connInfo, ok := trace(ctx)
-	if ok && !proto.Equal(connInfo.Request, request) {
+	if !ok {
+		return
+	}
+	request = redact.Default().Apply(request)
+	if !proto.Equal(connInfo.Request, request) {
 		if connInfo.Request != nil && connInfo.Request.ProtoReflect().Descriptor().FullName() == request.ProtoReflect().Descriptor().FullName() {
 			requestDiff, hadChanges := Diff(connInfo.Request.ProtoReflect(), request.ProtoReflect())
 			if hadChanges {
@@ -47,7 +52,7 @@ func logRequest(ctx context.Context, request proto.Message, prefixes ...string)
 		} else {
 			logObjectTrace(ctx, msg, request)
 		}
-		connInfo.Request = proto.Clone(request)
+		connInfo.Request = request
 	}
 }
 
@@ -56,7 +61,11 @@ func logResponse(ctx context.Context, response proto.Message, prefixes ...string
 	diffMsg := strings.Join(append(prefixes, "response", "diff"), "-")
 
 	connInfo, ok := trace(ctx)
-	if ok && !proto.Equal(connInfo.Response, response) {
+	if !ok {
+		return
+	}
+	response = redact.Default().Apply(response)
+	if !proto.Equal(connInfo.Response, response) {
 		if connInfo.Response != nil {
 			responseDiff, changed := Diff(connInfo.Response.ProtoReflect(), response.ProtoReflect())
 			if changed {
@@ -65,8 +74,7 @@ func logResponse(ctx context.Context, response proto.Message, prefixes ...string
 		} else {
 			logObjectTrace(ctx, msg, response)
 		}
-		connInfo.Response = proto.Clone(response)
-		return
+		connInfo.Response = response
 	}
 }
 
@@ -20,12 +20,15 @@ package trace
 
 import (
 	"context"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/inflight"
+	"github.com/networkservicemesh/sdk/pkg/tools/provenance"
 	"github.com/networkservicemesh/sdk/pkg/tools/typeutils"
 )
 
@@ -43,16 +46,25 @@ func NewNetworkServiceServer(traced networkservice.NetworkServiceServer) network
 	)
 }
 
+// Unwrap returns the traced server, it is used to inspect the chain
+func (t *beginTraceServer) Unwrap() networkservice.NetworkServiceServer {
+	return t.traced
+}
+
 func (t *beginTraceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	// Create a new logger
 	operation := typeutils.GetFuncName(t.traced, "Request")
 	ctx, finish := withLog(ctx, operation, request.GetConnection().GetId())
 	defer finish()
+	defer inflight.Enter(ctx, operation)()
 
 	logRequest(ctx, request, "request")
 	// Actually call the next
-	rv, err := t.traced.Request(ctx, request)
+	rv, err := timeRequest(ctx, operation, request.GetConnection(), func(ctx context.Context) (*networkservice.Connection, error) {
+		return t.traced.Request(ctx, request)
+	})
 	if err != nil {
+		err = provenance.Wrap(err, strings.TrimSuffix(operation, ".Request"), "Request", request.GetConnection().GetPath().GetIndex())
 		return nil, logError(ctx, err, operation)
 	}
 	logResponse(ctx, rv, "request")
@@ -68,6 +80,7 @@ func (t *beginTraceServer) Close(ctx context.Context, conn *networkservice.Conne
 	logRequest(ctx, conn, "close")
 	rv, err := t.traced.Close(ctx, conn)
 	if err != nil {
+		err = provenance.Wrap(err, strings.TrimSuffix(operation, ".Close"), "Close", conn.GetPath().GetIndex())
 		return nil, logError(ctx, err, operation)
 	}
 	logResponse(ctx, conn, "close")
@@ -76,7 +89,9 @@ func (t *beginTraceServer) Close(ctx context.Context, conn *networkservice.Conne
 
 func (t *endTraceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
 	logRequest(ctx, request, "request")
-	conn, err := next.Server(ctx).Request(ctx, request)
+	conn, err := timeDownstream(ctx, func() (*networkservice.Connection, error) {
+		return next.Server(ctx).Request(ctx, request)
+	})
 	logResponse(ctx, conn, "request")
 	return conn, err
 }
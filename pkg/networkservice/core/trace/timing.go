@@ -0,0 +1,151 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	grpcTimingKey                 = "GrpcTiming"
+	timingInfoKey  contextKeyType = "TimingInfo"
+	timingFrameKey contextKeyType = "TimingFrame"
+
+	// TimingPrefix is a prefix of the Connection.Context.ExtraContext keys containing per element Request durations:
+	//
+	//	timing/<path segment name>/<element name> = <duration>
+	TimingPrefix = "timing/"
+)
+
+// timingInfo accumulates per element Request durations for a single hop
+type timingInfo struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+// timingFrame accumulates the time spent in the downstream elements of a single element
+type timingFrame struct {
+	downstream time.Duration
+}
+
+// WithTiming - enables per element timing breakdown for outgoing context. Durations are returned in the
+// Connection.Context.ExtraContext with TimingPrefix keys.
+func WithTiming(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, grpcTimingKey, "true")
+}
+
+func isTimingEnabled(ctx context.Context) (incoming, outgoing bool) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(grpcTimingKey)) > 0 {
+		incoming = true
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(grpcTimingKey)) > 0 {
+		outgoing = true
+	}
+	return incoming, outgoing
+}
+
+// withTiming - provides a timingInfo and a new timingFrame in context if timing is enabled. The grpc metadata is checked
+// only by the first trace element of the hop, the result is cached in the context (nil timingInfo if disabled). The
+// first element also strips the stale timing keys from the connection.
+func withTiming(parent context.Context, conn *networkservice.Connection) (ctx context.Context, info *timingInfo, isRoot bool) {
+	info, ok := parent.Value(timingInfoKey).(*timingInfo)
+	if !ok {
+		stripTiming(conn)
+
+		incoming, outgoing := isTimingEnabled(parent)
+		switch {
+		case !incoming && !outgoing:
+			info = nil
+		case !outgoing:
+			parent = WithTiming(parent)
+			fallthrough
+		default:
+			info = &timingInfo{durations: make(map[string]time.Duration)}
+		}
+		parent = context.WithValue(parent, timingInfoKey, info)
+	}
+	if info == nil {
+		return parent, nil, false
+	}
+	return context.WithValue(parent, timingFrameKey, new(timingFrame)), info, !ok
+}
+
+// timeRequest - calls request and stores its duration without the downstream elements duration in the timingInfo
+func timeRequest(ctx context.Context, operation string, conn *networkservice.Connection, request func(ctx context.Context) (*networkservice.Connection, error)) (*networkservice.Connection, error) {
+	ctx, info, isRoot := withTiming(ctx, conn)
+	if info == nil {
+		return request(ctx)
+	}
+
+	start := clock.FromContext(ctx).Now()
+	rv, err := request(ctx)
+	duration := clock.FromContext(ctx).Since(start) - ctx.Value(timingFrameKey).(*timingFrame).downstream
+
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	info.durations[strings.TrimSuffix(operation, ".Request")] += duration
+	if isRoot && rv != nil {
+		info.storeTo(rv)
+	}
+	return rv, err
+}
+
+// timeDownstream - calls request and adds its duration to the downstream duration of the current timingFrame
+func timeDownstream(ctx context.Context, request func() (*networkservice.Connection, error)) (*networkservice.Connection, error) {
+	frame, ok := ctx.Value(timingFrameKey).(*timingFrame)
+	if !ok {
+		return request()
+	}
+
+	start := clock.FromContext(ctx).Now()
+	defer func() {
+		frame.downstream += clock.FromContext(ctx).Since(start)
+	}()
+	return request()
+}
+
+func (i *timingInfo) storeTo(conn *networkservice.Connection) {
+	if conn.GetContext() == nil {
+		conn.Context = new(networkservice.ConnectionContext)
+	}
+	if conn.GetContext().GetExtraContext() == nil {
+		conn.GetContext().ExtraContext = make(map[string]string)
+	}
+
+	prefix := TimingPrefix + conn.GetCurrentPathSegment().GetName() + "/"
+	for name, duration := range i.durations {
+		conn.GetContext().GetExtraContext()[prefix+name] = duration.String()
+	}
+}
+
+func stripTiming(conn *networkservice.Connection) {
+	extraContext := conn.GetContext().GetExtraContext()
+	for key := range extraContext {
+		if strings.HasPrefix(key, TimingPrefix) {
+			delete(extraContext, key)
+		}
+	}
+}
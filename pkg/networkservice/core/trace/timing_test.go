@@ -0,0 +1,123 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/trace"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+type slowServer struct {
+	clock *clockmock.Mock
+	delay time.Duration
+}
+
+func (s *slowServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	s.clock.Add(s.delay)
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *slowServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+type fastServer struct{}
+
+func (s *fastServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *fastServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func timingRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{{Name: "nsmgr"}},
+			},
+		},
+	}
+}
+
+func durations(t *testing.T, conn *networkservice.Connection) map[string]time.Duration {
+	rv := make(map[string]time.Duration)
+	for key, value := range conn.GetContext().GetExtraContext() {
+		require.True(t, strings.HasPrefix(key, trace.TimingPrefix+"nsmgr/"))
+		duration, err := time.ParseDuration(value)
+		require.NoError(t, err)
+		rv[key[strings.LastIndex(key, "/")+1:]] = duration
+	}
+	return rv
+}
+
+func TestTiming(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server := chain.NewNetworkServiceServer(
+		&fastServer{},
+		&slowServer{clock: clockMock, delay: time.Second},
+	)
+
+	conn, err := server.Request(trace.WithTiming(ctx), timingRequest())
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]time.Duration{
+		"fastServer": 0,
+		"slowServer": time.Second,
+	}, durations(t, conn))
+
+	// Stale timing keys are stripped on refresh
+	conn.GetContext().GetExtraContext()[trace.TimingPrefix+"nsmgr/removedServer"] = "1s"
+	conn, err = server.Request(trace.WithTiming(ctx), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Len(t, durations(t, conn), 2)
+
+	conn, err = server.Request(ctx, &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Empty(t, conn.GetContext().GetExtraContext())
+}
+
+func TestTiming_Disabled(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := chain.NewNetworkServiceServer(&fastServer{})
+
+	conn, err := server.Request(context.Background(), timingRequest())
+	require.NoError(t, err)
+	require.Empty(t, conn.GetContext().GetExtraContext())
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package point2pointipam
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
+)
+
+// Mode is the IP families allocation mode
+type Mode int
+
+const (
+	// AnyFamily allocates the addresses from the first prefix having free addresses, in the prefixes order. It is the
+	// default mode.
+	AnyFamily Mode = iota
+	// IPv6First allocates the addresses from the IPv6 prefixes, falls back to the IPv4 prefixes if all the IPv6 ones
+	// are exhausted
+	IPv6First
+	// IPv4Only allocates the addresses only from the IPv4 prefixes
+	IPv4Only
+	// IPv6Only allocates the addresses only from the IPv6 prefixes
+	IPv6Only
+	// DualStack allocates both the IPv4 and IPv6 addresses, fails if any of the families is exhausted
+	DualStack
+)
+
+// Option is an option pattern for NewServerWithOptions
+type Option func(o *options)
+
+// WithMode sets the IP families allocation mode
+func WithMode(mode Mode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+// WithPairing makes the IPv6 addresses paired with the IPv4 ones in the DualStack mode: the IPv6 address has the same
+// offset in its prefix as the IPv4 address in its one, e.g. 10.0.0.5 from 10.0.0.0/24 is paired with fd00::5 from
+// fd00::/64. The request fails if the paired address is not available.
+func WithPairing() Option {
+	return func(o *options) {
+		o.pairing = true
+	}
+}
+
+// WithBindingStore sets the store of the connection addresses. The bindings are restored from the store on the first
+// request, so the client reconnecting after the NSE restart with the same connection ID gets the same addresses. The
+// binding is deleted on Close.
+func WithBindingStore(store ipbinding.Store) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+// WithRestoreGracePeriod sets how long the restored binding is kept for the connection to be requested again, the
+// addresses are released if it is not. Default is ipbinding.DefaultRestoreGracePeriod.
+func WithRestoreGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.restoreGracePeriod = gracePeriod
+	}
+}
+
+type options struct {
+	mode               Mode
+	pairing            bool
+	store              ipbinding.Store
+	restoreGracePeriod time.Duration
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
@@ -28,6 +29,8 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
 	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
 )
 
@@ -35,52 +38,196 @@ type ipamServer struct {
 	Map
 	ipPools  []*ippool.IPPool
 	prefixes []*net.IPNet
+	mode     Mode
+	pairing  bool
+	store    ipbinding.Store
 	once     sync.Once
 	initErr  error
+
+	restoreGracePeriod time.Duration
+	// restored are the expiration timers of the restored bindings not requested yet
+	restored   map[string]clock.Timer
+	restoredMu sync.Mutex
 }
 
 type connectionInfo struct {
+	allocations []*allocation
+}
+
+type allocation struct {
 	ipPool  *ippool.IPPool
 	srcAddr string
 	dstAddr string
 }
 
 func (i *connectionInfo) shouldUpdate(exclude *ippool.IPPool) bool {
-	srcIP, _, srcErr := net.ParseCIDR(i.srcAddr)
-	dstIP, _, dstErr := net.ParseCIDR(i.dstAddr)
+	for _, a := range i.allocations {
+		srcIP, _, srcErr := net.ParseCIDR(a.srcAddr)
+		dstIP, _, dstErr := net.ParseCIDR(a.dstAddr)
 
-	return srcErr == nil && exclude.ContainsString(srcIP.String()) || dstErr == nil && exclude.ContainsString(dstIP.String())
+		if srcErr == nil && exclude.ContainsString(srcIP.String()) || dstErr == nil && exclude.ContainsString(dstIP.String()) {
+			return true
+		}
+	}
+	return false
 }
 
 // NewServer - creates a new NetworkServiceServer chain element that implements IPAM service.
 func NewServer(prefixes ...*net.IPNet) networkservice.NetworkServiceServer {
+	return NewServerWithOptions(prefixes)
+}
+
+// NewServerWithOptions - creates a new NetworkServiceServer chain element that implements IPAM service with the
+// IP families allocation configured by options.
+func NewServerWithOptions(prefixes []*net.IPNet, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		restoreGracePeriod: ipbinding.DefaultRestoreGracePeriod,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &ipamServer{
-		prefixes: prefixes,
+		prefixes:           prefixes,
+		mode:               o.mode,
+		pairing:            o.pairing,
+		store:              o.store,
+		restoreGracePeriod: o.restoreGracePeriod,
+		restored:           make(map[string]clock.Timer),
 	}
 }
 
-func (s *ipamServer) init() {
+func (s *ipamServer) init(ctx context.Context) {
 	if len(s.prefixes) == 0 {
 		s.initErr = errors.New("required one or more prefixes")
 		return
 	}
 
+	var hasIPv4, hasIPv6 bool
 	for _, prefix := range s.prefixes {
 		if prefix == nil {
 			s.initErr = errors.Errorf("prefix must not be nil: %+v", s.prefixes)
 			return
 		}
+		hasIPv4 = hasIPv4 || isIPv4(prefix)
+		hasIPv6 = hasIPv6 || isIPv6(prefix)
 		s.ipPools = append(s.ipPools, ippool.NewWithNet(prefix))
 	}
+
+	switch {
+	case (s.mode == IPv4Only || s.mode == DualStack) && !hasIPv4:
+		s.initErr = errors.Errorf("required one or more IPv4 prefixes: %+v", s.prefixes)
+	case (s.mode == IPv6Only || s.mode == DualStack) && !hasIPv6:
+		s.initErr = errors.Errorf("required one or more IPv6 prefixes: %+v", s.prefixes)
+	case s.store != nil:
+		s.restore(ctx)
+	}
+}
+
+// restore reserves the addresses stored in the binding store, the bindings not fitting the prefixes are deleted. The
+// restored bindings not requested during the grace period are released.
+func (s *ipamServer) restore(ctx context.Context) {
+	s.restoredMu.Lock()
+	defer s.restoredMu.Unlock()
+
+	clockTime := clock.FromContext(ctx)
+	var invalid []string
+	s.store.Range(func(id string, binding *ipbinding.Binding) bool {
+		if connInfo, ok := s.reserve(binding); ok {
+			s.Store(id, connInfo)
+			s.restored[id] = clockTime.AfterFunc(s.restoreGracePeriod, func() {
+				s.expireRestored(id)
+			})
+		} else {
+			invalid = append(invalid, id)
+		}
+		return true
+	})
+	for _, id := range invalid {
+		_ = s.store.Delete(id)
+	}
+}
+
+// expireRestored releases the restored binding if it has not been requested yet
+func (s *ipamServer) expireRestored(id string) {
+	s.restoredMu.Lock()
+	defer s.restoredMu.Unlock()
+
+	if _, ok := s.restored[id]; !ok {
+		return
+	}
+	delete(s.restored, id)
+	if connInfo, ok := s.LoadAndDelete(id); ok {
+		s.free(connInfo)
+		s.deleteBinding(id)
+	}
+}
+
+// claimRestored stops the expiration of the restored binding, it should be called before loading the connection info
+func (s *ipamServer) claimRestored(id string) {
+	s.restoredMu.Lock()
+	defer s.restoredMu.Unlock()
+
+	if timer, ok := s.restored[id]; ok {
+		timer.Stop()
+		delete(s.restored, id)
+	}
+}
+
+func (s *ipamServer) reserve(binding *ipbinding.Binding) (*connectionInfo, bool) {
+	connInfo := new(connectionInfo)
+	for i := range binding.SrcAddrs {
+		if i >= len(binding.DstAddrs) {
+			break
+		}
+		a, ok := s.reserveP2PAddrs(binding.SrcAddrs[i], binding.DstAddrs[i])
+		if !ok {
+			s.free(connInfo)
+			return nil, false
+		}
+		connInfo.allocations = append(connInfo.allocations, a)
+	}
+	return connInfo, len(connInfo.allocations) != 0
+}
+
+func (s *ipamServer) reserveP2PAddrs(srcAddr, dstAddr string) (*allocation, bool) {
+	srcIP, _, srcErr := net.ParseCIDR(srcAddr)
+	dstIP, _, dstErr := net.ParseCIDR(dstAddr)
+	if srcErr != nil || dstErr != nil {
+		return nil, false
+	}
+	if srcIP.To4() != nil {
+		srcIP, dstIP = srcIP.To4(), dstIP.To4()
+	}
+
+	for i, ipPool := range s.ipPools {
+		if !s.prefixes[i].Contains(srcIP) || !s.prefixes[i].Contains(dstIP) {
+			continue
+		}
+		if err := ipPool.PullIP(dstIP); err != nil {
+			return nil, false
+		}
+		if err := ipPool.PullIP(srcIP); err != nil {
+			ipPool.Add(dstIP)
+			return nil, false
+		}
+		return &allocation{
+			ipPool:  ipPool,
+			srcAddr: srcAddr,
+			dstAddr: dstAddr,
+		}, true
+	}
+	return nil, false
 }
 
 func (s *ipamServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
-	s.once.Do(s.init)
+	s.once.Do(func() { s.init(ctx) })
 	if s.initErr != nil {
 		return nil, s.initErr
 	}
 
 	conn := request.GetConnection()
+	s.claimRestored(conn.GetId())
 	if conn.GetContext() == nil {
 		conn.Context = &networkservice.ConnectionContext{}
 	}
@@ -95,30 +242,40 @@ func (s *ipamServer) Request(ctx context.Context, request *networkservice.Networ
 	var err error
 	if loaded && (connInfo.shouldUpdate(excludeIP4) || connInfo.shouldUpdate(excludeIP6)) {
 		// some of the existing addresses are excluded
-		deleteAddr(&ipContext.SrcIpAddrs, connInfo.srcAddr)
-		deleteAddr(&ipContext.DstIpAddrs, connInfo.dstAddr)
-		deleteRoute(&ipContext.SrcRoutes, connInfo.dstAddr)
-		deleteRoute(&ipContext.DstRoutes, connInfo.srcAddr)
+		for _, a := range connInfo.allocations {
+			deleteAddr(&ipContext.SrcIpAddrs, a.srcAddr)
+			deleteAddr(&ipContext.DstIpAddrs, a.dstAddr)
+			deleteRoute(&ipContext.SrcRoutes, a.dstAddr)
+			deleteRoute(&ipContext.DstRoutes, a.srcAddr)
+		}
 		s.free(connInfo)
+		s.deleteBinding(conn.GetId())
 		loaded = false
 	}
 	if !loaded {
-		if connInfo, err = s.getP2PAddrs(excludeIP4, excludeIP6); err != nil {
+		if connInfo, err = s.allocate(excludeIP4, excludeIP6); err != nil {
+			return nil, err
+		}
+		if err = s.storeBinding(conn.GetId(), connInfo); err != nil {
+			s.free(connInfo)
 			return nil, err
 		}
 		s.Store(conn.GetId(), connInfo)
 	}
 
-	addAddr(&ipContext.SrcIpAddrs, connInfo.srcAddr)
-	addRoute(&ipContext.SrcRoutes, connInfo.dstAddr)
+	for _, a := range connInfo.allocations {
+		addAddr(&ipContext.SrcIpAddrs, a.srcAddr)
+		addRoute(&ipContext.SrcRoutes, a.dstAddr)
 
-	addAddr(&ipContext.DstIpAddrs, connInfo.dstAddr)
-	addRoute(&ipContext.DstRoutes, connInfo.srcAddr)
+		addAddr(&ipContext.DstIpAddrs, a.dstAddr)
+		addRoute(&ipContext.DstRoutes, a.srcAddr)
+	}
 
 	conn, err = next.Server(ctx).Request(ctx, request)
 	if err != nil {
 		if !loaded {
 			s.free(connInfo)
+			s.deleteBinding(request.GetConnection().GetId())
 		}
 		return nil, err
 	}
@@ -126,11 +283,76 @@ func (s *ipamServer) Request(ctx context.Context, request *networkservice.Networ
 	return conn, nil
 }
 
-func (s *ipamServer) getP2PAddrs(excludeIP4, excludeIP6 *ippool.IPPool) (connInfo *connectionInfo, err error) {
+func (s *ipamServer) storeBinding(id string, connInfo *connectionInfo) error {
+	if s.store == nil {
+		return nil
+	}
+	binding := new(ipbinding.Binding)
+	for _, a := range connInfo.allocations {
+		binding.SrcAddrs = append(binding.SrcAddrs, a.srcAddr)
+		binding.DstAddrs = append(binding.DstAddrs, a.dstAddr)
+	}
+	return errors.Wrap(s.store.Store(id, binding), "failed to store IP binding")
+}
+
+func (s *ipamServer) deleteBinding(id string) {
+	if s.store != nil {
+		_ = s.store.Delete(id)
+	}
+}
+
+func (s *ipamServer) allocate(excludeIP4, excludeIP6 *ippool.IPPool) (*connectionInfo, error) {
+	var a *allocation
+	var err error
+	switch s.mode {
+	case IPv4Only:
+		a, err = s.getP2PAddrs(isIPv4, excludeIP4, excludeIP6)
+	case IPv6Only:
+		a, err = s.getP2PAddrs(isIPv6, excludeIP4, excludeIP6)
+	case IPv6First:
+		if a, err = s.getP2PAddrs(isIPv6, excludeIP4, excludeIP6); err != nil {
+			a, err = s.getP2PAddrs(isIPv4, excludeIP4, excludeIP6)
+		}
+	case DualStack:
+		return s.allocateDualStack(excludeIP4, excludeIP6)
+	default:
+		a, err = s.getP2PAddrs(func(*net.IPNet) bool { return true }, excludeIP4, excludeIP6)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &connectionInfo{allocations: []*allocation{a}}, nil
+}
+
+func (s *ipamServer) allocateDualStack(excludeIP4, excludeIP6 *ippool.IPPool) (*connectionInfo, error) {
+	a4, err := s.getP2PAddrs(isIPv4, excludeIP4, excludeIP6)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to allocate IPv4 addresses")
+	}
+
+	var a6 *allocation
+	if s.pairing {
+		a6, err = s.getPairedP2PAddrs(a4, excludeIP6)
+	} else {
+		a6, err = s.getP2PAddrs(isIPv6, excludeIP4, excludeIP6)
+	}
+	if err != nil {
+		a4.free()
+		return nil, errors.Wrap(err, "failed to allocate IPv6 addresses")
+	}
+
+	return &connectionInfo{allocations: []*allocation{a4, a6}}, nil
+}
+
+func (s *ipamServer) getP2PAddrs(family func(*net.IPNet) bool, excludeIP4, excludeIP6 *ippool.IPPool) (*allocation, error) {
+	err := errors.New("no prefixes for the allocation mode")
 	var dstAddr, srcAddr *net.IPNet
-	for _, ipPool := range s.ipPools {
+	for i, ipPool := range s.ipPools {
+		if !family(s.prefixes[i]) {
+			continue
+		}
 		if dstAddr, srcAddr, err = ipPool.PullP2PAddrs(excludeIP4, excludeIP6); err == nil {
-			return &connectionInfo{
+			return &allocation{
 				ipPool:  ipPool,
 				srcAddr: srcAddr.String(),
 				dstAddr: dstAddr.String(),
@@ -140,6 +362,51 @@ func (s *ipamServer) getP2PAddrs(excludeIP4, excludeIP6 *ippool.IPPool) (connInf
 	return nil, err
 }
 
+// getPairedP2PAddrs allocates the IPv6 addresses having the same offsets in the first fitting IPv6 prefix as the IPv4
+// addresses have in their prefix, the prefixes with the paired addresses excluded or already in use are skipped
+func (s *ipamServer) getPairedP2PAddrs(a4 *allocation, excludeIP6 *ippool.IPPool) (*allocation, error) {
+	var prefix4 *net.IPNet
+	for i, ipPool := range s.ipPools {
+		if ipPool == a4.ipPool {
+			prefix4 = s.prefixes[i]
+		}
+	}
+
+	err := errors.Errorf("no IPv6 prefix fits the paired addresses for %s, %s", a4.dstAddr, a4.srcAddr)
+	dstOffset, srcOffset := offset(prefix4, a4.dstAddr), offset(prefix4, a4.srcAddr)
+	for i, ipPool := range s.ipPools {
+		prefix6 := s.prefixes[i]
+		if isIPv4(prefix6) {
+			continue
+		}
+
+		dstIP, srcIP := addOffset(prefix6, dstOffset), addOffset(prefix6, srcOffset)
+		if !prefix6.Contains(dstIP) || !prefix6.Contains(srcIP) {
+			continue
+		}
+		if excludeIP6.Contains(dstIP) || excludeIP6.Contains(srcIP) {
+			err = errors.Errorf("paired addresses are excluded: %v, %v", dstIP, srcIP)
+			continue
+		}
+
+		if err = ipPool.PullIP(dstIP); err != nil {
+			continue
+		}
+		if err = ipPool.PullIP(srcIP); err != nil {
+			ipPool.Add(dstIP)
+			continue
+		}
+
+		mask := net.CIDRMask(net.IPv6len*8, net.IPv6len*8)
+		return &allocation{
+			ipPool:  ipPool,
+			srcAddr: (&net.IPNet{IP: srcIP, Mask: mask}).String(),
+			dstAddr: (&net.IPNet{IP: dstIP, Mask: mask}).String(),
+		}, nil
+	}
+	return nil, err
+}
+
 func deleteRoute(routes *[]*networkservice.Route, prefix string) {
 	for i, route := range *routes {
 		if route.Prefix == prefix {
@@ -179,19 +446,27 @@ func addAddr(addrs *[]string, addr string) {
 }
 
 func (s *ipamServer) Close(ctx context.Context, conn *networkservice.Connection) (_ *empty.Empty, err error) {
-	s.once.Do(s.init)
+	s.once.Do(func() { s.init(ctx) })
 	if s.initErr != nil {
 		return nil, s.initErr
 	}
 
+	s.claimRestored(conn.GetId())
 	if connInfo, ok := s.Load(conn.GetId()); ok {
 		s.free(connInfo)
+		s.deleteBinding(conn.GetId())
 	}
 
 	return next.Server(ctx).Close(ctx, conn)
 }
 
 func (s *ipamServer) free(connInfo *connectionInfo) {
-	connInfo.ipPool.AddNetString(connInfo.srcAddr)
-	connInfo.ipPool.AddNetString(connInfo.dstAddr)
+	for _, a := range connInfo.allocations {
+		a.free()
+	}
+}
+
+func (a *allocation) free() {
+	a.ipPool.AddNetString(a.srcAddr)
+	a.ipPool.AddNetString(a.dstAddr)
 }
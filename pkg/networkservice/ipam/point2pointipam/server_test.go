@@ -19,7 +19,9 @@ package point2pointipam_test
 import (
 	"context"
 	"net"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -32,6 +34,9 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/point2pointipam"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
 )
 
 func newIpamServer(prefixes ...*net.IPNet) networkservice.NetworkServiceServer {
@@ -423,3 +428,243 @@ func TestRefreshRequestMultiServer(t *testing.T) {
 	require.NoError(t, err)
 	validateConns(t, conn, []string{"192.168.0.4/32", "fe80::4/128"}, []string{"192.168.0.5/32", "fe80::5/128"})
 }
+
+func newIpamServerWithOptions(prefixes []*net.IPNet, opts ...point2pointipam.Option) networkservice.NetworkServiceServer {
+	return next.NewNetworkServiceServer(
+		updatepath.NewServer("ipam"),
+		metadata.NewServer(),
+		point2pointipam.NewServerWithOptions(prefixes, opts...),
+	)
+}
+
+func TestServer_Modes(t *testing.T) {
+	_, ipNet1, err := net.ParseCIDR("192.168.0.0/31")
+	require.NoError(t, err)
+	_, ipNet2, err := net.ParseCIDR("fd00::/127")
+	require.NoError(t, err)
+
+	prefixes := []*net.IPNet{ipNet1, ipNet2}
+
+	conn, err := newIpamServerWithOptions(prefixes, point2pointipam.WithMode(point2pointipam.IPv6Only)).
+		Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn, "fd00::/128", "fd00::1/128")
+
+	srv := newIpamServerWithOptions(prefixes, point2pointipam.WithMode(point2pointipam.IPv4Only))
+	conn, err = srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.0/32", "192.168.0.1/32")
+
+	_, err = srv.Request(context.Background(), newRequest())
+	require.Error(t, err)
+
+	srv = newIpamServerWithOptions(prefixes, point2pointipam.WithMode(point2pointipam.IPv6First))
+	conn, err = srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn, "fd00::/128", "fd00::1/128")
+
+	conn, err = srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConn(t, conn, "192.168.0.0/32", "192.168.0.1/32")
+
+	_, err = newIpamServerWithOptions([]*net.IPNet{ipNet1}, point2pointipam.WithMode(point2pointipam.IPv6Only)).
+		Request(context.Background(), newRequest())
+	require.Error(t, err)
+}
+
+func TestServer_DualStack(t *testing.T) {
+	_, ipNet1, err := net.ParseCIDR("192.168.0.0/30")
+	require.NoError(t, err)
+	_, ipNet2, err := net.ParseCIDR("fd00::/127")
+	require.NoError(t, err)
+
+	srv := newIpamServerWithOptions([]*net.IPNet{ipNet2, ipNet1}, point2pointipam.WithMode(point2pointipam.DualStack))
+
+	conn1, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConns(t, conn1, []string{"192.168.0.0/32", "fd00::/128"}, []string{"192.168.0.1/32", "fd00::1/128"})
+
+	// IPv6 is exhausted, so the request fails and the IPv4 addresses are not leaked
+	_, err = srv.Request(context.Background(), newRequest())
+	require.Error(t, err)
+
+	_, err = srv.Close(context.Background(), conn1)
+	require.NoError(t, err)
+
+	conn2, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConns(t, conn2, []string{"192.168.0.0/32", "fd00::/128"}, []string{"192.168.0.1/32", "fd00::1/128"})
+
+	req := newRequest()
+	req.Connection = conn2.Clone()
+	conn2, err = srv.Request(context.Background(), req)
+	require.NoError(t, err)
+	validateConns(t, conn2, []string{"192.168.0.0/32", "fd00::/128"}, []string{"192.168.0.1/32", "fd00::1/128"})
+}
+
+func TestServer_DualStackPairing(t *testing.T) {
+	_, ipNet1, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+	_, ipNet2, err := net.ParseCIDR("fd00::/64")
+	require.NoError(t, err)
+
+	srv := newIpamServerWithOptions([]*net.IPNet{ipNet1, ipNet2},
+		point2pointipam.WithMode(point2pointipam.DualStack),
+		point2pointipam.WithPairing(),
+	)
+
+	req := newRequest()
+	req.Connection.Context.IpContext.ExcludedPrefixes = []string{"10.0.0.0/30"}
+	conn1, err := srv.Request(context.Background(), req)
+	require.NoError(t, err)
+	validateConns(t, conn1, []string{"10.0.0.4/32", "fd00::4/128"}, []string{"10.0.0.5/32", "fd00::5/128"})
+
+	conn2, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConns(t, conn2, []string{"10.0.0.0/32", "fd00::/128"}, []string{"10.0.0.1/32", "fd00::1/128"})
+
+	// The paired IPv6 address is excluded
+	req = newRequest()
+	req.Connection.Context.IpContext.ExcludedPrefixes = []string{"fd00::2/128"}
+	_, err = srv.Request(context.Background(), req)
+	require.Error(t, err)
+
+	conn3, err := srv.Request(context.Background(), newRequest())
+	require.NoError(t, err)
+	validateConns(t, conn3, []string{"10.0.0.2/32", "fd00::2/128"}, []string{"10.0.0.3/32", "fd00::3/128"})
+}
+
+func TestServer_DualStackPairingNextPrefix(t *testing.T) {
+	_, ipNet1, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+	_, ipNet2, err := net.ParseCIDR("fd00::/64")
+	require.NoError(t, err)
+	_, ipNet3, err := net.ParseCIDR("fd01::/64")
+	require.NoError(t, err)
+
+	srv := newIpamServerWithOptions([]*net.IPNet{ipNet1, ipNet2, ipNet3},
+		point2pointipam.WithMode(point2pointipam.DualStack),
+		point2pointipam.WithPairing(),
+	)
+
+	// The paired addresses are excluded in the first IPv6 prefix
+	req := newRequest()
+	req.Connection.Context.IpContext.ExcludedPrefixes = []string{"fd00::/126"}
+	conn1, err := srv.Request(context.Background(), req)
+	require.NoError(t, err)
+	validateConns(t, conn1, []string{"10.0.0.0/32", "fd01::/128"}, []string{"10.0.0.1/32", "fd01::1/128"})
+
+	// The paired addresses are in use in the first IPv6 prefix
+	_, ipNet4, err := net.ParseCIDR("10.0.1.0/24")
+	require.NoError(t, err)
+
+	srv = newIpamServerWithOptions([]*net.IPNet{ipNet1, ipNet4, ipNet2, ipNet3},
+		point2pointipam.WithMode(point2pointipam.DualStack),
+		point2pointipam.WithPairing(),
+	)
+
+	req = newRequest()
+	req.Connection.Id = "id-1"
+	req.Connection.Context.IpContext.ExcludedPrefixes = []string{"10.0.0.0/24"}
+	conn2, err := srv.Request(context.Background(), req)
+	require.NoError(t, err)
+	validateConns(t, conn2, []string{"10.0.1.0/32", "fd00::/128"}, []string{"10.0.1.1/32", "fd00::1/128"})
+
+	req = newRequest()
+	req.Connection.Id = "id-2"
+	conn3, err := srv.Request(context.Background(), req)
+	require.NoError(t, err)
+	validateConns(t, conn3, []string{"10.0.0.0/32", "fd01::/128"}, []string{"10.0.0.1/32", "fd01::1/128"})
+}
+
+func TestServer_BindingStore(t *testing.T) {
+	_, ipNet, err := net.ParseCIDR("192.168.0.0/24")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ipam.json")
+	store, err := ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+
+	srv := newIpamServerWithOptions([]*net.IPNet{ipNet}, point2pointipam.WithBindingStore(store))
+
+	req := newRequest()
+	req.Connection.Id = "conn-1"
+	_, err = srv.Request(context.Background(), req.Clone())
+	require.NoError(t, err)
+
+	req.Connection.Id = "conn-2"
+	_, err = srv.Request(context.Background(), req.Clone())
+	require.NoError(t, err)
+
+	// NSE restart
+	store, err = ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+
+	srv = newIpamServerWithOptions([]*net.IPNet{ipNet}, point2pointipam.WithBindingStore(store))
+
+	req.Connection.Id = "conn-3"
+	conn3, err := srv.Request(context.Background(), req.Clone())
+	require.NoError(t, err)
+	validateConn(t, conn3, "192.168.0.4/32", "192.168.0.5/32")
+
+	req.Connection.Id = "conn-2"
+	conn2, err := srv.Request(context.Background(), req.Clone())
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+
+	_, err = srv.Close(context.Background(), conn2)
+	require.NoError(t, err)
+
+	_, ok := store.Load("conn-2")
+	require.False(t, ok)
+	_, ok = store.Load("conn-1")
+	require.True(t, ok)
+}
+
+func TestServer_BindingStoreRestoreGracePeriod(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	_, ipNet, err := net.ParseCIDR("192.168.0.0/24")
+	require.NoError(t, err)
+
+	store, err := ipbinding.NewStore()
+	require.NoError(t, err)
+	require.NoError(t, store.Store("conn-1", &ipbinding.Binding{SrcAddrs: []string{"192.168.0.1/32"}, DstAddrs: []string{"192.168.0.0/32"}}))
+	require.NoError(t, store.Store("conn-2", &ipbinding.Binding{SrcAddrs: []string{"192.168.0.3/32"}, DstAddrs: []string{"192.168.0.2/32"}}))
+
+	srv := newIpamServerWithOptions([]*net.IPNet{ipNet},
+		point2pointipam.WithBindingStore(store),
+		point2pointipam.WithRestoreGracePeriod(time.Minute),
+	)
+
+	// conn-2 reconnects during the grace period
+	req := newRequest()
+	req.Connection.Id = "conn-2"
+	conn2, err := srv.Request(ctx, req.Clone())
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+
+	clockMock.Add(time.Minute)
+
+	// conn-1 doesn't, so its addresses are released
+	require.Eventually(t, func() bool {
+		_, ok := store.Load("conn-1")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+	_, ok := store.Load("conn-2")
+	require.True(t, ok)
+
+	req.Connection.Id = "conn-3"
+	conn3, err := srv.Request(ctx, req.Clone())
+	require.NoError(t, err)
+	validateConn(t, conn3, "192.168.0.0/32", "192.168.0.1/32")
+
+	req.Connection.Id = "conn-2"
+	conn2, err = srv.Request(ctx, req.Clone())
+	require.NoError(t, err)
+	validateConn(t, conn2, "192.168.0.2/32", "192.168.0.3/32")
+}
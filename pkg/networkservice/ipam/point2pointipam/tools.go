@@ -17,6 +17,7 @@
 package point2pointipam
 
 import (
+	"math/big"
 	"net"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
@@ -31,3 +32,29 @@ func exclude(prefixes ...string) (ipv4exclude, ipv6exclude *ippool.IPPool) {
 	}
 	return
 }
+
+func isIPv4(prefix *net.IPNet) bool {
+	return prefix.IP.To4() != nil
+}
+
+func isIPv6(prefix *net.IPNet) bool {
+	return !isIPv4(prefix)
+}
+
+// offset returns the offset of the addr IP address in the prefix
+func offset(prefix *net.IPNet, addr string) *big.Int {
+	ip, _, _ := net.ParseCIDR(addr)
+	return new(big.Int).Sub(new(big.Int).SetBytes(ip.To16()), new(big.Int).SetBytes(prefix.IP.To16()))
+}
+
+// addOffset returns the IPv6 address having the offset in the prefix
+func addOffset(prefix *net.IPNet, offset *big.Int) net.IP {
+	sum := new(big.Int).Add(new(big.Int).SetBytes(prefix.IP.To16()), offset).Bytes()
+	if len(sum) > net.IPv6len {
+		return nil
+	}
+
+	ip := make(net.IP, net.IPv6len)
+	copy(ip[net.IPv6len-len(sum):], sum)
+	return ip
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyipam
+
+import (
+	"net"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+)
+
+// Pool is the IPAM pool configuration
+type Pool struct {
+	// Name is the unique pool name
+	Name string `json:"name"`
+	// Prefixes are the prefixes the addresses are allocated from, the prefixes may be disjoint
+	Prefixes []string `json:"prefixes"`
+	// Exclude are the prefixes never allocated from the pool
+	Exclude []string `json:"exclude,omitempty"`
+	// Selector is the connection labels selecting the pool, all of them should match. Empty selector matches all the
+	// connections.
+	Selector map[string]string `json:"selector,omitempty"`
+	// Fallback is the name of the pool used when the pool is exhausted
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// Config is the IPAM pools configuration
+type Config struct {
+	// Pools are the pools matched against the connection labels in order
+	Pools []*Pool `json:"pools"`
+}
+
+// Parse parses and validates the config in YAML or JSON format
+func Parse(bytes []byte) (*Config, error) {
+	c := new(Config)
+	if err := yaml.Unmarshal(bytes, c); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal IPAM config")
+	}
+	if _, err := newPools(c.Pools); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *Pool) matches(labels map[string]string) bool {
+	for k, v := range p.Selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+type pool struct {
+	*Pool
+	ipPools []*ippool.IPPool
+}
+
+// newPools validates the pools configuration and creates the address pools
+func newPools(configs []*Pool) (map[string]*pool, error) {
+	pools := make(map[string]*pool, len(configs))
+	for _, c := range configs {
+		if c == nil || c.Name == "" {
+			return nil, errors.New("pool name is required")
+		}
+		if _, ok := pools[c.Name]; ok {
+			return nil, errors.Errorf("duplicate pool %s", c.Name)
+		}
+		p, err := newPool(c)
+		if err != nil {
+			return nil, err
+		}
+		pools[c.Name] = p
+	}
+
+	for _, p := range pools {
+		visited := map[string]struct{}{p.Name: {}}
+		for name := p.Fallback; name != ""; name = pools[name].Fallback {
+			if _, ok := pools[name]; !ok {
+				return nil, errors.Errorf("pool %s: unknown fallback pool %s", p.Name, name)
+			}
+			if _, ok := visited[name]; ok {
+				return nil, errors.Errorf("pool %s: fallback cycle", p.Name)
+			}
+			visited[name] = struct{}{}
+		}
+	}
+
+	return pools, nil
+}
+
+// newPool validates the pool prefixes and creates the address pools for them
+func newPool(c *Pool) (*pool, error) {
+	if len(c.Prefixes) == 0 {
+		return nil, errors.Errorf("pool %s: required one or more prefixes", c.Name)
+	}
+
+	var excluded []*net.IPNet
+	for _, prefix := range c.Exclude {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pool %s: invalid excluded prefix", c.Name)
+		}
+		excluded = append(excluded, ipNet)
+	}
+
+	var ipPools []*ippool.IPPool
+	for _, prefix := range c.Prefixes {
+		_, ipNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pool %s: invalid prefix", c.Name)
+		}
+		ipPool := ippool.NewWithNet(ipNet)
+		for _, e := range excluded {
+			if (e.IP.To4() == nil) == (ipNet.IP.To4() == nil) {
+				ipPool.Exclude(e)
+			}
+		}
+		ipPools = append(ipPools, ipPool)
+	}
+
+	return &pool{
+		Pool:    c,
+		ipPools: ipPools,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyipam
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
+)
+
+type options struct {
+	pools              []*Pool
+	configPath         string
+	store              ipbinding.Store
+	restoreGracePeriod time.Duration
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithPools sets the pools matched against the connection labels in order
+func WithPools(pools ...*Pool) Option {
+	return func(o *options) {
+		o.pools = pools
+	}
+}
+
+// WithConfigPath sets the path of the config file in the Config format, e.g. mounted from the config map. The pools
+// from the file replace the pools set by WithPools once the file is read.
+func WithConfigPath(configPath string) Option {
+	return func(o *options) {
+		o.configPath = configPath
+	}
+}
+
+// WithBindingStore sets the store of the connection addresses. The bindings are restored from the store on creation,
+// so the client reconnecting after the NSE restart with the same connection ID gets the same addresses. The binding is
+// deleted on Close.
+func WithBindingStore(store ipbinding.Store) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+// WithRestoreGracePeriod sets how long the restored binding is kept for the connection to be requested again, the
+// addresses are released if it is not. Default is ipbinding.DefaultRestoreGracePeriod.
+func WithRestoreGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.restoreGracePeriod = gracePeriod
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policyipam provides a p2p IPAM server chain element allocating the addresses from the multiple pools
+// selected by the connection labels, with the per-pool excluded prefixes and the fallback pools used on exhaustion.
+package policyipam
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
+	"github.com/networkservicemesh/sdk/pkg/tools/ippool"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+type connectionInfo struct {
+	pool    *pool
+	ipPool  *ippool.IPPool
+	srcAddr string
+	dstAddr string
+}
+
+func (i *connectionInfo) shouldUpdate(exclude *ippool.IPPool) bool {
+	srcIP, _, srcErr := net.ParseCIDR(i.srcAddr)
+	dstIP, _, dstErr := net.ParseCIDR(i.dstAddr)
+
+	return srcErr == nil && exclude.ContainsString(srcIP.String()) || dstErr == nil && exclude.ContainsString(dstIP.String())
+}
+
+type policyIPAMServer struct {
+	mu    sync.Mutex
+	order []*pool
+	pools map[string]*pool
+	conns map[string]*connectionInfo
+	store ipbinding.Store
+	// restored are the expiration timers of the restored bindings not requested yet
+	restored map[string]clock.Timer
+}
+
+// NewServer - creates a new NetworkServiceServer chain element allocating the p2p addresses from the first pool
+// matching the connection labels, or from its fallback pools if the pool is exhausted. If the config file is set by
+// WithConfigPath, the pools are reloaded on the file changes, the allocated addresses still belonging to the reloaded
+// pools are kept.
+func NewServer(ctx context.Context, opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		restoreGracePeriod: ipbinding.DefaultRestoreGracePeriod,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &policyIPAMServer{
+		pools:    make(map[string]*pool),
+		conns:    make(map[string]*connectionInfo),
+		store:    o.store,
+		restored: make(map[string]clock.Timer),
+	}
+	if err := s.setPools(o.pools); err != nil {
+		log.FromContext(ctx).Errorf("invalid IPAM pools: %v", err.Error())
+	}
+
+	var updateCh <-chan []byte
+	if o.configPath != "" {
+		updateCh = fs.WatchFile(ctx, o.configPath)
+		s.update(ctx, <-updateCh)
+	}
+
+	if s.store != nil {
+		s.restore(ctx, o.restoreGracePeriod)
+	}
+
+	if updateCh != nil {
+		go func() {
+			for bytes := range updateCh {
+				s.update(ctx, bytes)
+			}
+		}()
+	}
+
+	return s
+}
+
+// restore reserves the addresses stored in the binding store, the bindings not fitting the pools are deleted. The
+// restored bindings not requested during the grace period are released.
+func (s *policyIPAMServer) restore(ctx context.Context, gracePeriod time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clockTime := clock.FromContext(ctx)
+	var invalid []string
+	s.store.Range(func(id string, binding *ipbinding.Binding) bool {
+		if len(binding.SrcAddrs) == 0 || len(binding.DstAddrs) == 0 {
+			invalid = append(invalid, id)
+			return true
+		}
+		if connInfo := s.reserveAddrs(binding.SrcAddrs[0], binding.DstAddrs[0]); connInfo != nil {
+			s.conns[id] = connInfo
+			s.restored[id] = clockTime.AfterFunc(gracePeriod, func() {
+				s.expireRestored(id)
+			})
+		} else {
+			invalid = append(invalid, id)
+		}
+		return true
+	})
+	for _, id := range invalid {
+		log.FromContext(ctx).Warnf("IP binding of %s doesn't fit the IPAM pools", id)
+		_ = s.store.Delete(id)
+	}
+}
+
+// expireRestored releases the restored binding if it has not been requested yet
+func (s *policyIPAMServer) expireRestored(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.restored[id]; !ok {
+		return
+	}
+	delete(s.restored, id)
+	if connInfo, ok := s.conns[id]; ok {
+		delete(s.conns, id)
+		free(connInfo)
+		s.deleteBinding(id)
+	}
+}
+
+// claimRestored stops the expiration of the restored binding. Must be called under s.mu.
+func (s *policyIPAMServer) claimRestored(id string) {
+	if timer, ok := s.restored[id]; ok {
+		timer.Stop()
+		delete(s.restored, id)
+	}
+}
+
+func (s *policyIPAMServer) reserveAddrs(srcAddr, dstAddr string) *connectionInfo {
+	srcIP, _, srcErr := net.ParseCIDR(srcAddr)
+	dstIP, _, dstErr := net.ParseCIDR(dstAddr)
+	if srcErr != nil || dstErr != nil {
+		return nil
+	}
+	if srcIP.To4() != nil {
+		srcIP, dstIP = srcIP.To4(), dstIP.To4()
+	}
+
+	for _, p := range s.order {
+		for _, ipPool := range p.ipPools {
+			if !ipPool.Contains(srcIP) || !ipPool.Contains(dstIP) {
+				continue
+			}
+			if ipPool.PullIP(dstIP) != nil {
+				return nil
+			}
+			if ipPool.PullIP(srcIP) != nil {
+				ipPool.Add(dstIP)
+				return nil
+			}
+			return &connectionInfo{
+				pool:    p,
+				ipPool:  ipPool,
+				srcAddr: srcAddr,
+				dstAddr: dstAddr,
+			}
+		}
+	}
+	return nil
+}
+
+func (s *policyIPAMServer) update(ctx context.Context, bytes []byte) {
+	// Missing or being written file keeps the current pools
+	if len(bytes) == 0 {
+		return
+	}
+	c, err := Parse(bytes)
+	if err == nil {
+		err = s.setPools(c.Pools)
+	}
+	if err != nil {
+		log.FromContext(ctx).Errorf("invalid IPAM config: %v", err.Error())
+	}
+}
+
+func (s *policyIPAMServer) setPools(configs []*Pool) error {
+	pools, err := newPools(configs)
+	if err != nil {
+		return err
+	}
+
+	order := make([]*pool, 0, len(configs))
+	for _, c := range configs {
+		order = append(order, pools[c.Name])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order = order
+	s.pools = pools
+	for _, connInfo := range s.conns {
+		s.reserve(connInfo)
+	}
+	return nil
+}
+
+// reserve moves the allocated addresses to the current pool with the same name if they are still free there
+func (s *policyIPAMServer) reserve(connInfo *connectionInfo) {
+	p, ok := s.pools[connInfo.pool.Name]
+	if !ok || p == connInfo.pool {
+		return
+	}
+	srcIP, _, _ := net.ParseCIDR(connInfo.srcAddr)
+	dstIP, _, _ := net.ParseCIDR(connInfo.dstAddr)
+	for _, ipPool := range p.ipPools {
+		if ipPool.Contains(srcIP) && ipPool.Contains(dstIP) {
+			ipPool.ExcludeString(connInfo.srcAddr)
+			ipPool.ExcludeString(connInfo.dstAddr)
+			connInfo.pool = p
+			connInfo.ipPool = ipPool
+			return
+		}
+	}
+}
+
+func (s *policyIPAMServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn := request.GetConnection()
+	if conn.GetContext() == nil {
+		conn.Context = &networkservice.ConnectionContext{}
+	}
+	if conn.GetContext().GetIpContext() == nil {
+		conn.GetContext().IpContext = &networkservice.IPContext{}
+	}
+	ipContext := conn.GetContext().GetIpContext()
+
+	excludeIP4, excludeIP6 := exclude(ipContext.GetExcludedPrefixes()...)
+
+	s.mu.Lock()
+	s.claimRestored(conn.GetId())
+	connInfo, loaded := s.conns[conn.GetId()]
+	if loaded && (s.isStale(connInfo) || connInfo.shouldUpdate(excludeIP4) || connInfo.shouldUpdate(excludeIP6)) {
+		// The pool is removed or changed, or some of the existing addresses are excluded
+		deleteAddr(&ipContext.SrcIpAddrs, connInfo.srcAddr)
+		deleteAddr(&ipContext.DstIpAddrs, connInfo.dstAddr)
+		deleteRoute(&ipContext.SrcRoutes, connInfo.dstAddr)
+		deleteRoute(&ipContext.DstRoutes, connInfo.srcAddr)
+		free(connInfo)
+		delete(s.conns, conn.GetId())
+		s.deleteBinding(conn.GetId())
+		loaded = false
+	}
+	if !loaded {
+		var err error
+		if connInfo, err = s.allocate(conn.GetLabels(), excludeIP4, excludeIP6); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		if err = s.storeBinding(conn.GetId(), connInfo); err != nil {
+			free(connInfo)
+			s.mu.Unlock()
+			return nil, err
+		}
+		s.conns[conn.GetId()] = connInfo
+	}
+	s.mu.Unlock()
+
+	addAddr(&ipContext.SrcIpAddrs, connInfo.srcAddr)
+	addRoute(&ipContext.SrcRoutes, connInfo.dstAddr)
+
+	addAddr(&ipContext.DstIpAddrs, connInfo.dstAddr)
+	addRoute(&ipContext.DstRoutes, connInfo.srcAddr)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		if !loaded {
+			s.release(request.GetConnection().GetId(), connInfo)
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *policyIPAMServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mu.Lock()
+	s.claimRestored(conn.GetId())
+	connInfo, ok := s.conns[conn.GetId()]
+	s.mu.Unlock()
+
+	if ok {
+		s.release(conn.GetId(), connInfo)
+	}
+
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+func (s *policyIPAMServer) release(id string, connInfo *connectionInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conns[id] == connInfo {
+		delete(s.conns, id)
+		free(connInfo)
+		s.deleteBinding(id)
+	}
+}
+
+func (s *policyIPAMServer) storeBinding(id string, connInfo *connectionInfo) error {
+	if s.store == nil {
+		return nil
+	}
+	return errors.Wrap(s.store.Store(id, &ipbinding.Binding{
+		SrcAddrs: []string{connInfo.srcAddr},
+		DstAddrs: []string{connInfo.dstAddr},
+	}), "failed to store IP binding")
+}
+
+func (s *policyIPAMServer) deleteBinding(id string) {
+	if s.store != nil {
+		_ = s.store.Delete(id)
+	}
+}
+
+// isStale returns true if the connection pool is not the current one after the reload
+func (s *policyIPAMServer) isStale(connInfo *connectionInfo) bool {
+	return s.pools[connInfo.pool.Name] != connInfo.pool
+}
+
+// allocate allocates the addresses from the first pool matching the labels or from its fallback pools
+func (s *policyIPAMServer) allocate(labels map[string]string, excludeIP4, excludeIP6 *ippool.IPPool) (*connectionInfo, error) {
+	var selected *pool
+	for _, p := range s.order {
+		if p.matches(labels) {
+			selected = p
+			break
+		}
+	}
+	if selected == nil {
+		return nil, errors.Errorf("no IPAM pool matches the connection labels: %v", labels)
+	}
+
+	var tried []string
+	for p := selected; p != nil; p = s.pools[p.Fallback] {
+		for _, ipPool := range p.ipPools {
+			dstAddr, srcAddr, err := ipPool.PullP2PAddrs(excludeIP4, excludeIP6)
+			if err == nil {
+				return &connectionInfo{
+					pool:    p,
+					ipPool:  ipPool,
+					srcAddr: srcAddr.String(),
+					dstAddr: dstAddr.String(),
+				}, nil
+			}
+		}
+		tried = append(tried, p.Name)
+	}
+	return nil, nsmerrors.Errorf(nsmerrors.IPAMExhausted, "IPAM pools are exhausted: %v", tried)
+}
+
+func free(connInfo *connectionInfo) {
+	connInfo.ipPool.AddNetString(connInfo.srcAddr)
+	connInfo.ipPool.AddNetString(connInfo.dstAddr)
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyipam_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/ipam/policyipam"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+func newRequest(id string, labels map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     id,
+			Labels: labels,
+		},
+	}
+}
+
+func requireWithin(t *testing.T, conn *networkservice.Connection, prefix string) {
+	_, ipNet, err := net.ParseCIDR(prefix)
+	require.NoError(t, err)
+	for _, addr := range append(conn.GetContext().GetIpContext().GetSrcIpAddrs(), conn.GetContext().GetIpContext().GetDstIpAddrs()...) {
+		ip, _, err := net.ParseCIDR(addr)
+		require.NoError(t, err)
+		require.True(t, ipNet.Contains(ip), "%s is not within %s", addr, prefix)
+	}
+}
+
+func TestPolicyIPAM_Selection(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := policyipam.NewServer(ctx, policyipam.WithPools(
+		&policyipam.Pool{
+			Name:     "tenant-a",
+			Prefixes: []string{"10.1.0.0/30", "10.2.0.0/30"},
+			Exclude:  []string{"10.1.0.0/31"},
+			Selector: map[string]string{"tenant": "a"},
+			Fallback: "shared",
+		},
+		&policyipam.Pool{
+			Name:     "service-b",
+			Prefixes: []string{"10.3.0.0/31"},
+			Selector: map[string]string{"service": "b"},
+		},
+		&policyipam.Pool{
+			Name:     "shared",
+			Prefixes: []string{"10.0.0.0/24"},
+		},
+	))
+
+	// The first prefix has 2 addresses left after the exclusion
+	conn, err := server.Request(ctx, newRequest("a-1", map[string]string{"tenant": "a"}))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.1.0.2/31")
+
+	conn, err = server.Request(ctx, newRequest("a-2", map[string]string{"tenant": "a"}))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.2.0.0/30")
+
+	conn, err = server.Request(ctx, newRequest("a-3", map[string]string{"tenant": "a"}))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.2.0.0/30")
+
+	// Exhausted tenant-a falls back to shared
+	conn, err = server.Request(ctx, newRequest("a-4", map[string]string{"tenant": "a"}))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.0.0.0/24")
+
+	conn, err = server.Request(ctx, newRequest("b-1", map[string]string{"service": "b"}))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.3.0.0/31")
+
+	// service-b has no fallback
+	_, err = server.Request(ctx, newRequest("b-2", map[string]string{"service": "b"}))
+	require.True(t, nsmerrors.Is(err, nsmerrors.IPAMExhausted))
+
+	// Closed connection addresses are reused
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+	conn, err = server.Request(ctx, newRequest("b-2", map[string]string{"service": "b"}))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.3.0.0/31")
+
+	conn, err = server.Request(ctx, newRequest("other", nil))
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.0.0.0/24")
+}
+
+func TestPolicyIPAM_NoMatchingPool(t *testing.T) {
+	server := policyipam.NewServer(context.Background(), policyipam.WithPools(&policyipam.Pool{
+		Name:     "tenant-a",
+		Prefixes: []string{"10.1.0.0/24"},
+		Selector: map[string]string{"tenant": "a"},
+	}))
+
+	_, err := server.Request(context.Background(), newRequest("id", map[string]string{"tenant": "b"}))
+	require.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	_, err := policyipam.Parse([]byte(`
+pools:
+  - name: a
+    prefixes: [10.1.0.0/24, "fe80::/64"]
+    exclude: [10.1.0.0/28]
+    fallback: b
+  - name: b
+    prefixes: [10.2.0.0/24]
+`))
+	require.NoError(t, err)
+
+	for _, invalid := range []string{
+		`pools: [{name: a}]`,
+		`pools: [{prefixes: [10.1.0.0/24]}]`,
+		`pools: [{name: a, prefixes: [invalid]}]`,
+		`pools: [{name: a, prefixes: [10.1.0.0/24], exclude: [invalid]}]`,
+		`pools: [{name: a, prefixes: [10.1.0.0/24], fallback: b}]`,
+		`pools: [{name: a, prefixes: [10.1.0.0/24], fallback: b}, {name: b, prefixes: [10.2.0.0/24], fallback: a}]`,
+		`pools: [{name: a, prefixes: [10.1.0.0/24]}, {name: a, prefixes: [10.2.0.0/24]}]`,
+	} {
+		_, err = policyipam.Parse([]byte(invalid))
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestPolicyIPAM_Reload(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configPath := filepath.Join(t.TempDir(), "ipam.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+pools:
+  - name: shared
+    prefixes: [10.0.0.0/24]
+`), os.ModePerm))
+
+	server := policyipam.NewServer(ctx, policyipam.WithConfigPath(configPath))
+
+	request := newRequest("id-1", nil)
+	conn, err := server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	requireWithin(t, conn, "10.0.0.0/24")
+
+	// The pool is extended, the allocated addresses are kept
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+pools:
+  - name: shared
+    prefixes: [10.0.0.0/24, 10.10.0.0/24]
+  - name: unused
+    prefixes: [10.20.0.0/24]
+    selector: {tenant: unused}
+`), os.ModePerm))
+	require.Eventually(t, func() bool {
+		_, reqErr := server.Request(ctx, newRequest("probe", map[string]string{"tenant": "unused"}))
+		return reqErr == nil
+	}, time.Second, 10*time.Millisecond)
+
+	request.Connection = conn.Clone()
+	refreshed, err := server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Equal(t, conn.GetContext().GetIpContext().GetSrcIpAddrs(), refreshed.GetContext().GetIpContext().GetSrcIpAddrs())
+
+	// The addresses are not reallocated to the other connections
+	other, err := server.Request(ctx, newRequest("id-2", nil))
+	require.NoError(t, err)
+	require.NotEqual(t, conn.GetContext().GetIpContext().GetSrcIpAddrs(), other.GetContext().GetIpContext().GetSrcIpAddrs())
+	require.NotEqual(t, conn.GetContext().GetIpContext().GetDstIpAddrs(), other.GetContext().GetIpContext().GetSrcIpAddrs())
+
+	// The pool prefix is changed, the addresses are reallocated on refresh
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+pools:
+  - name: shared
+    prefixes: [10.30.0.0/24]
+`), os.ModePerm))
+	require.Eventually(t, func() bool {
+		probe, reqErr := server.Request(ctx, newRequest("probe", nil))
+		return reqErr == nil && strings.HasPrefix(probe.GetContext().GetIpContext().GetSrcIpAddrs()[0], "10.30.")
+	}, time.Second, 10*time.Millisecond)
+
+	request.Connection = refreshed.Clone()
+	refreshed, err = server.Request(ctx, request.Clone())
+	require.NoError(t, err)
+	require.Len(t, refreshed.GetContext().GetIpContext().GetSrcIpAddrs(), 1)
+	requireWithin(t, refreshed, "10.30.0.0/24")
+}
+
+func TestPolicyIPAM_BindingStore(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pools := policyipam.WithPools(&policyipam.Pool{
+		Name:     "shared",
+		Prefixes: []string{"10.0.0.0/24"},
+	})
+
+	path := filepath.Join(t.TempDir(), "ipam.json")
+	store, err := ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+
+	server := policyipam.NewServer(ctx, pools, policyipam.WithBindingStore(store))
+
+	conn1, err := server.Request(ctx, newRequest("conn-1", nil))
+	require.NoError(t, err)
+
+	// NSE restart
+	store, err = ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+
+	server = policyipam.NewServer(ctx, pools, policyipam.WithBindingStore(store))
+
+	conn2, err := server.Request(ctx, newRequest("conn-2", nil))
+	require.NoError(t, err)
+	require.NotEqual(t, conn1.GetContext().GetIpContext().GetSrcIpAddrs(), conn2.GetContext().GetIpContext().GetSrcIpAddrs())
+
+	conn, err := server.Request(ctx, newRequest("conn-1", nil))
+	require.NoError(t, err)
+	require.Equal(t, conn1.GetContext().GetIpContext(), conn.GetContext().GetIpContext())
+
+	_, err = server.Close(ctx, conn)
+	require.NoError(t, err)
+
+	_, ok := store.Load("conn-1")
+	require.False(t, ok)
+}
+
+func TestPolicyIPAM_BindingStoreRestoreGracePeriod(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	store, err := ipbinding.NewStore()
+	require.NoError(t, err)
+	require.NoError(t, store.Store("conn-1", &ipbinding.Binding{SrcAddrs: []string{"10.0.0.1/32"}, DstAddrs: []string{"10.0.0.0/32"}}))
+
+	server := policyipam.NewServer(ctx,
+		policyipam.WithPools(&policyipam.Pool{
+			Name:     "shared",
+			Prefixes: []string{"10.0.0.0/31"},
+		}),
+		policyipam.WithBindingStore(store),
+		policyipam.WithRestoreGracePeriod(time.Minute),
+	)
+
+	_, err = server.Request(ctx, newRequest("conn-2", nil))
+	require.Error(t, err)
+
+	clockMock.Add(time.Minute)
+
+	require.Eventually(t, func() bool {
+		_, ok := store.Load("conn-1")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	conn, err := server.Request(ctx, newRequest("conn-2", nil))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1/32"}, conn.GetContext().GetIpContext().GetSrcIpAddrs())
+}
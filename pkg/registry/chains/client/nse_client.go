@@ -22,6 +22,7 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/common/batchrefresh"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/connect"
@@ -42,13 +43,22 @@ func NewNetworkServiceEndpointRegistryClient(ctx context.Context, opts ...Option
 		opt(clientOpts)
 	}
 
+	var refreshOpts []refresh.Option
+	if clientOpts.refreshWatch {
+		refreshOpts = append(refreshOpts, refresh.WithWatch())
+	}
+	var refresher = refresh.NewNetworkServiceEndpointRegistryClient(ctx, refreshOpts...)
+	if clientOpts.batchRefresh {
+		refresher = batchrefresh.NewNetworkServiceEndpointRegistryClient(ctx, clientOpts.batchRefreshOptions...)
+	}
+
 	return chain.NewNetworkServiceEndpointRegistryClient(
 		append(
 			[]registry.NetworkServiceEndpointRegistryClient{
 				begin.NewNetworkServiceEndpointRegistryClient(),
 				retry.NewNetworkServiceEndpointRegistryClient(ctx),
 				heal.NewNetworkServiceEndpointRegistryClient(ctx),
-				refresh.NewNetworkServiceEndpointRegistryClient(ctx),
+				refresher,
 				clientOpts.nseClientURLResolver,
 				clientconn.NewNetworkServiceEndpointRegistryClient(),
 				dial.NewNetworkServiceEndpointRegistryClient(ctx,
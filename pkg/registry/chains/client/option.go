@@ -23,6 +23,7 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
+	"github.com/networkservicemesh/sdk/pkg/registry/common/batchrefresh"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
 )
 
@@ -72,10 +73,30 @@ func WithDialOptions(dialOptions ...grpc.DialOption) Option {
 	}
 }
 
+// WithBatchRefresh makes NSE client refresh all registered NSEs in batches driven by one timer instead of refreshing
+// each of them independently. It should be used by the processes hosting many NSEs.
+func WithBatchRefresh(opts ...batchrefresh.Option) Option {
+	return func(clientOpts *clientOptions) {
+		clientOpts.batchRefresh = true
+		clientOpts.batchRefreshOptions = opts
+	}
+}
+
+// WithRefreshWatch makes NSE client watch the registered NSEs in the registry and refresh them immediately if the
+// registry deletes them or shortens their expiration time
+func WithRefreshWatch() Option {
+	return func(clientOpts *clientOptions) {
+		clientOpts.refreshWatch = true
+	}
+}
+
 type clientOptions struct {
 	nsClientURLResolver        registry.NetworkServiceRegistryClient
 	nseClientURLResolver       registry.NetworkServiceEndpointRegistryClient
 	nsAdditionalFunctionality  []registry.NetworkServiceRegistryClient
 	nseAdditionalFunctionality []registry.NetworkServiceEndpointRegistryClient
 	dialOptions                []grpc.DialOption
+	batchRefresh               bool
+	batchRefreshOptions        []batchrefresh.Option
+	refreshWatch               bool
 }
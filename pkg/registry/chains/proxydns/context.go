@@ -0,0 +1,32 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxydns
+
+import "context"
+
+type domainRewritesKey struct{}
+
+// WithDomainRewrites returns ctx with the rules rewriting the external domain suffixes to the internal ones, see
+// dnsresolve.WithDomainRewrites. The rules are used by the server created with ctx.
+func WithDomainRewrites(ctx context.Context, rules map[string]string) context.Context {
+	return context.WithValue(ctx, domainRewritesKey{}, rules)
+}
+
+func domainRewrites(ctx context.Context) map[string]string {
+	rules, _ := ctx.Value(domainRewritesKey{}).(map[string]string)
+	return rules
+}
@@ -29,13 +29,22 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/common/dial"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/proxyevents"
 )
 
-// NewServer creates new stateless registry server that proxies queries to the second registries by DNS domains
+// NewServer creates new stateless registry server that proxies queries to the second registries by DNS domains.
+// The proxy events are emitted to the proxyevents sink from ctx.
+// The dials to the remote registries are limited by the diallimit limiter from ctx, if any.
+// Wrap dnsResolver with dnsresolve.NewCachingResolver to cache the interdomain lookups.
+// The domains are rewritten by the WithDomainRewrites rules from ctx, if any.
 func NewServer(ctx context.Context, dnsResolver dnsresolve.Resolver, dialOptions ...grpc.DialOption) registry.Registry {
 	nseChain := chain.NewNetworkServiceEndpointRegistryServer(
 		begin.NewNetworkServiceEndpointRegistryServer(),
-		dnsresolve.NewNetworkServiceEndpointRegistryServer(dnsresolve.WithResolver(dnsResolver)),
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(
+			dnsresolve.WithResolver(dnsResolver),
+			dnsresolve.WithEventSink(proxyevents.FromContext(ctx)),
+			dnsresolve.WithDomainRewrites(domainRewrites(ctx)),
+		),
 		connect.NewNetworkServiceEndpointRegistryServer(
 			chain.NewNetworkServiceEndpointRegistryClient(
 				clientconn.NewNetworkServiceEndpointRegistryClient(),
@@ -47,7 +56,11 @@ func NewServer(ctx context.Context, dnsResolver dnsresolve.Resolver, dialOptions
 		))
 	nsChain := chain.NewNetworkServiceRegistryServer(
 		begin.NewNetworkServiceRegistryServer(),
-		dnsresolve.NewNetworkServiceRegistryServer(dnsresolve.WithResolver(dnsResolver)),
+		dnsresolve.NewNetworkServiceRegistryServer(
+			dnsresolve.WithResolver(dnsResolver),
+			dnsresolve.WithEventSink(proxyevents.FromContext(ctx)),
+			dnsresolve.WithDomainRewrites(domainRewrites(ctx)),
+		),
 		connect.NewNetworkServiceRegistryServer(
 			chain.NewNetworkServiceRegistryClient(
 				clientconn.NewNetworkServiceRegistryClient(),
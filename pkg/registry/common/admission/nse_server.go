@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission provides a chain element limiting the number of in-flight Registers, Unregister is never queued
+// behind the waiting Registers so resources are released promptly under the overload.
+package admission
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/admission"
+)
+
+type admissionNSEServer struct {
+	limiter *admission.Limiter
+}
+
+// NewNetworkServiceEndpointRegistryServer - returns a new admission NSE server chain element. It should be placed
+// before begin, so the Registers waiting for admission don't hold the begin executor.
+// The limiter could be shared with other chain elements to admit their operations together.
+func NewNetworkServiceEndpointRegistryServer(limiter *admission.Limiter) registry.NetworkServiceEndpointRegistryServer {
+	return &admissionNSEServer{
+		limiter: limiter,
+	}
+}
+
+func (s *admissionNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	release, err := s.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *admissionNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *admissionNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	defer s.limiter.AcquirePriority()()
+
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batchrefresh provides a registry client refreshing all NSEs registered through it in batches driven by one
+// timer, instead of N independent timers and refresh RPCs. It is intended for the processes hosting many NSEs (e.g.
+// composition gateways): the refreshes of one batch are sent over the same connection in parallel.
+package batchrefresh
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultWindow      = 10 * time.Second
+	defaultMaxParallel = 16
+)
+
+type entry struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	factory   begin.EventFactory
+	refreshAt time.Time
+}
+
+type batchRefreshNSEClient struct {
+	ctx         context.Context
+	window      time.Duration
+	maxParallel int
+
+	entries map[string]*entry
+	wakeCh  chan struct{}
+	mu      sync.Mutex
+}
+
+// NewNetworkServiceEndpointRegistryClient creates new NetworkServiceEndpointRegistryClient that will refresh
+// expiration time for registered NSEs in batches. It should be used instead of the refresh chain element.
+func NewNetworkServiceEndpointRegistryClient(ctx context.Context, opts ...Option) registry.NetworkServiceEndpointRegistryClient {
+	c := &batchRefreshNSEClient{
+		ctx:         ctx,
+		window:      defaultWindow,
+		maxParallel: defaultMaxParallel,
+		entries:     make(map[string]*entry),
+		wakeCh:      make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	go c.run()
+
+	return c
+}
+
+func (c *batchRefreshNSEClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	var factory = begin.FromContext(ctx)
+
+	resp, err := next.NetworkServiceEndpointRegistryClient(ctx).Register(ctx, nse, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[nse.GetName()]
+	if !ok {
+		e = new(entry)
+		e.ctx, e.cancel = context.WithCancel(c.ctx)
+		c.entries[nse.GetName()] = e
+	}
+	e.factory = factory
+	e.refreshAt = time.Time{}
+
+	if resp.GetExpirationTime() != nil {
+		var clockTime = clock.FromContext(ctx)
+		e.refreshAt = clockTime.Now().Add(2 * clockTime.Until(resp.GetExpirationTime().AsTime().Local()) / 3)
+		c.wake()
+	}
+
+	return resp, nil
+}
+
+func (c *batchRefreshNSEClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, query, opts...)
+}
+
+func (c *batchRefreshNSEClient) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[nse.GetName()]; ok {
+		e.cancel()
+		delete(c.entries, nse.GetName())
+	}
+	c.mu.Unlock()
+
+	return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, nse, opts...)
+}
+
+func (c *batchRefreshNSEClient) wake() {
+	select {
+	case c.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// run is the only timer driving the refreshes of all NSEs
+func (c *batchRefreshNSEClient) run() {
+	var clockTime = clock.FromContext(c.ctx)
+	for {
+		var timer clock.Timer
+		var timerCh <-chan time.Time
+		if refreshAt, ok := c.nextRefresh(); ok {
+			timer = clockTime.Timer(clockTime.Until(refreshAt))
+			timerCh = timer.C()
+		}
+
+		select {
+		case <-c.ctx.Done():
+		case <-c.wakeCh:
+		case <-timerCh:
+			c.refresh(c.takeBatch(clockTime.Now()))
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if c.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (c *batchRefreshNSEClient) nextRefresh() (refreshAt time.Time, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.entries {
+		if e.refreshAt.IsZero() {
+			continue
+		}
+		if !ok || e.refreshAt.Before(refreshAt) {
+			refreshAt, ok = e.refreshAt, true
+		}
+	}
+	return refreshAt, ok
+}
+
+func (c *batchRefreshNSEClient) takeBatch(now time.Time) (batch []*entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadline := now.Add(c.window)
+	for _, e := range c.entries {
+		if e.refreshAt.IsZero() || e.refreshAt.After(deadline) {
+			continue
+		}
+		// Refreshed Register schedules the next refresh
+		e.refreshAt = time.Time{}
+		batch = append(batch, e)
+	}
+	return batch
+}
+
+func (c *batchRefreshNSEClient) refresh(batch []*entry) {
+	var wg sync.WaitGroup
+	var sem = make(chan struct{}, c.maxParallel)
+	for _, e := range batch {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(e *entry) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			<-e.factory.Register(begin.CancelContext(e.ctx))
+		}(e)
+	}
+	wg.Wait()
+}
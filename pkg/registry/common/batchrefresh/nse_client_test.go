@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchrefresh_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/batchrefresh"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/null"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+const (
+	testWait = 100 * time.Millisecond
+	testTick = testWait / 100
+)
+
+type expireNSEClient struct {
+	registry.NetworkServiceEndpointRegistryClient
+	clock     clock.Clock
+	lifetimes map[string]time.Duration
+	counts    map[string]int
+	mu        sync.Mutex
+}
+
+func (c *expireNSEClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts[nse.GetName()]++
+	resp := nse.Clone()
+	resp.ExpirationTime = timestamppb.New(c.clock.Now().Add(c.lifetimes[nse.GetName()]))
+	return resp, nil
+}
+
+func (c *expireNSEClient) count(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[name]
+}
+
+func TestBatchRefreshNSEClient(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	expireClient := &expireNSEClient{
+		NetworkServiceEndpointRegistryClient: null.NewNetworkServiceEndpointRegistryClient(),
+		clock:                                clockMock,
+		lifetimes: map[string]time.Duration{
+			"nse-1": 3 * time.Minute,
+			"nse-2": 3*time.Minute + 5*time.Second,
+			"nse-3": 10 * time.Minute,
+		},
+		counts: make(map[string]int),
+	}
+	client := next.NewNetworkServiceEndpointRegistryClient(
+		begin.NewNetworkServiceEndpointRegistryClient(),
+		batchrefresh.NewNetworkServiceEndpointRegistryClient(ctx, batchrefresh.WithBatchWindow(10*time.Second)),
+		expireClient,
+	)
+
+	var regs []*registry.NetworkServiceEndpoint
+	for _, name := range []string{"nse-1", "nse-2", "nse-3"} {
+		reg, err := client.Register(ctx, &registry.NetworkServiceEndpoint{Name: name})
+		require.NoError(t, err)
+		regs = append(regs, reg)
+	}
+
+	// nse-2 is due in 2m3s, but it is refreshed in the same batch with nse-1
+	clockMock.Add(2 * time.Minute)
+	require.Eventually(t, func() bool {
+		return expireClient.count("nse-1") == 2 && expireClient.count("nse-2") == 2
+	}, testWait, testTick)
+	require.Equal(t, 1, expireClient.count("nse-3"))
+
+	for _, reg := range regs {
+		_, err := client.Unregister(ctx, reg)
+		require.NoError(t, err)
+	}
+
+	clockMock.Add(10 * time.Minute)
+	require.Never(t, func() bool {
+		return expireClient.count("nse-1") > 2 || expireClient.count("nse-3") > 1
+	}, testWait, testTick)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchrefresh
+
+import "time"
+
+// Option is an option pattern for NewNetworkServiceEndpointRegistryClient
+type Option func(c *batchRefreshNSEClient)
+
+// WithBatchWindow sets the window for NSEs refreshed in one batch: NSEs expiring within the window after the first
+// due NSE are refreshed together with it, a bit earlier than needed. Default is 10 seconds.
+func WithBatchWindow(window time.Duration) Option {
+	return func(c *batchRefreshNSEClient) {
+		c.window = window
+	}
+}
+
+// WithMaxParallel limits the number of Registers in flight in one batch. Default is 16.
+func WithMaxParallel(maxParallel int) Option {
+	return func(c *batchRefreshNSEClient) {
+		if maxParallel > 0 {
+			c.maxParallel = maxParallel
+		}
+	}
+}
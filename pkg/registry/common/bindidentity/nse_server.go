@@ -0,0 +1,281 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindidentity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+)
+
+type binding struct {
+	id         spiffeid.ID
+	name       string
+	url        string
+	expireTime time.Time
+	timer      clock.Timer
+}
+
+// claim reserves the NSE name or URL for the identity while its Register is in progress
+type claim struct {
+	id   spiffeid.ID
+	refs int
+}
+
+type bindIdentityNSEServer struct {
+	overrideIDs map[spiffeid.ID]struct{}
+	names       map[string]*binding
+	urls        map[string]*binding
+	nameClaims  map[string]*claim
+	urlClaims   map[string]*claim
+	mu          sync.Mutex
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element that
+// records the SPIFFE ID used during Register and rejects registrations, updates and unregistrations of the same NSE
+// name or URL by different identities. Calls without SPIFFE ID are rejected for the already bound names and URLs.
+func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	o := &options{
+		overrideIDs: make(map[spiffeid.ID]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &bindIdentityNSEServer{
+		overrideIDs: o.overrideIDs,
+		names:       make(map[string]*binding),
+		urls:        make(map[string]*binding),
+		nameClaims:  make(map[string]*claim),
+		urlClaims:   make(map[string]*claim),
+	}
+}
+
+func (s *bindIdentityNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	id, err := spire.SpiffeIDFromContext(ctx)
+	if err != nil {
+		if err = s.checkUnbound(ctx, nse); err != nil {
+			return nil, err
+		}
+		return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	}
+
+	if err = s.checkAndClaim(ctx, id, nse); err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unclaim(id, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	// Override IDs don't take the ownership of the already registered NSEs
+	if prev, ok := s.names[resp.GetName()]; ok {
+		if _, isOverride := s.overrideIDs[id]; isOverride && prev.isActive(clock.FromContext(ctx).Now()) {
+			id = prev.id
+		}
+		s.unbind(prev)
+	}
+	b := &binding{
+		id:   id,
+		name: resp.GetName(),
+		url:  resp.GetUrl(),
+	}
+	if resp.GetExpirationTime() != nil {
+		b.expireTime = resp.GetExpirationTime().AsTime()
+	}
+	s.bind(ctx, b)
+
+	return resp, nil
+}
+
+func (s *bindIdentityNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *bindIdentityNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	id, err := spire.SpiffeIDFromContext(ctx)
+	if err != nil {
+		err = s.checkUnbound(ctx, nse)
+	} else {
+		err = s.check(ctx, id, nse)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if b, ok := s.names[nse.GetName()]; ok {
+		s.unbind(b)
+	}
+
+	return resp, nil
+}
+
+func (s *bindIdentityNSEServer) check(ctx context.Context, id spiffeid.ID, nse *registry.NetworkServiceEndpoint) error {
+	if _, ok := s.overrideIDs[id]; ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checkLocked(clock.FromContext(ctx).Now(), id, nse)
+}
+
+// checkAndClaim checks the NSE name and URL and claims them for the identity until unclaim is called, so the
+// concurrent Register by another identity is rejected even before the binding is stored
+func (s *bindIdentityNSEServer) checkAndClaim(ctx context.Context, id spiffeid.ID, nse *registry.NetworkServiceEndpoint) error {
+	if _, ok := s.overrideIDs[id]; ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkLocked(clock.FromContext(ctx).Now(), id, nse); err != nil {
+		return err
+	}
+	addClaim(s.nameClaims, nse.GetName(), id)
+	addClaim(s.urlClaims, nse.GetUrl(), id)
+	return nil
+}
+
+// unclaim releases the claims made by checkAndClaim. Must be called under s.mu.
+func (s *bindIdentityNSEServer) unclaim(id spiffeid.ID, nse *registry.NetworkServiceEndpoint) {
+	if _, ok := s.overrideIDs[id]; ok {
+		return
+	}
+	removeClaim(s.nameClaims, nse.GetName())
+	removeClaim(s.urlClaims, nse.GetUrl())
+}
+
+// checkLocked returns an error if the NSE name or URL is bound or claimed by another identity. Must be called under
+// s.mu.
+func (s *bindIdentityNSEServer) checkLocked(now time.Time, id spiffeid.ID, nse *registry.NetworkServiceEndpoint) error {
+	if b, ok := s.names[nse.GetName()]; ok && b.isActive(now) && b.id != id {
+		return status.Errorf(codes.PermissionDenied, "NSE %s is registered by another identity", nse.GetName())
+	}
+	if c, ok := s.nameClaims[nse.GetName()]; ok && c.id != id {
+		return status.Errorf(codes.PermissionDenied, "NSE %s is being registered by another identity", nse.GetName())
+	}
+	if b, ok := s.urls[nse.GetUrl()]; ok && b.isActive(now) && b.id != id {
+		return status.Errorf(codes.PermissionDenied, "NSE URL %s is registered by another identity", nse.GetUrl())
+	}
+	if c, ok := s.urlClaims[nse.GetUrl()]; ok && c.id != id {
+		return status.Errorf(codes.PermissionDenied, "NSE URL %s is being registered by another identity", nse.GetUrl())
+	}
+	return nil
+}
+
+func (s *bindIdentityNSEServer) checkUnbound(ctx context.Context, nse *registry.NetworkServiceEndpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock.FromContext(ctx).Now()
+	if b, ok := s.names[nse.GetName()]; ok && b.isActive(now) {
+		return status.Errorf(codes.PermissionDenied, "NSE %s is registered by another identity", nse.GetName())
+	}
+	if _, ok := s.nameClaims[nse.GetName()]; ok {
+		return status.Errorf(codes.PermissionDenied, "NSE %s is being registered by another identity", nse.GetName())
+	}
+	if b, ok := s.urls[nse.GetUrl()]; ok && b.isActive(now) {
+		return status.Errorf(codes.PermissionDenied, "NSE URL %s is registered by another identity", nse.GetUrl())
+	}
+	if _, ok := s.urlClaims[nse.GetUrl()]; ok {
+		return status.Errorf(codes.PermissionDenied, "NSE URL %s is being registered by another identity", nse.GetUrl())
+	}
+	return nil
+}
+
+// bind stores the binding and schedules its removal on expiration. Must be called under s.mu.
+func (s *bindIdentityNSEServer) bind(ctx context.Context, b *binding) {
+	s.names[b.name] = b
+	if b.url != "" {
+		s.urls[b.url] = b
+	}
+	if !b.expireTime.IsZero() {
+		clockTime := clock.FromContext(ctx)
+		b.timer = clockTime.AfterFunc(clockTime.Until(b.expireTime), func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			s.unbind(b)
+		})
+	}
+}
+
+// unbind removes the binding if it is still stored. Must be called under s.mu.
+func (s *bindIdentityNSEServer) unbind(b *binding) {
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	if s.names[b.name] == b {
+		delete(s.names, b.name)
+	}
+	if s.urls[b.url] == b {
+		delete(s.urls, b.url)
+	}
+}
+
+func (b *binding) isActive(now time.Time) bool {
+	return b.expireTime.IsZero() || b.expireTime.After(now)
+}
+
+func addClaim(claims map[string]*claim, key string, id spiffeid.ID) {
+	// Empty names are generated by the registry, so they can't be claimed
+	if key == "" {
+		return
+	}
+	c, ok := claims[key]
+	if !ok {
+		c = &claim{id: id}
+		claims[key] = c
+	}
+	c.refs++
+}
+
+func removeClaim(claims map[string]*claim, key string) {
+	c, ok := claims[key]
+	if !ok {
+		return
+	}
+	if c.refs--; c.refs == 0 {
+		delete(claims, key)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindidentity_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/bindidentity"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffepeer"
+)
+
+func TestBindIdentityNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		bindidentity.NewNetworkServiceEndpointRegistryServer(
+			bindidentity.WithOverrideIDs(spiffeid.RequireFromString("spiffe://test.com/admin")),
+		),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	owner := spiffepeer.NewContext(context.Background(), "spiffe://test.com/nse")
+	attacker := spiffepeer.NewContext(context.Background(), "spiffe://test.com/attacker")
+	admin := spiffepeer.NewContext(context.Background(), "spiffe://test.com/admin")
+
+	nse, err := server.Register(owner, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+
+	_, err = server.Register(owner, nse.Clone())
+	require.NoError(t, err)
+
+	_, err = server.Register(attacker, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://6.6.6.6"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.Register(attacker, &registry.NetworkServiceEndpoint{Name: "fake-nse", Url: "tcp://1.1.1.1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.Unregister(attacker, nse.Clone())
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.Register(admin, nse.Clone())
+	require.NoError(t, err)
+
+	_, err = server.Unregister(owner, nse.Clone())
+	require.NoError(t, err)
+
+	_, err = server.Register(attacker, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://6.6.6.6"})
+	require.NoError(t, err)
+}
+
+func TestBindIdentityNSEServer_NoIdentity(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		bindidentity.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	owner := spiffepeer.NewContext(context.Background(), "spiffe://test.com/nse")
+
+	_, err := server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "unbound", Url: "tcp://2.2.2.2"})
+	require.NoError(t, err)
+
+	nse, err := server.Register(owner, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+
+	_, err = server.Register(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://6.6.6.6"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.Unregister(context.Background(), nse.Clone())
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestBindIdentityNSEServer_URLChange(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		bindidentity.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	owner := spiffepeer.NewContext(context.Background(), "spiffe://test.com/nse")
+	other := spiffepeer.NewContext(context.Background(), "spiffe://test.com/other")
+
+	_, err := server.Register(owner, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+	_, err = server.Register(owner, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://2.2.2.2"})
+	require.NoError(t, err)
+
+	_, err = server.Register(other, &registry.NetworkServiceEndpoint{Name: "other", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+}
+
+func TestBindIdentityNSEServer_Expiration(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		bindidentity.NewNetworkServiceEndpointRegistryServer(),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	owner := spiffepeer.NewContext(ctx, "spiffe://test.com/nse")
+	other := spiffepeer.NewContext(ctx, "spiffe://test.com/other")
+
+	_, err := server.Register(owner, &registry.NetworkServiceEndpoint{
+		Name:           "nse",
+		Url:            "tcp://1.1.1.1",
+		ExpirationTime: timestamppb.New(clockMock.Now().Add(time.Minute)),
+	})
+	require.NoError(t, err)
+
+	_, err = server.Register(other, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://1.1.1.1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	clockMock.Add(time.Minute)
+
+	_, err = server.Register(other, &registry.NetworkServiceEndpoint{Name: "nse", Url: "tcp://1.1.1.1"})
+	require.NoError(t, err)
+}
+
+type blockingNSEServer struct {
+	releaseCh chan struct{}
+}
+
+func (s *blockingNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	<-s.releaseCh
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *blockingNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *blockingNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func TestBindIdentityNSEServer_ConcurrentRegister(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	blockingServer := &blockingNSEServer{releaseCh: make(chan struct{})}
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		bindidentity.NewNetworkServiceEndpointRegistryServer(),
+		blockingServer,
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	const count = 10
+
+	var succeeded, denied int32
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		ctx := spiffepeer.NewContext(context.Background(), fmt.Sprintf("spiffe://test.com/nse-%d", i))
+		nse := &registry.NetworkServiceEndpoint{Name: "nse", Url: fmt.Sprintf("tcp://1.1.1.%d", i)}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := server.Register(ctx, nse)
+			switch status.Code(err) {
+			case codes.OK:
+				atomic.AddInt32(&succeeded, 1)
+			case codes.PermissionDenied:
+				atomic.AddInt32(&denied, 1)
+			}
+		}()
+	}
+
+	// Only one Register can be in progress for the name, all the others should be rejected without waiting for it
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&denied) == count-1 }, time.Second, 10*time.Millisecond)
+
+	close(blockingServer.releaseCh)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&succeeded))
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindidentity
+
+import "github.com/spiffe/go-spiffe/v2/spiffeid"
+
+type options struct {
+	overrideIDs map[spiffeid.ID]struct{}
+}
+
+// Option is an option pattern for NewNetworkServiceEndpointRegistryServer
+type Option func(o *options)
+
+// WithOverrideIDs sets SPIFFE IDs allowed to update or unregister NSEs registered by other identities
+func WithOverrideIDs(ids ...spiffeid.ID) Option {
+	return func(o *options) {
+		for _, id := range ids {
+			o.overrideIDs[id] = struct{}{}
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/certpin"
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
@@ -63,7 +64,7 @@ func (c *dialNSEClient) Register(ctx context.Context, in *registry.NetworkServic
 	if di.clientURL != nil && di.clientURL.String() != clientURL.String() {
 		closeCtx, closeCancel := closeContextFunc()
 		defer closeCancel()
-		err := di.Dial(closeCtx, di.clientURL)
+		err := di.Dial(certpin.WithServerName(closeCtx, di.serverName), di.clientURL)
 		if err != nil {
 			log.FromContext(ctx).Errorf("can not redial to %v, err %v. Deleting clientconn...", grpcutils.URLToTarget(di.clientURL), err)
 			clientconn.Delete(ctx)
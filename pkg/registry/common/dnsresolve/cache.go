@@ -0,0 +1,176 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsresolve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	defaultCacheTTL         = 30 * time.Second
+	defaultNegativeCacheTTL = 5 * time.Second
+	defaultMaxStale         = 5 * time.Minute
+)
+
+type cacheKey struct {
+	service string
+	proto   string
+	name    string
+	// host is set for the IP lookups
+	host string
+}
+
+func (k cacheKey) String() string {
+	if k.host != "" {
+		return k.host
+	}
+	return fmt.Sprintf("_%s._%s.%s", k.service, k.proto, k.name)
+}
+
+type cacheEntry struct {
+	cname      string
+	srv        []*net.SRV
+	ips        []net.IPAddr
+	err        error
+	expires    time.Time
+	staleUntil time.Time
+}
+
+// CachingResolver is a Resolver caching the lookup results of another Resolver:
+//
+//   - successful lookups are cached for the TTL;
+//   - "not found" lookups are cached for the negative TTL;
+//   - if a lookup fails with any other error, the expired successful result is served for up to the max stale
+//     duration, so the interdomain lookups survive transient DNS outages.
+type CachingResolver struct {
+	resolver    Resolver
+	clock       clock.Clock
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxStale    time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+// NewCachingResolver creates a new CachingResolver caching the lookup results of the resolver. The clock is taken
+// from ctx.
+func NewCachingResolver(ctx context.Context, resolver Resolver, opts ...CacheOption) *CachingResolver {
+	o := &cacheOptions{
+		ttl:         defaultCacheTTL,
+		negativeTTL: defaultNegativeCacheTTL,
+		maxStale:    defaultMaxStale,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	r := &CachingResolver{
+		resolver:    resolver,
+		clock:       clock.FromContext(ctx),
+		ttl:         o.ttl,
+		negativeTTL: o.negativeTTL,
+		maxStale:    o.maxStale,
+		entries:     make(map[cacheKey]*cacheEntry),
+	}
+	r.seed(o.snapshot)
+
+	return r
+}
+
+// LookupSRV returns the cached result of the SRV lookup or lookups it with the underlying resolver
+func (r *CachingResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	e, err := r.lookup(ctx, cacheKey{service: service, proto: proto, name: name}, func() (*cacheEntry, error) {
+		cname, srv, err := r.resolver.LookupSRV(ctx, service, proto, name)
+		return &cacheEntry{cname: cname, srv: srv}, err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return e.cname, e.srv, nil
+}
+
+// LookupIPAddr returns the cached result of the host lookup or lookups it with the underlying resolver
+func (r *CachingResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	e, err := r.lookup(ctx, cacheKey{host: host}, func() (*cacheEntry, error) {
+		ips, err := r.resolver.LookupIPAddr(ctx, host)
+		return &cacheEntry{ips: ips}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.ips, nil
+}
+
+func (r *CachingResolver) lookup(ctx context.Context, key cacheKey, resolve func() (*cacheEntry, error)) (*cacheEntry, error) {
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	cached, ok := r.entries[key]
+	r.mu.Unlock()
+
+	if ok && now.Before(cached.expires) {
+		return cached, cached.err
+	}
+
+	e, err := resolve()
+	now = r.clock.Now()
+	switch {
+	case err == nil:
+		e.expires = now.Add(r.ttl)
+		e.staleUntil = e.expires.Add(r.maxStale)
+	case isNotFound(err):
+		e = &cacheEntry{err: err, expires: now.Add(r.negativeTTL)}
+		e.staleUntil = e.expires
+	case ok && cached.err == nil && now.Before(cached.staleUntil):
+		log.FromContext(ctx).Warnf("serving stale DNS result for %s: %s", key, err.Error())
+		return cached, nil
+	default:
+		return nil, err
+	}
+
+	r.store(key, e, now)
+	return e, e.err
+}
+
+func (r *CachingResolver) store(key cacheKey, e *cacheEntry, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, entry := range r.entries {
+		if !now.Before(entry.staleUntil) {
+			delete(r.entries, k)
+		}
+	}
+	r.entries[key] = e
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+var _ Resolver = (*CachingResolver)(nil)
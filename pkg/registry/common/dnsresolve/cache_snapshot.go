@@ -0,0 +1,127 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsresolve
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// SRVRecord is a cached SRV lookup result
+type SRVRecord struct {
+	Service string     `json:"service,omitempty"`
+	Proto   string     `json:"proto,omitempty"`
+	Name    string     `json:"name"`
+	CName   string     `json:"cname,omitempty"`
+	Records []*net.SRV `json:"records"`
+}
+
+// IPRecord is a cached IP lookup result
+type IPRecord struct {
+	Host string       `json:"host"`
+	IPs  []net.IPAddr `json:"ips"`
+}
+
+// CacheSnapshot is the snapshot of the CachingResolver successful lookup results, it can be serialized with
+// encoding/json
+type CacheSnapshot struct {
+	SRV []*SRVRecord `json:"srv,omitempty"`
+	IP  []*IPRecord  `json:"ip,omitempty"`
+}
+
+// Snapshot returns the snapshot of the successful lookup results including the stale ones
+func (r *CachingResolver) Snapshot() *CacheSnapshot {
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := new(CacheSnapshot)
+	for key, e := range r.entries {
+		if e.err != nil || !now.Before(e.staleUntil) {
+			continue
+		}
+		if key.host != "" {
+			snapshot.IP = append(snapshot.IP, &IPRecord{
+				Host: key.host,
+				IPs:  e.ips,
+			})
+			continue
+		}
+		snapshot.SRV = append(snapshot.SRV, &SRVRecord{
+			Service: key.service,
+			Proto:   key.proto,
+			Name:    key.name,
+			CName:   e.cname,
+			Records: e.srv,
+		})
+	}
+	return snapshot
+}
+
+// seed stores the snapshot lookup results as if they were just resolved
+func (r *CachingResolver) seed(snapshot *CacheSnapshot) {
+	if snapshot == nil {
+		return
+	}
+
+	expires := r.clock.Now().Add(r.ttl)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range snapshot.SRV {
+		r.entries[cacheKey{service: record.Service, proto: record.Proto, name: record.Name}] = &cacheEntry{
+			cname:      record.CName,
+			srv:        record.Records,
+			expires:    expires,
+			staleUntil: expires.Add(r.maxStale),
+		}
+	}
+	for _, record := range snapshot.IP {
+		r.entries[cacheKey{host: record.Host}] = &cacheEntry{
+			ips:        record.IPs,
+			expires:    expires,
+			staleUntil: expires.Add(r.maxStale),
+		}
+	}
+}
+
+// ReadCacheSnapshot reads the snapshot from the file written by WriteCacheSnapshot
+func ReadCacheSnapshot(path string) (*CacheSnapshot, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - the path is set by the application
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read DNS cache snapshot %s", path)
+	}
+	snapshot := new(CacheSnapshot)
+	if err = json.Unmarshal(data, snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal DNS cache snapshot %s", path)
+	}
+	return snapshot, nil
+}
+
+// WriteCacheSnapshot writes the snapshot to the file
+func WriteCacheSnapshot(path string, snapshot *CacheSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal DNS cache snapshot")
+	}
+	return errors.Wrapf(os.WriteFile(path, data, 0o600), "failed to write DNS cache snapshot %s", path)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsresolve_test
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+const (
+	cacheTestDomain        = "registry.domain"
+	cacheTestMissingDomain = "registry.missing"
+)
+
+func newCacheTestResolver(t *testing.T) (context.Context, *clockmock.Mock, *sandbox.FakeDNSResolver, *dnsresolve.CachingResolver) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	fake := new(sandbox.FakeDNSResolver)
+	fake.AddSRVEntry("domain", "registry", &url.URL{Scheme: "tcp", Host: "127.0.0.1:5001"})
+
+	resolver := dnsresolve.NewCachingResolver(ctx, fake,
+		dnsresolve.WithCacheTTL(time.Minute),
+		dnsresolve.WithNegativeCacheTTL(time.Second),
+		dnsresolve.WithMaxStale(time.Hour),
+	)
+	return ctx, clockMock, fake, resolver
+}
+
+func TestCachingResolver_TTL(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, clockMock, fake, resolver := newCacheTestResolver(t)
+
+	for i := 0; i < 3; i++ {
+		_, srv, err := resolver.LookupSRV(ctx, "", "", cacheTestDomain)
+		require.NoError(t, err)
+		require.Equal(t, uint16(5001), srv[0].Port)
+
+		ips, err := resolver.LookupIPAddr(ctx, cacheTestDomain)
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1", ips[0].IP.String())
+	}
+	require.Equal(t, 2, fake.LookupCount(cacheTestDomain))
+
+	clockMock.Add(time.Minute)
+
+	_, _, err := resolver.LookupSRV(ctx, "", "", cacheTestDomain)
+	require.NoError(t, err)
+	require.Equal(t, 3, fake.LookupCount(cacheTestDomain))
+}
+
+func TestCachingResolver_Negative(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, clockMock, fake, resolver := newCacheTestResolver(t)
+
+	fake.Script(cacheTestMissingDomain, sandbox.FakeDNSStep{Failure: sandbox.FakeDNSNXDomain})
+
+	for i := 0; i < 3; i++ {
+		_, err := resolver.LookupIPAddr(ctx, cacheTestMissingDomain)
+		require.Error(t, err)
+	}
+	require.Equal(t, 1, fake.LookupCount(cacheTestMissingDomain))
+
+	clockMock.Add(time.Second)
+
+	_, err := resolver.LookupIPAddr(ctx, cacheTestMissingDomain)
+	require.Error(t, err)
+	require.Equal(t, 2, fake.LookupCount(cacheTestMissingDomain))
+
+	// Temporary failures are not cached
+	fake.Script(cacheTestDomain, sandbox.FakeDNSStep{Failure: sandbox.FakeDNSServFail})
+
+	_, err = resolver.LookupIPAddr(ctx, cacheTestDomain)
+	require.Error(t, err)
+	_, err = resolver.LookupIPAddr(ctx, cacheTestDomain)
+	require.NoError(t, err)
+	require.Equal(t, 2, fake.LookupCount(cacheTestDomain))
+}
+
+func TestCachingResolver_Stale(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, clockMock, fake, resolver := newCacheTestResolver(t)
+
+	_, err := resolver.LookupIPAddr(ctx, cacheTestDomain)
+	require.NoError(t, err)
+
+	fake.Script(cacheTestDomain,
+		sandbox.FakeDNSStep{Failure: sandbox.FakeDNSServFail},
+		sandbox.FakeDNSStep{Failure: sandbox.FakeDNSServFail},
+	)
+
+	clockMock.Add(time.Minute)
+
+	ips, err := resolver.LookupIPAddr(ctx, cacheTestDomain)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", ips[0].IP.String())
+
+	clockMock.Add(time.Hour)
+
+	_, err = resolver.LookupIPAddr(ctx, cacheTestDomain)
+	require.Error(t, err)
+	require.Equal(t, 3, fake.LookupCount(cacheTestDomain))
+}
+
+func TestCachingResolver_Snapshot(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, _, fake, resolver := newCacheTestResolver(t)
+
+	_, _, err := resolver.LookupSRV(ctx, "", "", cacheTestDomain)
+	require.NoError(t, err)
+	_, err = resolver.LookupIPAddr(ctx, cacheTestDomain)
+	require.NoError(t, err)
+	_, err = resolver.LookupIPAddr(ctx, cacheTestMissingDomain)
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "dns.json")
+	require.NoError(t, dnsresolve.WriteCacheSnapshot(path, resolver.Snapshot()))
+
+	snapshot, err := dnsresolve.ReadCacheSnapshot(path)
+	require.NoError(t, err)
+	require.Len(t, snapshot.SRV, 1)
+	require.Len(t, snapshot.IP, 1)
+
+	// The warm resolver doesn't lookup DNS
+	warm := dnsresolve.NewCachingResolver(ctx, fake, dnsresolve.WithCacheSnapshot(snapshot))
+
+	_, srv, err := warm.LookupSRV(ctx, "", "", cacheTestDomain)
+	require.NoError(t, err)
+	require.Equal(t, uint16(5001), srv[0].Port)
+
+	ips, err := warm.LookupIPAddr(ctx, cacheTestDomain)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", ips[0].IP.String())
+
+	require.Equal(t, 2, fake.LookupCount(cacheTestDomain))
+}
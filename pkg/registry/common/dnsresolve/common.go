@@ -25,6 +25,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/certpin"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
@@ -97,4 +98,13 @@ func resolveDomain(ctx context.Context, service, domain string, r Resolver) (*ur
 	return u, nil
 }
 
+// withServerName returns ctx with the domain as the name the client URL has been resolved from, so the peer
+// certificate pins are checked for the domain and not for the address it has been resolved or rewritten to
+func withServerName(ctx context.Context, domain string) context.Context {
+	if ip, _ := parseIPPort(domain); ip != nil {
+		return ctx
+	}
+	return certpin.WithServerName(ctx, domain)
+}
+
 var _ Resolver = (*net.Resolver)(nil)
@@ -0,0 +1,85 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsresolve
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/proxyevents"
+)
+
+// eventEmitter emits the proxy events of a single registry operation
+type eventEmitter struct {
+	sink      proxyevents.Sink
+	resource  string
+	operation string
+	name      string
+	domain    string
+
+	registryService string
+	rewrites        rewriteRules
+}
+
+func (e *eventEmitter) emit(ctx context.Context, eventType proxyevents.Type, service string, u *url.URL, err error) {
+	event := &proxyevents.Event{
+		Version:   proxyevents.SchemaVersion,
+		Type:      eventType,
+		Time:      clock.FromContext(ctx).Now(),
+		Resource:  e.resource,
+		Operation: e.operation,
+		Name:      e.name,
+		Domain:    e.domain,
+		Service:   service,
+	}
+	if u != nil {
+		event.URL = u.String()
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	e.sink.Emit(ctx, event)
+}
+
+// resolve resolves the service URL in the domain
+func (e *eventEmitter) resolve(ctx context.Context, service string, r Resolver) (*url.URL, error) {
+	var u *url.URL
+	var err error
+	if domain, port := e.rewrites.rewrite(e.domain); port != "" && service == e.registryService {
+		u, err = resolveHostPort(ctx, domain, port, r)
+	} else {
+		u, err = resolveDomain(ctx, service, domain, r)
+	}
+	e.emit(ctx, proxyevents.DomainResolved, service, u, err)
+	return u, err
+}
+
+// forward returns ctx forwarding the request to u
+func (e *eventEmitter) forward(ctx context.Context, service string, u *url.URL) context.Context {
+	e.emit(ctx, proxyevents.ProxyHopChosen, service, u, nil)
+	return withServerName(clienturlctx.WithClientURL(ctx, u), e.domain)
+}
+
+// remoteError emits the RemoteError event if err is not nil and returns err
+func (e *eventEmitter) remoteError(ctx context.Context, err error) error {
+	if err != nil {
+		e.emit(ctx, proxyevents.RemoteError, "", clienturlctx.ClientURL(ctx), err)
+	}
+	return err
+}
@@ -62,7 +62,7 @@ func (d *dnsNSResolveClient) Register(ctx context.Context, ns *registry.NetworkS
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturlctx.WithClientURL(ctx, url)
+	ctx = withServerName(clienturlctx.WithClientURL(ctx, url), domain)
 	ns.Name = interdomain.Target(ns.Name)
 	resp, err := next.NetworkServiceRegistryClient(ctx).Register(ctx, ns, opts...)
 	if err != nil {
@@ -98,7 +98,7 @@ func (d *dnsNSResolveClient) Find(ctx context.Context, q *registry.NetworkServic
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturlctx.WithClientURL(ctx, url)
+	ctx = withServerName(clienturlctx.WithClientURL(ctx, url), domain)
 	q.NetworkService.Name = interdomain.Target(q.NetworkService.Name)
 
 	resp, err := next.NetworkServiceRegistryClient(ctx).Find(ctx, q, opts...)
@@ -118,7 +118,7 @@ func (d *dnsNSResolveClient) Unregister(ctx context.Context, ns *registry.Networ
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturlctx.WithClientURL(ctx, url)
+	ctx = withServerName(clienturlctx.WithClientURL(ctx, url), domain)
 	ns.Name = interdomain.Target(ns.Name)
 	defer func() {
 		ns.Name = interdomain.Join(ns.Name, domain)
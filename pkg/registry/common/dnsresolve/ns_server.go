@@ -21,9 +21,8 @@ import (
 	"errors"
 	"net"
 
-	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
-
 	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
+	"github.com/networkservicemesh/sdk/pkg/tools/proxyevents"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
@@ -35,6 +34,8 @@ import (
 type dnsNSResolveServer struct {
 	resolver        Resolver
 	registryService string
+	eventSink       proxyevents.Sink
+	rewrites        rewriteRules
 }
 
 // NewNetworkServiceRegistryServer creates new NetworkServiceRegistryServer that can resolve passed domain to clienturl
@@ -42,6 +43,7 @@ func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegi
 	var serverOptions = &options{
 		resolver:        net.DefaultResolver,
 		registryService: DefaultRegistryService,
+		eventSink:       proxyevents.NewLogSink(),
 	}
 
 	for _, opt := range opts {
@@ -51,6 +53,8 @@ func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegi
 	r := &dnsNSResolveServer{
 		resolver:        serverOptions.resolver,
 		registryService: serverOptions.registryService,
+		eventSink:       serverOptions.eventSink,
+		rewrites:        serverOptions.rewrites,
 	}
 
 	return r
@@ -58,15 +62,16 @@ func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegi
 
 func (d *dnsNSResolveServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
 	domain := interdomain.Domain(ns.Name)
-	url, err := resolveDomain(ctx, d.registryService, domain, d.resolver)
+	events := d.events(proxyevents.OperationRegister, ns.Name, domain)
+	url, err := events.resolve(ctx, d.registryService, d.resolver)
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturlctx.WithClientURL(ctx, url)
+	ctx = events.forward(ctx, d.registryService, url)
 	ns.Name = interdomain.Target(ns.Name)
 	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
 	if err != nil {
-		return nil, err
+		return nil, events.remoteError(ctx, err)
 	}
 
 	resp.Name = interdomain.Join(resp.Name, domain)
@@ -90,28 +95,45 @@ func (d *dnsNSResolveServer) Find(q *registry.NetworkServiceQuery, s registry.Ne
 	if domain == "" {
 		return errors.New("domain cannot be empty")
 	}
-	url, err := resolveDomain(ctx, d.registryService, domain, d.resolver)
+	events := d.events(proxyevents.OperationFind, q.NetworkService.Name, domain)
+	url, err := events.resolve(ctx, d.registryService, d.resolver)
 	if err != nil {
 		return err
 	}
-	ctx = clienturlctx.WithClientURL(s.Context(), url)
+	ctx = events.forward(ctx, d.registryService, url)
 	s = streamcontext.NetworkServiceRegistryFindServer(ctx, s)
 	q.NetworkService.Name = interdomain.Target(q.NetworkService.Name)
-	return next.NetworkServiceRegistryServer(s.Context()).Find(q, &dnsFindNSServer{domain: domain, NetworkServiceRegistry_FindServer: s})
+	err = next.NetworkServiceRegistryServer(ctx).Find(q, &dnsFindNSServer{domain: domain, NetworkServiceRegistry_FindServer: s})
+	return events.remoteError(ctx, err)
 }
 
 func (d *dnsNSResolveServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
 	domain := interdomain.Domain(ns.Name)
-	url, err := resolveDomain(ctx, d.registryService, domain, d.resolver)
+	events := d.events(proxyevents.OperationUnregister, ns.Name, domain)
+	url, err := events.resolve(ctx, d.registryService, d.resolver)
 	if err != nil {
 		return nil, err
 	}
-	ctx = clienturlctx.WithClientURL(ctx, url)
+	ctx = events.forward(ctx, d.registryService, url)
 	ns.Name = interdomain.Target(ns.Name)
 	defer func() {
 		ns.Name = interdomain.Join(ns.Name, domain)
 	}()
-	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	return resp, events.remoteError(ctx, err)
+}
+
+func (d *dnsNSResolveServer) events(operation, name, domain string) *eventEmitter {
+	return &eventEmitter{
+		sink:      d.eventSink,
+		resource:  proxyevents.ResourceNS,
+		operation: operation,
+		name:      name,
+		domain:    domain,
+
+		registryService: d.registryService,
+		rewrites:        d.rewrites,
+	}
 }
 
 func (d *dnsNSResolveServer) setResolver(r Resolver) {
@@ -63,7 +63,7 @@ func (d *dnsNSEResolveClient) Register(ctx context.Context, nse *registry.Networ
 		return nil, err
 	}
 
-	ctx = clienturlctx.WithClientURL(ctx, u)
+	ctx = withServerName(clienturlctx.WithClientURL(ctx, u), domain)
 
 	translateNSE(nse, interdomain.Target)
 
@@ -106,7 +106,7 @@ func (d *dnsNSEResolveClient) Find(ctx context.Context, q *registry.NetworkServi
 		return nil, err
 	}
 
-	ctx = clienturlctx.WithClientURL(ctx, nsmgrProxyURL)
+	ctx = withServerName(clienturlctx.WithClientURL(ctx, nsmgrProxyURL), domain)
 	translateNSE(q.NetworkServiceEndpoint, interdomain.Target)
 
 	resp, err := next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, q, opts...)
@@ -128,7 +128,7 @@ func (d *dnsNSEResolveClient) Unregister(ctx context.Context, nse *registry.Netw
 		return nil, err
 	}
 
-	ctx = clienturlctx.WithClientURL(ctx, u)
+	ctx = withServerName(clienturlctx.WithClientURL(ctx, u), domain)
 
 	translateNSE(nse, interdomain.Target)
 
@@ -23,8 +23,8 @@ import (
 	"net"
 	"net/url"
 
-	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/proxyevents"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
 
@@ -39,6 +39,8 @@ type dnsNSEResolveServer struct {
 	resolver          Resolver
 	nsmgrProxyService string
 	registryService   string
+	eventSink         proxyevents.Sink
+	rewrites          rewriteRules
 }
 
 // NewNetworkServiceEndpointRegistryServer creates new NetworkServiceRegistryServer that can resolve passed domain to clienturl
@@ -47,6 +49,7 @@ func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkSer
 		resolver:          net.DefaultResolver,
 		registryService:   DefaultRegistryService,
 		nsmgrProxyService: DefaultNsmgrProxyService,
+		eventSink:         proxyevents.NewLogSink(),
 	}
 
 	for _, opt := range opts {
@@ -57,6 +60,8 @@ func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkSer
 		resolver:          serverOptions.resolver,
 		nsmgrProxyService: serverOptions.nsmgrProxyService,
 		registryService:   serverOptions.registryService,
+		eventSink:         serverOptions.eventSink,
+		rewrites:          serverOptions.rewrites,
 	}
 
 	return r
@@ -103,20 +108,21 @@ func resolveNSE(nse *registry.NetworkServiceEndpoint) string {
 
 func (d *dnsNSEResolveServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	var domain = resolveNSE(nse)
-	var u, err = resolveDomain(ctx, d.registryService, domain, d.resolver)
+	var events = d.events(proxyevents.OperationRegister, nse.Name, domain)
+	var u, err = events.resolve(ctx, d.registryService, d.resolver)
 
 	if err != nil {
 		return nil, err
 	}
 
-	ctx = clienturlctx.WithClientURL(ctx, u)
+	ctx = events.forward(ctx, d.registryService, u)
 
 	translateNSE(nse, interdomain.Target)
 
 	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
 
 	if err != nil {
-		return nil, err
+		return nil, events.remoteError(ctx, err)
 	}
 
 	translateNSE(resp, func(s string) string {
@@ -147,14 +153,15 @@ func (s *dnsFindNSEServer) Send(nseResp *registry.NetworkServiceEndpointResponse
 func (d *dnsNSEResolveServer) Find(q *registry.NetworkServiceEndpointQuery, s registry.NetworkServiceEndpointRegistry_FindServer) error {
 	var ctx = s.Context()
 	var domain = resolveNSE(q.NetworkServiceEndpoint)
-	var nsmgrProxyURL, err = resolveDomain(ctx, d.registryService, domain, d.resolver)
+	var events = d.events(proxyevents.OperationFind, q.NetworkServiceEndpoint.Name, domain)
+	var nsmgrProxyURL, err = events.resolve(ctx, d.registryService, d.resolver)
 
 	if err != nil {
 		return err
 	}
 
-	ctx = clienturlctx.WithClientURL(s.Context(), nsmgrProxyURL)
-	nsmgrProxyURL, err = resolveDomain(ctx, d.nsmgrProxyService, domain, d.resolver)
+	ctx = events.forward(ctx, d.registryService, nsmgrProxyURL)
+	nsmgrProxyURL, err = events.resolve(ctx, d.nsmgrProxyService, d.resolver)
 
 	if err != nil {
 		log.FromContext(ctx).Errorf("nsmgrProxyService is not reachable by domain: %v", domain)
@@ -164,22 +171,40 @@ func (d *dnsNSEResolveServer) Find(q *registry.NetworkServiceEndpointQuery, s re
 
 	translateNSE(q.NetworkServiceEndpoint, interdomain.Target)
 
-	return next.NetworkServiceEndpointRegistryServer(s.Context()).Find(q, &dnsFindNSEServer{NetworkServiceEndpointRegistry_FindServer: s, domain: domain, nseURL: nsmgrProxyURL})
+	err = next.NetworkServiceEndpointRegistryServer(ctx).Find(q, &dnsFindNSEServer{NetworkServiceEndpointRegistry_FindServer: s, domain: domain, nseURL: nsmgrProxyURL})
+
+	return events.remoteError(ctx, err)
 }
 
 func (d *dnsNSEResolveServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
 	var domain = resolveNSE(nse)
-	var u, err = resolveDomain(ctx, d.registryService, domain, d.resolver)
+	var events = d.events(proxyevents.OperationUnregister, nse.Name, domain)
+	var u, err = events.resolve(ctx, d.registryService, d.resolver)
 
 	if err != nil {
 		return nil, err
 	}
 
-	ctx = clienturlctx.WithClientURL(ctx, u)
+	ctx = events.forward(ctx, d.registryService, u)
 
 	translateNSE(nse, interdomain.Target)
 
-	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+
+	return resp, events.remoteError(ctx, err)
+}
+
+func (d *dnsNSEResolveServer) events(operation, name, domain string) *eventEmitter {
+	return &eventEmitter{
+		sink:      d.eventSink,
+		resource:  proxyevents.ResourceNSE,
+		operation: operation,
+		name:      name,
+		domain:    domain,
+
+		registryService: d.registryService,
+		rewrites:        d.rewrites,
+	}
 }
 
 func (d *dnsNSEResolveServer) setResolver(r Resolver) {
@@ -18,17 +18,20 @@ package dnsresolve_test
 
 import (
 	"context"
+	"net"
 	"net/url"
 	"testing"
 	"time"
 
 	"go.uber.org/goleak"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/certpin"
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
 	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
@@ -36,15 +39,21 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/registry/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/proxyevents"
 )
 
 type checkNSEContext struct {
 	*testing.T
-	expectedURL *url.URL
+	expectedURL        *url.URL
+	expectedServerName string
 }
 
 func (c *checkNSEContext) Register(ctx context.Context, ns *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	require.Equal(c, c.expectedURL, clienturlctx.ClientURL(ctx))
+	if c.expectedServerName != "" {
+		require.Equal(c, c.expectedServerName, certpin.ServerName(ctx))
+	}
 	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, ns)
 }
 
@@ -151,3 +160,169 @@ func Test_DNSResolve_LookupNsmgrProxy(t *testing.T) {
 	_, err = s.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@" + domain})
 	require.NoError(t, err)
 }
+
+func Test_DNSResolve_DomainRewrites(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	const srv = "service1"
+
+	var resolver = new(sandbox.FakeDNSResolver)
+
+	u, err := url.Parse("tcp://127.0.0.1:80")
+	require.NoError(t, err)
+
+	resolver.AddSRVEntry("remote.svc.example.com", srv, u)
+
+	rules := map[string]string{
+		"remote":         "example.com",
+		"cluster.remote": "remote.svc.example.com",
+		"pinned.remote":  "127.0.0.2:5002",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(
+			dnsresolve.WithRegistryService(srv),
+			dnsresolve.WithResolver(resolver),
+			dnsresolve.WithDomainRewrites(rules),
+		),
+		&checkNSEContext{T: t, expectedURL: u, expectedServerName: "cluster.remote"},
+	)
+
+	// The pins are checked for the requested domain and not for the one it has been rewritten to
+	resp, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@cluster.remote"})
+	require.NoError(t, err)
+	require.Equal(t, "nse-1@cluster.remote", resp.Name)
+
+	pinnedURL, err := url.Parse("tcp://127.0.0.2:5002")
+	require.NoError(t, err)
+
+	s = next.NewNetworkServiceEndpointRegistryServer(
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(
+			dnsresolve.WithRegistryService(srv),
+			dnsresolve.WithResolver(resolver),
+			dnsresolve.WithDomainRewrites(rules),
+		),
+		&checkNSEContext{T: t, expectedURL: pinnedURL, expectedServerName: "pinned.remote"},
+	)
+
+	resp, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@pinned.remote"})
+	require.NoError(t, err)
+	require.Equal(t, "nse-1@pinned.remote", resp.Name)
+}
+
+func Test_DNSResolve_ResolverFailures(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	const srv = "service1"
+
+	var resolver = new(sandbox.FakeDNSResolver)
+
+	u, err := url.Parse("tcp://127.0.0.1:80")
+	require.NoError(t, err)
+
+	resolver.AddSRVEntry("domain1", srv, u)
+	resolver.Script(srv+".domain1",
+		sandbox.FakeDNSStep{Failure: sandbox.FakeDNSServFail},
+		sandbox.FakeDNSStep{Failure: sandbox.FakeDNSNXDomain},
+		sandbox.FakeDNSStep{Latency: time.Hour},
+	)
+
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(
+			dnsresolve.WithRegistryService(srv),
+			dnsresolve.WithResolver(resolver),
+		),
+		&checkNSEContext{T: t, expectedURL: u},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var dnsErr *net.DNSError
+
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.True(t, errors.As(err, &dnsErr))
+	require.True(t, dnsErr.IsTemporary)
+
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.True(t, errors.As(err, &dnsErr))
+	require.True(t, dnsErr.IsNotFound)
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer timeoutCancel()
+	_, err = s.Register(timeoutCtx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.True(t, errors.As(err, &dnsErr))
+	require.True(t, dnsErr.IsTimeout)
+
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.NoError(t, err)
+
+	// 3 failed SRV lookups, then SRV and IP lookups
+	require.Equal(t, 5, resolver.LookupCount(srv+".domain1"))
+}
+
+func Test_DNSResolve_Events(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	const srv = "service1"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var resolver = new(sandbox.FakeDNSResolver)
+
+	u, err := url.Parse("tcp://127.0.0.1:80")
+	require.NoError(t, err)
+	resolver.AddSRVEntry("domain1", srv, u)
+
+	var events []*proxyevents.Event
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		dnsresolve.NewNetworkServiceEndpointRegistryServer(
+			dnsresolve.WithRegistryService(srv),
+			dnsresolve.WithResolver(resolver),
+			dnsresolve.WithEventSink(proxyevents.SinkFunc(func(_ context.Context, event *proxyevents.Event) {
+				events = append(events, event)
+			})),
+		),
+		injecterror.NewNetworkServiceEndpointRegistryServer(
+			injecterror.WithRegisterErrorTimes(1),
+			injecterror.WithFindErrorTimes(),
+			injecterror.WithUnregisterErrorTimes(),
+		),
+	)
+
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, proxyevents.DomainResolved, events[0].Type)
+	require.Equal(t, proxyevents.ProxyHopChosen, events[1].Type)
+	for _, event := range events {
+		require.Equal(t, proxyevents.SchemaVersion, event.Version)
+		require.Equal(t, proxyevents.ResourceNSE, event.Resource)
+		require.Equal(t, proxyevents.OperationRegister, event.Operation)
+		require.Equal(t, "nse-1@domain1", event.Name)
+		require.Equal(t, "domain1", event.Domain)
+		require.Equal(t, srv, event.Service)
+		require.Equal(t, u.String(), event.URL)
+		require.Empty(t, event.Error)
+	}
+
+	events = nil
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain1"})
+	require.Error(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, proxyevents.RemoteError, events[2].Type)
+	require.Equal(t, u.String(), events[2].URL)
+	require.Equal(t, err.Error(), events[2].Error)
+
+	events = nil
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1@domain2"})
+	require.Error(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, proxyevents.DomainResolved, events[0].Type)
+	require.Equal(t, "domain2", events[0].Domain)
+	require.NotEmpty(t, events[0].Error)
+}
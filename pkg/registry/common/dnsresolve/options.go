@@ -16,10 +16,18 @@
 
 package dnsresolve
 
+import (
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/proxyevents"
+)
+
 type options struct {
 	resolver          Resolver
 	nsmgrProxyService string
 	registryService   string
+	eventSink         proxyevents.Sink
+	rewrites          rewriteRules
 }
 
 // Option is option to configure dnsresovle chain elements
@@ -45,3 +53,60 @@ func WithRegistryService(service string) Option {
 		o.registryService = service
 	}
 }
+
+// WithEventSink sets the sink receiving the proxy events emitted by the server chain elements, by default the events
+// are logged
+func WithEventSink(sink proxyevents.Sink) Option {
+	return func(o *options) {
+		o.eventSink = sink
+	}
+}
+
+// WithDomainRewrites sets the rules rewriting the external domain suffixes to the internal ones before the lookup, e.g.
+// "cluster.remote" -> "remote.svc.example.com". If the rule target has a port, e.g. "remote.svc.example.com:5002",
+// the registry is resolved to the target host and port without the SRV lookup.
+func WithDomainRewrites(rules map[string]string) Option {
+	return func(o *options) {
+		o.rewrites = rules
+	}
+}
+
+type cacheOptions struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxStale    time.Duration
+	snapshot    *CacheSnapshot
+}
+
+// CacheOption is option to configure CachingResolver
+type CacheOption func(*cacheOptions)
+
+// WithCacheTTL sets the time the successful lookup results are cached for, by default 30 seconds
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL sets the time the "not found" lookup results are cached for, by default 5 seconds
+func WithNegativeCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.negativeTTL = ttl
+	}
+}
+
+// WithMaxStale sets the time the expired successful lookup results are served for if the lookup fails, by default
+// 5 minutes
+func WithMaxStale(maxStale time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.maxStale = maxStale
+	}
+}
+
+// WithCacheSnapshot sets the snapshot to warm up the cache on start, so the first lookups after the restart don't have
+// to wait for DNS
+func WithCacheSnapshot(snapshot *CacheSnapshot) CacheOption {
+	return func(o *cacheOptions) {
+		o.snapshot = snapshot
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsresolve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// rewriteRules maps the external domain suffixes to the internal ones
+type rewriteRules map[string]string
+
+// rewrite returns the internal domain for the domain by the longest matching suffix rule. If the rule target has a
+// port, the port is returned separately.
+func (r rewriteRules) rewrite(domain string) (target, port string) {
+	var suffix string
+	for s := range r {
+		if (domain == s || strings.HasSuffix(domain, "."+s)) && len(s) > len(suffix) {
+			suffix = s
+		}
+	}
+	if suffix == "" {
+		return domain, ""
+	}
+
+	target = strings.TrimSuffix(domain, suffix) + r[suffix]
+	if host, p, err := net.SplitHostPort(target); err == nil {
+		return host, p
+	}
+	return target, ""
+}
+
+func resolveHostPort(ctx context.Context, host, port string, r Resolver) (*url.URL, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		ips, err := r.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) == 0 {
+			return nil, errors.New("resolver.LookupIPAddr return empty result")
+		}
+		ip = ips[0].IP
+	}
+
+	return url.Parse(fmt.Sprintf("tcp://%v", net.JoinHostPort(ip.String(), port)))
+}
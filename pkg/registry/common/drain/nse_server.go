@@ -0,0 +1,58 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drain provides a registry chain element feeding the drain.Tracker with the pending NSE unregistrations
+package drain
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/drain"
+)
+
+type drainNSEServer struct {
+	tracker *drain.Tracker
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a NetworkServiceEndpointRegistryServer marking the NSE as
+// unregistering in the tracker before passing the Unregister further and removing the mark on the successful Register
+func NewNetworkServiceEndpointRegistryServer(tracker *drain.Tracker) registry.NetworkServiceEndpointRegistryServer {
+	return &drainNSEServer{
+		tracker: tracker,
+	}
+}
+
+func (s *drainNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	s.tracker.Registered(resp.GetName())
+	return resp, nil
+}
+
+func (s *drainNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *drainNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.tracker.Unregistering(nse.GetName())
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	registrydrain "github.com/networkservicemesh/sdk/pkg/registry/common/drain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/drain"
+)
+
+func TestDrainNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	tracker := drain.NewTracker(context.Background())
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		registrydrain.NewNetworkServiceEndpointRegistryServer(tracker),
+		injecterror.NewNetworkServiceEndpointRegistryServer(
+			injecterror.WithRegisterErrorTimes(0),
+			injecterror.WithUnregisterErrorTimes(),
+		),
+	)
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse"}
+
+	// The NSE is draining since the unregistration has started even if it has not completed yet
+	_, err := server.Unregister(context.Background(), nse)
+	require.NoError(t, err)
+	require.True(t, tracker.IsUnregistering(nse.GetName()))
+
+	// Failed registration doesn't clear the mark
+	_, err = server.Register(context.Background(), nse)
+	require.Error(t, err)
+	require.True(t, tracker.IsUnregistering(nse.GetName()))
+
+	_, err = server.Register(context.Background(), nse)
+	require.NoError(t, err)
+	require.False(t, tracker.IsUnregistering(nse.GetName()))
+}
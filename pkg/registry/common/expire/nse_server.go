@@ -28,22 +28,31 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockskew"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type expireNSEServer struct {
 	nseExpiration time.Duration
+	maxDuration   time.Duration
 	ctx           context.Context
 	cancelsMap
 }
 
 // NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceServer chain element that implements unregister
 // of expired connections for the subsequent chain elements.
-func NewNetworkServiceEndpointRegistryServer(ctx context.Context, nseExpiration time.Duration) registry.NetworkServiceEndpointRegistryServer {
-	return &expireNSEServer{
+// nseExpiration is used for the NSEs not requesting their own ExpirationTime, the requested ones are clamped by
+// WithMaxDuration.
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, nseExpiration time.Duration, opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	s := &expireNSEServer{
 		nseExpiration: nseExpiration,
+		maxDuration:   nseExpiration,
 		ctx:           ctx,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *expireNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
@@ -54,7 +63,11 @@ func (s *expireNSEServer) Register(ctx context.Context, nse *registry.NetworkSer
 	logger := log.FromContext(ctx).WithField("expireNSEServer", "Register")
 
 	if nse.GetExpirationTime() != nil {
-		if nseExpirationTime := nse.GetExpirationTime().AsTime().Local(); nseExpirationTime.Before(expirationTime) {
+		nseExpirationTime := nse.GetExpirationTime().AsTime().Local()
+		if maxExpirationTime := timeClock.Now().Add(s.maxDuration).Local(); nseExpirationTime.After(maxExpirationTime) {
+			nseExpirationTime = maxExpirationTime
+		}
+		if !nseExpirationTime.Equal(expirationTime) {
 			expirationTime = nseExpirationTime
 			logger.Infof("selected expiration time %v for %v", expirationTime, nse.GetName())
 		}
@@ -78,7 +91,7 @@ func (s *expireNSEServer) Register(ctx context.Context, nse *registry.NetworkSer
 	}
 	s.cancelsMap.Store(nse.GetName(), cancel)
 
-	expireCh := timeClock.After(timeClock.Until(expirationTime.Local()))
+	expireCh := timeClock.After(clockskew.Until(ctx, expirationTime))
 
 	go func() {
 		select {
@@ -148,6 +148,57 @@ func TestExpireNSEServer_ShouldUseLessExpirationTimeFromResponse(t *testing.T) {
 	require.Equal(t, expireTimeout/2, clockMock.Until(resp.ExpirationTime.AsTime()))
 }
 
+func TestExpireNSEServer_WithMaxDuration(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		expire.NewNetworkServiceEndpointRegistryServer(ctx, expireTimeout, expire.WithMaxDuration(3*expireTimeout)),
+		mem,
+	)
+
+	// Default expiration
+	resp, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Equal(t, expireTimeout, clockMock.Until(resp.ExpirationTime.AsTime()))
+
+	// Clamped expiration
+	resp, err = s.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-2",
+		ExpirationTime: timestamppb.New(clockMock.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3*expireTimeout, clockMock.Until(resp.ExpirationTime.AsTime()))
+
+	// Requested expiration longer than the default one
+	resp, err = s.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-3",
+		ExpirationTime: timestamppb.New(clockMock.Now().Add(2 * expireTimeout)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2*expireTimeout, clockMock.Until(resp.ExpirationTime.AsTime()))
+
+	clockMock.Add(expireTimeout)
+	require.Eventually(t, func() bool {
+		nses, err := find(ctx, adapters.NetworkServiceEndpointServerToClient(mem))
+		return err == nil && len(nses) == 2
+	}, testWait, testTick)
+
+	clockMock.Add(expireTimeout)
+	require.Eventually(t, func() bool {
+		nses, err := find(ctx, adapters.NetworkServiceEndpointServerToClient(mem))
+		return err == nil && len(nses) == 1 && nses[0].GetName() == "nse-2"
+	}, testWait, testTick)
+}
+
 func TestExpireNSEServer_ShouldRemoveNSEAfterExpirationTime(t *testing.T) {
 	t.Cleanup(func() { goleak.VerifyNone(t) })
 
@@ -0,0 +1,33 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expire
+
+import (
+	"time"
+)
+
+// Option is an option pattern for NewNetworkServiceEndpointRegistryServer
+type Option func(s *expireNSEServer)
+
+// WithMaxDuration sets the maximum expiration duration the NSE can request with its ExpirationTime, the requested
+// expiration time is clamped to now + maxDuration
+// Default: the default expiration duration
+func WithMaxDuration(maxDuration time.Duration) Option {
+	return func(s *expireNSEServer) {
+		s.maxDuration = maxDuration
+	}
+}
@@ -19,6 +19,7 @@ package memory
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/edwarnicke/serialize"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -27,7 +28,6 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
-	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
 )
 
 type memoryNSServer struct {
@@ -53,6 +53,8 @@ func (s *memoryNSServer) setEventChannelSize(l int) {
 	s.eventChannelSize = l
 }
 
+func (s *memoryNSServer) setTombstoneTTL(time.Duration) {}
+
 func (s *memoryNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
 	r, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
 	if err != nil {
@@ -76,8 +78,10 @@ func (s *memoryNSServer) sendEvent(event *registry.NetworkService) {
 }
 
 func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	match := newNSMatcher(server.Context(), query)
+
 	if !query.Watch {
-		for _, ns := range s.allMatches(query) {
+		for _, ns := range s.allMatches(match) {
 			nsResp := &registry.NetworkServiceResponse{
 				NetworkService: ns,
 			}
@@ -94,14 +98,14 @@ func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server regist
 
 	s.executor.AsyncExec(func() {
 		s.eventChannels[id] = eventCh
-		for _, entity := range s.allMatches(query) {
+		for _, entity := range s.allMatches(match) {
 			eventCh <- entity
 		}
 	})
 	defer s.closeEventChannel(id, eventCh)
 
 	var err error
-	for ; err == nil; err = s.receiveEvent(query, server, eventCh) {
+	for ; err == nil; err = s.receiveEvent(match, server, eventCh) {
 	}
 	if err != io.EOF {
 		return err
@@ -109,9 +113,9 @@ func (s *memoryNSServer) Find(query *registry.NetworkServiceQuery, server regist
 	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
 }
 
-func (s *memoryNSServer) allMatches(query *registry.NetworkServiceQuery) (matches []*registry.NetworkService) {
+func (s *memoryNSServer) allMatches(match nsMatcher) (matches []*registry.NetworkService) {
 	s.networkServices.Range(func(_ string, ns *registry.NetworkService) bool {
-		if matchutils.MatchNetworkServices(query.NetworkService, ns) {
+		if match(ns) {
 			matches = append(matches, ns.Clone())
 		}
 		return true
@@ -137,7 +141,7 @@ func (s *memoryNSServer) closeEventChannel(id string, eventCh <-chan *registry.N
 }
 
 func (s *memoryNSServer) receiveEvent(
-	query *registry.NetworkServiceQuery,
+	match nsMatcher,
 	server registry.NetworkServiceRegistry_FindServer,
 	eventCh <-chan *registry.NetworkService,
 ) error {
@@ -145,7 +149,7 @@ func (s *memoryNSServer) receiveEvent(
 	case <-server.Context().Done():
 		return io.EOF
 	case event := <-eventCh:
-		if matchutils.MatchNetworkServices(query.NetworkService, event) {
+		if match(event) {
 			nse := &registry.NetworkServiceResponse{
 				NetworkService: event,
 			}
@@ -19,6 +19,7 @@ package memory
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/edwarnicke/serialize"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -27,7 +28,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
-	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
 type memoryNSEServer struct {
@@ -35,6 +36,13 @@ type memoryNSEServer struct {
 	executor                serialize.Executor
 	eventChannels           map[string]chan *registry.NetworkServiceEndpointResponse
 	eventChannelSize        int
+	tombstoneTTL            time.Duration
+	tombstones              map[string]*tombstone
+}
+
+type tombstone struct {
+	nse       *registry.NetworkServiceEndpoint
+	deletedAt time.Time
 }
 
 // NewNetworkServiceEndpointRegistryServer creates new memory based NetworkServiceEndpointRegistryServer
@@ -42,6 +50,7 @@ func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.Network
 	s := &memoryNSEServer{
 		eventChannelSize: defaultEventChannelSize,
 		eventChannels:    make(map[string]chan *registry.NetworkServiceEndpointResponse),
+		tombstones:       make(map[string]*tombstone),
 	}
 	for _, o := range options {
 		o.apply(s)
@@ -53,6 +62,10 @@ func (s *memoryNSEServer) setEventChannelSize(l int) {
 	s.eventChannelSize = l
 }
 
+func (s *memoryNSEServer) setTombstoneTTL(ttl time.Duration) {
+	s.tombstoneTTL = ttl
+}
+
 func (s *memoryNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	r, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
 	if err != nil {
@@ -61,6 +74,12 @@ func (s *memoryNSEServer) Register(ctx context.Context, nse *registry.NetworkSer
 
 	s.networkServiceEndpoints.Store(r.Name, r.Clone())
 
+	if s.tombstoneTTL > 0 {
+		s.executor.AsyncExec(func() {
+			delete(s.tombstones, r.GetName())
+		})
+	}
+
 	s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: r})
 
 	return r, err
@@ -76,19 +95,30 @@ func (s *memoryNSEServer) sendEvent(event *registry.NetworkServiceEndpointRespon
 }
 
 func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	match, err := newNSEMatcher(server.Context(), query)
+	if err != nil {
+		return err
+	}
+
 	if !query.Watch {
-		for _, nse := range s.allMatches(query) {
+		var matches []*registry.NetworkServiceEndpoint
+		if p, ok := pageFromContext(server.Context()); ok {
+			matches = s.pageMatches(match, p)
+		} else {
+			matches = s.allMatches(match)
+		}
+		for _, nse := range matches {
 			nseResp := &registry.NetworkServiceEndpointResponse{
 				NetworkServiceEndpoint: nse,
 			}
-			if err := server.Send(nseResp); err != nil {
+			if err = server.Send(nseResp); err != nil {
 				return err
 			}
 		}
 		return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
 	}
 
-	if err := next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server); err != nil {
+	if err = next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server); err != nil {
 		return err
 	}
 
@@ -97,14 +127,16 @@ func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 
 	s.executor.AsyncExec(func() {
 		s.eventChannels[id] = eventCh
-		for _, entity := range s.allMatches(query) {
+		for _, entity := range s.allMatches(match) {
 			eventCh <- &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: entity}
 		}
+		for _, entity := range s.tombstoneMatches(clock.FromContext(server.Context()).Now(), match) {
+			eventCh <- &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: entity, Deleted: true}
+		}
 	})
 	defer s.closeEventChannel(id, eventCh)
 
-	var err error
-	for ; err == nil; err = s.receiveEvent(query, server, eventCh) {
+	for ; err == nil; err = s.receiveEvent(match, server, eventCh) {
 	}
 	if err != io.EOF {
 		return err
@@ -112,9 +144,9 @@ func (s *memoryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, serv
 	return nil
 }
 
-func (s *memoryNSEServer) allMatches(query *registry.NetworkServiceEndpointQuery) (matches []*registry.NetworkServiceEndpoint) {
+func (s *memoryNSEServer) allMatches(match nseMatcher) (matches []*registry.NetworkServiceEndpoint) {
 	s.networkServiceEndpoints.Range(func(_ string, nse *registry.NetworkServiceEndpoint) bool {
-		if matchutils.MatchNetworkServiceEndpoints(query.NetworkServiceEndpoint, nse) {
+		if match(nse) {
 			matches = append(matches, nse.Clone())
 		}
 		return true
@@ -122,6 +154,20 @@ func (s *memoryNSEServer) allMatches(query *registry.NetworkServiceEndpointQuery
 	return matches
 }
 
+// tombstoneMatches prunes the expired tombstones and returns the matching ones, must be called in the executor
+func (s *memoryNSEServer) tombstoneMatches(now time.Time, match nseMatcher) (matches []*registry.NetworkServiceEndpoint) {
+	for name, t := range s.tombstones {
+		if now.Sub(t.deletedAt) > s.tombstoneTTL {
+			delete(s.tombstones, name)
+			continue
+		}
+		if match(t.nse) {
+			matches = append(matches, t.nse.Clone())
+		}
+	}
+	return matches
+}
+
 func (s *memoryNSEServer) closeEventChannel(id string, eventCh <-chan *registry.NetworkServiceEndpointResponse) {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -140,7 +186,7 @@ func (s *memoryNSEServer) closeEventChannel(id string, eventCh <-chan *registry.
 }
 
 func (s *memoryNSEServer) receiveEvent(
-	query *registry.NetworkServiceEndpointQuery,
+	match nseMatcher,
 	server registry.NetworkServiceEndpointRegistry_FindServer,
 	eventCh <-chan *registry.NetworkServiceEndpointResponse,
 ) error {
@@ -148,7 +194,7 @@ func (s *memoryNSEServer) receiveEvent(
 	case <-server.Context().Done():
 		return io.EOF
 	case event := <-eventCh:
-		if matchutils.MatchNetworkServiceEndpoints(query.NetworkServiceEndpoint, event.NetworkServiceEndpoint) {
+		if match(event.NetworkServiceEndpoint) {
 			if err := server.Send(event); err != nil {
 				if server.Context().Err() != nil {
 					return io.EOF
@@ -163,6 +209,15 @@ func (s *memoryNSEServer) receiveEvent(
 func (s *memoryNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
 	if unregisterNSE, ok := s.networkServiceEndpoints.LoadAndDelete(nse.GetName()); ok {
 		unregisterNSE = unregisterNSE.Clone()
+		if s.tombstoneTTL > 0 {
+			t := &tombstone{
+				nse:       unregisterNSE.Clone(),
+				deletedAt: clock.FromContext(ctx).Now(),
+			}
+			s.executor.AsyncExec(func() {
+				s.tombstones[t.nse.GetName()] = t
+			})
+		}
 		s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: unregisterNSE, Deleted: true})
 	}
 	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
@@ -33,6 +33,8 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
 )
 
 func TestNetworkServiceEndpointRegistryServer_RegisterAndFind(t *testing.T) {
@@ -374,6 +376,72 @@ func TestNetworkServiceEndpointRegistryServer_ShouldReceiveAllUnregisters(t *tes
 	<-ctx.Done()
 }
 
+func TestNetworkServiceEndpointRegistryServer_Tombstones(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	s := memory.NewNetworkServiceEndpointRegistryServer(memory.WithTombstoneTTL(time.Minute))
+
+	for _, name := range []string{"nse-1", "nse-2"} {
+		_, err := s.Register(ctx, &registry.NetworkServiceEndpoint{Name: name})
+		require.NoError(t, err)
+	}
+	_, err := s.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+
+	watch := func() (events []*registry.NetworkServiceEndpointResponse) {
+		findCtx, findCancel := context.WithCancel(ctx)
+		defer findCancel()
+
+		ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+		go func() {
+			defer close(ch)
+			assert.NoError(t, s.Find(&registry.NetworkServiceEndpointQuery{
+				NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+				Watch:                  true,
+			}, streamchannel.NewNetworkServiceEndpointFindServer(findCtx, ch)))
+		}()
+
+		for len(events) < 2 {
+			select {
+			case event := <-ch:
+				events = append(events, event)
+			case <-time.After(50 * time.Millisecond):
+				return events
+			}
+		}
+		return events
+	}
+
+	// Reconnecting watcher observes the deletion
+	events := watch()
+	require.Len(t, events, 2)
+	require.Equal(t, "nse-2", events[0].GetNetworkServiceEndpoint().GetName())
+	require.False(t, events[0].GetDeleted())
+	require.Equal(t, "nse-1", events[1].GetNetworkServiceEndpoint().GetName())
+	require.True(t, events[1].GetDeleted())
+
+	// Tombstone is dropped after TTL
+	clockMock.Add(time.Minute + time.Second)
+	events = watch()
+	require.Len(t, events, 1)
+	require.Equal(t, "nse-2", events[0].GetNetworkServiceEndpoint().GetName())
+
+	// Tombstone is dropped on the new registration
+	_, err = s.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+	events = watch()
+	require.Len(t, events, 1)
+	require.False(t, events[0].GetDeleted())
+}
+
 func createLabeledNSE1() *registry.NetworkServiceEndpoint {
 	labels := map[string]*registry.NetworkServiceLabels{
 		"Service1": {
@@ -16,8 +16,11 @@
 
 package memory
 
+import "time"
+
 type configurable interface {
 	setEventChannelSize(int)
+	setTombstoneTTL(time.Duration)
 }
 
 // Option is memory registry configuration option
@@ -37,3 +40,13 @@ func WithEventChannelSize(l int) Option {
 		c.setEventChannelSize(l)
 	})
 }
+
+// WithTombstoneTTL sets for how long deleted NSEs are retained as tombstones. Watchers connecting within this window
+// after the delete receive the deleted NSE with Deleted flag set, so reconnecting watchers don't miss the deletion
+// and don't keep the stale entry in their caches. 0 (default) disables tombstones.
+// NS registry doesn't send deletion events, so the option has no effect for it.
+func WithTombstoneTTL(ttl time.Duration) Option {
+	return applierFunc(func(c configurable) {
+		c.setTombstoneTTL(ttl)
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// PageSizeKey is a gRPC metadata key of the Find page size
+	PageSizeKey = "nsm-page-size"
+	// CursorKey is a gRPC metadata key of the Find cursor: the name of the last entry of the previous page
+	CursorKey = "nsm-cursor"
+)
+
+// WithPage returns the context asking the memory registry to return a single page of the non-watch Find results:
+// at most pageSize NSEs with the names greater than cursor sorted by name. Empty cursor means the first page.
+func WithPage(ctx context.Context, pageSize int, cursor string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, PageSizeKey, strconv.Itoa(pageSize), CursorKey, cursor)
+}
+
+type page struct {
+	size   int
+	cursor string
+}
+
+func pageFromContext(ctx context.Context) (*page, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	sizes := md.Get(PageSizeKey)
+	if len(sizes) == 0 {
+		return nil, false
+	}
+	size, err := strconv.Atoi(sizes[len(sizes)-1])
+	if err != nil || size <= 0 {
+		return nil, false
+	}
+	p := &page{size: size}
+	if cursors := md.Get(CursorKey); len(cursors) > 0 {
+		p.cursor = cursors[len(cursors)-1]
+	}
+	return p, true
+}
+
+// NSECursor iterates the NSEs matching the query page by page, so the huge registries don't send all the NSEs in a
+// single Find stream and the iteration can be resumed from the Cursor
+type NSECursor struct {
+	client   registry.NetworkServiceEndpointRegistryClient
+	query    *registry.NetworkServiceEndpointQuery
+	pageSize int
+	cursor   string
+	done     bool
+}
+
+// NewNSECursor creates a new NSECursor starting after the cursor, empty cursor means the beginning
+func NewNSECursor(client registry.NetworkServiceEndpointRegistryClient, query *registry.NetworkServiceEndpointQuery, pageSize int, cursor string) *NSECursor {
+	return &NSECursor{
+		client:   client,
+		query:    query,
+		pageSize: pageSize,
+		cursor:   cursor,
+	}
+}
+
+// Next returns the next page of the NSEs, returns io.EOF if there are no more pages
+func (c *NSECursor) Next(ctx context.Context) ([]*registry.NetworkServiceEndpoint, error) {
+	if c.done {
+		return nil, io.EOF
+	}
+
+	stream, err := c.client.Find(WithPage(ctx, c.pageSize, c.cursor), c.query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the next page")
+	}
+	var nses []*registry.NetworkServiceEndpoint
+	for {
+		resp, recvErr := stream.Recv()
+		if recvErr == io.EOF {
+			break
+		}
+		if recvErr != nil {
+			return nil, errors.Wrap(recvErr, "failed to receive the next page")
+		}
+		nses = append(nses, resp.GetNetworkServiceEndpoint())
+	}
+
+	// The short page is the last one, the page longer than requested means the registry doesn't support pagination
+	if len(nses) != c.pageSize {
+		c.done = true
+	}
+	if len(nses) == 0 {
+		return nil, io.EOF
+	}
+	c.cursor = nses[len(nses)-1].GetName()
+
+	return nses, nil
+}
+
+// Cursor returns the cursor to resume the iteration with
+func (c *NSECursor) Cursor() string {
+	return c.cursor
+}
+
+// pageMatches returns the page of the matching NSEs, clones only the NSEs of the page
+func (s *memoryNSEServer) pageMatches(match nseMatcher, p *page) []*registry.NetworkServiceEndpoint {
+	var matches []*registry.NetworkServiceEndpoint
+	s.networkServiceEndpoints.Range(func(name string, nse *registry.NetworkServiceEndpoint) bool {
+		if name > p.cursor && match(nse) {
+			matches = append(matches, nse)
+		}
+		return true
+	})
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].GetName() < matches[j].GetName()
+	})
+	if len(matches) > p.size {
+		matches = matches[:p.size]
+	}
+	for i := range matches {
+		matches[i] = matches[i].Clone()
+	}
+	return matches
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+func TestNSECursor(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+	for i := 0; i < 25; i++ {
+		_, err := mem.Register(ctx, &registry.NetworkServiceEndpoint{
+			Name:                fmt.Sprintf("nse-%02d", i),
+			NetworkServiceNames: []string{fmt.Sprintf("ns-%d", i%2)},
+		})
+		require.NoError(t, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	registry.RegisterNetworkServiceEndpointRegistryServer(grpcServer, mem)
+
+	listenOn := &url.URL{Scheme: "tcp", Host: "127.0.0.1:0"}
+	select {
+	case serveErr := <-grpcutils.ListenAndServe(ctx, listenOn, grpcServer):
+		require.NoError(t, serveErr)
+	default:
+	}
+
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(listenOn), grpc.WithBlock(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := registry.NewNetworkServiceEndpointRegistryClient(cc)
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)}
+
+	cursor := memory.NewNSECursor(client, query, 10, "")
+	var names []string
+	for _, expected := range []int{10, 10, 5} {
+		nses, nextErr := cursor.Next(ctx)
+		require.NoError(t, nextErr)
+		require.Len(t, nses, expected)
+		for _, nse := range nses {
+			names = append(names, nse.GetName())
+		}
+	}
+	_, err = cursor.Next(ctx)
+	require.Equal(t, io.EOF, err)
+	require.Len(t, names, 25)
+	require.IsIncreasing(t, names)
+
+	// Resume the iteration of the matching NSEs
+	query = &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"ns-1"}},
+	}
+	cursor = memory.NewNSECursor(client, query, 5, "nse-10")
+	nses, err := cursor.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "nse-11", nses[0].GetName())
+	require.Equal(t, "nse-19", cursor.Cursor())
+
+	resumed := memory.NewNSECursor(client, query, 5, cursor.Cursor())
+	nses, err = resumed.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, nses, 2)
+	require.Equal(t, "nse-23", resumed.Cursor())
+	_, err = resumed.Next(ctx)
+	require.Equal(t, io.EOF, err)
+
+	// Find without the page returns all the NSEs
+	stream, err := client.Find(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, registry.ReadNetworkServiceEndpointList(stream), 12)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+const (
+	// LabelSelectorKey is a gRPC metadata key of the Find label selector expression, see matchutils.ParseLabelSelector
+	LabelSelectorKey = "nsm-label-selector"
+	// CapabilityKey is a gRPC metadata key of the NSE Find capability filter
+	CapabilityKey = "nsm-capability"
+	// PayloadKey is a gRPC metadata key of the NS Find payload filter
+	PayloadKey = "nsm-payload"
+
+	// CapabilitiesLabel is the NSE network service label holding the comma separated capabilities of the NSE
+	CapabilitiesLabel = "capabilities"
+)
+
+// WithLabelSelector returns the context asking the memory registry to return only the NSEs with the labels matching
+// the selector expression. If the query has NetworkServiceNames, only the labels of these services are checked.
+func WithLabelSelector(ctx context.Context, selector string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, LabelSelectorKey, selector)
+}
+
+// WithCapabilities returns the context asking the memory registry to return only the NSEs having all the capabilities
+// listed in the CapabilitiesLabel. If the query has NetworkServiceNames, only the labels of these services are checked.
+func WithCapabilities(ctx context.Context, capabilities ...string) context.Context {
+	for _, capability := range capabilities {
+		ctx = metadata.AppendToOutgoingContext(ctx, CapabilityKey, capability)
+	}
+	return ctx
+}
+
+// WithPayload returns the context asking the memory registry to return only the network services with the payload
+func WithPayload(ctx context.Context, payload string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, PayloadKey, payload)
+}
+
+type nseMatcher func(nse *registry.NetworkServiceEndpoint) bool
+
+type nsMatcher func(ns *registry.NetworkService) bool
+
+// newNSEMatcher returns the matcher of the NSEs by the query and the label selector from the ctx
+func newNSEMatcher(ctx context.Context, query *registry.NetworkServiceEndpointQuery) (nseMatcher, error) {
+	var selector *matchutils.LabelSelector
+	var capabilities []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if exprs := md.Get(LabelSelectorKey); len(exprs) > 0 {
+			var err error
+			if selector, err = matchutils.ParseLabelSelector(exprs[len(exprs)-1]); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		capabilities = md.Get(CapabilityKey)
+	}
+
+	services := query.GetNetworkServiceEndpoint().GetNetworkServiceNames()
+	return func(nse *registry.NetworkServiceEndpoint) bool {
+		return matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), nse) &&
+			matchutils.MatchNetworkServiceEndpointLabels(selector, services, nse) &&
+			matchCapabilities(capabilities, services, nse)
+	}, nil
+}
+
+// newNSMatcher returns the matcher of the network services by the query and the payload from the ctx
+func newNSMatcher(ctx context.Context, query *registry.NetworkServiceQuery) nsMatcher {
+	var payload string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if payloads := md.Get(PayloadKey); len(payloads) > 0 {
+			payload = payloads[len(payloads)-1]
+		}
+	}
+
+	return func(ns *registry.NetworkService) bool {
+		return matchutils.MatchNetworkServices(query.GetNetworkService(), ns) &&
+			(payload == "" || ns.GetPayload() == payload)
+	}
+}
+
+// matchCapabilities returns true if any of the services of the NSE has all the capabilities. If services are empty,
+// all the NSE services are checked.
+func matchCapabilities(capabilities, services []string, nse *registry.NetworkServiceEndpoint) bool {
+	if len(capabilities) == 0 {
+		return true
+	}
+	if len(services) == 0 {
+		for service := range nse.GetNetworkServiceLabels() {
+			services = append(services, service)
+		}
+	}
+	for _, service := range services {
+		has := make(map[string]struct{})
+		for _, c := range strings.Split(nse.GetNetworkServiceLabels()[service].GetLabels()[CapabilitiesLabel], ",") {
+			has[strings.TrimSpace(c)] = struct{}{}
+		}
+		matches := true
+		for _, c := range capabilities {
+			if _, ok := has[c]; !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+)
+
+func findNames(ctx context.Context, t *testing.T, s registry.NetworkServiceEndpointRegistryServer, selector string, services ...string) []string {
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(memory.LabelSelectorKey, selector))
+
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	err := s.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{NetworkServiceNames: services},
+	}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+	require.NoError(t, err)
+	close(ch)
+
+	var names []string
+	for resp := range ch {
+		names = append(names, resp.GetNetworkServiceEndpoint().GetName())
+	}
+	return names
+}
+
+func TestNetworkServiceEndpointRegistryServer_LabelSelector(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := memory.NewNetworkServiceEndpointRegistryServer()
+	for name, labels := range map[string]map[string]string{
+		"nse-1": {"app": "firewall", "zone": "east"},
+		"nse-2": {"app": "firewall", "zone": "west", "canary": "true"},
+		"nse-3": {"app": "vpn", "zone": "east"},
+	} {
+		_, err := s.Register(ctx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			NetworkServiceNames: []string{"ns-1", "ns-2"},
+			NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+				"ns-1": {Labels: labels},
+				"ns-2": {Labels: map[string]string{"app": "other"}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	require.ElementsMatch(t, []string{"nse-1", "nse-2"}, findNames(ctx, t, s, "app=firewall"))
+	require.ElementsMatch(t, []string{"nse-1"}, findNames(ctx, t, s, "app=firewall,!canary"))
+	require.ElementsMatch(t, []string{"nse-2"}, findNames(ctx, t, s, "zone notin (east), app in (firewall, vpn), canary", "ns-1"))
+	require.ElementsMatch(t, []string{"nse-1", "nse-3"}, findNames(ctx, t, s, "zone=east", "ns-1"))
+	require.Empty(t, findNames(ctx, t, s, "zone=east", "ns-2"))
+
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	err := s.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	}, streamchannel.NewNetworkServiceEndpointFindServer(metadata.NewIncomingContext(ctx, metadata.Pairs(memory.LabelSelectorKey, "zone in (east")), ch))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestNetworkServiceEndpointRegistryServer_Capabilities(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := memory.NewNetworkServiceEndpointRegistryServer()
+	for name, capabilities := range map[string]string{
+		"nse-1": "ipv4,ipv6",
+		"nse-2": "ipv4",
+		"nse-3": "",
+	} {
+		_, err := s.Register(ctx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			NetworkServiceNames: []string{"ns-1", "ns-2"},
+			NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+				"ns-1": {Labels: map[string]string{memory.CapabilitiesLabel: capabilities}},
+				"ns-2": {Labels: map[string]string{memory.CapabilitiesLabel: "ipv6"}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	find := func(query *registry.NetworkServiceEndpoint, capabilities ...string) []string {
+		md := metadata.MD{}
+		for _, c := range capabilities {
+			md.Append(memory.CapabilityKey, c)
+		}
+
+		ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+		err := s.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: query,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(metadata.NewIncomingContext(ctx, md), ch))
+		require.NoError(t, err)
+		close(ch)
+
+		var names []string
+		for resp := range ch {
+			names = append(names, resp.GetNetworkServiceEndpoint().GetName())
+		}
+		return names
+	}
+
+	require.ElementsMatch(t, []string{"nse-1", "nse-2", "nse-3"}, find(new(registry.NetworkServiceEndpoint)))
+	require.ElementsMatch(t, []string{"nse-1", "nse-2", "nse-3"}, find(new(registry.NetworkServiceEndpoint), "ipv6"))
+	require.ElementsMatch(t, []string{"nse-1"}, find(&registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1"},
+	}, "ipv4", "ipv6"))
+	require.ElementsMatch(t, []string{"nse-1", "nse-2"}, find(&registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1"},
+	}, "ipv4"))
+	require.Empty(t, find(new(registry.NetworkServiceEndpoint), "ipv4", "mpls"))
+}
+
+func TestNetworkServiceRegistryServer_Payload(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := memory.NewNetworkServiceRegistryServer()
+	for name, payload := range map[string]string{
+		"ns-1": "IP",
+		"ns-2": "ETHERNET",
+		"ns-3": "IP",
+	} {
+		_, err := s.Register(ctx, &registry.NetworkService{Name: name, Payload: payload})
+		require.NoError(t, err)
+	}
+
+	findCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(memory.PayloadKey, "IP"))
+
+	ch := make(chan *registry.NetworkServiceResponse, 10)
+	err := s.Find(&registry.NetworkServiceQuery{
+		NetworkService: new(registry.NetworkService),
+	}, streamchannel.NewNetworkServiceFindServer(findCtx, ch))
+	require.NoError(t, err)
+	close(ch)
+
+	var names []string
+	for resp := range ch {
+		names = append(names, resp.GetNetworkService().GetName())
+	}
+	require.ElementsMatch(t, []string{"ns-1", "ns-3"}, names)
+
+	// Watch mode applies the same filter to the updates
+	watchCtx, watchCancel := context.WithCancel(findCtx)
+	defer watchCancel()
+
+	ch = make(chan *registry.NetworkServiceResponse, 10)
+	go func() {
+		_ = s.Find(&registry.NetworkServiceQuery{
+			NetworkService: new(registry.NetworkService),
+			Watch:          true,
+		}, streamchannel.NewNetworkServiceFindServer(watchCtx, ch))
+	}()
+
+	names = nil
+	for i := 0; i < 2; i++ {
+		names = append(names, (<-ch).GetNetworkService().GetName())
+	}
+	require.ElementsMatch(t, []string{"ns-1", "ns-3"}, names)
+
+	_, err = s.Register(ctx, &registry.NetworkService{Name: "ns-4", Payload: "ETHERNET"})
+	require.NoError(t, err)
+	_, err = s.Register(ctx, &registry.NetworkService{Name: "ns-5", Payload: "IP"})
+	require.NoError(t, err)
+
+	require.Equal(t, "ns-5", (<-ch).GetNetworkService().GetName())
+}
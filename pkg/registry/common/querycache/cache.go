@@ -30,6 +30,7 @@ type cache struct {
 	expireTimeout time.Duration
 	entries       cacheEntryMap
 	clockTime     clock.Clock
+	snapshot      *Snapshot
 }
 
 func newCache(ctx context.Context, opts ...Option) *cache {
@@ -42,6 +43,10 @@ func newCache(ctx context.Context, opts ...Option) *cache {
 		opt(c)
 	}
 
+	for _, nse := range c.snapshot.GetNetworkServiceEndpoints() {
+		c.storeWarm(nse)
+	}
+
 	ticker := c.clockTime.Ticker(c.expireTimeout)
 	go func() {
 		for {
@@ -81,6 +86,28 @@ func (c *cache) LoadOrStore(key string, nse *registry.NetworkServiceEndpoint, ca
 	})
 }
 
+// storeWarm stores the NSE from the snapshot. Warm entries are not watched for the updates, so unlike the other
+// entries they are not prolonged on Load and expire in the expire timeout after the start.
+func (c *cache) storeWarm(nse *registry.NetworkServiceEndpoint) {
+	key := (&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: nse.GetName(),
+		},
+	}).String()
+
+	var once sync.Once
+	c.entries.Store(key, &cacheEntry{
+		nse:            nse.Clone(),
+		expirationTime: c.clockTime.Now().Add(c.expireTimeout),
+		warm:           true,
+		cleanup: func() {
+			once.Do(func() {
+				c.entries.Delete(key)
+			})
+		},
+	})
+}
+
 func (c *cache) Load(key string) (*registry.NetworkServiceEndpoint, bool) {
 	e, ok := c.entries.Load(key)
 	if !ok {
@@ -95,7 +122,9 @@ func (c *cache) Load(key string) (*registry.NetworkServiceEndpoint, bool) {
 		return nil, false
 	}
 
-	e.expirationTime = c.clockTime.Now().Add(c.expireTimeout)
+	if !e.warm {
+		e.expirationTime = c.clockTime.Now().Add(c.expireTimeout)
+	}
 
 	return e.nse, true
 }
@@ -103,6 +132,7 @@ func (c *cache) Load(key string) (*registry.NetworkServiceEndpoint, bool) {
 type cacheEntry struct {
 	nse            *registry.NetworkServiceEndpoint
 	expirationTime time.Time
+	warm           bool
 	lock           sync.Mutex
 	cleanup        func()
 }
@@ -18,6 +18,7 @@ package querycache_test
 
 import (
 	"context"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -190,6 +191,61 @@ func Test_QueryCacheClient_ShouldCleanUpOnTimeout(t *testing.T) {
 	require.Errorf(t, err, "find error")
 }
 
+func Test_QueryCacheClient_ShouldWarmUpFromSnapshot(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+
+	_, err := mem.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name: name,
+		Url:  url1,
+	})
+	require.NoError(t, err)
+
+	// 1. Take the snapshot from the registry and save it
+	snapshot, err := querycache.FindSnapshot(ctx, adapters.NetworkServiceEndpointServerToClient(mem))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, querycache.WriteSnapshot(path, snapshot))
+
+	snapshot, err = querycache.ReadSnapshot(path)
+	require.NoError(t, err)
+	require.Len(t, snapshot.NetworkServiceEndpoints, 1)
+
+	// 2. Find from the warm cache while the registry is not available
+	failureClient := &failureNSEClient{shouldFail: 1}
+	c := next.NewNetworkServiceEndpointRegistryClient(
+		querycache.NewClient(ctx, querycache.WithExpireTimeout(expireTimeout), querycache.WithSnapshot(snapshot)),
+		failureClient,
+		adapters.NetworkServiceEndpointServerToClient(mem),
+	)
+
+	stream, err := c.Find(ctx, testNSEQuery(name))
+	require.NoError(t, err)
+
+	nseResp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, url1, nseResp.NetworkServiceEndpoint.Url)
+
+	// 3. Warm entries are not prolonged on find and expire
+	clockMock.Add(expireTimeout / 2)
+
+	_, err = c.Find(ctx, testNSEQuery(name))
+	require.NoError(t, err)
+
+	clockMock.Add(expireTimeout)
+
+	_, err = c.Find(ctx, testNSEQuery(name))
+	require.Errorf(t, err, "find error")
+}
+
 type failureNSEClient struct {
 	shouldFail int32
 }
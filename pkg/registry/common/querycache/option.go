@@ -27,3 +27,11 @@ func WithExpireTimeout(expireTimeout time.Duration) Option {
 		c.expireTimeout = expireTimeout
 	}
 }
+
+// WithSnapshot sets the snapshot of NSEs to warm up the cache on start, so the first queries after the restart don't
+// have to wait for the registry
+func WithSnapshot(snapshot *Snapshot) Option {
+	return func(c *cache) {
+		c.snapshot = snapshot
+	}
+}
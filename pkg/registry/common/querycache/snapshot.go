@@ -0,0 +1,109 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querycache
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Snapshot is the snapshot of the NSEs to warm up the cache, it can be serialized with encoding/json
+type Snapshot struct {
+	NetworkServiceEndpoints []*registry.NetworkServiceEndpoint
+}
+
+type snapshotJSON struct {
+	NetworkServiceEndpoints []json.RawMessage `json:"networkServiceEndpoints"`
+}
+
+// GetNetworkServiceEndpoints returns the snapshot NSEs, it is nil-safe
+func (s *Snapshot) GetNetworkServiceEndpoints() []*registry.NetworkServiceEndpoint {
+	if s == nil {
+		return nil
+	}
+	return s.NetworkServiceEndpoints
+}
+
+// MarshalJSON implements json.Marshaler
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	v := new(snapshotJSON)
+	for _, nse := range s.NetworkServiceEndpoints {
+		data, err := protojson.Marshal(nse)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal nse %s", nse.GetName())
+		}
+		v.NetworkServiceEndpoints = append(v.NetworkServiceEndpoints, data)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	var v snapshotJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Wrap(err, "failed to unmarshal snapshot")
+	}
+	s.NetworkServiceEndpoints = nil
+	for _, raw := range v.NetworkServiceEndpoints {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := protojson.Unmarshal(raw, nse); err != nil {
+			return errors.Wrap(err, "failed to unmarshal nse")
+		}
+		s.NetworkServiceEndpoints = append(s.NetworkServiceEndpoints, nse)
+	}
+	return nil
+}
+
+// FindSnapshot creates a snapshot from the full Find query to the registry client
+func FindSnapshot(ctx context.Context, c registry.NetworkServiceEndpointRegistryClient) (*Snapshot, error) {
+	stream, err := c.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find nses")
+	}
+	return &Snapshot{
+		NetworkServiceEndpoints: registry.ReadNetworkServiceEndpointList(stream),
+	}, nil
+}
+
+// ReadSnapshot reads the snapshot from the file written by WriteSnapshot
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - the path is set by the application
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snapshot %s", path)
+	}
+	s := new(Snapshot)
+	if err = json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// WriteSnapshot writes the snapshot to the file
+func WriteSnapshot(path string, s *Snapshot) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(os.WriteFile(path, data, 0o600), "failed to write snapshot %s", path)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides chain elements limiting the rate of Registers and Finds per peer identity and globally, to
+// protect the registries exposed to many clusters. Unregister is never limited so resources are released promptly.
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/ratelimit"
+)
+
+type rateLimitNSEServer struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewNetworkServiceEndpointRegistryServer - returns a new rate limit NSE server chain element rejecting the Registers
+// and Finds over the limits with RESOURCE_EXHAUSTED. The limiter could be shared with other chain elements to limit
+// their operations together.
+func NewNetworkServiceEndpointRegistryServer(limiter *ratelimit.Limiter) registry.NetworkServiceEndpointRegistryServer {
+	return &rateLimitNSEServer{
+		limiter: limiter,
+	}
+}
+
+func (s *rateLimitNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.limiter.Allow(ctx); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *rateLimitNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if err := s.limiter.Allow(server.Context()); err != nil {
+		return err
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *rateLimitNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/ratelimit"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	toolsratelimit "github.com/networkservicemesh/sdk/pkg/tools/ratelimit"
+)
+
+func withPeer(ctx context.Context, ip string) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 5000},
+	})
+}
+
+func TestRateLimitNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		ratelimit.NewNetworkServiceEndpointRegistryServer(toolsratelimit.NewLimiter(toolsratelimit.WithIdentityLimit(1, 1))),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	ctxA, ctxB := withPeer(ctx, "10.0.0.1"), withPeer(ctx, "10.0.0.2")
+
+	_, err := server.Register(ctxA, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+
+	_, err = server.Register(ctxA, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	retryAfter, ok := toolsratelimit.RetryAfter(err)
+	require.True(t, ok)
+	require.Equal(t, time.Second, retryAfter)
+
+	err = server.Find(&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)},
+		streamchannel.NewNetworkServiceEndpointFindServer(ctxA, make(chan *registry.NetworkServiceEndpointResponse, 10)))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Unregister is never limited
+	_, err = server.Unregister(ctxA, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+
+	_, err = server.Register(ctxB, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+
+	clockMock.Add(retryAfter)
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	require.NoError(t, server.Find(&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)},
+		streamchannel.NewNetworkServiceEndpointFindServer(ctxA, ch)))
+	require.Len(t, ch, 1)
+}
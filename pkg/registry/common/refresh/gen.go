@@ -19,7 +19,12 @@ package refresh
 import "sync"
 
 //go:generate go-syncmap -output sync_map.gen.go -type cancelsMap<string,context.CancelFunc>
+//go:generate go-syncmap -output watches_map.gen.go -type watchesMap<string,*entryWatch>
 
 // cancelsMap is like a Go map[string]context.CancelFunc but is safe for concurrent use
 // by multiple goroutines without additional locking or coordination
 type cancelsMap sync.Map
+
+// watchesMap is like a Go map[string]*entryWatch but is safe for concurrent use
+// by multiple goroutines without additional locking or coordination
+type watchesMap sync.Map
@@ -30,16 +30,22 @@ import (
 )
 
 type refreshNSEClient struct {
-	ctx context.Context
+	ctx   context.Context
+	watch bool
 	cancelsMap
+	watches watchesMap
 }
 
 // NewNetworkServiceEndpointRegistryClient creates new NetworkServiceEndpointRegistryClient that will refresh expiration
 // time for registered NSEs
-func NewNetworkServiceEndpointRegistryClient(ctx context.Context) registry.NetworkServiceEndpointRegistryClient {
-	return &refreshNSEClient{
+func NewNetworkServiceEndpointRegistryClient(ctx context.Context, opts ...Option) registry.NetworkServiceEndpointRegistryClient {
+	c := &refreshNSEClient{
 		ctx: ctx,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 func (c *refreshNSEClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
@@ -59,6 +65,10 @@ func (c *refreshNSEClient) Register(ctx context.Context, nse *registry.NetworkSe
 
 	c.Store(nse.Name, cancel)
 
+	if c.watch {
+		c.watchEntry(ctx, factory, resp)
+	}
+
 	var clockTime = clock.FromContext(ctx)
 
 	if resp.GetExpirationTime() != nil {
@@ -85,5 +95,8 @@ func (c *refreshNSEClient) Unregister(ctx context.Context, nse *registry.Network
 	if v, ok := c.LoadAndDelete(nse.GetName()); ok {
 		v()
 	}
+	if w, ok := c.watches.LoadAndDelete(nse.GetName()); ok {
+		w.cancel()
+	}
 	return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, nse, opts...)
 }
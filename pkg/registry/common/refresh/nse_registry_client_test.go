@@ -32,8 +32,10 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/registry"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/null"
 	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/registry/utils/checks/checknse"
 	"github.com/networkservicemesh/sdk/pkg/tools/clock"
@@ -300,6 +302,58 @@ func Test_RefreshNSEClient_CorrectInitialRegTime(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_RefreshNSEClient_Watch(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	mem := memory.NewNetworkServiceEndpointRegistryServer()
+	countClient := new(requestCountClient)
+	client := next.NewNetworkServiceEndpointRegistryClient(
+		begin.NewNetworkServiceEndpointRegistryClient(),
+		refresh.NewNetworkServiceEndpointRegistryClient(ctx, refresh.WithWatch()),
+		countClient,
+		checknse.NewClient(t, func(t *testing.T, nse *registry.NetworkServiceEndpoint) {
+			nse.ExpirationTime = testNSE(clockMock).ExpirationTime
+		}),
+		adapters.NetworkServiceEndpointServerToClient(mem),
+	)
+
+	reg, err := client.Register(ctx, testNSE(clockMock))
+	require.NoError(t, err)
+
+	// Wait for the watch to be established
+	time.Sleep(testWait)
+
+	// Entry disappears from the registry
+	_, err = mem.Unregister(ctx, reg.Clone())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) == 2
+	}, testWait, testTick)
+
+	// Registry shortens the expiration time
+	shortened := reg.Clone()
+	shortened.ExpirationTime = timestamppb.New(clockMock.Now().Add(time.Second))
+	_, err = mem.Register(ctx, shortened)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) == 3
+	}, testWait, testTick)
+
+	// Own refreshes don't trigger more refreshes
+	require.Never(t, func() bool {
+		return atomic.LoadInt32(&countClient.requestCount) > 3
+	}, testWait, testTick)
+
+	_, err = client.Unregister(ctx, reg)
+	require.NoError(t, err)
+}
+
 type requestCountClient struct {
 	requestCount int32
 
@@ -315,3 +369,7 @@ func (t *requestCountClient) Register(ctx context.Context, nse *registry.Network
 func (t *requestCountClient) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return next.NetworkServiceEndpointRegistryClient(ctx).Unregister(ctx, nse, opts...)
 }
+
+func (t *requestCountClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return next.NetworkServiceEndpointRegistryClient(ctx).Find(ctx, query, opts...)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package refresh
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// entryWatch watches the registered NSE in the registry
+type entryWatch struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	factory        begin.EventFactory
+	expirationTime time.Time
+	mu             sync.Mutex
+}
+
+func (w *entryWatch) update(factory begin.EventFactory, nse *registry.NetworkServiceEndpoint) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.factory = factory
+	w.expirationTime = time.Time{}
+	if nse.GetExpirationTime() != nil {
+		w.expirationTime = nse.GetExpirationTime().AsTime()
+	}
+}
+
+// isStale returns true if the registry has deleted the NSE or shortened its expiration time
+func (w *entryWatch) isStale(resp *registry.NetworkServiceEndpointResponse) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if resp.GetDeleted() {
+		return true
+	}
+	if resp.GetNetworkServiceEndpoint().GetExpirationTime() == nil || w.expirationTime.IsZero() {
+		return false
+	}
+	return resp.GetNetworkServiceEndpoint().GetExpirationTime().AsTime().Before(w.expirationTime)
+}
+
+func (w *entryWatch) refresh() {
+	w.mu.Lock()
+	factory := w.factory
+	w.mu.Unlock()
+
+	<-factory.Register(begin.CancelContext(w.ctx))
+}
+
+// watchEntry starts watching the registered NSE if it is not watched yet, the watch lives until Unregister
+func (c *refreshNSEClient) watchEntry(ctx context.Context, factory begin.EventFactory, nse *registry.NetworkServiceEndpoint) {
+	if w, ok := c.watches.Load(nse.GetName()); ok {
+		w.update(factory, nse)
+		return
+	}
+
+	w := new(entryWatch)
+	w.ctx, w.cancel = context.WithCancel(c.ctx)
+	w.update(factory, nse)
+	c.watches.Store(nse.GetName(), w)
+
+	// The watch outlives the Register, but still needs the chain values from its context
+	watchCtx := extend.WithValuesFromContext(w.ctx, ctx)
+	stream, err := next.NetworkServiceEndpointRegistryClient(ctx).Find(watchCtx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: nse.GetName(),
+		},
+		Watch: true,
+	})
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to watch %s, refreshing only by timer: %s", nse.GetName(), err.Error())
+		return
+	}
+
+	go func() {
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr != nil {
+				if w.ctx.Err() == nil {
+					log.FromContext(w.ctx).Warnf("watch for %s is closed, refreshing only by timer: %s", nse.GetName(), recvErr.Error())
+				}
+				return
+			}
+			if resp.GetNetworkServiceEndpoint().GetName() != nse.GetName() || !w.isStale(resp) {
+				continue
+			}
+			log.FromContext(w.ctx).Infof("%s is deleted or expires earlier in the registry, refreshing", nse.GetName())
+			w.refresh()
+		}
+	}()
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resync provides registry chain elements periodically resynchronizing the watch Find streams, so the
+// watchers detect the deletes missed during network partitions without restarting and re-listing.
+// On each resync the element lists the current entries from the next elements and sends them to the watcher as
+// updates, sends delete events for the entries the watcher has seen but which are no longer present, and then sends
+// a bookmark event if the watcher has asked for it with WithBookmarks.
+package resync
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+)
+
+const (
+	// BookmarksKey is a gRPC metadata key asking the resync element to send the bookmark events
+	BookmarksKey = "nsm-bookmarks"
+	// BookmarkMarker marks the bookmark events: it is set as NetworkService.Payload and NetworkServiceEndpoint.Url of
+	// the entries with the empty names
+	BookmarkMarker = "nsm-bookmark"
+)
+
+// WithBookmarks returns the context asking the resync element to send a bookmark event after each resync of the watch
+// Find stream. Only the watchers supporting the bookmarks should ask for them, because the bookmark is sent as a
+// regular entry, see IsNSBookmark and IsNSEBookmark.
+func WithBookmarks(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, BookmarksKey, strconv.FormatBool(true))
+}
+
+// IsNSBookmark returns true if the response is a bookmark event
+func IsNSBookmark(resp *registry.NetworkServiceResponse) bool {
+	return resp.GetNetworkService().GetName() == "" && resp.GetNetworkService().GetPayload() == BookmarkMarker
+}
+
+// IsNSEBookmark returns true if the response is a bookmark event
+func IsNSEBookmark(resp *registry.NetworkServiceEndpointResponse) bool {
+	return resp.GetNetworkServiceEndpoint().GetName() == "" && resp.GetNetworkServiceEndpoint().GetUrl() == BookmarkMarker
+}
+
+func bookmarksRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(BookmarksKey)
+	if len(values) == 0 {
+		return false
+	}
+	requested, _ := strconv.ParseBool(values[len(values)-1])
+	return requested
+}
+
+// withoutPage returns the context without the pagination metadata, so the resync lists all the entries
+func withoutPage(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md = md.Copy()
+	delete(md, memory.PageSizeKey)
+	delete(md, memory.CursorKey)
+	return metadata.NewIncomingContext(ctx, md)
+}
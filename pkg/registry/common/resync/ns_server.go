@@ -0,0 +1,193 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type resyncNSServer struct {
+	period time.Duration
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer periodically resynchronizing the watch Find streams
+func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegistryServer {
+	o := &options{
+		period: defaultPeriod,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &resyncNSServer{
+		period: o.period,
+	}
+}
+
+func (s *resyncNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *resyncNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	nextServer := next.NetworkServiceRegistryServer(server.Context())
+	if !query.GetWatch() {
+		return nextServer.Find(query, server)
+	}
+
+	watchServer := &nsWatchServer{
+		NetworkServiceRegistry_FindServer: server,
+		sent:                              make(map[string]*registry.NetworkService),
+	}
+
+	ctx, cancel := context.WithCancel(server.Context())
+	ticker := clock.FromContext(ctx).Ticker(s.period)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchServer.resyncLoop(ctx, ticker, nextServer, query)
+	}()
+	defer func() {
+		cancel()
+		<-done
+		ticker.Stop()
+	}()
+
+	return nextServer.Find(query, watchServer)
+}
+
+func (s *resyncNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+// nsWatchServer tracks the entries sent to the watcher, so the resync can find the missed deletes
+type nsWatchServer struct {
+	registry.NetworkServiceRegistry_FindServer
+
+	mu   sync.Mutex
+	sent map[string]*registry.NetworkService
+	// touched are the names sent while the resync lists the entries, the listed state of them may be already stale
+	touched map[string]struct{}
+}
+
+func (s *nsWatchServer) resyncLoop(ctx context.Context, ticker clock.Ticker, nextServer registry.NetworkServiceRegistryServer, query *registry.NetworkServiceQuery) {
+	logger := log.FromContext(ctx).WithField("resyncNSServer", "Find")
+	bookmarks := bookmarksRequested(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+		if err := s.resync(ctx, nextServer, query, bookmarks); err != nil && ctx.Err() == nil {
+			logger.Warnf("failed to resync the watch: %s", err.Error())
+		}
+	}
+}
+
+func (s *nsWatchServer) Send(resp *registry.NetworkServiceResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.send(resp)
+}
+
+func (s *nsWatchServer) send(resp *registry.NetworkServiceResponse) error {
+	name := resp.GetNetworkService().GetName()
+	if resp.GetDeleted() {
+		delete(s.sent, name)
+	} else {
+		s.sent[name] = resp.GetNetworkService()
+	}
+	if s.touched != nil {
+		s.touched[name] = struct{}{}
+	}
+	return s.NetworkServiceRegistry_FindServer.Send(resp)
+}
+
+func (s *nsWatchServer) resync(ctx context.Context, nextServer registry.NetworkServiceRegistryServer, query *registry.NetworkServiceQuery, bookmarks bool) error {
+	s.mu.Lock()
+	s.touched = make(map[string]struct{})
+	s.mu.Unlock()
+
+	nss, err := listNSs(ctx, nextServer, query)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	touched := s.touched
+	s.touched = nil
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]struct{})
+	for _, ns := range nss {
+		current[ns.GetName()] = struct{}{}
+		if _, ok := touched[ns.GetName()]; ok {
+			continue
+		}
+		if err = s.send(&registry.NetworkServiceResponse{NetworkService: ns}); err != nil {
+			return err
+		}
+	}
+	for name, ns := range s.sent {
+		_, isCurrent := current[name]
+		_, isTouched := touched[name]
+		if isCurrent || isTouched {
+			continue
+		}
+		if err = s.send(&registry.NetworkServiceResponse{NetworkService: ns, Deleted: true}); err != nil {
+			return err
+		}
+	}
+
+	if bookmarks {
+		return s.NetworkServiceRegistry_FindServer.Send(&registry.NetworkServiceResponse{
+			NetworkService: &registry.NetworkService{Payload: BookmarkMarker},
+		})
+	}
+	return nil
+}
+
+func listNSs(ctx context.Context, nextServer registry.NetworkServiceRegistryServer, query *registry.NetworkServiceQuery) ([]*registry.NetworkService, error) {
+	listQuery := proto.Clone(query).(*registry.NetworkServiceQuery)
+	listQuery.Watch = false
+
+	ch := make(chan *registry.NetworkServiceResponse)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		errCh <- nextServer.Find(listQuery, streamchannel.NewNetworkServiceFindServer(withoutPage(ctx), ch))
+	}()
+
+	var nss []*registry.NetworkService
+	for resp := range ch {
+		nss = append(nss, resp.GetNetworkService())
+	}
+	return nss, <-errCh
+}
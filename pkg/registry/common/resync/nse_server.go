@@ -0,0 +1,193 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type resyncNSEServer struct {
+	period time.Duration
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer periodically resynchronizing the watch Find streams
+func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	o := &options{
+		period: defaultPeriod,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &resyncNSEServer{
+		period: o.period,
+	}
+}
+
+func (s *resyncNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *resyncNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	nextServer := next.NetworkServiceEndpointRegistryServer(server.Context())
+	if !query.GetWatch() {
+		return nextServer.Find(query, server)
+	}
+
+	watchServer := &nseWatchServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		sent: make(map[string]*registry.NetworkServiceEndpoint),
+	}
+
+	ctx, cancel := context.WithCancel(server.Context())
+	ticker := clock.FromContext(ctx).Ticker(s.period)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchServer.resyncLoop(ctx, ticker, nextServer, query)
+	}()
+	defer func() {
+		cancel()
+		<-done
+		ticker.Stop()
+	}()
+
+	return nextServer.Find(query, watchServer)
+}
+
+func (s *resyncNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// nseWatchServer tracks the entries sent to the watcher, so the resync can find the missed deletes
+type nseWatchServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+
+	mu   sync.Mutex
+	sent map[string]*registry.NetworkServiceEndpoint
+	// touched are the names sent while the resync lists the entries, the listed state of them may be already stale
+	touched map[string]struct{}
+}
+
+func (s *nseWatchServer) resyncLoop(ctx context.Context, ticker clock.Ticker, nextServer registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery) {
+	logger := log.FromContext(ctx).WithField("resyncNSEServer", "Find")
+	bookmarks := bookmarksRequested(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+		if err := s.resync(ctx, nextServer, query, bookmarks); err != nil && ctx.Err() == nil {
+			logger.Warnf("failed to resync the watch: %s", err.Error())
+		}
+	}
+}
+
+func (s *nseWatchServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.send(resp)
+}
+
+func (s *nseWatchServer) send(resp *registry.NetworkServiceEndpointResponse) error {
+	name := resp.GetNetworkServiceEndpoint().GetName()
+	if resp.GetDeleted() {
+		delete(s.sent, name)
+	} else {
+		s.sent[name] = resp.GetNetworkServiceEndpoint()
+	}
+	if s.touched != nil {
+		s.touched[name] = struct{}{}
+	}
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+}
+
+func (s *nseWatchServer) resync(ctx context.Context, nextServer registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery, bookmarks bool) error {
+	s.mu.Lock()
+	s.touched = make(map[string]struct{})
+	s.mu.Unlock()
+
+	nses, err := listNSEs(ctx, nextServer, query)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	touched := s.touched
+	s.touched = nil
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]struct{})
+	for _, nse := range nses {
+		current[nse.GetName()] = struct{}{}
+		if _, ok := touched[nse.GetName()]; ok {
+			continue
+		}
+		if err = s.send(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse}); err != nil {
+			return err
+		}
+	}
+	for name, nse := range s.sent {
+		_, isCurrent := current[name]
+		_, isTouched := touched[name]
+		if isCurrent || isTouched {
+			continue
+		}
+		if err = s.send(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse, Deleted: true}); err != nil {
+			return err
+		}
+	}
+
+	if bookmarks {
+		return s.NetworkServiceEndpointRegistry_FindServer.Send(&registry.NetworkServiceEndpointResponse{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Url: BookmarkMarker},
+		})
+	}
+	return nil
+}
+
+func listNSEs(ctx context.Context, nextServer registry.NetworkServiceEndpointRegistryServer, query *registry.NetworkServiceEndpointQuery) ([]*registry.NetworkServiceEndpoint, error) {
+	listQuery := proto.Clone(query).(*registry.NetworkServiceEndpointQuery)
+	listQuery.Watch = false
+
+	ch := make(chan *registry.NetworkServiceEndpointResponse)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		errCh <- nextServer.Find(listQuery, streamchannel.NewNetworkServiceEndpointFindServer(withoutPage(ctx), ch))
+	}()
+
+	var nses []*registry.NetworkServiceEndpoint
+	for resp := range ch {
+		nses = append(nses, resp.GetNetworkServiceEndpoint())
+	}
+	return nses, <-errCh
+}
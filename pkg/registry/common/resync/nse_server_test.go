@@ -0,0 +1,181 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resync_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/resync"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+// lossyNSEServer sends only the initial state to the watchers, so all the following changes are missed by them
+type lossyNSEServer struct {
+	mu   sync.Mutex
+	nses []*registry.NetworkServiceEndpoint
+}
+
+func (s *lossyNSEServer) set(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nses = nil
+	for _, name := range names {
+		s.nses = append(s.nses, &registry.NetworkServiceEndpoint{Name: name})
+	}
+}
+
+func (s *lossyNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *lossyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	s.mu.Lock()
+	nses := s.nses
+	s.mu.Unlock()
+
+	for _, nse := range nses {
+		if err := server.Send(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse}); err != nil {
+			return err
+		}
+	}
+	if query.GetWatch() {
+		<-server.Context().Done()
+	}
+	return nil
+}
+
+func (s *lossyNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func receive(t *testing.T, ch <-chan *registry.NetworkServiceEndpointResponse) *registry.NetworkServiceEndpointResponse {
+	select {
+	case resp := <-ch:
+		return resp
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return nil
+	}
+}
+
+func TestResyncNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	lossy := new(lossyNSEServer)
+	lossy.set("nse-1", "nse-2")
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		resync.NewNetworkServiceEndpointRegistryServer(resync.WithPeriod(time.Minute)),
+		lossy,
+	)
+
+	findCtx, findCancel := context.WithCancel(metadata.NewIncomingContext(ctx, metadata.Pairs(resync.BookmarksKey, "true")))
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+			Watch:                  true,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(findCtx, ch))
+	}()
+
+	require.Equal(t, "nse-1", receive(t, ch).GetNetworkServiceEndpoint().GetName())
+	require.Equal(t, "nse-2", receive(t, ch).GetNetworkServiceEndpoint().GetName())
+
+	// nse-2 is deleted and nse-3 is registered, but the watcher misses it
+	lossy.set("nse-1", "nse-3")
+	clockMock.Add(time.Minute)
+
+	resp := receive(t, ch)
+	require.Equal(t, "nse-1", resp.GetNetworkServiceEndpoint().GetName())
+	require.False(t, resp.GetDeleted())
+	resp = receive(t, ch)
+	require.Equal(t, "nse-3", resp.GetNetworkServiceEndpoint().GetName())
+	require.False(t, resp.GetDeleted())
+	resp = receive(t, ch)
+	require.Equal(t, "nse-2", resp.GetNetworkServiceEndpoint().GetName())
+	require.True(t, resp.GetDeleted())
+	require.True(t, resync.IsNSEBookmark(receive(t, ch)))
+
+	// The next resync doesn't repeat the delete
+	clockMock.Add(time.Minute)
+	require.Equal(t, "nse-1", receive(t, ch).GetNetworkServiceEndpoint().GetName())
+	require.Equal(t, "nse-3", receive(t, ch).GetNetworkServiceEndpoint().GetName())
+	require.True(t, resync.IsNSEBookmark(receive(t, ch)))
+
+	findCancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestResyncNSEServer_NoBookmarks(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	lossy := new(lossyNSEServer)
+	lossy.set("nse-1")
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		resync.NewNetworkServiceEndpointRegistryServer(resync.WithPeriod(time.Minute)),
+		lossy,
+	)
+
+	findCtx, findCancel := context.WithCancel(ctx)
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+			Watch:                  true,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(findCtx, ch))
+	}()
+	require.Equal(t, "nse-1", receive(t, ch).GetNetworkServiceEndpoint().GetName())
+
+	lossy.set()
+	clockMock.Add(time.Minute)
+
+	resp := receive(t, ch)
+	require.Equal(t, "nse-1", resp.GetNetworkServiceEndpoint().GetName())
+	require.True(t, resp.GetDeleted())
+	require.Never(t, func() bool { return len(ch) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	findCancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+type boltStore struct {
+	db     *bbolt.DB
+	bucket []byte
+}
+
+// NewBoltStore creates a Store keeping the values in the bucket of the bbolt database. Different stores should use
+// different buckets of the same database, because the database file can't be opened twice.
+func NewBoltStore(db *bbolt.DB, bucket string) (Store, error) {
+	s := &boltStore{
+		db:     db,
+		bucket: []byte(bucket),
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(s.bucket)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to create bucket: %s", bucket)
+	}
+	return s, nil
+}
+
+func (s *boltStore) Put(_ context.Context, key string, value []byte) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(key), value)
+	})
+}
+
+func (s *boltStore) Delete(_ context.Context, key string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(key))
+	})
+}
+
+func (s *boltStore) Load(_ context.Context) (map[string][]byte, error) {
+	values := make(map[string][]byte)
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(s.bucket).ForEach(func(k, v []byte) error {
+			// The values are valid only during the transaction
+			values[string(k)] = append([]byte(nil), v...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage provides registry chain elements persisting the registered network services and network service
+// endpoints into a pluggable Store, so the registry state survives registry restarts without Kubernetes.
+// The elements should be placed right before the memory elements. On startup, the stored entries should be restored
+// with RestoreNetworkServices and RestoreNetworkServiceEndpoints passing the whole registry chain, so they go through
+// the same chain elements (e.g. expire) as the newly registered ones.
+package storage
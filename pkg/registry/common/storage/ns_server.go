@@ -0,0 +1,73 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type storageNSServer struct {
+	store Store
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer persisting the registered nss into the store
+func NewNetworkServiceRegistryServer(store Store) registry.NetworkServiceRegistryServer {
+	return &storageNSServer{
+		store: store,
+	}
+}
+
+func (s *storageNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(resp)
+	if err == nil {
+		err = s.store.Put(ctx, resp.GetName(), data)
+	}
+	if err != nil {
+		if _, unregisterErr := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, resp); unregisterErr != nil {
+			err = errors.Wrapf(err, "failed to unregister %s: %s", resp.GetName(), unregisterErr.Error())
+		}
+		return nil, errors.Wrapf(err, "failed to store %s", resp.GetName())
+	}
+
+	return resp, nil
+}
+
+func (s *storageNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *storageNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if _, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns); err != nil {
+		return nil, err
+	}
+	if err := s.store.Delete(ctx, ns.GetName()); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete %s from the store", ns.GetName())
+	}
+	return new(empty.Empty), nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/storage"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+)
+
+func TestStorageNSServer_Restore(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := openStore(t, filepath.Join(t.TempDir(), "registry.db"), "ns")
+
+	server := next.NewNetworkServiceRegistryServer(
+		storage.NewNetworkServiceRegistryServer(store),
+		memory.NewNetworkServiceRegistryServer(),
+	)
+
+	_, err := server.Register(ctx, &registry.NetworkService{Name: "ns-1", Payload: "IP"})
+	require.NoError(t, err)
+	ns2, err := server.Register(ctx, &registry.NetworkService{Name: "ns-2"})
+	require.NoError(t, err)
+	_, err = server.Unregister(ctx, ns2)
+	require.NoError(t, err)
+
+	// Restore into the new registry
+	server = next.NewNetworkServiceRegistryServer(
+		storage.NewNetworkServiceRegistryServer(store),
+		memory.NewNetworkServiceRegistryServer(),
+	)
+	require.NoError(t, storage.RestoreNetworkServices(ctx, store, server))
+
+	ch := make(chan *registry.NetworkServiceResponse, 10)
+	err = server.Find(&registry.NetworkServiceQuery{
+		NetworkService: new(registry.NetworkService),
+	}, streamchannel.NewNetworkServiceFindServer(ctx, ch))
+	require.NoError(t, err)
+	close(ch)
+
+	require.Len(t, ch, 1)
+	ns := (<-ch).GetNetworkService()
+	require.Equal(t, "ns-1", ns.GetName())
+	require.Equal(t, "IP", ns.GetPayload())
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type storageNSEServer struct {
+	store Store
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer persisting the registered nses into the store
+func NewNetworkServiceEndpointRegistryServer(store Store) registry.NetworkServiceEndpointRegistryServer {
+	return &storageNSEServer{
+		store: store,
+	}
+}
+
+func (s *storageNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(resp)
+	if err == nil {
+		err = s.store.Put(ctx, resp.GetName(), data)
+	}
+	if err != nil {
+		if _, unregisterErr := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, resp); unregisterErr != nil {
+			err = errors.Wrapf(err, "failed to unregister %s: %s", resp.GetName(), unregisterErr.Error())
+		}
+		return nil, errors.Wrapf(err, "failed to store %s", resp.GetName())
+	}
+
+	return resp, nil
+}
+
+func (s *storageNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *storageNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if _, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse); err != nil {
+		return nil, err
+	}
+	if err := s.store.Delete(ctx, nse.GetName()); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete %s from the store", nse.GetName())
+	}
+	return new(empty.Empty), nil
+}
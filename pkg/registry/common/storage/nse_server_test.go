@@ -0,0 +1,148 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/storage"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func openStore(t *testing.T, path, bucket string) storage.Store {
+	db, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store, err := storage.NewBoltStore(db, bucket)
+	require.NoError(t, err)
+	return store
+}
+
+func findNSENames(ctx context.Context, t *testing.T, server registry.NetworkServiceEndpointRegistryServer) []string {
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	err := server.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, ch))
+	require.NoError(t, err)
+	close(ch)
+
+	var names []string
+	for resp := range ch {
+		names = append(names, resp.GetNetworkServiceEndpoint().GetName())
+	}
+	return names
+}
+
+func TestStorageNSEServer_Restore(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	path := filepath.Join(t.TempDir(), "registry.db")
+	db, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	store, err := storage.NewBoltStore(db, "nse")
+	require.NoError(t, err)
+
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		storage.NewNetworkServiceEndpointRegistryServer(store),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err = server.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns"},
+	})
+	require.NoError(t, err)
+	_, err = server.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-2",
+		ExpirationTime: timestamppb.New(clockMock.Now().Add(time.Minute)),
+	})
+	require.NoError(t, err)
+	nse3, err := server.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name: "nse-3",
+	})
+	require.NoError(t, err)
+	_, err = server.Unregister(ctx, nse3)
+	require.NoError(t, err)
+
+	// Restart the registry
+	require.NoError(t, db.Close())
+	clockMock.Add(2 * time.Minute)
+
+	store = openStore(t, path, "nse")
+	server = next.NewNetworkServiceEndpointRegistryServer(
+		storage.NewNetworkServiceEndpointRegistryServer(store),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	require.NoError(t, storage.RestoreNetworkServiceEndpoints(ctx, store, server))
+
+	require.Equal(t, []string{"nse-1"}, findNSENames(ctx, t, server))
+
+	values, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	require.Contains(t, values, "nse-1")
+}
+
+type failingStore struct {
+	storage.Store
+}
+
+func (s *failingStore) Put(context.Context, string, []byte) error {
+	return errors.New("failed to put")
+}
+
+func TestStorageNSEServer_StoreFailure(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &failingStore{
+		Store: openStore(t, filepath.Join(t.TempDir(), "registry.db"), "nse"),
+	}
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		storage.NewNetworkServiceEndpointRegistryServer(store),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err := server.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Error(t, err)
+
+	// The registration is rolled back
+	require.Empty(t, findNSENames(ctx, t, server))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// RestoreNetworkServices registers the stored network services into the server. The entries failed to be restored are
+// removed from the store.
+func RestoreNetworkServices(ctx context.Context, store Store, server registry.NetworkServiceRegistryServer) error {
+	values, err := store.Load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to load network services")
+	}
+
+	logger := log.FromContext(ctx).WithField("storage", "RestoreNetworkServices")
+	for name, data := range values {
+		ns := new(registry.NetworkService)
+		if err = proto.Unmarshal(data, ns); err == nil {
+			_, err = server.Register(ctx, ns)
+		}
+		if err != nil {
+			logger.Warnf("failed to restore network service %s: %s", name, err.Error())
+			deleteFromStore(ctx, store, name, logger)
+		}
+	}
+	return nil
+}
+
+// RestoreNetworkServiceEndpoints registers the stored network service endpoints into the server. The expired entries
+// and the entries failed to be restored are removed from the store.
+func RestoreNetworkServiceEndpoints(ctx context.Context, store Store, server registry.NetworkServiceEndpointRegistryServer) error {
+	values, err := store.Load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to load network service endpoints")
+	}
+
+	logger := log.FromContext(ctx).WithField("storage", "RestoreNetworkServiceEndpoints")
+	now := clock.FromContext(ctx).Now()
+	for name, data := range values {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err = proto.Unmarshal(data, nse); err != nil {
+			logger.Warnf("failed to restore network service endpoint %s: %s", name, err.Error())
+			deleteFromStore(ctx, store, name, logger)
+			continue
+		}
+		if expirationTime := nse.GetExpirationTime(); expirationTime != nil && !expirationTime.AsTime().After(now) {
+			logger.Infof("network service endpoint %s has expired", name)
+			deleteFromStore(ctx, store, name, logger)
+			continue
+		}
+		if _, err = server.Register(ctx, nse); err != nil {
+			logger.Warnf("failed to restore network service endpoint %s: %s", name, err.Error())
+			deleteFromStore(ctx, store, name, logger)
+		}
+	}
+	return nil
+}
+
+func deleteFromStore(ctx context.Context, store Store, name string, logger log.Logger) {
+	if err := store.Delete(ctx, name); err != nil {
+		logger.Warnf("failed to delete %s from the store: %s", name, err.Error())
+	}
+}
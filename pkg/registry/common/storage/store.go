@@ -0,0 +1,31 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+)
+
+// Store is a persistent key-value store of the registry entries
+type Store interface {
+	// Put stores the value by the key replacing the existing one
+	Put(ctx context.Context, key string, value []byte) error
+	// Delete deletes the value by the key. It doesn't fail if there is no such key.
+	Delete(ctx context.Context, key string) error
+	// Load returns all the stored values by the keys
+	Load(ctx context.Context) (map[string][]byte, error)
+}
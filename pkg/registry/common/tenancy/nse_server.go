@@ -0,0 +1,90 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamcontext"
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+// NSEServerFactory creates the storage chain element instance of the tenant
+type NSEServerFactory func(tenant string) registry.NetworkServiceEndpointRegistryServer
+
+type partitionedNSEServer struct {
+	*partitions
+	factory NSEServerFactory
+
+	instancesMu sync.Mutex
+	instances   map[string]registry.NetworkServiceEndpointRegistryServer
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer passing the calls to the
+// tenant own instance of the storage chain element created by factory
+func NewNetworkServiceEndpointRegistryServer(factory NSEServerFactory, opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	return &partitionedNSEServer{
+		partitions: newPartitions(opts...),
+		factory:    factory,
+		instances:  make(map[string]registry.NetworkServiceEndpointRegistryServer),
+	}
+}
+
+func (s *partitionedNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	tenant, bound, err := s.bind(ctx, nse.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.instance(tenant).Register(tenancy.WithTenant(ctx, tenant), nse)
+	if err != nil && bound {
+		s.unbind(nse.GetName())
+	}
+	return resp, err
+}
+
+func (s *partitionedNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	tenant := s.resolver.ResolveIncoming(server.Context())
+	ctx := tenancy.WithTenant(server.Context(), tenant)
+	return s.instance(tenant).Find(query, streamcontext.NetworkServiceEndpointRegistryFindServer(ctx, server))
+}
+
+func (s *partitionedNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	tenant, err := s.owner(ctx, nse.GetName())
+	if err != nil {
+		return nil, err
+	}
+	defer s.unbind(nse.GetName())
+
+	return s.instance(tenant).Unregister(tenancy.WithTenant(ctx, tenant), nse)
+}
+
+func (s *partitionedNSEServer) instance(tenant string) registry.NetworkServiceEndpointRegistryServer {
+	s.instancesMu.Lock()
+	defer s.instancesMu.Unlock()
+
+	instance, ok := s.instances[tenant]
+	if !ok {
+		instance = s.factory(tenant)
+		s.instances[tenant] = instance
+	}
+	return instance
+}
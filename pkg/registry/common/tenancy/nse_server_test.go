@@ -0,0 +1,98 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/tenancy"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	tenancytools "github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+func tenantContext(ctx context.Context, tenant string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(tenancytools.MetadataKey, tenant))
+}
+
+func find(ctx context.Context, t *testing.T, client registry.NetworkServiceEndpointRegistryClient) []string {
+	stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		names = append(names, nse.GetName())
+	}
+	return names
+}
+
+func TestPartitionedNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tenantA := tenantContext(ctx, "tenant-a")
+	tenantB := tenantContext(ctx, "tenant-b")
+
+	quota := tenancytools.NewQuota(tenancytools.WithLimit("tenant-b", 1))
+	server := tenancy.NewNetworkServiceEndpointRegistryServer(func(string) registry.NetworkServiceEndpointRegistryServer {
+		return memory.NewNetworkServiceEndpointRegistryServer()
+	}, tenancy.WithQuota(quota))
+	client := adapters.NetworkServiceEndpointServerToClient(server)
+
+	_, err := client.Register(tenantA, &registry.NetworkServiceEndpoint{Name: "nse-a"})
+	require.NoError(t, err)
+	_, err = client.Register(tenantB, &registry.NetworkServiceEndpoint{Name: "nse-b"})
+	require.NoError(t, err)
+
+	// The tenants see their own NSEs only
+	require.Equal(t, []string{"nse-a"}, find(tenantA, t, client))
+	require.Equal(t, []string{"nse-b"}, find(tenantB, t, client))
+
+	// The names are bound to the tenants
+	_, err = client.Register(tenantB, &registry.NetworkServiceEndpoint{Name: "nse-a"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.Unregister(tenantB, &registry.NetworkServiceEndpoint{Name: "nse-a"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Refresh doesn't take the quota
+	_, err = client.Register(tenantB, &registry.NetworkServiceEndpoint{Name: "nse-b"})
+	require.NoError(t, err)
+	_, err = client.Register(tenantB, &registry.NetworkServiceEndpoint{Name: "nse-b-2"})
+	require.True(t, errors.Is(err, tenancytools.ErrQuotaExceeded))
+
+	// The calls with no tenant (e.g. expiration) unregister the NSE from its tenant storage
+	_, err = client.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-b"})
+	require.NoError(t, err)
+	require.Empty(t, find(tenantB, t, client))
+	require.Equal(t, 0, quota.Used("tenant-b"))
+
+	_, err = client.Register(tenantB, &registry.NetworkServiceEndpoint{Name: "nse-b-2"})
+	require.NoError(t, err)
+}
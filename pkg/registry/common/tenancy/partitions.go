@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenancy provides registry chain elements partitioning the registry storage per tenant: each tenant has its
+// own instance of the storage chain element (e.g. memory), so Find returns only the tenant own NSs and NSEs and the
+// names of different tenants don't conflict. The tenant is derived from the peer identity or from the
+// tenancy.MetadataKey grpc metadata.
+package tenancy
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+// partitions binds the registered names to the tenants
+type partitions struct {
+	resolver *tenancy.Resolver
+	quota    *tenancy.Quota
+
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func newPartitions(opts ...Option) *partitions {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &partitions{
+		resolver: tenancy.NewResolver(o.resolverOpts...),
+		quota:    o.quota,
+		names:    make(map[string]string),
+	}
+}
+
+// bind binds the name to the ctx tenant, bound is true if the name is bound by this call
+func (p *partitions) bind(ctx context.Context, name string) (tenant string, bound bool, err error) {
+	tenant = p.resolver.ResolveIncoming(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if current, ok := p.names[name]; ok {
+		if current != tenant {
+			return "", false, status.Errorf(codes.PermissionDenied, "%s is registered by another tenant", name)
+		}
+		return tenant, false, nil
+	}
+	if err := p.quota.Acquire(tenant); err != nil {
+		return "", false, err
+	}
+	p.names[name] = tenant
+	return tenant, true, nil
+}
+
+func (p *partitions) unbind(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tenant, ok := p.names[name]; ok {
+		delete(p.names, name)
+		p.quota.Release(tenant)
+	}
+}
+
+// owner returns the tenant of the name. The calls with no tenant (e.g. the expiration) are allowed to access the names
+// of any tenant, the calls of the other tenants are rejected.
+func (p *partitions) owner(ctx context.Context, name string) (string, error) {
+	tenant := p.resolver.ResolveIncoming(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current, ok := p.names[name]
+	if !ok {
+		return tenant, nil
+	}
+	if tenant != tenancy.DefaultTenant && tenant != current {
+		return "", status.Errorf(codes.PermissionDenied, "%s is registered by another tenant", name)
+	}
+	return current, nil
+}
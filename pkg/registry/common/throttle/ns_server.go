@@ -0,0 +1,80 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package throttle
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type throttleNSServer struct {
+	interval time.Duration
+	counter  *Counter
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer throttling the updates of the same NS in the watch Find streams
+func NewNetworkServiceRegistryServer(opts ...Option) registry.NetworkServiceRegistryServer {
+	o := &options{
+		interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &throttleNSServer{
+		interval: o.interval,
+		counter:  o.counter,
+	}
+}
+
+func (s *throttleNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *throttleNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if !query.GetWatch() {
+		return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+	}
+
+	t := newThrottler(clock.FromContext(server.Context()), s.interval, s.counter)
+	defer t.stop()
+
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, &throttleNSFindServer{
+		NetworkServiceRegistry_FindServer: server,
+		throttler:                         t,
+	})
+}
+
+func (s *throttleNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type throttleNSFindServer struct {
+	registry.NetworkServiceRegistry_FindServer
+	throttler *throttler
+}
+
+func (s *throttleNSFindServer) Send(resp *registry.NetworkServiceResponse) error {
+	return s.throttler.send(resp.GetNetworkService().GetName(), func() error {
+		return s.NetworkServiceRegistry_FindServer.Send(resp)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package throttle
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type throttleNSEServer struct {
+	interval time.Duration
+	counter  *Counter
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer throttling the updates of the same NSE in the watch Find streams
+func NewNetworkServiceEndpointRegistryServer(opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	o := &options{
+		interval: defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &throttleNSEServer{
+		interval: o.interval,
+		counter:  o.counter,
+	}
+}
+
+func (s *throttleNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *throttleNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if !query.GetWatch() {
+		return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+	}
+
+	t := newThrottler(clock.FromContext(server.Context()), s.interval, s.counter)
+	defer t.stop()
+
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, &throttleNSEFindServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		throttler: t,
+	})
+}
+
+func (s *throttleNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+type throttleNSEFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	throttler *throttler
+}
+
+func (s *throttleNSEFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	return s.throttler.send(resp.GetNetworkServiceEndpoint().GetName(), func() error {
+		return s.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+	})
+}
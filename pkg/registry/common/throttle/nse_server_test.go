@@ -0,0 +1,102 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package throttle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/throttle"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+)
+
+func receive(t *testing.T, ch <-chan *registry.NetworkServiceEndpointResponse) *registry.NetworkServiceEndpoint {
+	select {
+	case resp := <-ch:
+		return resp.GetNetworkServiceEndpoint()
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return nil
+	}
+}
+
+func TestThrottleNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	counter := new(throttle.Counter)
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		throttle.NewNetworkServiceEndpointRegistryServer(throttle.WithInterval(time.Second), throttle.WithCounter(counter)),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	register := func(name, url string) {
+		_, err := server.Register(ctx, &registry.NetworkServiceEndpoint{Name: name, Url: url})
+		require.NoError(t, err)
+	}
+
+	register("nse-1", "tcp://1.1.1.1")
+
+	findCtx, findCancel := context.WithCancel(ctx)
+	ch := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+			Watch:                  true,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(findCtx, ch))
+	}()
+
+	require.Equal(t, "nse-1", receive(t, ch).GetName())
+
+	// nse-1 is flapping, the updates are coalesced to the latest one
+	register("nse-1", "tcp://2.2.2.2")
+	register("nse-1", "tcp://3.3.3.3")
+	register("nse-2", "tcp://4.4.4.4")
+
+	require.Equal(t, "nse-2", receive(t, ch).GetName())
+	require.Equal(t, uint64(1), counter.Suppressed())
+	require.Empty(t, ch)
+
+	clockMock.Add(time.Second)
+
+	nse := receive(t, ch)
+	require.Equal(t, "nse-1", nse.GetName())
+	require.Equal(t, "tcp://3.3.3.3", nse.GetUrl())
+
+	// The update after the interval is sent immediately
+	clockMock.Add(time.Second)
+	register("nse-1", "tcp://5.5.5.5")
+	require.Equal(t, "tcp://5.5.5.5", receive(t, ch).GetUrl())
+
+	findCancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package throttle provides registry chain elements coalescing rapid successive updates of the same registry entry in
+// the watch Find streams, so a flapping NSE doesn't overload the watchers. Each entry is sent to a watcher at most once
+// per interval, the updates received during the interval are coalesced to the latest one.
+package throttle
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+// Counter counts the updates suppressed by the throttle chain elements, it can be shared between the chain elements
+type Counter struct {
+	suppressed uint64
+}
+
+// Suppressed returns the number of the suppressed updates
+func (c *Counter) Suppressed() uint64 {
+	return atomic.LoadUint64(&c.suppressed)
+}
+
+func (c *Counter) inc() {
+	if c != nil {
+		atomic.AddUint64(&c.suppressed, 1)
+	}
+}
+
+type throttleEntry struct {
+	lastSent time.Time
+	pending  func() error
+	timer    clock.Timer
+}
+
+// throttler serializes the sends to the watch stream and delays the sends of the same entry for the interval
+type throttler struct {
+	clock    clock.Clock
+	interval time.Duration
+	counter  *Counter
+
+	mu      sync.Mutex
+	entries map[string]*throttleEntry
+	err     error
+	stopped bool
+}
+
+func newThrottler(clk clock.Clock, interval time.Duration, counter *Counter) *throttler {
+	return &throttler{
+		clock:    clk,
+		interval: interval,
+		counter:  counter,
+		entries:  make(map[string]*throttleEntry),
+	}
+}
+
+// send sends the entry update immediately if the entry was not sent during the interval or delays it otherwise
+func (t *throttler) send(name string, send func() error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return t.err
+	}
+
+	now := t.clock.Now()
+	e, ok := t.entries[name]
+	if !ok || (e.timer == nil && now.Sub(e.lastSent) >= t.interval) {
+		t.entries[name] = &throttleEntry{lastSent: now}
+		return send()
+	}
+
+	if e.pending != nil {
+		t.counter.inc()
+	}
+	e.pending = send
+	if e.timer == nil {
+		e.timer = t.clock.AfterFunc(e.lastSent.Add(t.interval).Sub(now), func() {
+			t.flush(name)
+		})
+	}
+	return nil
+}
+
+func (t *throttler) flush(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	e, ok := t.entries[name]
+	if !ok || t.stopped || e.pending == nil {
+		return
+	}
+
+	send := e.pending
+	e.pending = nil
+	e.timer = nil
+	e.lastSent = t.clock.Now()
+	if err := send(); err != nil && t.err == nil {
+		t.err = err
+	}
+}
+
+// stop drops the pending updates, the stream should not be used after the Find returns
+func (t *throttler) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+	for _, e := range t.entries {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package urlprobe provides a registry chain element probing the URLs of the registered NSEs in the background and
+// filtering unreachable NSEs out of Find results, so NSCs don't waste their request deadline dialing dead addresses
+// of the NSEs that crashed without unregistering.
+package urlprobe
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type probe struct {
+	url      string
+	failures int32
+	cancel   context.CancelFunc
+}
+
+type urlProbeNSEServer struct {
+	ctx              context.Context
+	prober           Prober
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+
+	probes map[string]*probe
+	mu     sync.Mutex
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer probing the registered
+// NSE URLs and filtering unreachable NSEs out of Find results. Deletion events are never filtered.
+func NewNetworkServiceEndpointRegistryServer(ctx context.Context, opts ...Option) registry.NetworkServiceEndpointRegistryServer {
+	s := &urlProbeNSEServer{
+		ctx:              ctx,
+		prober:           DialProber(),
+		interval:         5 * time.Second,
+		timeout:          time.Second,
+		failureThreshold: 2,
+		probes:           make(map[string]*probe),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *urlProbeNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	u, parseErr := url.Parse(resp.GetUrl())
+	if resp.GetUrl() == "" || parseErr != nil {
+		return resp, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.probes[resp.GetName()]; ok {
+		if p.url == resp.GetUrl() {
+			return resp, nil
+		}
+		p.cancel()
+	}
+
+	probeCtx, cancel := context.WithCancel(s.ctx)
+	p := &probe{
+		url:    resp.GetUrl(),
+		cancel: cancel,
+	}
+	s.probes[resp.GetName()] = p
+
+	go s.runProbe(probeCtx, p, u)
+
+	return resp, nil
+}
+
+func (s *urlProbeNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, &urlProbeFindNSEServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		s: s,
+	})
+}
+
+func (s *urlProbeNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.mu.Lock()
+	if p, ok := s.probes[nse.GetName()]; ok {
+		p.cancel()
+		delete(s.probes, nse.GetName())
+	}
+	s.mu.Unlock()
+
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func (s *urlProbeNSEServer) isReachable(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.probes[name]
+	return !ok || int(atomic.LoadInt32(&p.failures)) < s.failureThreshold
+}
+
+func (s *urlProbeNSEServer) runProbe(ctx context.Context, p *probe, u *url.URL) {
+	var clockTime = clock.FromContext(ctx)
+	var ticker = clockTime.Ticker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		probeCtx, cancel := clockTime.WithTimeout(ctx, s.timeout)
+		err := s.prober(probeCtx, u)
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			if int(atomic.AddInt32(&p.failures, 1)) == s.failureThreshold {
+				log.FromContext(ctx).Warnf("NSE URL %s is unreachable: %s", u, err.Error())
+			}
+		default:
+			if int(atomic.SwapInt32(&p.failures, 0)) >= s.failureThreshold {
+				log.FromContext(ctx).Infof("NSE URL %s is reachable again", u)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+type urlProbeFindNSEServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	s *urlProbeNSEServer
+}
+
+func (s *urlProbeFindNSEServer) Send(nseResp *registry.NetworkServiceEndpointResponse) error {
+	if !nseResp.GetDeleted() && !s.s.isReachable(nseResp.GetNetworkServiceEndpoint().GetName()) {
+		log.FromContext(s.Context()).Debugf("skipping unreachable NSE %s", nseResp.GetNetworkServiceEndpoint().GetName())
+		return nil
+	}
+	return s.NetworkServiceEndpointRegistry_FindServer.Send(nseResp)
+}
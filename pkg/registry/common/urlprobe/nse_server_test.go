@@ -0,0 +1,88 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package urlprobe_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/urlprobe"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+func find(ctx context.Context, t *testing.T, s registry.NetworkServiceEndpointRegistryServer) (names []string) {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(s).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	})
+	require.NoError(t, err)
+	for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+		names = append(names, nse.GetName())
+	}
+	return names
+}
+
+func TestURLProbeNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	alive, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = alive.Close() }()
+
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, dead.Close())
+
+	s := next.NewNetworkServiceEndpointRegistryServer(
+		urlprobe.NewNetworkServiceEndpointRegistryServer(ctx,
+			urlprobe.WithInterval(10*time.Millisecond),
+			urlprobe.WithFailureThreshold(1),
+		),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "alive", Url: "tcp://" + alive.Addr().String()})
+	require.NoError(t, err)
+	_, err = s.Register(ctx, &registry.NetworkServiceEndpoint{Name: "dead", Url: "tcp://" + dead.Addr().String()})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		names := find(ctx, t, s)
+		return len(names) == 1 && names[0] == "alive"
+	}, time.Second, 10*time.Millisecond)
+
+	// The NSE becomes unreachable after it crashes
+	require.NoError(t, alive.Close())
+	require.Eventually(t, func() bool {
+		return len(find(ctx, t, s)) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	for _, name := range []string{"alive", "dead"} {
+		_, err = s.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: name})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package urlprobe
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+// Prober checks if the NSE URL is reachable
+type Prober func(ctx context.Context, u *url.URL) error
+
+// DialProber returns a Prober checking if the NSE URL accepts the TCP (or unix socket) connections
+func DialProber() Prober {
+	return func(ctx context.Context, u *url.URL) error {
+		network, addr := grpcutils.TargetToNetAddr(grpcutils.URLToTarget(u))
+		conn, err := new(net.Dialer).DialContext(ctx, network, addr)
+		if err != nil {
+			return errors.Wrapf(err, "failed to dial %s", u)
+		}
+		return conn.Close()
+	}
+}
+
+// GRPCHealthProber returns a Prober checking the NSE with the gRPC health service
+func GRPCHealthProber(dialOptions ...grpc.DialOption) Prober {
+	return func(ctx context.Context, u *url.URL) error {
+		cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), append(dialOptions, grpc.WithBlock())...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to dial %s", u)
+		}
+		defer func() { _ = cc.Close() }()
+
+		resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, new(grpc_health_v1.HealthCheckRequest))
+		if err != nil {
+			return errors.Wrapf(err, "failed to check health of %s", u)
+		}
+		if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return errors.Errorf("%s is not serving: %s", u, resp.GetStatus())
+		}
+		return nil
+	}
+}
+
+// Option is an option pattern for NewNetworkServiceEndpointRegistryServer
+type Option func(s *urlProbeNSEServer)
+
+// WithProber sets the Prober. Default is DialProber.
+func WithProber(prober Prober) Option {
+	return func(s *urlProbeNSEServer) {
+		s.prober = prober
+	}
+}
+
+// WithInterval sets the probing interval. Default is 5 seconds.
+func WithInterval(interval time.Duration) Option {
+	return func(s *urlProbeNSEServer) {
+		s.interval = interval
+	}
+}
+
+// WithTimeout sets the timeout for a single probe. Default is 1 second.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *urlProbeNSEServer) {
+		s.timeout = timeout
+	}
+}
+
+// WithFailureThreshold sets the number of consecutive failed probes after which the NSE is considered unreachable.
+// Default is 2.
+func WithFailureThreshold(threshold int) Option {
+	return func(s *urlProbeNSEServer) {
+		if threshold > 0 {
+			s.failureThreshold = threshold
+		}
+	}
+}
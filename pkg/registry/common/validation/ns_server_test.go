@@ -0,0 +1,91 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/validation"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	"github.com/networkservicemesh/sdk/pkg/tools/payloadtypes"
+)
+
+func TestValidationNSServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := validation.NewNetworkServiceRegistryServer(validation.WithMaxLabels(2))
+
+	ns := &registry.NetworkService{
+		Name: "ns-1@domain.com",
+		Matches: []*registry.Match{
+			{
+				SourceSelector: map[string]string{"app": "client"},
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"app": "firewall"}},
+				},
+			},
+		},
+	}
+	_, err := server.Register(context.Background(), ns)
+	require.NoError(t, err)
+
+	ns.Matches = append(ns.Matches, &registry.Match{SourceSelector: map[string]string{"app": "other"}})
+	_, err = server.Register(context.Background(), ns)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.Register(context.Background(), &registry.NetworkService{Name: "ns/1"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestValidationNSServer_PayloadTypes(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	types := payloadtypes.NewRegistry()
+	require.NoError(t, types.Register("MPLS", nil))
+
+	server := next.NewNetworkServiceRegistryServer(
+		validation.NewNetworkServiceRegistryServer(validation.WithPayloadTypes(types)),
+		memory.NewNetworkServiceRegistryServer(),
+	)
+
+	_, err := server.Register(context.Background(), &registry.NetworkService{Name: "ns-1", Payload: "MPLS"})
+	require.NoError(t, err)
+
+	_, err = server.Register(context.Background(), &registry.NetworkService{Name: "ns-2", Payload: "L2TP"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	nses, err := adapters.NetworkServiceServerToClient(server).Find(context.Background(), &registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{Payload: "MPLS"},
+	})
+	require.NoError(t, err)
+	require.Len(t, registry.ReadNetworkServiceList(nses), 1)
+
+	err = server.Find(&registry.NetworkServiceQuery{
+		NetworkService: &registry.NetworkService{Payload: "L2TP"},
+	}, streamchannel.NewNetworkServiceFindServer(context.Background(), nil))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
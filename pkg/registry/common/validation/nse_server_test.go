@@ -0,0 +1,124 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/validation"
+)
+
+func newNSE() *registry.NetworkServiceEndpoint {
+	return &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1", "ns-2@domain.com"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns-1": {Labels: map[string]string{"app": "firewall"}},
+		},
+		Url: "tcp://127.0.0.1:5000",
+	}
+}
+
+func TestValidationNSEServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	manyLabels := make(map[string]string)
+	for i := 0; i < 65; i++ {
+		manyLabels[fmt.Sprint(i)] = "value"
+	}
+
+	for name, sample := range map[string]struct {
+		mutate func(nse *registry.NetworkServiceEndpoint)
+		valid  bool
+	}{
+		"Valid":              {mutate: func(*registry.NetworkServiceEndpoint) {}, valid: true},
+		"EmptyURL":           {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Url = "" }, valid: true},
+		"EmptyName":          {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Name = "" }},
+		"LongName":           {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Name = strings.Repeat("a", 254) }},
+		"NameCharset":        {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Name = "nse 1" }},
+		"NetworkServiceName": {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.NetworkServiceNames[0] = "-ns" }},
+		"TooManyLabels": {mutate: func(nse *registry.NetworkServiceEndpoint) {
+			nse.NetworkServiceLabels["ns-1"].Labels = manyLabels
+		}},
+		"LongURL":    {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Url = "tcp://" + strings.Repeat("a", 2048) }},
+		"InvalidURL": {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Url = "tcp://[::1" }},
+		"URLScheme":  {mutate: func(nse *registry.NetworkServiceEndpoint) { nse.Url = "http://127.0.0.1:5000" }},
+	} {
+		sample := sample
+		t.Run(name, func(t *testing.T) {
+			nse := newNSE()
+			sample.mutate(nse)
+
+			_, err := validation.NewNetworkServiceEndpointRegistryServer().Register(context.Background(), nse)
+			if sample.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestValidationNSEServer_Options(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := validation.NewNetworkServiceEndpointRegistryServer(
+		validation.WithMaxNameLength(4),
+		validation.WithMaxLabels(0),
+		validation.WithMaxURLLength(32),
+		validation.WithURLSchemes("unix"),
+		validation.WithNamePattern(regexp.MustCompile(`^[a-z]+$`)),
+	)
+
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+		Url:                 "unix:///var/lib/nse.sock",
+	}
+	_, err := server.Register(context.Background(), nse)
+	require.NoError(t, err)
+
+	for _, mutate := range []func(nse *registry.NetworkServiceEndpoint){
+		func(nse *registry.NetworkServiceEndpoint) { nse.Name = "nse-1" },
+		func(nse *registry.NetworkServiceEndpoint) { nse.NetworkServiceNames = []string{"longer"} },
+		func(nse *registry.NetworkServiceEndpoint) {
+			nse.NetworkServiceLabels = map[string]*registry.NetworkServiceLabels{"ns": {Labels: map[string]string{"a": "b"}}}
+		},
+		func(nse *registry.NetworkServiceEndpoint) { nse.Url = "unix:///var/lib/networkservicemesh/nse.sock" },
+		func(nse *registry.NetworkServiceEndpoint) { nse.Url = "tcp://127.0.0.1:5000" },
+	} {
+		invalid := nse.Clone()
+		mutate(invalid)
+		_, err = server.Register(context.Background(), invalid)
+		require.Error(t, err)
+	}
+
+	// Unregister is never validated
+	_, err = server.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse 1"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/payloadtypes"
+)
+
+const (
+	defaultMaxNameLength = 253
+	defaultMaxLabels     = 64
+	defaultMaxURLLength  = 2048
+)
+
+var defaultNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._:@-]*$`)
+
+type options struct {
+	maxNameLength int
+	maxLabels     int
+	maxURLLength  int
+	urlSchemes    map[string]struct{}
+	namePattern   *regexp.Regexp
+	payloadTypes  *payloadtypes.Registry
+}
+
+// Option is an option pattern for NewNetworkServiceRegistryServer, NewNetworkServiceEndpointRegistryServer
+type Option func(o *options)
+
+// WithMaxNameLength sets the maximum length of the NS, NSE and NSE network service names
+// Default: 253
+func WithMaxNameLength(length int) Option {
+	return func(o *options) {
+		o.maxNameLength = length
+	}
+}
+
+// WithMaxLabels sets the maximum number of the labels: NSE network service labels or NS match selectors in total
+// Default: 64
+func WithMaxLabels(count int) Option {
+	return func(o *options) {
+		o.maxLabels = count
+	}
+}
+
+// WithMaxURLLength sets the maximum length of the NSE URL
+// Default: 2048
+func WithMaxURLLength(length int) Option {
+	return func(o *options) {
+		o.maxURLLength = length
+	}
+}
+
+// WithURLSchemes sets the allowed NSE URL schemes
+// Default: "tcp", "unix"
+func WithURLSchemes(schemes ...string) Option {
+	return func(o *options) {
+		o.urlSchemes = make(map[string]struct{}, len(schemes))
+		for _, scheme := range schemes {
+			o.urlSchemes[scheme] = struct{}{}
+		}
+	}
+}
+
+// WithNamePattern sets the pattern the NS, NSE and NSE network service names must match
+// Default: `^[a-zA-Z0-9][a-zA-Z0-9._:@-]*$`
+func WithNamePattern(pattern *regexp.Regexp) Option {
+	return func(o *options) {
+		o.namePattern = pattern
+	}
+}
+
+// WithPayloadTypes sets the registry of the supported NS payload types, NS registrations and queries with the not
+// registered payload types are rejected
+// Default: payload types are not validated
+func WithPayloadTypes(types *payloadtypes.Registry) Option {
+	return func(o *options) {
+		o.payloadTypes = types
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		maxNameLength: defaultMaxNameLength,
+		maxLabels:     defaultMaxLabels,
+		maxURLLength:  defaultMaxURLLength,
+		urlSchemes: map[string]struct{}{
+			"tcp":  {},
+			"unix": {},
+		},
+		namePattern: defaultNamePattern,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
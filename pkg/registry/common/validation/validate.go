@@ -0,0 +1,111 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validation provides registry chain elements rejecting NS and NSE registrations exceeding the configured
+// limits or having malformed fields, so corrupt or abusive entries don't degrade Find performance and downstream
+// parsers. NS Find queries are validated only for the payload type. Unregister is never validated, so already stored
+// entries can always be removed.
+package validation
+
+import (
+	"net/url"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (o *options) validateName(kind, name string) error {
+	if name == "" {
+		return status.Errorf(codes.InvalidArgument, "%s name is empty", kind)
+	}
+	if len(name) > o.maxNameLength {
+		return status.Errorf(codes.InvalidArgument, "%s name %.32q... is longer than %d", kind, name, o.maxNameLength)
+	}
+	if !o.namePattern.MatchString(name) {
+		return status.Errorf(codes.InvalidArgument, "%s name %q doesn't match %s", kind, name, o.namePattern.String())
+	}
+	return nil
+}
+
+func (o *options) validateLabels(kind, name string, count int) error {
+	if count > o.maxLabels {
+		return status.Errorf(codes.InvalidArgument, "%s %q has %d labels, maximum is %d", kind, name, count, o.maxLabels)
+	}
+	return nil
+}
+
+func (o *options) validateNS(ns *registry.NetworkService) error {
+	if err := o.validateName("network service", ns.GetName()); err != nil {
+		return err
+	}
+	var count int
+	for _, match := range ns.GetMatches() {
+		count += len(match.GetSourceSelector())
+		for _, route := range match.GetRoutes() {
+			count += len(route.GetDestinationSelector())
+		}
+	}
+	if err := o.validateLabels("network service", ns.GetName(), count); err != nil {
+		return err
+	}
+	return o.validatePayload(ns.GetPayload())
+}
+
+// validatePayload validates the NS payload type if it is set and the payload types registry is configured
+func (o *options) validatePayload(payloadType string) error {
+	if payloadType == "" || o.payloadTypes == nil || o.payloadTypes.IsKnown(payloadType) {
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "network service payload type %q is not supported", payloadType)
+}
+
+func (o *options) validateNSE(nse *registry.NetworkServiceEndpoint) error {
+	if err := o.validateName("network service endpoint", nse.GetName()); err != nil {
+		return err
+	}
+	for _, ns := range nse.GetNetworkServiceNames() {
+		if err := o.validateName("network service", ns); err != nil {
+			return err
+		}
+	}
+	var count int
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		count += len(labels.GetLabels())
+	}
+	if err := o.validateLabels("network service endpoint", nse.GetName(), count); err != nil {
+		return err
+	}
+	return o.validateURL(nse)
+}
+
+// validateURL validates the NSE URL if it is set, the URL can be empty for the NSEs registered through NSMgr
+func (o *options) validateURL(nse *registry.NetworkServiceEndpoint) error {
+	if nse.GetUrl() == "" {
+		return nil
+	}
+	if len(nse.GetUrl()) > o.maxURLLength {
+		return status.Errorf(codes.InvalidArgument, "network service endpoint %q URL is longer than %d", nse.GetName(), o.maxURLLength)
+	}
+	u, err := url.Parse(nse.GetUrl())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "network service endpoint %q URL is invalid: %s", nse.GetName(), err.Error())
+	}
+	if _, ok := o.urlSchemes[u.Scheme]; !ok {
+		return status.Errorf(codes.InvalidArgument, "network service endpoint %q URL scheme %q is not allowed", nse.GetName(), u.Scheme)
+	}
+	return nil
+}
@@ -22,8 +22,10 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
 )
 
 type stackTracer interface {
@@ -45,6 +47,9 @@ func logError(ctx context.Context, err error, operation string) error {
 
 func logObjectTrace(ctx context.Context, k, v interface{}) {
 	s := log.FromContext(ctx)
+	if m, ok := v.(proto.Message); ok {
+		v = redact.Default().Apply(m)
+	}
 	msg := ""
 	cc, err := json.Marshal(v)
 	if err == nil {
@@ -0,0 +1,117 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission provides a Limiter bounding the number of in-flight operations, prioritizing the operations
+// releasing resources (Close, Unregister) over the operations allocating them (Request, Register).
+package admission
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Limiter bounds the number of in-flight operations.
+// Regular operations wait in FIFO order until the number of in-flight operations drops below the limit. Priority
+// operations are never queued: they are admitted immediately and occupy a slot, so the waiting regular operations are
+// admitted only after them.
+type Limiter struct {
+	limit    int
+	inflight int
+	waiters  list.List
+	mu       sync.Mutex
+}
+
+// NewLimiter creates a new Limiter admitting up to limit regular in-flight operations. Limit <= 0 means no limit.
+func NewLimiter(limit int) *Limiter {
+	return &Limiter{
+		limit: limit,
+	}
+}
+
+// Acquire blocks until the regular operation can be admitted or ctx is done. On success, release must be called
+// when the operation completes.
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	l.mu.Lock()
+	if l.limit <= 0 || (l.inflight < l.limit && l.waiters.Len() == 0) {
+		l.inflight++
+		l.mu.Unlock()
+		return l.release, nil
+	}
+	admitted := make(chan struct{})
+	elem := l.waiters.PushBack(admitted)
+	l.mu.Unlock()
+
+	select {
+	case <-admitted:
+		return l.release, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-admitted:
+			// Admitted concurrently with the cancel, give the slot to the next waiter
+			l.inflight--
+			l.admit()
+		default:
+			l.waiters.Remove(elem)
+		}
+		return nil, errors.Wrap(ctx.Err(), "admission: operation is not admitted")
+	}
+}
+
+// AcquirePriority admits the priority operation immediately. Release must be called when the operation completes.
+func (l *Limiter) AcquirePriority() (release func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight++
+	return l.release
+}
+
+// InFlight returns the number of in-flight operations
+func (l *Limiter) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inflight
+}
+
+// Waiting returns the number of regular operations waiting for admission
+func (l *Limiter) Waiting() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.waiters.Len()
+}
+
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+	l.admit()
+}
+
+func (l *Limiter) admit() {
+	for l.inflight < l.limit && l.waiters.Len() > 0 {
+		admitted := l.waiters.Remove(l.waiters.Front()).(chan struct{})
+		l.inflight++
+		close(admitted)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/admission"
+)
+
+func TestLimiter_PriorityIsNotQueued(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	limiter := admission.NewLimiter(1)
+
+	release, err := limiter.Acquire(ctx)
+	require.NoError(t, err)
+
+	admitted := make(chan func())
+	go func() {
+		waiterRelease, waiterErr := limiter.Acquire(ctx)
+		require.NoError(t, waiterErr)
+		admitted <- waiterRelease
+	}()
+	require.Eventually(t, func() bool { return limiter.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	// Priority operation is admitted over the limit and before the waiting one
+	priorityRelease := limiter.AcquirePriority()
+	require.Equal(t, 2, limiter.InFlight())
+
+	release()
+	select {
+	case <-admitted:
+		require.FailNow(t, "regular operation is admitted while priority one is in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	priorityRelease()
+	(<-admitted)()
+	require.Equal(t, 0, limiter.InFlight())
+}
+
+func TestLimiter_Cancel(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	limiter := admission.NewLimiter(1)
+
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = limiter.Acquire(ctx)
+	require.Error(t, err)
+	require.Equal(t, 0, limiter.Waiting())
+
+	release()
+	release, err = limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	require.Equal(t, 0, limiter.InFlight())
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	limiter := admission.NewLimiter(0)
+
+	for i := 0; i < 10; i++ {
+		_, err := limiter.Acquire(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, 10, limiter.InFlight())
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certpin
+
+import (
+	"context"
+)
+
+type serverNameKey struct{}
+
+// WithServerName returns a new context with the name the client URL has been resolved from. The dial chain elements
+// handshake with this name as the authority, so the pins are checked for it and not for the resolved address.
+func WithServerName(parent context.Context, name string) context.Context {
+	if parent == nil {
+		panic("cannot create context from nil parent")
+	}
+	return context.WithValue(parent, serverNameKey{}, name)
+}
+
+// ServerName returns the name the client URL has been resolved from or "" if it is not set
+func ServerName(ctx context.Context) string {
+	if rv, ok := ctx.Value(serverNameKey{}).(string); ok {
+		return rv
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certpin provides grpc transport credentials pinning peer certificates or SPIFFE IDs per URL or per domain,
+// so a compromised DNS answer can't redirect traffic to a peer which is valid for the trusted (federated) CA but is
+// not the expected one.
+//
+// Use it as a replacement for grpc.WithTransportCredentials in the dial options passed to the connect/dial chain
+// elements:
+//
+//	dial.WithDialOptions(certpin.WithTransportCredentials(creds, certpin.WithDomainPins("my.domain", certpin.SpiffeID(id))))
+//
+// The pins are looked up by the handshake authority. dnsresolve stores the domain it resolves (or rewrites) to the
+// client URL with WithServerName and the dial chain elements use it as the authority, so the pins of the domain are
+// checked even if the client URL is an IP address or a name in the other domain.
+package certpin
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type pins struct {
+	hosts   map[string][]Pin
+	domains map[string][]Pin
+}
+
+func (p *pins) lookup(authority string) []Pin {
+	if rv, ok := p.hosts[authority]; ok {
+		return rv
+	}
+	host, _, err := net.SplitHostPort(authority)
+	if err != nil {
+		host = authority
+	}
+	if rv, ok := p.hosts[host]; ok {
+		return rv
+	}
+	for domain := host; domain != ""; {
+		if rv, ok := p.domains[domain]; ok {
+			return rv
+		}
+		idx := strings.Index(domain, ".")
+		if idx < 0 {
+			break
+		}
+		domain = domain[idx+1:]
+	}
+	return nil
+}
+
+type pinnedCredentials struct {
+	credentials.TransportCredentials
+	pins *pins
+}
+
+// NewTransportCredentials wraps creds with the peer certificate pinning check
+func NewTransportCredentials(creds credentials.TransportCredentials, opts ...Option) credentials.TransportCredentials {
+	p := &pins{
+		hosts:   make(map[string][]Pin),
+		domains: make(map[string][]Pin),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return &pinnedCredentials{
+		TransportCredentials: creds,
+		pins:                 p,
+	}
+}
+
+// WithTransportCredentials returns a grpc.DialOption with creds wrapped with the peer certificate pinning check
+func WithTransportCredentials(creds credentials.TransportCredentials, opts ...Option) grpc.DialOption {
+	return grpc.WithTransportCredentials(NewTransportCredentials(creds, opts...))
+}
+
+func (c *pinnedCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, authInfo, err := c.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	expected := c.pins.lookup(authority)
+	if len(expected) == 0 {
+		return conn, authInfo, nil
+	}
+
+	tlsInfo, ok := authInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		_ = conn.Close()
+		return nil, nil, errors.Errorf("no peer certificate to check pins for %s", authority)
+	}
+	for _, pin := range expected {
+		if pin(tlsInfo.State.PeerCertificates[0]) {
+			return conn, authInfo, nil
+		}
+	}
+
+	_ = conn.Close()
+	return nil, nil, errors.Errorf("peer certificate for %s doesn't match any of the pins", authority)
+}
+
+func (c *pinnedCredentials) Clone() credentials.TransportCredentials {
+	return &pinnedCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		pins:                 c.pins,
+	}
+}
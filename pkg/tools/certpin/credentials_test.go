@@ -0,0 +1,159 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certpin_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dial"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/certpin"
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+)
+
+const host = "registry.my.domain"
+
+func newCertificate(t *testing.T, id spiffeid.ID) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{host},
+		URIs:                  []*url.URL{id.URL()},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func handshake(t *testing.T, cert tls.Certificate, opts ...certpin.Option) error {
+	x509Cert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(x509Cert)
+
+	clientConn, serverConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+		_ = tls.Server(serverConn, &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}).Handshake()
+		_ = serverConn.Close()
+	}()
+
+	creds := certpin.NewTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}), opts...)
+	conn, _, err := creds.ClientHandshake(context.Background(), host+":5001", clientConn)
+	if conn != nil {
+		_ = conn.Close()
+	} else {
+		_ = clientConn.Close()
+	}
+	<-serverDone
+	return err
+}
+
+func TestPinnedCredentials(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	registryID := spiffeid.RequireFromString("spiffe://my.domain/registry")
+	attackerID := spiffeid.RequireFromString("spiffe://my.domain/attacker")
+	cert := newCertificate(t, registryID)
+	sum := sha256.Sum256(cert.Certificate[0])
+
+	require.NoError(t, handshake(t, cert))
+	require.NoError(t, handshake(t, cert, certpin.WithDomainPins("my.domain", certpin.SpiffeID(registryID))))
+	require.NoError(t, handshake(t, cert, certpin.WithURLPins(host+":5001", certpin.Fingerprint(hex.EncodeToString(sum[:])))))
+	require.NoError(t, handshake(t, cert, certpin.WithDomainPins("other.domain", certpin.SpiffeID(attackerID))))
+
+	require.Error(t, handshake(t, cert, certpin.WithDomainPins("my.domain", certpin.SpiffeID(attackerID))))
+	require.Error(t, handshake(t, cert, certpin.WithURLPins(host, certpin.Fingerprint("00"))))
+}
+
+func register(ctx context.Context, u *url.URL, serverName string, opts ...certpin.Option) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The peer certificate is checked only by the pins, as with the SPIFFE TLS config
+	creds := credentials.NewTLS(&tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}) // #nosec
+	client := next.NewNetworkServiceRegistryClient(
+		clientconn.NewNetworkServiceRegistryClient(),
+		dial.NewNetworkServiceRegistryClient(ctx, dial.WithDialOptions(certpin.WithTransportCredentials(creds, opts...))),
+		connect.NewNetworkServiceRegistryClient(),
+	)
+
+	_, err := client.Register(certpin.WithServerName(clienturlctx.WithClientURL(ctx, u), serverName), &registry.NetworkService{Name: "ns"})
+	return err
+}
+
+func TestPinnedCredentials_ServerName(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	registryID := spiffeid.RequireFromString("spiffe://my.domain/registry")
+	attackerID := spiffeid.RequireFromString("spiffe://my.domain/attacker")
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{newCertificate(t, registryID)},
+		MinVersion:   tls.VersionTLS12,
+	})))
+	registry.RegisterNetworkServiceRegistryServer(server, memory.NewNetworkServiceRegistryServer())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	// The client URL is the address the domain has been resolved to, the pins are checked for the domain
+	u := &url.URL{Scheme: "tcp", Host: l.Addr().String()}
+
+	require.NoError(t, register(ctx, u, host, certpin.WithDomainPins("my.domain", certpin.SpiffeID(registryID))))
+	require.Error(t, register(ctx, u, host, certpin.WithDomainPins("my.domain", certpin.SpiffeID(attackerID))))
+	require.Error(t, register(ctx, u, host, certpin.WithURLPins(host, certpin.SpiffeID(attackerID))))
+}
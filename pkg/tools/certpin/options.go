@@ -0,0 +1,65 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certpin
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// Pin checks if the peer certificate is the expected one
+type Pin func(cert *x509.Certificate) bool
+
+// SpiffeID returns a Pin accepting only certificates issued for the id
+func SpiffeID(id spiffeid.ID) Pin {
+	return func(cert *x509.Certificate) bool {
+		certID, err := x509svid.IDFromCert(cert)
+		return err == nil && certID == id
+	}
+}
+
+// Fingerprint returns a Pin accepting only the certificate with the given hex encoded SHA-256 fingerprint
+func Fingerprint(sha256Hex string) Pin {
+	sha256Hex = strings.ToLower(strings.ReplaceAll(sha256Hex, ":", ""))
+	return func(cert *x509.Certificate) bool {
+		sum := sha256.Sum256(cert.Raw)
+		return hex.EncodeToString(sum[:]) == sha256Hex
+	}
+}
+
+// Option is an option pattern for NewTransportCredentials
+type Option func(p *pins)
+
+// WithURLPins sets pins for the exact URL host ("host:port" or "host")
+func WithURLPins(host string, p ...Pin) Option {
+	return func(o *pins) {
+		o.hosts[host] = append(o.hosts[host], p...)
+	}
+}
+
+// WithDomainPins sets pins for the domain and all its subdomains
+func WithDomainPins(domain string, p ...Pin) Option {
+	return func(o *pins) {
+		domain = strings.TrimPrefix(domain, ".")
+		o.domains[domain] = append(o.domains[domain], p...)
+	}
+}
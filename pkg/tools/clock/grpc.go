@@ -0,0 +1,59 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a server interceptor injecting the clock into the incoming request contexts, so the
+// chain elements use the same clock as the server
+func UnaryServerInterceptor(clock Clock) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(WithClock(ctx, clock), req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor injecting the clock into the incoming stream contexts, so the
+// chain elements use the same clock as the server
+func StreamServerInterceptor(clock Clock) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &clockServerStream{
+			ServerStream: ss,
+			ctx:          WithClock(ss.Context(), clock),
+		})
+	}
+}
+
+// WithServerClock returns server options injecting the clock into the incoming request and stream contexts
+func WithServerClock(clock Clock) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(clock)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(clock)),
+	}
+}
+
+type clockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *clockServerStream) Context() context.Context {
+	return s.ctx
+}
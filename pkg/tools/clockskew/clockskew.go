@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clockskew provides expiration math tolerating the configured max clock skew between components. Peer
+// provided timestamps (token and registration expiration times) should be compared with the helpers from this
+// package, so skewed nodes don't cause premature expirations.
+package clockskew
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// minWarnSkew is the minimal skew to warn about: token timestamps have the second precision
+const minWarnSkew = time.Second
+
+var maxSkew int64 = 0
+
+// SetMaxSkew sets the max clock skew between components
+func SetMaxSkew(skew time.Duration) {
+	atomic.StoreInt64(&maxSkew, int64(skew))
+}
+
+// MaxSkew returns the max clock skew between components. Default: 0
+func MaxSkew() time.Duration {
+	return time.Duration(atomic.LoadInt64(&maxSkew))
+}
+
+// Expired returns true if the peer provided expiration time has passed even with the max skew taken into account
+func Expired(ctx context.Context, expirationTime time.Time) bool {
+	return Until(ctx, expirationTime) <= 0
+}
+
+// Until returns the duration until the peer provided expiration time extended by the max skew
+func Until(ctx context.Context, expirationTime time.Time) time.Duration {
+	return clock.FromContext(ctx).Until(expirationTime) + MaxSkew()
+}
+
+// CheckIssuedAt warns if the peer provided issue time is in the future by more than the max skew, which means that
+// the peer clock is ahead and peer provided expiration times are not reliable
+func CheckIssuedAt(ctx context.Context, peer string, issuedAt time.Time) {
+	threshold := MaxSkew()
+	if threshold < minWarnSkew {
+		threshold = minWarnSkew
+	}
+	if ahead := clock.FromContext(ctx).Until(issuedAt); ahead > threshold {
+		log.FromContext(ctx).WithField("clockskew", "CheckIssuedAt").
+			Warnf("%s clock is ahead by %s, which exceeds the max clock skew %s", peer, ahead, MaxSkew())
+	}
+}
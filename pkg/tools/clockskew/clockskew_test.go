@@ -0,0 +1,54 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clockskew_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockskew"
+)
+
+func TestClockSkew(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	expirationTime := clockMock.Now().Add(time.Minute)
+
+	require.Equal(t, time.Minute, clockskew.Until(ctx, expirationTime))
+	require.False(t, clockskew.Expired(ctx, expirationTime))
+
+	clockMock.Add(time.Minute)
+	require.True(t, clockskew.Expired(ctx, expirationTime))
+
+	clockskew.SetMaxSkew(10 * time.Second)
+	t.Cleanup(func() { clockskew.SetMaxSkew(0) })
+
+	require.Equal(t, 10*time.Second, clockskew.Until(ctx, expirationTime))
+	require.False(t, clockskew.Expired(ctx, expirationTime))
+
+	clockMock.Add(10 * time.Second)
+	require.True(t, clockskew.Expired(ctx, expirationTime))
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance provides the suite checking that the NSM components (NSMgr, forwarder, registry) behave the way
+// the SDK expects. The suite runs on a sandbox.Domain, so it can be run both against the in-process components and
+// against the external ones with sandbox.NewExternalDomain.
+package conformance
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+const tick = 10 * time.Millisecond
+
+type suite struct {
+	ctx    context.Context
+	domain *sandbox.Domain
+	*options
+}
+
+// Run runs the conformance suite against the domain, each check is a subtest:
+//   - RegistryFind: registered NSE can be found and watched, unregistered NSE is gone
+//   - RegistryExpiration: NSE is removed from the registry after its expiration time
+//   - Request: client Request and Close reach the NSE through the NSMgr and forwarder
+//   - Refresh: connection is refreshed before the client token expires
+//   - Heal: connection is healed to the new NSE after the old one dies
+//   - InterdomainFind: NSE registered in the remote domain can be found with the "name@domain" query
+func Run(ctx context.Context, t *testing.T, domain *sandbox.Domain, opts ...Option) {
+	s := &suite{
+		ctx:    ctx,
+		domain: domain,
+		options: &options{
+			mechanisms: []*networkservice.Mechanism{
+				{Cls: cls.LOCAL, Type: kernel.MECHANISM},
+			},
+			tokenGenerator:    sandbox.GenerateTestToken,
+			timeout:           10 * time.Second,
+			expirationTimeout: time.Second,
+		},
+	}
+	for _, opt := range opts {
+		opt(s.options)
+	}
+
+	t.Run("RegistryFind", s.testRegistryFind)
+	t.Run("RegistryExpiration", s.testRegistryExpiration)
+	t.Run("Request", s.testRequest)
+	t.Run("Refresh", s.testRefresh)
+	t.Run("Heal", s.testHeal)
+	t.Run("InterdomainFind", s.testInterdomainFind)
+}
+
+func (s *suite) testRegistryFind(t *testing.T) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	nseClient := s.nseRegistryClient(ctx, t, s.domain)
+
+	nse := s.newNSE(time.Minute)
+
+	stream, err := nseClient.Find(ctx, &registryapi.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registryapi.NetworkServiceEndpoint{Name: nse.Name},
+		Watch:                  true,
+	})
+	require.NoError(t, err)
+
+	reg, err := nseClient.Register(ctx, nse.Clone())
+	require.NoError(t, err)
+	require.Equal(t, nse.Name, reg.Name)
+	require.NotNil(t, reg.ExpirationTime, "registry should set the expiration time")
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, nse.Name, resp.NetworkServiceEndpoint.Name)
+	require.False(t, resp.Deleted)
+
+	require.Len(t, s.findNSEs(ctx, t, nseClient, nse.Name), 1)
+
+	_, err = nseClient.Unregister(ctx, reg)
+	require.NoError(t, err)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, nse.Name, resp.NetworkServiceEndpoint.Name)
+	require.True(t, resp.Deleted, "watchers should be notified about the unregistered NSE")
+
+	require.Empty(t, s.findNSEs(ctx, t, nseClient, nse.Name))
+}
+
+func (s *suite) testRegistryExpiration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	nseClient := s.nseRegistryClient(ctx, t, s.domain)
+
+	nse := s.newNSE(s.expirationTimeout)
+
+	_, err := nseClient.Register(ctx, nse.Clone())
+	require.NoError(t, err)
+
+	require.Len(t, s.findNSEs(ctx, t, nseClient, nse.Name), 1)
+	require.Eventually(t, func() bool {
+		return len(s.findNSEs(ctx, t, nseClient, nse.Name)) == 0
+	}, s.timeout, tick, "NSE should be removed after the expiration time")
+}
+
+func (s *suite) testRequest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	node := s.node(t)
+
+	counter := new(count.Server)
+	nseReg := s.newNSE(0)
+	s.registerNS(ctx, t, nseReg.NetworkServiceNames[0])
+	node.NewEndpoint(ctx, nseReg, s.tokenGenerator, counter)
+
+	nsc := node.NewClient(ctx, s.tokenGenerator)
+
+	conn, err := nsc.Request(ctx, s.newRequest(nseReg.NetworkServiceNames[0]))
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.UniqueRequests())
+	require.Equal(t, nseReg.NetworkServiceNames[0], conn.NetworkService)
+	require.Equal(t, nseReg.Name, conn.NetworkServiceEndpointName)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.UniqueCloses())
+}
+
+func (s *suite) testRefresh(t *testing.T) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	node := s.node(t)
+
+	counter := new(count.Server)
+	nseReg := s.newNSE(0)
+	s.registerNS(ctx, t, nseReg.NetworkServiceNames[0])
+	node.NewEndpoint(ctx, nseReg, s.tokenGenerator, counter)
+
+	nsc := node.NewClient(ctx, sandbox.GenerateExpiringToken(s.expirationTimeout))
+
+	conn, err := nsc.Request(ctx, s.newRequest(nseReg.NetworkServiceNames[0]))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return counter.Requests() > 1
+	}, s.timeout, tick, "connection should be refreshed before the token expires")
+	require.Equal(t, 1, counter.UniqueRequests())
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func (s *suite) testHeal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	node := s.node(t)
+
+	counter := new(count.Server)
+	nseReg := s.newNSE(0)
+	s.registerNS(ctx, t, nseReg.NetworkServiceNames[0])
+	nse := node.NewEndpoint(ctx, nseReg, s.tokenGenerator, counter)
+
+	nsc := node.NewClient(ctx, s.tokenGenerator)
+
+	conn, err := nsc.Request(ctx, s.newRequest(nseReg.NetworkServiceNames[0]))
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.UniqueRequests())
+
+	nse.Cancel()
+
+	nseReg2 := s.newNSE(0)
+	nseReg2.NetworkServiceNames = nseReg.NetworkServiceNames
+	node.NewEndpoint(ctx, nseReg2, s.tokenGenerator, counter)
+
+	require.Eventually(t, func() bool {
+		return counter.UniqueRequests() == 2
+	}, s.timeout, tick, "connection should be healed to the new NSE")
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func (s *suite) testInterdomainFind(t *testing.T) {
+	if s.remote == nil {
+		t.Skip("remote domain is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.timeout)
+	defer cancel()
+
+	nse := s.newNSE(time.Minute)
+
+	reg, err := s.nseRegistryClient(ctx, t, s.remote).Register(ctx, nse.Clone())
+	require.NoError(t, err)
+
+	name := reg.Name + "@" + s.remote.Name
+
+	nses := s.findNSEs(ctx, t, s.nseRegistryClient(ctx, t, s.domain), name)
+	require.Len(t, nses, 1)
+	require.Equal(t, name, nses[0].Name)
+}
+
+func (s *suite) registerNS(ctx context.Context, t *testing.T, name string) {
+	_, err := s.domain.NewNSRegistryClient(ctx, s.tokenGenerator).Register(ctx, &registryapi.NetworkService{
+		Name: name,
+	})
+	require.NoError(t, err)
+}
+
+func (s *suite) node(t *testing.T) *sandbox.Node {
+	if len(s.domain.Nodes) == 0 {
+		t.Skip("domain has no nodes")
+	}
+	return s.domain.Nodes[0]
+}
+
+// nseRegistryClient creates a plain NSE registry client with no refresh, heal and other SDK client side logic, so
+// the registry behavior is checked as is
+func (s *suite) nseRegistryClient(ctx context.Context, t *testing.T, domain *sandbox.Domain) registryapi.NetworkServiceEndpointRegistryClient {
+	var registryURL *url.URL
+	switch {
+	case domain.Registry != nil:
+		registryURL = domain.Registry.URL
+	case len(domain.Nodes) != 0:
+		registryURL = domain.Nodes[0].NSMgr.URL
+	default:
+		t.Skip("domain has no registry")
+	}
+
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(registryURL),
+		append(sandbox.DialOptions(sandbox.WithTokenGenerator(s.tokenGenerator)), grpc.WithBlock())...)
+	require.NoError(t, err)
+
+	go func() {
+		<-ctx.Done()
+		_ = cc.Close()
+	}()
+
+	return registryapi.NewNetworkServiceEndpointRegistryClient(cc)
+}
+
+func (s *suite) findNSEs(
+	ctx context.Context,
+	t *testing.T,
+	nseClient registryapi.NetworkServiceEndpointRegistryClient,
+	name string,
+) []*registryapi.NetworkServiceEndpoint {
+	stream, err := nseClient.Find(ctx, &registryapi.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registryapi.NetworkServiceEndpoint{Name: name},
+	})
+	require.NoError(t, err)
+
+	return registryapi.ReadNetworkServiceEndpointList(stream)
+}
+
+// newNSE creates a new NSE with unique names, if expiration is set the NSE expiration time is set to now + expiration
+func (s *suite) newNSE(expiration time.Duration) *registryapi.NetworkServiceEndpoint {
+	nse := &registryapi.NetworkServiceEndpoint{
+		Name:                sandbox.UniqueName("conformance-nse"),
+		NetworkServiceNames: []string{sandbox.UniqueName("conformance-ns")},
+	}
+	if expiration != 0 {
+		nse.Url = "tcp://127.0.0.1:5000"
+		nse.ExpirationTime = timestamppb.New(time.Now().Add(expiration))
+	}
+	return nse
+}
+
+func (s *suite) newRequest(nsName string) *networkservice.NetworkServiceRequest {
+	var mechanisms []*networkservice.Mechanism
+	for _, mechanism := range s.mechanisms {
+		mechanisms = append(mechanisms, mechanism.Clone())
+	}
+
+	return &networkservice.NetworkServiceRequest{
+		MechanismPreferences: mechanisms,
+		Connection: &networkservice.Connection{
+			Id:             sandbox.UniqueName("conformance-conn"),
+			NetworkService: nsName,
+			Context:        &networkservice.ConnectionContext{},
+			Labels:         make(map[string]string),
+		},
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/conformance"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func TestConformance_Sandbox(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	dnsServer := new(sandbox.FakeDNSResolver)
+
+	domain1 := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetDNSResolver(dnsServer).
+		Build()
+
+	domain2 := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(0).
+		SetDNSResolver(dnsServer).
+		SetDNSDomainName("domain2").
+		Build()
+
+	// The suite sees the sandbox components only by URLs, the same way as the external ones
+	local := sandbox.NewExternalDomain(t, &sandbox.ExternalConfig{
+		Name:        domain1.Name,
+		RegistryURL: domain1.Registry.URL,
+		NSMgrURLs:   []*url.URL{domain1.Nodes[0].NSMgr.URL},
+	})
+	remote := sandbox.NewExternalDomain(t, &sandbox.ExternalConfig{
+		Name:        domain2.Name,
+		RegistryURL: domain2.Registry.URL,
+	})
+
+	conformance.Run(ctx, t, local, conformance.WithRemoteDomain(remote))
+}
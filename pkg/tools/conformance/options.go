@@ -0,0 +1,75 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+)
+
+// Option is an option pattern for Run
+type Option func(o *options)
+
+// WithRemoteDomain sets the domain used for the interdomain checks. The remote registry should be reachable by the
+// local domain registry with the remote domain name. The interdomain checks are skipped if not set.
+func WithRemoteDomain(remote *sandbox.Domain) Option {
+	return func(o *options) {
+		o.remote = remote
+	}
+}
+
+// WithTokenGenerator sets the token generator used by the in-process components
+func WithTokenGenerator(tokenGenerator token.GeneratorFunc) Option {
+	return func(o *options) {
+		o.tokenGenerator = tokenGenerator
+	}
+}
+
+// WithTimeout sets the timeout for each check
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithExpirationTimeout sets the expiration timeout used for the expiration and refresh checks. It should be long
+// enough for the components under test to process the Register and Request.
+func WithExpirationTimeout(expirationTimeout time.Duration) Option {
+	return func(o *options) {
+		o.expirationTimeout = expirationTimeout
+	}
+}
+
+// WithMechanismPreferences sets the mechanism preferences for the client requests, the forwarder under test should
+// support at least one of them. Default is the local kernel mechanism.
+func WithMechanismPreferences(mechanisms ...*networkservice.Mechanism) Option {
+	return func(o *options) {
+		o.mechanisms = mechanisms
+	}
+}
+
+type options struct {
+	mechanisms        []*networkservice.Mechanism
+	remote            *sandbox.Domain
+	tokenGenerator    token.GeneratorFunc
+	timeout           time.Duration
+	expirationTimeout time.Duration
+}
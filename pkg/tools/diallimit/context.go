@@ -0,0 +1,36 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diallimit
+
+import (
+	"context"
+)
+
+type limiterKey struct{}
+
+// WithLimiter returns a new context carrying the limiter
+func WithLimiter(ctx context.Context, limiter *Limiter) context.Context {
+	return context.WithValue(ctx, limiterKey{}, limiter)
+}
+
+// FromContext returns the limiter from ctx or nil if there is no such
+func FromContext(ctx context.Context) *Limiter {
+	if limiter, ok := ctx.Value(limiterKey{}).(*Limiter); ok {
+		return limiter
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diallimit provides a limiter of the concurrent outbound dial attempts per remote target, so a blackholed
+// remote domain can't exhaust local file descriptors and goroutines during retry storms.
+// The dial chain elements use the limiter from the chain context, so it is enabled for the interdomain chains
+// (nsmgr-proxy, proxy registry) by passing the context created with WithLimiter to their constructors.
+package diallimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	defaultMaxConcurrent = 8
+	defaultMaxQueued     = 64
+	defaultQueueTimeout  = 10 * time.Second
+)
+
+// Limiter limits the number of concurrent dial attempts per key, the attempts exceeding the limit wait in the queue
+type Limiter struct {
+	maxConcurrent int
+	maxQueued     int
+	queueTimeout  time.Duration
+
+	mu      sync.Mutex
+	targets map[string]*target
+}
+
+type target struct {
+	slots  chan struct{}
+	queued int
+	users  int
+}
+
+// NewLimiter creates a new Limiter
+func NewLimiter(opts ...Option) *Limiter {
+	l := &Limiter{
+		maxConcurrent: defaultMaxConcurrent,
+		maxQueued:     defaultMaxQueued,
+		queueTimeout:  defaultQueueTimeout,
+		targets:       make(map[string]*target),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Acquire waits for a free dial slot for the key. It fails if the queue of the key is full, the queue timeout expires
+// or the ctx is done. The returned release function must be called once the dial attempt is finished.
+func (l *Limiter) Acquire(ctx context.Context, key string) (release func(), err error) {
+	t := l.load(key)
+
+	select {
+	case t.slots <- struct{}{}:
+		return l.releaseFunc(key, t), nil
+	default:
+	}
+
+	if !l.enqueue(t) {
+		l.unload(key, t)
+		return nil, errors.Errorf("too many queued dials to %s", key)
+	}
+	defer l.dequeue(t)
+
+	select {
+	case t.slots <- struct{}{}:
+		return l.releaseFunc(key, t), nil
+	case <-ctx.Done():
+		l.unload(key, t)
+		return nil, errors.Wrapf(ctx.Err(), "canceled waiting for a dial slot to %s", key)
+	case <-clock.FromContext(ctx).After(l.queueTimeout):
+		l.unload(key, t)
+		return nil, errors.Errorf("timed out waiting for a dial slot to %s", key)
+	}
+}
+
+func (l *Limiter) releaseFunc(key string, t *target) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-t.slots
+			l.unload(key, t)
+		})
+	}
+}
+
+func (l *Limiter) load(key string) *target {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t, ok := l.targets[key]
+	if !ok {
+		t = &target{
+			slots: make(chan struct{}, l.maxConcurrent),
+		}
+		l.targets[key] = t
+	}
+	t.users++
+	return t
+}
+
+// unload deletes the target once it has no users, so the limiter doesn't grow with the number of dialed keys
+func (l *Limiter) unload(key string, t *target) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.users--; t.users == 0 {
+		delete(l.targets, key)
+	}
+}
+
+func (l *Limiter) enqueue(t *target) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.queued >= l.maxQueued {
+		return false
+	}
+	t.queued++
+	return true
+}
+
+func (l *Limiter) dequeue(t *target) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t.queued--
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diallimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/diallimit"
+)
+
+func TestLimiter_MaxConcurrent(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	limiter := diallimit.NewLimiter(diallimit.WithMaxConcurrent(2))
+
+	release1, err := limiter.Acquire(ctx, "domain-1")
+	require.NoError(t, err)
+	release2, err := limiter.Acquire(ctx, "domain-1")
+	require.NoError(t, err)
+
+	// Other keys are not limited
+	release3, err := limiter.Acquire(ctx, "domain-2")
+	require.NoError(t, err)
+	release3()
+
+	acquired := make(chan func(), 1)
+	go func() {
+		release, acquireErr := limiter.Acquire(ctx, "domain-1")
+		if acquireErr == nil {
+			acquired <- release
+		}
+	}()
+	require.Never(t, func() bool { return len(acquired) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	release1()
+	// Release is idempotent, so it doesn't free one more slot
+	release1()
+
+	var release4 func()
+	select {
+	case release4 = <-acquired:
+	case <-time.After(time.Second):
+		require.FailNow(t, "queued dial hasn't acquired the released slot")
+	}
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer timeoutCancel()
+	_, err = limiter.Acquire(timeoutCtx, "domain-1")
+	require.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+
+	release2()
+	release4()
+}
+
+func TestLimiter_QueueTimeout(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	limiter := diallimit.NewLimiter(
+		diallimit.WithMaxConcurrent(1),
+		diallimit.WithQueueTimeout(10*time.Second),
+	)
+
+	release, err := limiter.Acquire(ctx, "domain-1")
+	require.NoError(t, err)
+	defer release()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, acquireErr := limiter.Acquire(ctx, "domain-1")
+		errCh <- acquireErr
+	}()
+
+	require.Eventually(t, func() bool {
+		clockMock.Add(time.Second)
+		return len(errCh) > 0
+	}, time.Second, 10*time.Millisecond)
+	require.Error(t, <-errCh)
+}
+
+func TestLimiter_MaxQueued(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	limiter := diallimit.NewLimiter(
+		diallimit.WithMaxConcurrent(1),
+		diallimit.WithMaxQueued(1),
+	)
+
+	release, err := limiter.Acquire(ctx, "domain-1")
+	require.NoError(t, err)
+	defer release()
+
+	queueCtx, queueCancel := context.WithCancel(ctx)
+	defer queueCancel()
+	errCh := make(chan error, 1)
+	go func() {
+		_, acquireErr := limiter.Acquire(queueCtx, "domain-1")
+		errCh <- acquireErr
+	}()
+
+	// Once the queue is full, the dial fails immediately without waiting for the ctx
+	canceledCtx, canceledCancel := context.WithCancel(ctx)
+	canceledCancel()
+	require.Eventually(t, func() bool {
+		_, acquireErr := limiter.Acquire(canceledCtx, "domain-1")
+		return acquireErr != nil && errors.Cause(acquireErr) != context.Canceled
+	}, time.Second, 10*time.Millisecond)
+
+	queueCancel()
+	require.Equal(t, context.Canceled, errors.Cause(<-errCh))
+}
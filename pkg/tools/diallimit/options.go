@@ -0,0 +1,46 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diallimit
+
+import (
+	"time"
+)
+
+// Option is an option pattern for NewLimiter
+type Option func(l *Limiter)
+
+// WithMaxConcurrent sets the max number of the concurrent dial attempts per key. Default value = 8.
+func WithMaxConcurrent(maxConcurrent int) Option {
+	return func(l *Limiter) {
+		l.maxConcurrent = maxConcurrent
+	}
+}
+
+// WithMaxQueued sets the max number of the dial attempts per key waiting for a free slot, the attempts exceeding it
+// fail immediately. Default value = 64.
+func WithMaxQueued(maxQueued int) Option {
+	return func(l *Limiter) {
+		l.maxQueued = maxQueued
+	}
+}
+
+// WithQueueTimeout sets the max time a dial attempt waits for a free slot. Default value = 10 seconds.
+func WithQueueTimeout(queueTimeout time.Duration) Option {
+	return func(l *Limiter) {
+		l.queueTimeout = queueTimeout
+	}
+}
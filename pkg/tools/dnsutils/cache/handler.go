@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package cache stores successful requests to DNS server
+// Package cache stores successful and negative (RFC 2308) requests to DNS server
 package cache
 
 import (
@@ -24,23 +24,29 @@ import (
 
 	"github.com/miekg/dns"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/metrics"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type dnsCacheHandler struct {
-	cache *msgMap
+	cache          *msgMap
+	maxNegativeTTL uint32
+	stats          *Stats
 
 	lastTTLUpdate time.Time
 	m             sync.Mutex
 }
 
 func (h *dnsCacheHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
-	h.updateTTL()
+	h.updateTTL(clock.FromContext(ctx).Now())
 	if val, ok := h.cache.Load(m.Question[0]); ok {
 		v := val.Copy()
 		if validateMsg(v) {
+			metrics.MarkCacheHit(ctx)
+			h.stats.hit()
 			v.Id = m.Id
 			if err := rw.WriteMsg(v); err != nil {
 				log.FromContext(ctx).WithField("dnsCacheHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
@@ -53,38 +59,55 @@ func (h *dnsCacheHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m
 		h.cache.Delete(m.Question[0])
 	}
 
+	h.stats.miss()
+
 	wrapper := responseWriterWrapper{
 		ResponseWriter: rw,
 		cache:          h.cache,
+		maxNegativeTTL: h.maxNegativeTTL,
 	}
 
 	next.Handler(ctx).ServeDNS(ctx, &wrapper, m)
 }
 
-func (h *dnsCacheHandler) updateTTL() {
-	now := time.Now()
+func (h *dnsCacheHandler) updateTTL(now time.Time) {
 	h.m.Lock()
 	defer h.m.Unlock()
 
+	// The cache is filled on the requests only, so it is enough to start counting from the first one
+	if h.lastTTLUpdate.IsZero() {
+		h.lastTTLUpdate = now
+		return
+	}
+
 	diff := uint32(now.Sub(h.lastTTLUpdate).Seconds())
 	if diff == 0 {
 		return
 	}
 
 	h.cache.Range(func(key dns.Question, value *dns.Msg) bool {
-		for i := range value.Answer {
-			if value.Answer[i].Header().Ttl < diff {
-				value.Answer[i].Header().Ttl = 0
-			} else {
-				value.Answer[i].Header().Ttl -= diff
-			}
-		}
+		decreaseTTL(value.Answer, diff)
+		decreaseTTL(value.Ns, diff)
 		return true
 	})
 	h.lastTTLUpdate = now
 }
 
+func decreaseTTL(rrs []dns.RR, diff uint32) {
+	for i := range rrs {
+		if rrs[i].Header().Ttl < diff {
+			rrs[i].Header().Ttl = 0
+		} else {
+			rrs[i].Header().Ttl -= diff
+		}
+	}
+}
+
 func validateMsg(m *dns.Msg) bool {
+	if isNegative(m) {
+		soa := findSOA(m)
+		return soa != nil && soa.Hdr.Ttl > 0
+	}
 	if len(m.Answer) == 0 {
 		return false
 	}
@@ -97,10 +120,23 @@ func validateMsg(m *dns.Msg) bool {
 	return true
 }
 
-// NewDNSHandler creates a new dns handler that stores successful requests to DNS server
-func NewDNSHandler() dnsutils.Handler {
-	return &dnsCacheHandler{
-		cache:         new(msgMap),
-		lastTTLUpdate: time.Now(),
+// NewDNSHandler creates a new dns handler that stores successful requests to DNS server. Negative answers are stored
+// for the SOA-derived TTL capped with WithMaxNegativeTTL.
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	o := &options{
+		maxNegativeTTL: DefaultMaxNegativeTTL,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &dnsCacheHandler{
+		cache:          new(msgMap),
+		maxNegativeTTL: uint32(o.maxNegativeTTL / time.Second),
+		stats:          o.stats,
+	}
+	if h.stats != nil {
+		h.stats.cache.Store(h.cache)
 	}
+	return h
 }
@@ -25,6 +25,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/cache"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
@@ -53,6 +55,9 @@ func TestCache(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
 	records := new(memory.Map)
 	records.Store("example.com.", []net.IP{net.ParseIP("1.1.1.1")})
 
@@ -69,7 +74,7 @@ func TestCache(t *testing.T) {
 	handler.ServeDNS(ctx, rw, m)
 	resp1 := rw.Response.Copy()
 
-	time.Sleep(time.Second)
+	clockMock.Add(time.Second)
 
 	handler.ServeDNS(ctx, rw, m)
 	resp2 := rw.Response.Copy()
@@ -77,3 +82,113 @@ func TestCache(t *testing.T) {
 	require.Equal(t, check.Count, 1)
 	require.Equal(t, resp1.Answer[0].Header().Ttl-resp2.Answer[0].Header().Ttl, uint32(1))
 }
+
+type negativeHandler struct {
+	soa *dns.SOA
+}
+
+func (h *negativeHandler) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetRcode(m, dns.RcodeNameError)
+	if h.soa != nil {
+		resp.Ns = append(resp.Ns, dns.Copy(h.soa))
+	}
+	_ = rw.WriteMsg(resp)
+}
+
+func newSOA(ttl, minttl uint32) *dns.SOA {
+	return &dns.SOA{
+		Hdr:    dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: ttl},
+		Ns:     "ns.example.com.",
+		Mbox:   "admin.example.com.",
+		Minttl: minttl,
+	}
+}
+
+func TestCache_Negative(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	check := &checkHandler{}
+	handler := next.NewDNSHandler(
+		cache.NewDNSHandler(),
+		check,
+		&negativeHandler{soa: newSOA(300, 60)},
+	)
+
+	rw := &ResponseWriter{}
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn("unknown.example.com"), dns.TypeA)
+
+	handler.ServeDNS(ctx, rw, m)
+	require.Equal(t, dns.RcodeNameError, rw.Response.Rcode)
+	require.Equal(t, uint32(300), rw.Response.Ns[0].Header().Ttl)
+
+	clockMock.Add(10 * time.Second)
+
+	handler.ServeDNS(ctx, rw, m)
+	require.Equal(t, 1, check.Count)
+	require.Equal(t, dns.RcodeNameError, rw.Response.Rcode)
+	require.Equal(t, uint32(50), rw.Response.Ns[0].Header().Ttl)
+
+	clockMock.Add(50 * time.Second)
+
+	handler.ServeDNS(ctx, rw, m)
+	require.Equal(t, 2, check.Count)
+}
+
+func TestCache_NegativeMaxTTL(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	check := &checkHandler{}
+	handler := next.NewDNSHandler(
+		cache.NewDNSHandler(cache.WithMaxNegativeTTL(5*time.Second)),
+		check,
+		&negativeHandler{soa: newSOA(300, 60)},
+	)
+
+	rw := &ResponseWriter{}
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn("unknown.example.com"), dns.TypeA)
+
+	handler.ServeDNS(ctx, rw, m)
+	handler.ServeDNS(ctx, rw, m)
+	require.Equal(t, 1, check.Count)
+
+	clockMock.Add(5 * time.Second)
+
+	handler.ServeDNS(ctx, rw, m)
+	require.Equal(t, 2, check.Count)
+}
+
+func TestCache_NegativeNotCached(t *testing.T) {
+	t.Run("without SOA", func(t *testing.T) {
+		require.Equal(t, 2, countNegativeQueries(&negativeHandler{}))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		require.Equal(t, 2, countNegativeQueries(&negativeHandler{soa: newSOA(300, 60)}, cache.WithMaxNegativeTTL(0)))
+	})
+}
+
+func countNegativeQueries(negative *negativeHandler, opts ...cache.Option) int {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	check := &checkHandler{}
+	handler := next.NewDNSHandler(cache.NewDNSHandler(opts...), check, negative)
+
+	rw := &ResponseWriter{}
+	m := &dns.Msg{}
+	m.SetQuestion(dns.Fqdn("unknown.example.com"), dns.TypeA)
+
+	handler.ServeDNS(ctx, rw, m)
+	handler.ServeDNS(ctx, rw, m)
+	return check.Count
+}
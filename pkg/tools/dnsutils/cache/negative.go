@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"github.com/miekg/dns"
+)
+
+// isNegative returns true for the NXDOMAIN and NODATA answers
+func isNegative(m *dns.Msg) bool {
+	switch m.Rcode {
+	case dns.RcodeNameError:
+		return true
+	case dns.RcodeSuccess:
+		return len(m.Answer) == 0
+	default:
+		return false
+	}
+}
+
+// findSOA returns the SOA record from the authority section of the negative answer
+func findSOA(m *dns.Msg) *dns.SOA {
+	for _, rr := range m.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa
+		}
+	}
+	return nil
+}
+
+// negativeCopy returns a copy of the negative answer to store in cache with the SOA TTL set to the negative TTL:
+// min(SOA TTL, SOA MINIMUM, maxTTL) as defined in RFC 2308, section 5. Negative answers without SOA must not be cached.
+func negativeCopy(m *dns.Msg, maxTTL uint32) (*dns.Msg, bool) {
+	if maxTTL == 0 || findSOA(m) == nil {
+		return nil, false
+	}
+
+	c := m.Copy()
+	soa := findSOA(c)
+	ttl := soa.Hdr.Ttl
+	if soa.Minttl < ttl {
+		ttl = soa.Minttl
+	}
+	if maxTTL < ttl {
+		ttl = maxTTL
+	}
+	if ttl == 0 {
+		return nil, false
+	}
+	soa.Hdr.Ttl = ttl
+	return c, true
+}
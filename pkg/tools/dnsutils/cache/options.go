@@ -0,0 +1,45 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import "time"
+
+// DefaultMaxNegativeTTL is the default cap of the negative answers TTL. RFC 2308 recommends one to three hours.
+const DefaultMaxNegativeTTL = 3 * time.Hour
+
+type options struct {
+	maxNegativeTTL time.Duration
+	stats          *Stats
+}
+
+// Option is an option pattern for NewDNSHandler
+type Option func(o *options)
+
+// WithMaxNegativeTTL sets the cap of the negative (NXDOMAIN/NODATA) answers TTL. Zero disables the negative caching.
+// Default: DefaultMaxNegativeTTL
+func WithMaxNegativeTTL(maxNegativeTTL time.Duration) Option {
+	return func(o *options) {
+		o.maxNegativeTTL = maxNegativeTTL
+	}
+}
+
+// WithStats sets the statistics filled by the handler
+func WithStats(stats *Stats) Option {
+	return func(o *options) {
+		o.stats = stats
+	}
+}
@@ -22,11 +22,18 @@ import (
 
 type responseWriterWrapper struct {
 	dns.ResponseWriter
-	cache *msgMap
+	cache          *msgMap
+	maxNegativeTTL uint32
 }
 
 func (r *responseWriterWrapper) WriteMsg(m *dns.Msg) error {
-	if m != nil && m.Rcode == dns.RcodeSuccess {
+	switch {
+	case m == nil:
+	case isNegative(m):
+		if c, ok := negativeCopy(m, r.maxNegativeTTL); ok {
+			r.cache.Store(m.Question[0], c)
+		}
+	case m.Rcode == dns.RcodeSuccess:
 		r.cache.Store(m.Question[0], m)
 	}
 	return r.ResponseWriter.WriteMsg(m)
@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"sync/atomic"
+
+	"github.com/miekg/dns"
+)
+
+// Stats are the cache statistics, it is filled by the cache handler created with WithStats
+type Stats struct {
+	hits   int64
+	misses int64
+	cache  atomic.Value
+}
+
+// Hits returns the number of the queries answered from the cache
+func (s *Stats) Hits() int64 {
+	return atomic.LoadInt64(&s.hits)
+}
+
+// Misses returns the number of the queries passed to the next handlers
+func (s *Stats) Misses() int64 {
+	return atomic.LoadInt64(&s.misses)
+}
+
+// Size returns the number of the cached answers including the expired ones not removed yet
+func (s *Stats) Size() int {
+	c, ok := s.cache.Load().(*msgMap)
+	if !ok {
+		return 0
+	}
+	var size int
+	c.Range(func(_ dns.Question, _ *dns.Msg) bool {
+		size++
+		return true
+	})
+	return size
+}
+
+func (s *Stats) hit() {
+	if s != nil {
+		atomic.AddInt64(&s.hits, 1)
+	}
+}
+
+func (s *Stats) miss() {
+	if s != nil {
+		atomic.AddInt64(&s.misses, 1)
+	}
+}
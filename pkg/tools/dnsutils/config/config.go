@@ -0,0 +1,133 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/doh"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dot"
+)
+
+const (
+	// PolicyParallel sends the queries to all the upstreams in parallel and returns the first successful answer
+	PolicyParallel = "parallel"
+	// PolicySequential sends the queries to the upstreams one by one in the config order until the successful answer
+	PolicySequential = "sequential"
+)
+
+// Config is a declarative configuration of the dnsutils handlers chain
+type Config struct {
+	// Upstreams are the upstream DNS servers URLs: "udp://", "tcp://", "tcp-tls://", "tls://" (DNS-over-TLS) or
+	// "https://" (DNS-over-HTTPS)
+	Upstreams []string `json:"upstreams,omitempty"`
+	// SearchDomains are the search domains used to expand the query names
+	SearchDomains []string `json:"searchDomains,omitempty"`
+	// Ndots is the number of dots a name must have to be queried as is before the search domains are tried
+	Ndots *int `json:"ndots,omitempty"`
+	// Cache is the answers cache configuration
+	Cache *CacheConfig `json:"cache,omitempty"`
+	// Fanout is the upstreams fanout configuration
+	Fanout *FanoutConfig `json:"fanout,omitempty"`
+}
+
+// CacheConfig is the answers cache configuration
+type CacheConfig struct {
+	// Enabled enables the answers cache
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxNegativeTTL is the cap of the negative answers TTL in the time.ParseDuration format, "0s" disables the
+	// negative caching
+	MaxNegativeTTL string `json:"maxNegativeTTL,omitempty"`
+}
+
+// FanoutConfig is the upstreams fanout configuration
+type FanoutConfig struct {
+	// Policy is PolicyParallel (default) or PolicySequential
+	Policy string `json:"policy,omitempty"`
+	// DefaultPort is the port used for the upstreams URLs without port
+	DefaultPort uint16 `json:"defaultPort,omitempty"`
+	// Pool enables the persistent connections to the "tcp" and "tcp-tls" upstreams
+	Pool bool `json:"pool,omitempty"`
+}
+
+// Parse parses and validates the config in YAML or JSON format
+func Parse(bytes []byte) (*Config, error) {
+	c := new(Config)
+	if err := yaml.Unmarshal(bytes, c); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal dns config")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Config) validate() error {
+	for _, upstream := range c.Upstreams {
+		u, err := url.Parse(upstream)
+		if err != nil {
+			return errors.Wrapf(err, "invalid upstream %s", upstream)
+		}
+		switch u.Scheme {
+		case "udp", "tcp", "tcp-tls", dot.Scheme, doh.Scheme:
+		default:
+			return errors.Errorf("unsupported upstream scheme %s", upstream)
+		}
+		if u.Host == "" {
+			return errors.Errorf("upstream %s has no host", upstream)
+		}
+	}
+	if c.Ndots != nil && *c.Ndots < 0 {
+		return errors.Errorf("invalid ndots %d", *c.Ndots)
+	}
+	if c.Cache != nil && c.Cache.MaxNegativeTTL != "" {
+		if _, err := time.ParseDuration(c.Cache.MaxNegativeTTL); err != nil {
+			return errors.Wrapf(err, "invalid cache max negative TTL %s", c.Cache.MaxNegativeTTL)
+		}
+	}
+	if c.Fanout != nil {
+		switch c.Fanout.Policy {
+		case "", PolicyParallel, PolicySequential:
+		default:
+			return errors.Errorf("unsupported fanout policy %s", c.Fanout.Policy)
+		}
+	}
+	return nil
+}
+
+func (c *Config) upstreamURLs() []url.URL {
+	urls := make([]url.URL, 0, len(c.Upstreams))
+	for _, upstream := range c.Upstreams {
+		// Already validated
+		u, _ := url.Parse(upstream)
+		urls = append(urls, *u)
+	}
+	return urls
+}
+
+func (c *Config) hasScheme(scheme string) bool {
+	for _, u := range c.upstreamURLs() {
+		if u.Scheme == scheme {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/config"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+func startServer(t *testing.T, name string, rcode int) string {
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{
+		PacketConn: udpConn,
+		Handler: dns.HandlerFunc(func(rw dns.ResponseWriter, msg *dns.Msg) {
+			resp := new(dns.Msg).SetRcode(msg, rcode)
+			if rcode == dns.RcodeSuccess {
+				resp.Answer = append(resp.Answer, &dns.TXT{
+					Hdr: dns.RR_Header{Name: msg.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+					Txt: []string{name},
+				})
+			}
+			_ = rw.WriteMsg(resp)
+		}),
+	}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return "udp://" + udpConn.LocalAddr().String()
+}
+
+func query(ctx context.Context, handler dnsutils.Handler) *dns.Msg {
+	rw := new(responseWriter)
+	handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion("example.com.", dns.TypeTXT))
+	return rw.Response
+}
+
+func TestParse(t *testing.T) {
+	c, err := config.Parse([]byte(`
+upstreams:
+  - udp://10.0.0.1:53
+  - tls://10.0.0.2
+searchDomains: [my.domain]
+ndots: 2
+cache:
+  enabled: true
+  maxNegativeTTL: 10m
+fanout:
+  policy: sequential
+  defaultPort: 5353
+`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"udp://10.0.0.1:53", "tls://10.0.0.2"}, c.Upstreams)
+	require.Equal(t, 2, *c.Ndots)
+	require.True(t, c.Cache.Enabled)
+	require.Equal(t, config.PolicySequential, c.Fanout.Policy)
+	require.Equal(t, uint16(5353), c.Fanout.DefaultPort)
+
+	for _, invalid := range []string{
+		`upstreams: [ftp://10.0.0.1]`,
+		`upstreams: ["udp://"]`,
+		`ndots: -1`,
+		`cache: {maxNegativeTTL: ten}`,
+		`fanout: {policy: random}`,
+		`upstreams: {}`,
+	} {
+		_, err = config.Parse([]byte(invalid))
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestNewDNSHandler_Reload(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	first := startServer(t, "first", dns.RcodeSuccess)
+	second := startServer(t, "second", dns.RcodeSuccess)
+
+	path := filepath.Join(t.TempDir(), "dns.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte("upstreams: ["+first+"]"), os.ModePerm))
+
+	handler := config.NewDNSHandler(ctx, path)
+
+	resp := query(ctx, handler)
+	require.NotNil(t, resp)
+	require.Equal(t, []string{"first"}, resp.Answer[0].(*dns.TXT).Txt)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("upstreams: ["+second+"]"), os.ModePerm))
+	require.Eventually(t, func() bool {
+		resp = query(ctx, handler)
+		return resp != nil && len(resp.Answer) == 1 && resp.Answer[0].(*dns.TXT).Txt[0] == "second"
+	}, time.Second*3, time.Millisecond*50)
+
+	// Invalid config keeps the previous chain
+	require.NoError(t, ioutil.WriteFile(path, []byte("upstreams: [ftp://"+first+"]"), os.ModePerm))
+	require.Never(t, func() bool {
+		resp = query(ctx, handler)
+		return resp == nil || resp.Answer[0].(*dns.TXT).Txt[0] != "second"
+	}, time.Millisecond*300, time.Millisecond*50)
+
+	cancel()
+}
+
+func TestNewDNSHandler_Sequential(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	failed := startServer(t, "failed", dns.RcodeServerFailure)
+	first := startServer(t, "first", dns.RcodeSuccess)
+	second := startServer(t, "second", dns.RcodeSuccess)
+
+	path := filepath.Join(t.TempDir(), "dns.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+upstreams: [`+failed+`, `+first+`, `+second+`]
+cache:
+  enabled: true
+fanout:
+  policy: sequential
+`), os.ModePerm))
+
+	handler := config.NewDNSHandler(ctx, path)
+
+	for i := 0; i < 3; i++ {
+		resp := query(ctx, handler)
+		require.NotNil(t, resp)
+		require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+		require.Equal(t, []string{"first"}, resp.Answer[0].(*dns.TXT).Txt)
+	}
+
+	cancel()
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config builds the dnsutils handlers chain from the declarative config file and rebuilds it on the file
+// changes
+package config
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/cache"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/doh"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dot"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/fanout"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/pool"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/searches"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/switchable"
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type configHandler struct {
+	*switchable.Handler
+	opts *options
+	pool *pool.Pool
+}
+
+// NewDNSHandler creates a new dns handler built from the config file and rebuilt when the file changes. Invalid config
+// updates are logged and ignored keeping the previous chain. Missing file means the empty config, so all the queries
+// fail until it is created.
+func NewDNSHandler(ctx context.Context, path string, opts ...Option) dnsutils.Handler {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &configHandler{
+		Handler: switchable.NewDNSHandler(),
+		opts:    o,
+	}
+
+	updateCh := fs.WatchFile(ctx, path)
+	h.update(ctx, <-updateCh)
+	go func() {
+		for bytes := range updateCh {
+			// The file is removed, wait for the new one
+			if bytes == nil {
+				continue
+			}
+			h.update(ctx, bytes)
+		}
+		if h.pool != nil {
+			h.pool.Close()
+		}
+	}()
+
+	return h
+}
+
+func (h *configHandler) update(ctx context.Context, bytes []byte) {
+	logger := log.FromContext(ctx).WithField("configHandler", "update")
+
+	c, err := Parse(bytes)
+	if err != nil {
+		logger.Errorf("invalid dns config: %v", err.Error())
+		return
+	}
+
+	h.Set(h.build(c)...)
+	logger.Infof("dns config applied: %v", h.Names())
+}
+
+// entryBuilders are the chain entries in the order of the chain, each builder returns false if the entry is not needed
+// for the config
+var entryBuilders = []struct {
+	name  string
+	build func(h *configHandler, c *Config) (dnsutils.Handler, bool)
+}{
+	{name: "upstreams", build: (*configHandler).buildUpstreams},
+	{name: "searches", build: (*configHandler).buildSearches},
+	{name: "cache", build: (*configHandler).buildCache},
+	{name: "sequential", build: (*configHandler).buildSequential},
+	{name: "dot", build: (*configHandler).buildDoT},
+	{name: "doh", build: (*configHandler).buildDoH},
+	{name: "fanout", build: (*configHandler).buildFanout},
+}
+
+func (h *configHandler) build(c *Config) []switchable.Entry {
+	var entries []switchable.Entry
+	for _, b := range entryBuilders {
+		if handler, ok := b.build(h, c); ok {
+			entries = append(entries, switchable.Named(b.name, handler))
+		}
+	}
+	return entries
+}
+
+func (h *configHandler) buildUpstreams(c *Config) (dnsutils.Handler, bool) {
+	return &upstreamsHandler{
+		urls:          c.upstreamURLs(),
+		searchDomains: c.SearchDomains,
+	}, true
+}
+
+func (h *configHandler) buildSearches(c *Config) (dnsutils.Handler, bool) {
+	if len(c.SearchDomains) == 0 {
+		return nil, false
+	}
+	var searchesOpts []searches.Option
+	if c.Ndots != nil {
+		searchesOpts = append(searchesOpts, searches.WithNdots(*c.Ndots))
+	}
+	return searches.NewDNSHandler(searchesOpts...), true
+}
+
+func (h *configHandler) buildCache(c *Config) (dnsutils.Handler, bool) {
+	if c.Cache == nil || !c.Cache.Enabled {
+		return nil, false
+	}
+	var cacheOpts []cache.Option
+	if c.Cache.MaxNegativeTTL != "" {
+		// Already validated
+		maxNegativeTTL, _ := time.ParseDuration(c.Cache.MaxNegativeTTL)
+		cacheOpts = append(cacheOpts, cache.WithMaxNegativeTTL(maxNegativeTTL))
+	}
+	return cache.NewDNSHandler(cacheOpts...), true
+}
+
+func (h *configHandler) buildSequential(c *Config) (dnsutils.Handler, bool) {
+	if c.Fanout == nil || c.Fanout.Policy != PolicySequential {
+		return nil, false
+	}
+	return new(sequentialHandler), true
+}
+
+func (h *configHandler) buildDoT(c *Config) (dnsutils.Handler, bool) {
+	if !c.hasScheme(dot.Scheme) {
+		return nil, false
+	}
+	var dotOpts []dot.Option
+	if h.opts.tlsConfig != nil {
+		dotOpts = append(dotOpts, dot.WithTLSConfig(h.opts.tlsConfig))
+	}
+	return dot.NewDNSHandler(dotOpts...), true
+}
+
+func (h *configHandler) buildDoH(c *Config) (dnsutils.Handler, bool) {
+	if !c.hasScheme(doh.Scheme) {
+		return nil, false
+	}
+	var dohOpts []doh.Option
+	if h.opts.tlsConfig != nil {
+		dohOpts = append(dohOpts, doh.WithTLSConfig(h.opts.tlsConfig))
+	}
+	return doh.NewDNSHandler(dohOpts...), true
+}
+
+func (h *configHandler) buildFanout(c *Config) (dnsutils.Handler, bool) {
+	fanoutConfig := c.Fanout
+	if fanoutConfig == nil {
+		fanoutConfig = new(FanoutConfig)
+	}
+
+	var fanoutOpts []fanout.Option
+	if fanoutConfig.DefaultPort != 0 {
+		fanoutOpts = append(fanoutOpts, fanout.WithDefaultDNSPort(fanoutConfig.DefaultPort))
+	}
+	if fanoutConfig.Pool {
+		if h.pool == nil {
+			h.pool = pool.NewPool()
+		}
+		fanoutOpts = append(fanoutOpts, fanout.WithPool(h.pool))
+	}
+	return fanout.NewDNSHandler(fanoutOpts...), true
+}
+
+// upstreamsHandler passes the configured upstreams and search domains to the next handlers
+type upstreamsHandler struct {
+	urls          []url.URL
+	searchDomains []string
+}
+
+func (h *upstreamsHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	ctx = clienturlctx.WithClientURLs(ctx, h.urls)
+	if len(h.searchDomains) > 0 {
+		ctx = searches.WithSearchDomains(ctx, h.searchDomains)
+	}
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
+}
+
+// sequentialHandler passes the query to the next handlers with the upstreams one by one until the successful answer
+type sequentialHandler struct{}
+
+func (h *sequentialHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	var last *dns.Msg
+	for _, u := range clienturlctx.ClientURLs(ctx) {
+		upstreamCtx := clienturlctx.WithClientURLs(ctx, []url.URL{u})
+
+		r := &responseWriter{ResponseWriter: rw}
+		next.Handler(upstreamCtx).ServeDNS(upstreamCtx, r, m.Copy())
+		if r.Response == nil {
+			continue
+		}
+		if r.Response.Rcode != dns.RcodeServerFailure {
+			h.write(ctx, rw, m, r.Response)
+			return
+		}
+		last = r.Response
+	}
+
+	if last == nil {
+		dns.HandleFailed(rw, m)
+		return
+	}
+	h.write(ctx, rw, m, last)
+}
+
+func (h *sequentialHandler) write(ctx context.Context, rw dns.ResponseWriter, m, resp *dns.Msg) {
+	resp.Id = m.Id
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("sequentialHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+		dns.HandleFailed(rw, m)
+	}
+}
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
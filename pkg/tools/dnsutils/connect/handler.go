@@ -25,23 +25,27 @@ import (
 
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/pool"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 // NewDNSHandler creates a new dnshandler that simply connects to the endpoint by passed url
 // connectTO is endpoint url
-func NewDNSHandler(connectTO *url.URL) dnsutils.Handler {
-	return &connectDNSHandler{connectTO: connectTO}
+func NewDNSHandler(connectTO *url.URL, opts ...Option) dnsutils.Handler {
+	c := &connectDNSHandler{connectTO: connectTO}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-type connectDNSHandler struct{ connectTO *url.URL }
+type connectDNSHandler struct {
+	connectTO *url.URL
+	pool      *pool.Pool
+}
 
 func (c *connectDNSHandler) ServeDNS(ctx context.Context, rp dns.ResponseWriter, msg *dns.Msg) {
-	var client = dns.Client{
-		Net: c.connectTO.Scheme,
-	}
-
-	var resp, _, err = client.Exchange(msg, c.connectTO.Host)
+	var resp, err = c.exchange(ctx, msg)
 
 	if err != nil {
 		log.FromContext(ctx).WithField("connectDNSHandler", "ServeDNS").Warnf("got an error during exchanging: %v", err.Error())
@@ -57,3 +61,15 @@ func (c *connectDNSHandler) ServeDNS(ctx context.Context, rp dns.ResponseWriter,
 
 	next.Handler(ctx).ServeDNS(ctx, rp, resp)
 }
+
+func (c *connectDNSHandler) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	if c.pool != nil && (c.connectTO.Scheme == "tcp" || c.connectTO.Scheme == "tcp-tls") {
+		return c.pool.Exchange(ctx, msg, c.connectTO.Scheme, c.connectTO.Host)
+	}
+
+	var client = dns.Client{
+		Net: c.connectTO.Scheme,
+	}
+	var resp, _, err = client.Exchange(msg, c.connectTO.Host)
+	return resp, err
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connect
+
+import "github.com/networkservicemesh/sdk/pkg/tools/dnsutils/pool"
+
+// Option is an option pattern for NewDNSHandler
+type Option func(c *connectDNSHandler)
+
+// WithPool sets the pool of the persistent connections used for the "tcp" and "tcp-tls" endpoints, so the queries are
+// not dialing the endpoint each time
+func WithPool(p *pool.Pool) Option {
+	return func(c *connectDNSHandler) {
+		c.pool = p
+	}
+}
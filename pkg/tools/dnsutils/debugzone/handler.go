@@ -0,0 +1,116 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debugzone provides a dns handler serving the synthetic zone with the component status encoded as TXT records
+package debugzone
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// DefaultZone is the default debug zone
+const DefaultZone = "debug.nsm."
+
+// maxTXTLen is the maximum length of the TXT record character string
+const maxTXTLen = 255
+
+// StatusFunc returns the component status as "key=value" strings
+type StatusFunc func(ctx context.Context) []string
+
+type debugZoneHandler struct {
+	zone     string
+	statuses map[string]StatusFunc
+}
+
+func (h *debugZoneHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if len(m.Question) == 0 || !dns.IsSubDomain(h.zone, dns.CanonicalName(m.Question[0].Name)) {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	q := m.Question[0]
+	name := dns.CanonicalName(q.Name)
+
+	resp := new(dns.Msg)
+	resp.SetReply(m)
+	resp.Authoritative = true
+
+	var txt []string
+	if name == h.zone {
+		txt = []string{"names=" + strings.Join(h.names(), ",")}
+	} else if status, ok := h.statuses[strings.TrimSuffix(name, "."+h.zone)]; ok {
+		txt = status(ctx)
+	} else {
+		resp.Rcode = dns.RcodeNameError
+	}
+
+	if resp.Rcode == dns.RcodeSuccess && (q.Qtype == dns.TypeTXT || q.Qtype == dns.TypeANY) {
+		resp.Answer = append(resp.Answer, &dns.TXT{
+			// Zero TTL prevents the status from being cached
+			Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET},
+			Txt: split(txt),
+		})
+	}
+
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("debugZoneHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+		dns.HandleFailed(rw, m)
+	}
+}
+
+func (h *debugZoneHandler) names() []string {
+	names := make([]string, 0, len(h.statuses))
+	for name := range h.statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// split splits the strings longer than the TXT character string limit
+func split(txt []string) []string {
+	var result []string
+	for _, s := range txt {
+		for len(s) > maxTXTLen {
+			result = append(result, s[:maxTXTLen])
+			s = s[maxTXTLen:]
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// NewDNSHandler creates a new dns handler serving the debug zone: TXT query for "<name>.<zone>" returns the status set
+// with WithStatus, TXT query for the zone itself returns the list of the available names. Queries outside the zone are
+// passed to the next handlers.
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	h := &debugZoneHandler{
+		zone:     DefaultZone,
+		statuses: make(map[string]StatusFunc),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugzone_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/cache"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/debugzone"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+func TestDebugZoneHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	configs := new(dnsconfig.Map)
+	configs.Store("conn-1", []*networkservice.DNSConfig{{
+		DnsServerIps:  []string{"10.0.0.1"},
+		SearchDomains: []string{"my.domain"},
+	}})
+
+	records := new(memory.Map)
+	records.Store("example.com.", []net.IP{net.ParseIP("1.1.1.1")})
+
+	stats := new(cache.Stats)
+	handler := next.NewDNSHandler(
+		debugzone.NewDNSHandler(
+			debugzone.WithStatus(debugzone.ConnectionsName, debugzone.ConnectionsStatus(configs)),
+			debugzone.WithStatus(debugzone.CacheName, debugzone.CacheStatus(stats)),
+			debugzone.WithStatus("long", func(_ context.Context) []string {
+				return []string{strings.Repeat("a", 300)}
+			}),
+		),
+		cache.NewDNSHandler(cache.WithStats(stats)),
+		memory.NewDNSHandler(records),
+	)
+
+	query := func(name string, qtype uint16) *dns.Msg {
+		m := new(dns.Msg).SetQuestion(name, qtype)
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, m)
+		require.NotNil(t, rw.Response)
+		return rw.Response
+	}
+	txt := func(resp *dns.Msg) []string {
+		require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+		require.Len(t, resp.Answer, 1)
+		require.Equal(t, uint32(0), resp.Answer[0].Header().Ttl)
+		return resp.Answer[0].(*dns.TXT).Txt
+	}
+
+	require.Len(t, query("example.com.", dns.TypeA).Answer, 1)
+	require.Len(t, query("example.com.", dns.TypeA).Answer, 1)
+
+	require.Equal(t, []string{"names=cache,connections,long"}, txt(query("debug.nsm.", dns.TypeTXT)))
+	require.Equal(t, []string{"count=1", "conn-1 servers=10.0.0.1 search=my.domain"}, txt(query("connections.debug.nsm.", dns.TypeTXT)))
+	require.Equal(t, []string{"hits=1", "misses=1", "size=1"}, txt(query("CACHE.debug.nsm.", dns.TypeTXT)))
+	require.Equal(t, []string{strings.Repeat("a", 255), strings.Repeat("a", 45)}, txt(query("long.debug.nsm.", dns.TypeTXT)))
+
+	resp := query("connections.debug.nsm.", dns.TypeA)
+	require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+	require.Empty(t, resp.Answer)
+
+	require.Equal(t, dns.RcodeNameError, query("unknown.debug.nsm.", dns.TypeTXT).Rcode)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugzone
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// Option is an option pattern for NewDNSHandler
+type Option func(h *debugZoneHandler)
+
+// WithZone sets the debug zone. Default: DefaultZone
+func WithZone(zone string) Option {
+	return func(h *debugZoneHandler) {
+		h.zone = dns.CanonicalName(zone)
+	}
+}
+
+// WithStatus adds the status served for "<name>.<zone>" TXT queries
+func WithStatus(name string, status StatusFunc) Option {
+	return func(h *debugZoneHandler) {
+		h.statuses[strings.ToLower(strings.TrimSuffix(name, "."))] = status
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugzone
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/cache"
+)
+
+const (
+	// ConnectionsName is the debug zone name of the ConnectionsStatus
+	ConnectionsName = "connections"
+	// CacheName is the debug zone name of the CacheStatus
+	CacheName = "cache"
+)
+
+// ConnectionsStatus returns the status of the active connections DNS configs: the connections count and the DNS
+// servers and search domains per connection
+func ConnectionsStatus(configs *dnsconfig.Map) StatusFunc {
+	return func(_ context.Context) []string {
+		var conns []string
+		configs.Range(func(connID string, value []*networkservice.DNSConfig) bool {
+			var servers, domains []string
+			for _, conf := range value {
+				servers = append(servers, conf.GetDnsServerIps()...)
+				domains = append(domains, conf.GetSearchDomains()...)
+			}
+			conns = append(conns, fmt.Sprintf("%s servers=%s search=%s", connID, strings.Join(servers, ","), strings.Join(domains, ",")))
+			return true
+		})
+		sort.Strings(conns)
+		return append([]string{fmt.Sprintf("count=%d", len(conns))}, conns...)
+	}
+}
+
+// CacheStatus returns the cache statistics
+func CacheStatus(stats *cache.Stats) StatusFunc {
+	return func(_ context.Context) []string {
+		return []string{
+			fmt.Sprintf("hits=%d", stats.Hits()),
+			fmt.Sprintf("misses=%d", stats.Misses()),
+			fmt.Sprintf("size=%d", stats.Size()),
+		}
+	}
+}
@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package dnsconfigs stores DNS configs
+// Package dnsconfigs stores DNS configs and routes the queries to the DNS servers of the matching configs
 package dnsconfigs
 
 import (
@@ -33,34 +33,100 @@ import (
 )
 
 type dnsConfigsHandler struct {
-	configs *dnsconfig.Map
+	configs          *dnsconfig.Map
+	defaultResolvers []url.URL
 }
 
 func (h *dnsConfigsHandler) ServeDNS(ctx context.Context, rp dns.ResponseWriter, m *dns.Msg) {
-	dnsIPs := make([]url.URL, 0)
+	var all, matched, defaults []*networkservice.DNSConfig
+	var matchedLabels int
 	searchDomains := make([]string, 0)
 
+	var name string
+	if len(m.Question) > 0 {
+		name = dns.CanonicalName(m.Question[0].Name)
+	}
+
 	h.configs.Range(func(key string, value []*networkservice.DNSConfig) bool {
 		for _, conf := range value {
-			for _, ip := range conf.DnsServerIps {
-				dnsIPs = append(dnsIPs,
-					url.URL{Scheme: "udp", Host: ip},
-					url.URL{Scheme: "tcp", Host: ip})
-			}
+			all = append(all, conf)
 			searchDomains = append(searchDomains, conf.SearchDomains...)
+
+			if len(conf.GetSearchDomains()) == 0 {
+				defaults = append(defaults, conf)
+				continue
+			}
+			if name == "" {
+				continue
+			}
+			switch labels := matchLabels(name, conf.GetSearchDomains()); {
+			case labels > matchedLabels:
+				matched, matchedLabels = []*networkservice.DNSConfig{conf}, labels
+			case labels > 0 && labels == matchedLabels:
+				matched = append(matched, conf)
+			}
 		}
 
 		return true
 	})
 
+	var dnsIPs []url.URL
+	switch {
+	case name == "":
+		dnsIPs = clientURLs(all)
+	case len(matched) > 0:
+		dnsIPs = clientURLs(matched)
+	case len(defaults) > 0 || len(h.defaultResolvers) > 0:
+		dnsIPs = append(clientURLs(defaults), h.defaultResolvers...)
+	default:
+		// There are no default resolvers, so the query is not sent to the DNS servers of the other domains. The next
+		// handlers still can answer it from the local records, otherwise the forwarding handler fails it with SERVFAIL.
+		dnsIPs = make([]url.URL, 0)
+	}
+
 	ctx = clienturlctx.WithClientURLs(ctx, dnsIPs)
 	ctx = searches.WithSearchDomains(ctx, searchDomains)
 	next.Handler(ctx).ServeDNS(ctx, rp, m)
 }
 
-// NewDNSHandler creates a new dns handler that stores DNS configs
-func NewDNSHandler(configs *dnsconfig.Map) dnsutils.Handler {
-	return &dnsConfigsHandler{
+// matchLabels returns the number of labels of the most specific search domain containing name plus one, so the root
+// domain differs from no match, or 0 if there is no such domain
+func matchLabels(name string, domains []string) int {
+	var result int
+	for _, domain := range domains {
+		domain = dns.CanonicalName(domain)
+		if labels := dns.CountLabel(domain) + 1; labels > result && dns.IsSubDomain(domain, name) {
+			result = labels
+		}
+	}
+	return result
+}
+
+func clientURLs(configs []*networkservice.DNSConfig) []url.URL {
+	dnsIPs := make([]url.URL, 0)
+	for _, conf := range configs {
+		for _, ip := range conf.GetDnsServerIps() {
+			dnsIPs = append(dnsIPs,
+				url.URL{Scheme: "udp", Host: ip},
+				url.URL{Scheme: "tcp", Host: ip})
+		}
+	}
+	return dnsIPs
+}
+
+// NewDNSHandler creates a new dns handler that stores DNS configs. The query is routed only to the DNS servers of the
+// configs with the most specific search domain containing the query name. If there are no such configs, it is routed
+// to the default resolvers: the configs without search domains and the ones set with WithDefaultResolvers. If there
+// are no default resolvers, it is routed to no DNS servers, so the forwarding handler (e.g. fanout) fails it with
+// SERVFAIL.
+// Search domains of all the configs are passed to the searches handler, to route the expanded names place one more
+// dnsconfigs handler with the same configs after the searches handler.
+func NewDNSHandler(configs *dnsconfig.Map, opts ...Option) dnsutils.Handler {
+	h := &dnsConfigsHandler{
 		configs: configs,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
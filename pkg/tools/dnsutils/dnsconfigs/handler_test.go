@@ -17,6 +17,7 @@
 package dnsconfigs_test
 
 import (
+	"net/url"
 	"testing"
 	"time"
 
@@ -28,10 +29,21 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dnsconfigs"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/fanout"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/searches"
 )
 
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
 type checkHandler struct {
 	Domains []string
 	URLs    []string
@@ -45,6 +57,8 @@ func (h *checkHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *d
 	for i := range urls {
 		h.URLs = append(h.URLs, urls[i].String())
 	}
+
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
 }
 
 func TestDNSConfigs(t *testing.T) {
@@ -94,3 +108,103 @@ func TestDNSConfigs(t *testing.T) {
 	require.Contains(t, urls, "udp://9.9.9.9")
 	require.Contains(t, urls, "tcp://9.9.9.9")
 }
+
+func TestDNSConfigs_Routing(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	configs := new(dnsconfig.Map)
+
+	configs.Store("1", []*networkservice.DNSConfig{
+		{
+			SearchDomains: []string{"example.com"},
+			DnsServerIps:  []string{"7.7.7.7"},
+		},
+		{
+			SearchDomains: []string{"com"},
+			DnsServerIps:  []string{"1.1.1.1"},
+		},
+	})
+
+	configs.Store("2", []*networkservice.DNSConfig{
+		{
+			SearchDomains: []string{"my.domain"},
+			DnsServerIps:  []string{"9.9.9.9"},
+		},
+	})
+
+	for _, sample := range []struct {
+		name     string
+		defaults []url.URL
+		urls     []string
+	}{
+		{name: "a.example.com.", urls: []string{"udp://7.7.7.7", "tcp://7.7.7.7"}},
+		{name: "a.other.com.", urls: []string{"udp://1.1.1.1", "tcp://1.1.1.1"}},
+		{name: "My.Domain.", urls: []string{"udp://9.9.9.9", "tcp://9.9.9.9"}},
+		{name: "a.net.", urls: []string{}},
+		{name: "a.net.", defaults: []url.URL{{Scheme: "udp", Host: "8.8.8.8"}}, urls: []string{"udp://8.8.8.8"}},
+		{name: "a.com.", defaults: []url.URL{{Scheme: "udp", Host: "8.8.8.8"}}, urls: []string{"udp://1.1.1.1", "tcp://1.1.1.1"}},
+	} {
+		check := &checkHandler{}
+		handler := next.NewDNSHandler(
+			dnsconfigs.NewDNSHandler(configs, dnsconfigs.WithDefaultResolvers(sample.defaults...)),
+			check,
+		)
+
+		m := new(dns.Msg)
+		m.SetQuestion(sample.name, dns.TypeA)
+		handler.ServeDNS(ctx, nil, m)
+
+		require.ElementsMatch(t, sample.urls, check.URLs, sample.name)
+		require.Len(t, check.Domains, 3)
+	}
+
+	// Configs without search domains are the default resolvers
+	configs.Store("3", []*networkservice.DNSConfig{
+		{
+			DnsServerIps: []string{"8.8.4.4"},
+		},
+	})
+
+	check := &checkHandler{}
+	handler := next.NewDNSHandler(
+		dnsconfigs.NewDNSHandler(configs),
+		check,
+	)
+
+	m := new(dns.Msg)
+	m.SetQuestion("a.net.", dns.TypeA)
+	handler.ServeDNS(ctx, nil, m)
+
+	require.ElementsMatch(t, []string{"udp://8.8.4.4", "tcp://8.8.4.4"}, check.URLs)
+}
+
+func TestDNSConfigs_NoDefaultResolvers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	configs := new(dnsconfig.Map)
+	configs.Store("1", []*networkservice.DNSConfig{
+		{
+			SearchDomains: []string{"com"},
+			DnsServerIps:  []string{"1.1.1.1"},
+		},
+	})
+
+	check := &checkHandler{}
+	handler := next.NewDNSHandler(
+		dnsconfigs.NewDNSHandler(configs),
+		check,
+		fanout.NewDNSHandler(),
+	)
+
+	// The query matching no search domain is not sent to the DNS servers of the other domains
+	m := new(dns.Msg)
+	m.SetQuestion("a.net.", dns.TypeA)
+	rw := &responseWriter{}
+	handler.ServeDNS(ctx, rw, m)
+
+	require.Empty(t, check.URLs)
+	require.NotNil(t, rw.Response)
+	require.Equal(t, dns.RcodeServerFailure, rw.Response.Rcode)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsconfigs
+
+import (
+	"net/url"
+)
+
+// Option is an option pattern for NewDNSHandler
+type Option func(h *dnsConfigsHandler)
+
+// WithDefaultResolvers sets the resolvers for the queries not matching any config search domain
+func WithDefaultResolvers(urls ...url.URL) Option {
+	return func(h *dnsConfigsHandler) {
+		h.defaultResolvers = append(h.defaultResolvers, urls...)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssec
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+// RootTrustAnchor returns the DS record of the root zone KSK-2017
+func RootTrustAnchor() *dns.DS {
+	return &dns.DS{
+		Hdr:        dns.RR_Header{Name: ".", Rrtype: dns.TypeDS, Class: dns.ClassINET},
+		KeyTag:     20326,
+		Algorithm:  dns.RSASHA256,
+		DigestType: dns.SHA256,
+		Digest:     "E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+	}
+}
+
+// ParseTrustAnchors parses the DS and DNSKEY trust anchors in zone file format
+func ParseTrustAnchors(s string) ([]dns.RR, error) {
+	var anchors []dns.RR
+	zp := dns.NewZoneParser(strings.NewReader(s), ".", "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		switch rr.(type) {
+		case *dns.DS, *dns.DNSKEY:
+			anchors = append(anchors, rr)
+		default:
+			return nil, errors.Errorf("trust anchor should be DS or DNSKEY: %s", rr.String())
+		}
+	}
+	if err := zp.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse trust anchors")
+	}
+	return anchors, nil
+}
+
+// matchAnchor returns true if key matches the DS or DNSKEY trust anchor
+func matchAnchor(key *dns.DNSKEY, anchor dns.RR) bool {
+	switch anchor := anchor.(type) {
+	case *dns.DS:
+		return matchDS(key, anchor)
+	case *dns.DNSKEY:
+		return key.Flags == anchor.Flags &&
+			key.Protocol == anchor.Protocol &&
+			key.Algorithm == anchor.Algorithm &&
+			key.PublicKey == anchor.PublicKey
+	default:
+		return false
+	}
+}
+
+func matchDS(key *dns.DNSKEY, ds *dns.DS) bool {
+	if key.KeyTag() != ds.KeyTag || key.Algorithm != ds.Algorithm {
+		return false
+	}
+	keyDS := key.ToDS(ds.DigestType)
+	return keyDS != nil && strings.EqualFold(keyDS.Digest, ds.Digest)
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnssec provides a dns handler validating DNSSEC signatures of the responses against the configured trust
+// anchors
+package dnssec
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	timeout = 5 * time.Second
+	udpSize = 4096
+)
+
+type keysEntry struct {
+	keys    []*dns.DNSKEY
+	expires time.Time
+}
+
+type dnssecHandler struct {
+	anchors map[string][]dns.RR
+
+	keysMu sync.Mutex
+	keys   map[string]*keysEntry
+}
+
+func (h *dnssecHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if len(m.Question) == 0 {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	logger := log.FromContext(ctx).WithField("dnssecHandler", "ServeDNS")
+
+	ctx, cancel := clock.FromContext(ctx).WithTimeout(ctx, timeout)
+	defer cancel()
+
+	clientOpt := m.IsEdns0()
+	newMsg := m.Copy()
+	setDO(newMsg)
+
+	r := &responseWriter{ResponseWriter: rw}
+	next.Handler(ctx).ServeDNS(ctx, r, newMsg)
+	resp := r.Response
+	if resp == nil {
+		dns.HandleFailed(rw, m)
+		return
+	}
+
+	v := &validator{
+		handler:    h,
+		rw:         rw,
+		now:        clock.FromContext(ctx).Now(),
+		inProgress: make(map[string]struct{}),
+	}
+	secure, err := v.validate(ctx, m.Question[0].Name, resp)
+	if err != nil {
+		logger.Warnf("bogus response for %s: %v", m.Question[0].Name, err.Error())
+		dns.HandleFailed(rw, m)
+		return
+	}
+
+	resp = resp.Copy()
+	resp.Id = m.Id
+	resp.AuthenticatedData = secure
+	if clientOpt == nil || !clientOpt.Do() {
+		stripDNSSEC(resp, m.Question[0].Qtype)
+	}
+	if clientOpt == nil {
+		stripOPT(resp)
+	}
+
+	if err := rw.WriteMsg(resp); err != nil {
+		logger.Warnf("got an error during write the message: %v", err.Error())
+		dns.HandleFailed(rw, m)
+	}
+}
+
+func (h *dnssecHandler) loadKeys(zone string, now time.Time) ([]*dns.DNSKEY, bool) {
+	h.keysMu.Lock()
+	defer h.keysMu.Unlock()
+
+	entry, ok := h.keys[zone]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expires) {
+		delete(h.keys, zone)
+		return nil, false
+	}
+	return entry.keys, true
+}
+
+func (h *dnssecHandler) storeKeys(zone string, keys []*dns.DNSKEY, expires time.Time) {
+	h.keysMu.Lock()
+	defer h.keysMu.Unlock()
+
+	h.keys[zone] = &keysEntry{
+		keys:    keys,
+		expires: expires,
+	}
+}
+
+// anchorZone returns the closest zone with the trust anchors containing name
+func (h *dnssecHandler) anchorZone(name string) (string, bool) {
+	name = dns.CanonicalName(name)
+	for {
+		if _, ok := h.anchors[name]; ok {
+			return name, true
+		}
+		if name == "." {
+			return "", false
+		}
+		name = parent(name)
+	}
+}
+
+func setDO(m *dns.Msg) {
+	if opt := m.IsEdns0(); opt != nil {
+		opt.SetDo()
+		return
+	}
+	m.SetEdns0(udpSize, true)
+}
+
+// stripDNSSEC removes the DNSSEC records not requested explicitly from the response as defined in RFC 3225
+func stripDNSSEC(m *dns.Msg, qtype uint16) {
+	filter := func(rrs []dns.RR) []dns.RR {
+		var result []dns.RR
+		for _, rr := range rrs {
+			switch rrtype := rr.Header().Rrtype; rrtype {
+			case dns.TypeRRSIG, dns.TypeNSEC, dns.TypeNSEC3:
+				if rrtype != qtype {
+					continue
+				}
+			}
+			result = append(result, rr)
+		}
+		return result
+	}
+	m.Answer = filter(m.Answer)
+	m.Ns = filter(m.Ns)
+	m.Extra = filter(m.Extra)
+}
+
+func stripOPT(m *dns.Msg) {
+	var extra []dns.RR
+	for _, rr := range m.Extra {
+		if rr.Header().Rrtype != dns.TypeOPT {
+			extra = append(extra, rr)
+		}
+	}
+	m.Extra = extra
+}
+
+// NewDNSHandler creates a new dns handler setting the DO bit in the requests and validating the RRSIG chains of the
+// responses against the trust anchors. Bogus responses are replaced with SERVFAIL, validated responses are marked with
+// the AD bit.
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	h := &dnssecHandler{
+		keys: make(map[string]*keysEntry),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.anchors == nil {
+		WithTrustAnchors(RootTrustAnchor())(h)
+	}
+	return h
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssec_test
+
+import (
+	"context"
+	"crypto"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dnssec"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+type zoneKey struct {
+	key  *dns.DNSKEY
+	priv crypto.Signer
+}
+
+func newZoneKey(t *testing.T, zone string) *zoneKey {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	require.NoError(t, err)
+	return &zoneKey{key: key, priv: priv.(crypto.Signer)}
+}
+
+func (k *zoneKey) sign(t *testing.T, rrs ...dns.RR) []dns.RR {
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rrs[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: rrs[0].Header().Ttl},
+		KeyTag:     k.key.KeyTag(),
+		SignerName: k.key.Hdr.Name,
+		Algorithm:  k.key.Algorithm,
+		Inception:  uint32(time.Now().Add(-time.Hour).Unix()),
+		Expiration: uint32(time.Now().Add(time.Hour).Unix()),
+	}
+	require.NoError(t, sig.Sign(k.priv, rrs))
+	return append(append([]dns.RR(nil), rrs...), sig)
+}
+
+// upstream is an authoritative-like handler answering from the prepared records
+type upstream struct {
+	mu      sync.Mutex
+	records map[dns.Question]*dns.Msg
+	noDO    bool
+}
+
+func (u *upstream) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if opt := m.IsEdns0(); opt == nil || !opt.Do() {
+		u.noDO = true
+	}
+
+	resp := new(dns.Msg)
+	resp.SetReply(m)
+	if stored, ok := u.records[m.Question[0]]; ok {
+		resp.Rcode = stored.Rcode
+		resp.Answer = stored.Answer
+		resp.Ns = stored.Ns
+	} else {
+		resp.Rcode = dns.RcodeNameError
+	}
+	resp.SetEdns0(4096, true)
+	_ = rw.WriteMsg(resp)
+}
+
+func (u *upstream) add(name string, qtype uint16, answer, ns []dns.RR) {
+	u.records[dns.Question{Name: name, Qtype: qtype, Qclass: dns.ClassINET}] = &dns.Msg{Answer: answer, Ns: ns}
+}
+
+func a(name, ip string) dns.RR {
+	return &dns.A{Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60}, A: net.ParseIP(ip)}
+}
+
+func newUpstream(t *testing.T) (*upstream, *zoneKey) {
+	u := &upstream{records: make(map[dns.Question]*dns.Msg)}
+
+	example := newZoneKey(t, "example.")
+	u.add("example.", dns.TypeDNSKEY, example.sign(t, example.key), nil)
+
+	// Signed sub.example. zone
+	sub := newZoneKey(t, "sub.example.")
+	u.add("sub.example.", dns.TypeDS, example.sign(t, sub.key.ToDS(dns.SHA256)), nil)
+	u.add("sub.example.", dns.TypeDNSKEY, sub.sign(t, sub.key), nil)
+	u.add("www.sub.example.", dns.TypeA, sub.sign(t, a("www.sub.example.", "10.0.0.1")), nil)
+	u.add("unsigned.sub.example.", dns.TypeA, []dns.RR{a("unsigned.sub.example.", "10.0.0.2")}, nil)
+	bogus := sub.sign(t, a("bogus.sub.example.", "10.0.0.3"))
+	bogus[0].(*dns.A).A = net.ParseIP("10.0.0.4")
+	u.add("bogus.sub.example.", dns.TypeA, bogus, nil)
+
+	// Insecure delegation of insecure.example.
+	nsec := &dns.NSEC{
+		Hdr:        dns.RR_Header{Name: "insecure.example.", Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 60},
+		NextDomain: "sub.example.",
+		TypeBitMap: []uint16{dns.TypeNS, dns.TypeRRSIG, dns.TypeNSEC},
+	}
+	u.add("insecure.example.", dns.TypeDS, nil, example.sign(t, nsec))
+	u.add("www.insecure.example.", dns.TypeA, []dns.RR{a("www.insecure.example.", "10.0.1.1")}, nil)
+
+	u.add("outside.org.", dns.TypeA, []dns.RR{a("outside.org.", "10.0.2.1")}, nil)
+
+	return u, example
+}
+
+func query(ctx context.Context, t *testing.T, u *upstream, anchor dns.RR, name string, do bool) *dns.Msg {
+	handler := next.NewDNSHandler(
+		dnssec.NewDNSHandler(dnssec.WithTrustAnchors(anchor)),
+		u,
+	)
+
+	m := new(dns.Msg).SetQuestion(name, dns.TypeA)
+	if do {
+		m.SetEdns0(4096, true)
+	}
+	rw := &responseWriter{}
+	handler.ServeDNS(ctx, rw, m)
+	require.NotNil(t, rw.Response)
+	require.Equal(t, m.Id, rw.Response.Id)
+	return rw.Response
+}
+
+func TestDNSSECHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	u, example := newUpstream(t)
+	dsAnchor := example.key.ToDS(dns.SHA256)
+
+	for _, anchor := range []dns.RR{example.key, dsAnchor} {
+		resp := query(ctx, t, u, anchor, "www.sub.example.", true)
+		require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+		require.True(t, resp.AuthenticatedData)
+		require.Len(t, resp.Answer, 2)
+	}
+
+	resp := query(ctx, t, u, dsAnchor, "www.sub.example.", false)
+	require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+	require.True(t, resp.AuthenticatedData)
+	require.Len(t, resp.Answer, 1)
+	require.Nil(t, resp.IsEdns0())
+
+	require.False(t, u.noDO)
+}
+
+func TestDNSSECHandler_Bogus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	u, example := newUpstream(t)
+
+	require.Equal(t, dns.RcodeServerFailure, query(ctx, t, u, example.key, "bogus.sub.example.", true).Rcode)
+	require.Equal(t, dns.RcodeServerFailure, query(ctx, t, u, example.key, "unsigned.sub.example.", true).Rcode)
+	require.Equal(t, dns.RcodeServerFailure, query(ctx, t, u, example.key, "missing.sub.example.", true).Rcode)
+
+	// Untrusted anchor
+	other := newZoneKey(t, "example.")
+	require.Equal(t, dns.RcodeServerFailure, query(ctx, t, u, other.key, "www.sub.example.", true).Rcode)
+}
+
+func TestDNSSECHandler_Insecure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	u, example := newUpstream(t)
+
+	resp := query(ctx, t, u, example.key, "www.insecure.example.", true)
+	require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+	require.False(t, resp.AuthenticatedData)
+	require.Len(t, resp.Answer, 1)
+
+	resp = query(ctx, t, u, example.key, "outside.org.", true)
+	require.Equal(t, dns.RcodeSuccess, resp.Rcode)
+	require.False(t, resp.AuthenticatedData)
+	require.Len(t, resp.Answer, 1)
+}
+
+func TestParseTrustAnchors(t *testing.T) {
+	anchors, err := dnssec.ParseTrustAnchors(dnssec.RootTrustAnchor().String())
+	require.NoError(t, err)
+	require.Len(t, anchors, 1)
+	require.Equal(t, dnssec.RootTrustAnchor().String(), anchors[0].String())
+
+	_, err = dnssec.ParseTrustAnchors("example. 60 IN A 10.0.0.1")
+	require.Error(t, err)
+}
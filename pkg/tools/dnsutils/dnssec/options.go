@@ -0,0 +1,35 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssec
+
+import (
+	"github.com/miekg/dns"
+)
+
+// Option is an option pattern for NewDNSHandler
+type Option func(h *dnssecHandler)
+
+// WithTrustAnchors sets the DS and DNSKEY trust anchors. Default: RootTrustAnchor
+func WithTrustAnchors(anchors ...dns.RR) Option {
+	return func(h *dnssecHandler) {
+		h.anchors = make(map[string][]dns.RR)
+		for _, anchor := range anchors {
+			zone := dns.CanonicalName(anchor.Header().Name)
+			h.anchors[zone] = append(h.anchors[zone], anchor)
+		}
+	}
+}
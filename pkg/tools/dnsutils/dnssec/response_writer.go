@@ -1,6 +1,4 @@
-// Copyright (c) 2020-2021 Cisco and/or its affiliates.
-//
-// Copyright (c) 2021 Nordix Foundation.
+// Copyright (c) 2022 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -16,18 +14,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package vni
+package dnssec
 
 import (
-	"sync"
+	"github.com/miekg/dns"
 )
 
-//go:generate go-syncmap -output vni_map.gen.go -type Map<vniKey,*vniKey>
-
-type vniKey struct {
-	srcIPString string
-	vni         uint32
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
 }
 
-// Map - sync.Map with key == vniKey and value == struct{}
-type Map sync.Map
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
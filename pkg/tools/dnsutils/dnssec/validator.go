@@ -0,0 +1,328 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssec
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+	class  uint16
+}
+
+type rrset struct {
+	rrs  []dns.RR
+	sigs []*dns.RRSIG
+}
+
+// validator validates a single response, it issues the DNSKEY and DS queries required to build the chain of trust to
+// the next handlers.
+type validator struct {
+	handler    *dnssecHandler
+	rw         dns.ResponseWriter
+	now        time.Time
+	inProgress map[string]struct{}
+}
+
+// validate returns true if the response is secure and false if it is insecure. The error is returned for the bogus
+// responses.
+func (v *validator) validate(ctx context.Context, qname string, resp *dns.Msg) (bool, error) {
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return false, nil
+	}
+	if _, ok := v.handler.anchorZone(qname); !ok {
+		return false, nil
+	}
+
+	sets := groupRRsets(append(append([]dns.RR(nil), resp.Answer...), resp.Ns...))
+	if len(sets) == 0 {
+		if v.provenInsecure(ctx, qname) {
+			return false, nil
+		}
+		return false, errors.Errorf("no signed records in the response for %s", qname)
+	}
+
+	secure := true
+	for key, set := range sets {
+		if len(set.sigs) == 0 {
+			if !v.provenInsecure(ctx, key.name) {
+				return false, errors.Errorf("missing signatures for %s %s", key.name, dns.TypeToString[key.rrtype])
+			}
+			secure = false
+			continue
+		}
+		if err := v.verify(ctx, set); err != nil {
+			return false, err
+		}
+	}
+	return secure, nil
+}
+
+// verify checks that at least one of the RRset signatures is valid and made with the validated key of the signer zone
+func (v *validator) verify(ctx context.Context, set *rrset) error {
+	owner := dns.CanonicalName(set.rrs[0].Header().Name)
+	for _, sig := range set.sigs {
+		signer := dns.CanonicalName(sig.SignerName)
+		if !dns.IsSubDomain(signer, owner) || !sig.ValidityPeriod(v.now) {
+			continue
+		}
+		keys, err := v.zoneKeys(ctx, signer)
+		if err != nil {
+			continue
+		}
+		for _, key := range keys {
+			if key.KeyTag() == sig.KeyTag && key.Algorithm == sig.Algorithm && sig.Verify(key, set.rrs) == nil {
+				return nil
+			}
+		}
+	}
+	return errors.Errorf("no valid signatures for %s %s", owner, dns.TypeToString[set.rrs[0].Header().Rrtype])
+}
+
+// zoneKeys returns the DNSKEY RRset of the zone validated with the trust anchor or the DS RRset of the parent zone
+func (v *validator) zoneKeys(ctx context.Context, zone string) ([]*dns.DNSKEY, error) {
+	if keys, ok := v.handler.loadKeys(zone, v.now); ok {
+		return keys, nil
+	}
+	if _, ok := v.inProgress[zone]; ok {
+		return nil, errors.Errorf("loop in the chain of trust for %s", zone)
+	}
+	v.inProgress[zone] = struct{}{}
+	defer delete(v.inProgress, zone)
+
+	if _, ok := v.handler.anchorZone(zone); !ok {
+		return nil, errors.Errorf("no trust anchor for %s", zone)
+	}
+
+	set, keys, err := v.fetchKeys(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors, err := v.trustAnchors(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	if !v.signedByAnchor(set, keys, anchors) {
+		return nil, errors.Errorf("DNSKEY records for %s are not signed with the trusted key", zone)
+	}
+	v.handler.storeKeys(zone, keys, v.now.Add(time.Duration(minTTL(set.rrs))*time.Second))
+	return keys, nil
+}
+
+// fetchKeys queries the DNSKEY RRset of the zone
+func (v *validator) fetchKeys(ctx context.Context, zone string) (*rrset, []*dns.DNSKEY, error) {
+	resp, err := v.query(ctx, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, nil, err
+	}
+	set, ok := groupRRsets(resp.Answer)[rrsetKey{name: zone, rrtype: dns.TypeDNSKEY, class: dns.ClassINET}]
+	if !ok {
+		return nil, nil, errors.Errorf("no DNSKEY records for %s", zone)
+	}
+
+	keys := make([]*dns.DNSKEY, 0, len(set.rrs))
+	for _, rr := range set.rrs {
+		if key, ok := rr.(*dns.DNSKEY); ok {
+			keys = append(keys, key)
+		}
+	}
+	return set, keys, nil
+}
+
+// signedByAnchor returns true if the DNSKEY RRset has a valid signature made with a key matching one of the anchors
+func (v *validator) signedByAnchor(set *rrset, keys []*dns.DNSKEY, anchors []dns.RR) bool {
+	for _, sig := range set.sigs {
+		if !sig.ValidityPeriod(v.now) {
+			continue
+		}
+		for _, key := range keys {
+			if key.KeyTag() == sig.KeyTag && key.Algorithm == sig.Algorithm && matchAnyAnchor(key, anchors) && sig.Verify(key, set.rrs) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// trustAnchors returns the configured trust anchors of the zone or the validated DS records from the parent zone
+func (v *validator) trustAnchors(ctx context.Context, zone string) ([]dns.RR, error) {
+	if anchors, ok := v.handler.anchors[zone]; ok {
+		return anchors, nil
+	}
+
+	resp, err := v.query(ctx, zone, dns.TypeDS)
+	if err != nil {
+		return nil, err
+	}
+	set, ok := groupRRsets(resp.Answer)[rrsetKey{name: zone, rrtype: dns.TypeDS, class: dns.ClassINET}]
+	if !ok {
+		return nil, errors.Errorf("no DS records for %s", zone)
+	}
+	if err := v.verify(ctx, set); err != nil {
+		return nil, err
+	}
+	return set.rrs, nil
+}
+
+// provenInsecure returns true if there is a validated proof of the insecure delegation between the trust anchor and
+// the name: NSEC or NSEC3 record for the zone cut with no DS or NSEC3 opt-out record covering it
+func (v *validator) provenInsecure(ctx context.Context, name string) bool {
+	name = dns.CanonicalName(name)
+	anchor, ok := v.handler.anchorZone(name)
+	if !ok {
+		return true
+	}
+
+	labels := dns.SplitDomainName(name)
+	for i := len(labels) - dns.CountLabel(anchor) - 1; i >= 0; i-- {
+		zone := dns.Fqdn(strings.Join(labels[i:], "."))
+		resp, err := v.query(ctx, zone, dns.TypeDS)
+		if err != nil {
+			return false
+		}
+		if set, ok := groupRRsets(resp.Answer)[rrsetKey{name: zone, rrtype: dns.TypeDS, class: dns.ClassINET}]; ok {
+			if v.verify(ctx, set) != nil {
+				return false
+			}
+			continue
+		}
+		if insecureDelegation(zone, resp.Ns) && v.verifyAll(ctx, resp.Ns) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validator) verifyAll(ctx context.Context, rrs []dns.RR) error {
+	for _, set := range groupRRsets(rrs) {
+		if err := v.verify(ctx, set); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *validator) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	m := new(dns.Msg).SetQuestion(name, qtype)
+	m.SetEdns0(udpSize, true)
+
+	r := &responseWriter{ResponseWriter: v.rw}
+	next.Handler(ctx).ServeDNS(ctx, r, m)
+	if r.Response == nil {
+		return nil, errors.Errorf("no response for %s %s", name, dns.TypeToString[qtype])
+	}
+	return r.Response, nil
+}
+
+func insecureDelegation(zone string, rrs []dns.RR) bool {
+	for _, rr := range rrs {
+		switch rr := rr.(type) {
+		case *dns.NSEC:
+			if dns.CanonicalName(rr.Hdr.Name) == zone && isDelegation(rr.TypeBitMap) {
+				return true
+			}
+		case *dns.NSEC3:
+			if rr.Match(zone) && isDelegation(rr.TypeBitMap) {
+				return true
+			}
+			// Opt-out
+			if rr.Flags&1 == 1 && rr.Cover(zone) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDelegation returns true for the insecure zone cut: NS without DS and SOA
+func isDelegation(types []uint16) bool {
+	var ns bool
+	for _, t := range types {
+		switch t {
+		case dns.TypeNS:
+			ns = true
+		case dns.TypeDS, dns.TypeSOA:
+			return false
+		}
+	}
+	return ns
+}
+
+func matchAnyAnchor(key *dns.DNSKEY, anchors []dns.RR) bool {
+	for _, anchor := range anchors {
+		if matchAnchor(key, anchor) {
+			return true
+		}
+	}
+	return false
+}
+
+func groupRRsets(rrs []dns.RR) map[rrsetKey]*rrset {
+	sets := make(map[rrsetKey]*rrset)
+	var sigs []*dns.RRSIG
+	for _, rr := range rrs {
+		switch rr := rr.(type) {
+		case *dns.OPT:
+		case *dns.RRSIG:
+			sigs = append(sigs, rr)
+		default:
+			key := rrsetKey{name: dns.CanonicalName(rr.Header().Name), rrtype: rr.Header().Rrtype, class: rr.Header().Class}
+			set, ok := sets[key]
+			if !ok {
+				set = new(rrset)
+				sets[key] = set
+			}
+			set.rrs = append(set.rrs, rr)
+		}
+	}
+	for _, sig := range sigs {
+		key := rrsetKey{name: dns.CanonicalName(sig.Hdr.Name), rrtype: sig.TypeCovered, class: sig.Hdr.Class}
+		if set, ok := sets[key]; ok {
+			set.sigs = append(set.sigs, sig)
+		}
+	}
+	return sets
+}
+
+func minTTL(rrs []dns.RR) uint32 {
+	var ttl uint32
+	for i, rr := range rrs {
+		if i == 0 || rr.Header().Ttl < ttl {
+			ttl = rr.Header().Ttl
+		}
+	}
+	return ttl
+}
+
+func parent(name string) string {
+	i, end := dns.NextLabel(name, 0)
+	if end {
+		return "."
+	}
+	return name[i:]
+}
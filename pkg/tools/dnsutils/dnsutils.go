@@ -28,11 +28,21 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
+// ServerOption is an option pattern for ListenAndServe
+type ServerOption func(server *dns.Server)
+
+// WithTsigSecret sets TSIG secrets (key name -> base64 secret) used for verifying requests and signing responses
+func WithTsigSecret(secrets map[string]string) ServerOption {
+	return func(server *dns.Server) {
+		server.TsigSecret = secrets
+	}
+}
+
 // ListenAndServe starts dns server with specific handler. Listens both udp/tcp networks.
 // ctx is using for keeping the server alive. As soon as <-ctx.Done() happens it stops dns server.
 // handler is using for hanlding dns queries.
 // listenOn is using for listen. Expects {ip}:{port} to listen. Examples: "127.0.0.1:53", ":53".
-func ListenAndServe(ctx context.Context, handler Handler, listenOn string) {
+func ListenAndServe(ctx context.Context, handler Handler, listenOn string, opts ...ServerOption) {
 	var networks = []string{"tcp", "udp"}
 
 	for _, network := range networks {
@@ -42,6 +52,9 @@ func ListenAndServe(ctx context.Context, handler Handler, listenOn string) {
 
 			handler.ServeDNS(timeoutCtx, w, m)
 		})}
+		for _, opt := range opts {
+			opt(server)
+		}
 
 		go func() {
 			<-ctx.Done()
@@ -0,0 +1,169 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package doh provides DNS-over-HTTPS (RFC 8484) dns handler forwarding the queries to the "https" client URLs and
+// a server exposing the dns handler chain over HTTP/2
+package doh
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/fips"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// Scheme is the client URL scheme of the DNS-over-HTTPS upstreams
+	Scheme = "https"
+	// DefaultPath is the path of the DNS-over-HTTPS endpoint used if the client URL has no path
+	DefaultPath = "/dns-query"
+	// ContentType is the media type of the DNS-over-HTTPS messages
+	ContentType = "application/dns-message"
+)
+
+type dohHandler struct {
+	tlsConfig  *tls.Config
+	httpClient *http.Client
+}
+
+// NewDNSHandler creates a new dns handler sending the queries in parallel to the "https" client URLs over HTTPS. The
+// other client URLs are passed to the next handler, it is also called with them if none of the HTTPS upstreams
+// responded. It should be placed before fanout.
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	var h = new(dohHandler)
+	for _, o := range opts {
+		o(h)
+	}
+	if h.httpClient == nil {
+		h.httpClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
+				TLSClientConfig:   fips.TLSConfig(h.tlsConfig),
+				ForceAttemptHTTP2: true,
+			},
+		}
+	}
+	return h
+}
+
+func (h *dohHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg *dns.Msg) {
+	var httpsURLs, otherURLs []url.URL
+	for _, u := range clienturlctx.ClientURLs(ctx) {
+		if u.Scheme == Scheme {
+			httpsURLs = append(httpsURLs, u)
+		} else {
+			otherURLs = append(otherURLs, u)
+		}
+	}
+	if len(httpsURLs) == 0 {
+		next.Handler(ctx).ServeDNS(ctx, rw, msg)
+		return
+	}
+
+	if resp := h.exchangeAll(ctx, httpsURLs, msg); resp != nil {
+		if err := rw.WriteMsg(resp); err != nil {
+			log.FromContext(ctx).WithField("dohHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+			dns.HandleFailed(rw, msg)
+		}
+		return
+	}
+
+	if len(otherURLs) == 0 {
+		dns.HandleFailed(rw, msg)
+		return
+	}
+	next.Handler(ctx).ServeDNS(clienturlctx.WithClientURLs(ctx, otherURLs), rw, msg)
+}
+
+// exchangeAll returns the first successful response of the upstreams
+func (h *dohHandler) exchangeAll(ctx context.Context, urls []url.URL, msg *dns.Msg) *dns.Msg {
+	var responseCh = make(chan *dns.Msg, len(urls))
+	for i := range urls {
+		go func(u *url.URL, msg *dns.Msg) {
+			resp, err := h.exchange(ctx, u, msg)
+			if err != nil {
+				log.FromContext(ctx).WithField("dohHandler", "ServeDNS").Warnf("got an error during exchanging with %v: %v", u, err.Error())
+			}
+			responseCh <- resp
+		}(&urls[i], msg.Copy())
+	}
+
+	for range urls {
+		select {
+		case resp := <-responseCh:
+			if resp != nil && resp.Rcode == dns.RcodeSuccess {
+				return resp
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+func (h *dohHandler) exchange(ctx context.Context, u *url.URL, msg *dns.Msg) (*dns.Msg, error) {
+	target := *u
+	if target.Path == "" {
+		target.Path = DefaultPath
+	}
+
+	// RFC 8484 4.1: the ID should be 0 to make the responses cache friendly
+	id := msg.Id
+	msg.Id = 0
+	body, err := msg.Pack()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack the message")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request to %s", target.String())
+	}
+	req.Header.Set("Content-Type", ContentType)
+	req.Header.Set("Accept", ContentType)
+
+	httpResp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to send request to %s", target.String())
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s responded with status %s", target.String(), httpResp.Status)
+	}
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read response from %s", target.String())
+	}
+
+	resp := new(dns.Msg)
+	if unpackErr := resp.Unpack(respBody); unpackErr != nil {
+		return nil, errors.Wrapf(unpackErr, "failed to unpack response from %s", target.String())
+	}
+	resp.Id = id
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doh_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/doh"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+type checkHandler struct {
+	URLs []url.URL
+}
+
+func (h *checkHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	h.URLs = clienturlctx.ClientURLs(ctx)
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
+}
+
+func startServer(t *testing.T) *httptest.Server {
+	records := new(memory.Map)
+	records.Store("example.com.", []net.IP{net.ParseIP("1.1.1.1")})
+
+	server := httptest.NewUnstartedServer(doh.NewHTTPHandler(memory.NewDNSHandler(records)))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDoHHandler(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	server := startServer(t)
+	httpsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	check := &checkHandler{}
+	handler := chain.NewDNSHandler(
+		doh.NewDNSHandler(doh.WithHTTPClient(server.Client())),
+		check,
+	)
+
+	udpURL := url.URL{Scheme: "udp", Host: "127.0.0.1:53"}
+	rw := &responseWriter{}
+	msg := new(dns.Msg).SetQuestion("example.com.", dns.TypeA)
+	handler.ServeDNS(clienturlctx.WithClientURLs(ctx, []url.URL{udpURL, *httpsURL}), rw, msg)
+
+	require.NotNil(t, rw.Response)
+	require.Equal(t, msg.Id, rw.Response.Id)
+	require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode)
+	require.Len(t, rw.Response.Answer, 1)
+	require.Equal(t, "1.1.1.1", rw.Response.Answer[0].(*dns.A).A.String())
+	require.Nil(t, check.URLs)
+
+	// Unknown name: falls back to the other URLs
+	handler.ServeDNS(clienturlctx.WithClientURLs(ctx, []url.URL{udpURL, *httpsURL}), &responseWriter{}, new(dns.Msg).SetQuestion("unknown.com.", dns.TypeA))
+	require.Equal(t, []url.URL{udpURL}, check.URLs)
+}
+
+func TestDoHServer_Get(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	server := startServer(t)
+
+	body, err := new(dns.Msg).SetQuestion("example.com.", dns.TypeA).Pack()
+	require.NoError(t, err)
+
+	resp, err := server.Client().Get(server.URL + doh.DefaultPath + "?dns=" + base64.RawURLEncoding.EncodeToString(body))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, resp.ProtoMajor)
+	require.Equal(t, doh.ContentType, resp.Header.Get("Content-Type"))
+	require.NotEmpty(t, resp.Header.Get("Cache-Control"))
+
+	resp, err = server.Client().Get(server.URL + doh.DefaultPath + "?dns=invalid")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
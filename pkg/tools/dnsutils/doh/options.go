@@ -0,0 +1,49 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doh
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// Option modifies default doh dns handler values
+type Option func(*dohHandler)
+
+// WithTLSConfig sets the TLS config for the upstreams. By default the system roots are used to verify the upstreams.
+// It is ignored if WithHTTPClient is set.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(h *dohHandler) {
+		h.tlsConfig = tlsConfig
+	}
+}
+
+// WithSPIFFE sets the SPIFFE mTLS config for the upstreams authorized by the authorizer
+func WithSPIFFE(svidSource x509svid.Source, bundleSource x509bundle.Source, authorizer tlsconfig.Authorizer) Option {
+	return WithTLSConfig(tlsconfig.MTLSClientConfig(svidSource, bundleSource, authorizer))
+}
+
+// WithHTTPClient sets the HTTP client for the upstreams
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(h *dohHandler) {
+		h.httpClient = httpClient
+	}
+}
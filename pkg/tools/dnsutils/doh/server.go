@@ -0,0 +1,205 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doh
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type httpHandler struct {
+	handler dnsutils.Handler
+}
+
+// NewHTTPHandler returns http.Handler serving RFC 8484 GET and POST requests with the dns handler
+func NewHTTPHandler(handler dnsutils.Handler) http.Handler {
+	return &httpHandler{
+		handler: handler,
+	}
+}
+
+func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	msg, err := readMsg(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var timeoutCtx, cancel = context.WithTimeout(r.Context(), time.Second*5)
+	defer cancel()
+
+	rw := &responseWriter{
+		localAddr:  tcpAddr(r.Context().Value(http.LocalAddrContextKey)),
+		remoteAddr: tcpAddr(r.RemoteAddr),
+	}
+	h.handler.ServeDNS(timeoutCtx, rw, msg)
+	if rw.msg == nil {
+		http.Error(w, "no response", http.StatusBadGateway)
+		return
+	}
+
+	body, err := rw.msg.Pack()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	if ttl, ok := minTTL(rw.msg); ok {
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", ttl))
+	}
+	_, _ = w.Write(body)
+}
+
+func readMsg(r *http.Request) (*dns.Msg, error) {
+	var body []byte
+	switch r.Method {
+	case http.MethodGet:
+		var err error
+		if body, err = base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns")); err != nil {
+			return nil, errors.Wrap(err, "failed to decode dns parameter")
+		}
+	case http.MethodPost:
+		if contentType := r.Header.Get("Content-Type"); contentType != ContentType {
+			return nil, errors.Errorf("unsupported content type: %s", contentType)
+		}
+		var err error
+		if body, err = io.ReadAll(io.LimitReader(r.Body, dns.MaxMsgSize)); err != nil {
+			return nil, errors.Wrap(err, "failed to read body")
+		}
+	default:
+		return nil, errors.Errorf("unsupported method: %s", r.Method)
+	}
+
+	msg := new(dns.Msg)
+	if err := msg.Unpack(body); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack the message")
+	}
+	return msg, nil
+}
+
+func minTTL(msg *dns.Msg) (ttl uint32, ok bool) {
+	for _, rr := range msg.Answer {
+		if !ok || rr.Header().Ttl < ttl {
+			ttl, ok = rr.Header().Ttl, true
+		}
+	}
+	return ttl, ok
+}
+
+func tcpAddr(addr interface{}) net.Addr {
+	switch a := addr.(type) {
+	case net.Addr:
+		return a
+	case string:
+		if resolved, err := net.ResolveTCPAddr("tcp", a); err == nil {
+			return resolved
+		}
+	}
+	return &net.TCPAddr{}
+}
+
+// ListenAndServe starts DNS-over-HTTPS server with specific handler.
+// ctx is using for keeping the server alive. As soon as <-ctx.Done() happens it stops the server.
+// handler is using for hanlding dns queries.
+// listenOn is using for listen. Expects {ip}:{port} to listen. Examples: "127.0.0.1:443", ":443".
+// tlsConfig is using for serving HTTPS. If it is nil, the server serves HTTP/2 without TLS (h2c), e.g. behind a TLS
+// terminating proxy.
+func ListenAndServe(ctx context.Context, handler dnsutils.Handler, listenOn string, tlsConfig *tls.Config) {
+	var httpHandler = NewHTTPHandler(handler)
+	if tlsConfig == nil {
+		httpHandler = h2c.NewHandler(httpHandler, new(http2.Server))
+	}
+	var server = &http.Server{
+		Addr:              listenOn,
+		Handler:           httpHandler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: time.Second * 5,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	go func() {
+		for ; ctx.Err() == nil; time.Sleep(time.Millisecond / 100) {
+			var err error
+			if tlsConfig == nil {
+				err = server.ListenAndServe()
+			} else {
+				err = server.ListenAndServeTLS("", "")
+			}
+			if err != nil && ctx.Err() == nil {
+				log.FromContext(ctx).Errorf("an error during serve dns over https: %v", err.Error())
+			}
+		}
+	}()
+}
+
+type responseWriter struct {
+	localAddr  net.Addr
+	remoteAddr net.Addr
+	msg        *dns.Msg
+}
+
+func (r *responseWriter) LocalAddr() net.Addr {
+	return r.localAddr
+}
+
+func (r *responseWriter) RemoteAddr() net.Addr {
+	return r.remoteAddr
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.msg = m
+	return nil
+}
+
+func (r *responseWriter) Write(b []byte) (int, error) {
+	msg := new(dns.Msg)
+	if err := msg.Unpack(b); err != nil {
+		return 0, errors.Wrap(err, "failed to unpack the message")
+	}
+	r.msg = msg
+	return len(b), nil
+}
+
+func (r *responseWriter) Close() error {
+	return nil
+}
+
+func (r *responseWriter) TsigStatus() error {
+	return nil
+}
+
+func (r *responseWriter) TsigTimersOnly(bool) {}
+
+func (r *responseWriter) Hijack() {}
@@ -0,0 +1,136 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dot provides a dns handler forwarding the queries to the "tls" client URLs over DNS-over-TLS (RFC 7858)
+package dot
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/fips"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// Scheme is the client URL scheme of the DNS-over-TLS upstreams
+	Scheme = "tls"
+
+	defaultDNSPort = 853
+)
+
+type dotHandler struct {
+	dnsPort   uint16
+	tlsConfig *tls.Config
+}
+
+// NewDNSHandler creates a new dns handler sending the queries in parallel to the "tls" client URLs over TLS. The other
+// client URLs are passed to the next handler, it is also called with them if none of the TLS upstreams responded. It
+// should be placed before fanout.
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	var h = &dotHandler{
+		dnsPort: defaultDNSPort,
+	}
+	for _, o := range opts {
+		o(h)
+	}
+	return h
+}
+
+func (h *dotHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg *dns.Msg) {
+	var tlsURLs, otherURLs []url.URL
+	for _, u := range clienturlctx.ClientURLs(ctx) {
+		if u.Scheme == Scheme {
+			tlsURLs = append(tlsURLs, u)
+		} else {
+			otherURLs = append(otherURLs, u)
+		}
+	}
+	if len(tlsURLs) == 0 {
+		next.Handler(ctx).ServeDNS(ctx, rw, msg)
+		return
+	}
+
+	if resp := h.exchangeAll(ctx, tlsURLs, msg); resp != nil {
+		if err := rw.WriteMsg(resp); err != nil {
+			log.FromContext(ctx).WithField("dotHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+			dns.HandleFailed(rw, msg)
+		}
+		return
+	}
+
+	if len(otherURLs) == 0 {
+		dns.HandleFailed(rw, msg)
+		return
+	}
+	next.Handler(ctx).ServeDNS(clienturlctx.WithClientURLs(ctx, otherURLs), rw, msg)
+}
+
+// exchangeAll returns the first successful response of the upstreams
+func (h *dotHandler) exchangeAll(ctx context.Context, urls []url.URL, msg *dns.Msg) *dns.Msg {
+	var responseCh = make(chan *dns.Msg, len(urls))
+	for i := range urls {
+		go func(u *url.URL, msg *dns.Msg) {
+			resp, err := h.exchange(ctx, u, msg)
+			if err != nil {
+				log.FromContext(ctx).WithField("dotHandler", "ServeDNS").Warnf("got an error during exchanging with %v: %v", u, err.Error())
+			}
+			responseCh <- resp
+		}(&urls[i], msg.Copy())
+	}
+
+	for range urls {
+		select {
+		case resp := <-responseCh:
+			if resp != nil && resp.Rcode == dns.RcodeSuccess {
+				return resp
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+func (h *dotHandler) exchange(ctx context.Context, u *url.URL, msg *dns.Msg) (*dns.Msg, error) {
+	address := u.Host
+	if u.Port() == "" {
+		address += fmt.Sprintf(":%d", h.dnsPort)
+	}
+
+	tlsConfig := fips.TLSConfig(h.tlsConfig)
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	client := &dns.Client{
+		Net:       "tcp-tls",
+		TLSConfig: tlsConfig,
+	}
+	resp, _, err := client.ExchangeContext(ctx, msg, address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to exchange with %s", address)
+	}
+	return resp, nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dot_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dot"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+type checkHandler struct {
+	URLs []url.URL
+}
+
+func (h *checkHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	h.URLs = clienturlctx.ClientURLs(ctx)
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
+}
+
+func newCertificate(t *testing.T) (cert tls.Certificate, certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, certPEM, keyPEM
+}
+
+func startServer(t *testing.T, cert tls.Certificate) *url.URL {
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})
+	require.NoError(t, err)
+
+	server := &dns.Server{
+		Listener: l,
+		Net:      "tcp-tls",
+		Handler: dns.HandlerFunc(func(rw dns.ResponseWriter, msg *dns.Msg) {
+			resp := new(dns.Msg).SetReply(msg)
+			resp.Answer = append(resp.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: msg.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET},
+				A:   net.ParseIP("1.1.1.1"),
+			})
+			_ = rw.WriteMsg(resp)
+		}),
+	}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return &url.URL{Scheme: dot.Scheme, Host: l.Addr().String()}
+}
+
+func TestDoTHandler(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	cert, certPEM, keyPEM := newCertificate(t)
+	tlsURL := startServer(t, cert)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+
+	tlsConfig, err := dot.TLSConfigFromFiles(caFile, caFile, keyFile)
+	require.NoError(t, err)
+	require.Len(t, tlsConfig.Certificates, 1)
+
+	check := &checkHandler{}
+	handler := chain.NewDNSHandler(
+		dot.NewDNSHandler(dot.WithTLSConfig(tlsConfig)),
+		check,
+	)
+
+	udpURL := url.URL{Scheme: "udp", Host: "127.0.0.1:53"}
+	rw := &responseWriter{}
+	handler.ServeDNS(clienturlctx.WithClientURLs(ctx, []url.URL{udpURL, *tlsURL}), rw, new(dns.Msg).SetQuestion("example.com.", dns.TypeA))
+
+	require.NotNil(t, rw.Response)
+	require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode)
+	require.Len(t, rw.Response.Answer, 1)
+	require.Equal(t, "1.1.1.1", rw.Response.Answer[0].(*dns.A).A.String())
+	require.Nil(t, check.URLs)
+}
+
+func TestDoTHandler_Fallback(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	cert, _, _ := newCertificate(t)
+	tlsURL := startServer(t, cert)
+
+	check := &checkHandler{}
+	// The server certificate is not trusted
+	handler := chain.NewDNSHandler(
+		dot.NewDNSHandler(dot.WithTLSConfig(&tls.Config{RootCAs: x509.NewCertPool(), MinVersion: tls.VersionTLS12})),
+		check,
+	)
+
+	udpURL := url.URL{Scheme: "udp", Host: "127.0.0.1:53"}
+	handler.ServeDNS(clienturlctx.WithClientURLs(ctx, []url.URL{udpURL, *tlsURL}), &responseWriter{}, new(dns.Msg).SetQuestion("example.com.", dns.TypeA))
+	require.Equal(t, []url.URL{udpURL}, check.URLs)
+
+	check.URLs = nil
+	handler.ServeDNS(clienturlctx.WithClientURLs(ctx, []url.URL{udpURL}), &responseWriter{}, new(dns.Msg).SetQuestion("example.com.", dns.TypeA))
+	require.Equal(t, []url.URL{udpURL}, check.URLs)
+}
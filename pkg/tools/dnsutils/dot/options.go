@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dot
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+// Option modifies default dot dns handler values
+type Option func(*dotHandler)
+
+// WithDefaultDNSPort sets default DNS port for dot dns handler if it is not presented in the client's URL
+// By default 853
+func WithDefaultDNSPort(port uint16) Option {
+	return func(h *dotHandler) {
+		h.dnsPort = port
+	}
+}
+
+// WithTLSConfig sets the TLS config for the upstreams. If the ServerName is not set, the client URL host is used.
+// By default the system roots are used to verify the upstreams.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(h *dotHandler) {
+		h.tlsConfig = tlsConfig
+	}
+}
+
+// WithSPIFFE sets the SPIFFE mTLS config for the upstreams authorized by the authorizer
+func WithSPIFFE(svidSource x509svid.Source, bundleSource x509bundle.Source, authorizer tlsconfig.Authorizer) Option {
+	return WithTLSConfig(tlsconfig.MTLSClientConfig(svidSource, bundleSource, authorizer))
+}
+
+// TLSConfigFromFiles returns the TLS config for the upstreams verified by the PEM CA bundle from caFile. If certFile
+// and keyFile are set, the client certificate is loaded from them.
+func TLSConfigFromFiles(caFile, certFile, keyFile string) (*tls.Config, error) {
+	caPEM, err := ioutil.ReadFile(caFile) // #nosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read CA file %s", caFile)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, errors.Errorf("no certificates found in CA file %s", caFile)
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
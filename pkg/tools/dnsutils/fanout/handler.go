@@ -26,13 +26,16 @@ import (
 	"github.com/miekg/dns"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/pool"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 type fanoutHandler struct {
 	dnsPort uint16
+	pool    *pool.Pool
 }
 
 func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg *dns.Msg) {
@@ -40,7 +43,7 @@ func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 	var responseCh = make(chan *dns.Msg, len(connectTO))
 
 	deadline, _ := ctx.Deadline()
-	timeout := time.Until(deadline)
+	timeout := clock.FromContext(ctx).Until(deadline)
 
 	if len(connectTO) == 0 {
 		log.FromContext(ctx).WithField("fanoutHandler", "ServeDNS").Error("no urls to fanout")
@@ -50,17 +53,12 @@ func (h *fanoutHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 
 	for i := 0; i < len(connectTO); i++ {
 		go func(u *url.URL, msg *dns.Msg) {
-			var client = dns.Client{
-				Net:     u.Scheme,
-				Timeout: timeout,
-			}
-
 			address := u.Host
 			if u.Port() == "" {
 				address += fmt.Sprintf(":%d", h.dnsPort)
 			}
 
-			var resp, _, err = client.Exchange(msg, address)
+			var resp, err = h.exchange(ctx, u.Scheme, address, timeout, msg)
 			if err != nil {
 				log.FromContext(ctx).WithField("fanoutHandler", "ServeDNS").Warnf("got an error during exchanging with address %v: %v", address, err.Error())
 				responseCh <- nil
@@ -115,6 +113,19 @@ func (h *fanoutHandler) waitResponse(ctx context.Context, respCh <-chan *dns.Msg
 	}
 }
 
+func (h *fanoutHandler) exchange(ctx context.Context, network, address string, timeout time.Duration, msg *dns.Msg) (*dns.Msg, error) {
+	if h.pool != nil && (network == "tcp" || network == "tcp-tls") {
+		return h.pool.Exchange(ctx, msg, network, address)
+	}
+
+	var client = dns.Client{
+		Net:     network,
+		Timeout: timeout,
+	}
+	var resp, _, err = client.Exchange(msg, address)
+	return resp, err
+}
+
 // NewDNSHandler creates a new dns handler instance that sends incoming queries in parallel to few endpoints
 func NewDNSHandler(opts ...Option) dnsutils.Handler {
 	var h = &fanoutHandler{
@@ -16,6 +16,8 @@
 
 package fanout
 
+import "github.com/networkservicemesh/sdk/pkg/tools/dnsutils/pool"
+
 // Option modifies default fanout dns handler values
 type Option func(*fanoutHandler)
 
@@ -25,3 +27,11 @@ func WithDefaultDNSPort(port uint16) Option {
 		h.dnsPort = port
 	}
 }
+
+// WithPool sets the pool of the persistent connections used for the "tcp" and "tcp-tls" endpoints, so the queries are
+// not dialing the endpoints each time
+func WithPool(p *pool.Pool) Option {
+	return func(h *fanoutHandler) {
+		h.pool = p
+	}
+}
@@ -21,7 +21,17 @@ import (
 )
 
 //go:generate go-syncmap -output sync_map.gen.go -type Map<string,[]net.IP>
+//go:generate go-syncmap -output srv_sync_map.gen.go -type SRVMap<string,[]*github.com/miekg/dns.SRV>
+//go:generate go-syncmap -output txt_sync_map.gen.go -type TXTMap<string,[]string>
 
 // Map is like a Go map[string][]net.IP but is safe for concurrent use
 // by multiple goroutines without additional locking or coordination
 type Map sync.Map
+
+// SRVMap is like a Go map[string][]*dns.SRV but is safe for concurrent use
+// by multiple goroutines without additional locking or coordination
+type SRVMap sync.Map
+
+// TXTMap is like a Go map[string][]string but is safe for concurrent use
+// by multiple goroutines without additional locking or coordination
+type TXTMap sync.Map
@@ -20,6 +20,7 @@ package memory
 import (
 	"context"
 	"net"
+	"strings"
 
 	"github.com/miekg/dns"
 
@@ -43,7 +44,11 @@ func (r *responseWriter) WriteMsg(m *dns.Msg) error {
 }
 
 type memoryHandler struct {
-	records *Map
+	records    *Map
+	srvRecords *SRVMap
+	txtRecords *TXTMap
+	rewrites   []rewrite
+	views      []view
 }
 
 func (f *memoryHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg *dns.Msg) {
@@ -52,14 +57,13 @@ func (f *memoryHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 		return
 	}
 
-	rwWrapper := &responseWriter{
-		ResponseWriter: rw,
-	}
-
 	var name = dns.Name(msg.Question[0].Name).String()
-	var records, ok = f.records.Load(name)
+	var answers = f.answers(rw, name, msg.Question[0].Qtype)
 
-	if !ok {
+	if len(answers) == 0 {
+		rwWrapper := &responseWriter{
+			ResponseWriter: rw,
+		}
 		next.Handler(ctx).ServeDNS(ctx, rwWrapper, msg)
 		if !rwWrapper.passed {
 			dns.HandleFailed(rw, msg)
@@ -70,33 +74,119 @@ func (f *memoryHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, msg
 	var resp = new(dns.Msg)
 	resp.SetReply(msg)
 	resp.Authoritative = true
+	resp.Answer = answers
 
-	switch msg.Question[0].Qtype {
-	case dns.TypeAAAA:
-		resp.Answer = append(resp.Answer, aaaa(name, records)...)
-	case dns.TypeA:
-		resp.Answer = append(resp.Answer, a(name, records)...)
+	if err := rw.WriteMsg(resp); err != nil {
+		dns.HandleFailed(rw, msg)
 	}
+}
 
-	if len(resp.Answer) == 0 {
-		next.Handler(ctx).ServeDNS(ctx, rwWrapper, msg)
-		if !rwWrapper.passed {
-			dns.HandleFailed(rw, msg)
+// answers returns the answers for the name after rewrites. A/AAAA records are looked up in the view matching the
+// client and then in the default records, SRV/TXT records are looked up in the default records only.
+func (f *memoryHandler) answers(rw dns.ResponseWriter, name string, qtype uint16) []dns.RR {
+	var key = name
+	for _, r := range f.rewrites {
+		key = r.pattern.ReplaceAllString(key, r.replacement)
+	}
+
+	switch qtype {
+	case dns.TypeAAAA, dns.TypeA:
+		var records []net.IP
+		var found bool
+		if v := f.view(rw); v != nil {
+			found = lookup(key, func(k string) (ok bool) {
+				records, ok = v.records.Load(k)
+				return ok
+			})
 		}
-		return
+		if !found {
+			lookup(key, func(k string) (ok bool) {
+				records, ok = f.records.Load(k)
+				return ok
+			})
+		}
+		if qtype == dns.TypeAAAA {
+			return aaaa(name, records)
+		}
+		return a(name, records)
+	case dns.TypeSRV:
+		var records []*dns.SRV
+		if f.srvRecords != nil {
+			lookup(key, func(k string) (ok bool) {
+				records, ok = f.srvRecords.Load(k)
+				return ok
+			})
+		}
+		return srv(name, records)
+	case dns.TypeTXT:
+		var records []string
+		if f.txtRecords != nil {
+			lookup(key, func(k string) (ok bool) {
+				records, ok = f.txtRecords.Load(k)
+				return ok
+			})
+		}
+		return txt(name, records)
 	}
+	return nil
+}
 
-	if err := rw.WriteMsg(resp); err != nil {
-		dns.HandleFailed(rw, msg)
+// view returns the first view matching the client address or nil
+func (f *memoryHandler) view(rw dns.ResponseWriter) *view {
+	if len(f.views) == 0 {
+		return nil
 	}
+
+	var ip net.IP
+	switch addr := rw.RemoteAddr().(type) {
+	case *net.UDPAddr:
+		ip = addr.IP
+	case *net.TCPAddr:
+		ip = addr.IP
+	default:
+		return nil
+	}
+
+	for i := range f.views {
+		if f.views[i].match(ip) {
+			return &f.views[i]
+		}
+	}
+	return nil
+}
+
+// lookup calls load for the name and, if there are no exact records, for the closest wildcard names: for
+// "a.b.svc.nsm." it tries "*.b.svc.nsm.", "*.svc.nsm." and "*.nsm.". It returns true once load returns true.
+func lookup(name string, load func(key string) bool) bool {
+	if load(name) {
+		return true
+	}
+	for labels := dns.SplitDomainName(name); len(labels) > 1; labels = labels[1:] {
+		if load(dns.Fqdn("*." + strings.Join(labels[1:], "."))) {
+			return true
+		}
+	}
+	return false
 }
 
-// NewDNSHandler creates a new dns handler instance that stores a/aaaa answers
-func NewDNSHandler(records *Map) dnsutils.Handler {
+// NewDNSHandler creates a new dns handler instance that stores a/aaaa answers, srv/txt answers are stored if set by
+// WithSRVRecords, WithTXTRecords. Records keys can be wildcard names like "*.svc.nsm.". The views set by WithView
+// answer differently for the different client sources.
+func NewDNSHandler(records *Map, opts ...Option) dnsutils.Handler {
 	if records == nil {
 		panic("records cannot be nil")
 	}
-	return &memoryHandler{records: records}
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &memoryHandler{
+		records:    records,
+		srvRecords: o.srvRecords,
+		txtRecords: o.txtRecords,
+		rewrites:   o.rewrites,
+		views:      o.views,
+	}
 }
 func a(domain string, ips []net.IP) []dns.RR {
 	answers := make([]dns.RR, len(ips))
@@ -125,3 +215,27 @@ func aaaa(domain string, ips []net.IP) []dns.RR {
 	}
 	return answers
 }
+
+func srv(domain string, records []*dns.SRV) []dns.RR {
+	answers := make([]dns.RR, 0, len(records))
+	for _, record := range records {
+		r := new(dns.SRV)
+		r.Hdr = dns.RR_Header{Name: domain, Rrtype: dns.TypeSRV, Class: dns.ClassINET, Ttl: defaultTTL}
+		r.Priority = record.Priority
+		r.Weight = record.Weight
+		r.Port = record.Port
+		r.Target = dns.Fqdn(record.Target)
+		answers = append(answers, r)
+	}
+	return answers
+}
+
+func txt(domain string, records []string) []dns.RR {
+	if len(records) == 0 {
+		return nil
+	}
+	r := new(dns.TXT)
+	r.Hdr = dns.RR_Header{Name: domain, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: defaultTTL}
+	r.Txt = records
+	return []dns.RR{r}
+}
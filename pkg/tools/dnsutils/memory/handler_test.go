@@ -0,0 +1,159 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+func query(name string) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), dns.TypeA)
+	return m
+}
+
+func TestMemoryHandler_Wildcard(t *testing.T) {
+	records := new(memory.Map)
+	records.Store("*.svc.nsm.", []net.IP{net.ParseIP("1.1.1.1")})
+	records.Store("exact.svc.nsm.", []net.IP{net.ParseIP("2.2.2.2")})
+
+	handler := memory.NewDNSHandler(records)
+
+	rw := new(responseWriter)
+	handler.ServeDNS(context.Background(), rw, query("client-1.svc.nsm"))
+	require.Len(t, rw.Response.Answer, 1)
+	require.Equal(t, "client-1.svc.nsm.", rw.Response.Answer[0].Header().Name)
+	require.Equal(t, "1.1.1.1", rw.Response.Answer[0].(*dns.A).A.String())
+
+	rw = new(responseWriter)
+	handler.ServeDNS(context.Background(), rw, query("a.client-1.svc.nsm"))
+	require.Equal(t, "1.1.1.1", rw.Response.Answer[0].(*dns.A).A.String())
+
+	rw = new(responseWriter)
+	handler.ServeDNS(context.Background(), rw, query("exact.svc.nsm"))
+	require.Equal(t, "2.2.2.2", rw.Response.Answer[0].(*dns.A).A.String())
+
+	rw = new(responseWriter)
+	handler.ServeDNS(context.Background(), rw, query("svc.other"))
+	require.NotEqual(t, dns.RcodeSuccess, rw.Response.Rcode)
+}
+
+func TestMemoryHandler_Rewrite(t *testing.T) {
+	records := new(memory.Map)
+	records.Store("nsc.vl3.", []net.IP{net.ParseIP("10.0.0.1")})
+
+	handler := memory.NewDNSHandler(records, memory.WithRewrite(regexp.MustCompile(`^nsc-[0-9]+\.`), "nsc."))
+
+	rw := new(responseWriter)
+	handler.ServeDNS(context.Background(), rw, query("nsc-42.vl3"))
+	require.Len(t, rw.Response.Answer, 1)
+	require.Equal(t, "nsc-42.vl3.", rw.Response.Answer[0].Header().Name)
+	require.Equal(t, "10.0.0.1", rw.Response.Answer[0].(*dns.A).A.String())
+}
+
+type remoteAddrWriter struct {
+	responseWriter
+	addr net.Addr
+}
+
+func (r *remoteAddrWriter) RemoteAddr() net.Addr {
+	return r.addr
+}
+
+func TestMemoryHandler_Views(t *testing.T) {
+	records := new(memory.Map)
+	records.Store("*.svc.nsm.", []net.IP{net.ParseIP("1.1.1.1")})
+	records.Store("other.nsm.", []net.IP{net.ParseIP("3.3.3.3")})
+
+	internal := new(memory.Map)
+	internal.Store("*.svc.nsm.", []net.IP{net.ParseIP("10.0.0.1")})
+
+	_, internalNet, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	handler := memory.NewDNSHandler(records, memory.WithView(internal, internalNet))
+
+	serve := func(ip, name string) *dns.Msg {
+		rw := &remoteAddrWriter{addr: &net.UDPAddr{IP: net.ParseIP(ip), Port: 5353}}
+		handler.ServeDNS(context.Background(), rw, query(name))
+		return rw.Response
+	}
+
+	resp := serve("10.1.2.3", "nsc.svc.nsm")
+	require.Len(t, resp.Answer, 1)
+	require.Equal(t, "10.0.0.1", resp.Answer[0].(*dns.A).A.String())
+
+	resp = serve("192.168.0.1", "nsc.svc.nsm")
+	require.Len(t, resp.Answer, 1)
+	require.Equal(t, "1.1.1.1", resp.Answer[0].(*dns.A).A.String())
+
+	// The names missing in the view are answered from the default records
+	resp = serve("10.1.2.3", "other.nsm")
+	require.Len(t, resp.Answer, 1)
+	require.Equal(t, "3.3.3.3", resp.Answer[0].(*dns.A).A.String())
+}
+
+func TestMemoryHandler_SRVAndTXT(t *testing.T) {
+	records := new(memory.Map)
+	records.Store("nsc.vl3.", []net.IP{net.ParseIP("10.0.0.1")})
+
+	srvRecords := new(memory.SRVMap)
+	srvRecords.Store("_http._tcp.nsc.vl3.", []*dns.SRV{{Port: 8080, Target: "nsc.vl3."}})
+
+	txtRecords := new(memory.TXTMap)
+	txtRecords.Store("*.vl3.", []string{"version=1.2"})
+
+	handler := memory.NewDNSHandler(records, memory.WithSRVRecords(srvRecords), memory.WithTXTRecords(txtRecords))
+
+	rw := new(responseWriter)
+	m := new(dns.Msg)
+	m.SetQuestion("_http._tcp.nsc.vl3.", dns.TypeSRV)
+	handler.ServeDNS(context.Background(), rw, m)
+	require.Len(t, rw.Response.Answer, 1)
+	require.Equal(t, uint16(8080), rw.Response.Answer[0].(*dns.SRV).Port)
+	require.Equal(t, "nsc.vl3.", rw.Response.Answer[0].(*dns.SRV).Target)
+
+	rw = new(responseWriter)
+	m.SetQuestion("nsc.vl3.", dns.TypeTXT)
+	handler.ServeDNS(context.Background(), rw, m)
+	require.Len(t, rw.Response.Answer, 1)
+	require.Equal(t, "nsc.vl3.", rw.Response.Answer[0].Header().Name)
+	require.Equal(t, []string{"version=1.2"}, rw.Response.Answer[0].(*dns.TXT).Txt)
+
+	rw = new(responseWriter)
+	m.SetQuestion("nsc.vl3.", dns.TypeSRV)
+	handler.ServeDNS(context.Background(), rw, m)
+	require.NotEqual(t, dns.RcodeSuccess, rw.Response.Rcode)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"net"
+	"regexp"
+)
+
+type rewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+type view struct {
+	records *Map
+	sources []*net.IPNet
+}
+
+func (v *view) match(ip net.IP) bool {
+	for _, source := range v.sources {
+		if source.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+type options struct {
+	srvRecords *SRVMap
+	txtRecords *TXTMap
+	rewrites   []rewrite
+	views      []view
+}
+
+// Option is an option pattern for NewDNSHandler
+type Option func(o *options)
+
+// WithRewrite adds a rewrite of the query names matching the pattern applied before the lookup. Rewrites are applied in
+// the order of adding, replacement supports regexp.Regexp.ReplaceAllString syntax. Answers keep the original query name.
+func WithRewrite(pattern *regexp.Regexp, replacement string) Option {
+	return func(o *options) {
+		o.rewrites = append(o.rewrites, rewrite{pattern: pattern, replacement: replacement})
+	}
+}
+
+// WithView adds the split-horizon view answering the queries from the sources with the records. The views are matched
+// by the client address in the order of adding, the names not found in the matched view are looked up in the default
+// records. Records keys can be wildcard names as well.
+func WithView(records *Map, sources ...*net.IPNet) Option {
+	return func(o *options) {
+		o.views = append(o.views, view{records: records, sources: sources})
+	}
+}
+
+// WithSRVRecords sets the srv records answering SRV queries. Targets are the names answered by the a/aaaa records.
+func WithSRVRecords(records *SRVMap) Option {
+	return func(o *options) {
+		o.srvRecords = records
+	}
+}
+
+// WithTXTRecords sets the txt records answering TXT queries. All the strings of the name are answered with a single
+// TXT record.
+func WithTXTRecords(records *TXTMap) Option {
+	return func(o *options) {
+		o.txtRecords = records
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+const recordKey contextKeyType = "MetricsRecord"
+
+type contextKeyType string
+
+type record struct {
+	cacheHit int32
+}
+
+func (r *record) isCacheHit() bool {
+	return atomic.LoadInt32(&r.cacheHit) != 0
+}
+
+func withRecord(parent context.Context, r *record) context.Context {
+	return context.WithValue(parent, recordKey, r)
+}
+
+// MarkCacheHit marks the query served with ctx as served from the cache
+func MarkCacheHit(ctx context.Context) {
+	if r, ok := ctx.Value(recordKey).(*record); ok {
+		atomic.StoreInt32(&r.cacheHit, 1)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides a dns handler exporting per-query metrics via OpenTelemetry: query count, latency
+// histogram, response codes and cache hits, keyed by search domain and upstream
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/searches"
+)
+
+const (
+	// QueriesMetricName is the name of the query counter
+	QueriesMetricName = "dns_queries"
+	// DurationMetricName is the name of the query latency histogram
+	DurationMetricName = "dns_query_duration_seconds"
+
+	searchDomainAttribute = "search_domain"
+	upstreamAttribute     = "upstream"
+	rcodeAttribute        = "rcode"
+	cacheHitAttribute     = "cache_hit"
+)
+
+type metricsHandler struct {
+	queries  syncint64.Counter
+	duration syncfloat64.Histogram
+}
+
+// NewDNSHandler creates a new dns handler exporting per-query metrics. It should be placed after searches (so the
+// expanded queries are keyed by the search domain) and before cache (so the cache hits are counted).
+func NewDNSHandler() dnsutils.Handler {
+	h := new(metricsHandler)
+	meter := global.Meter("")
+	if counter, err := meter.SyncInt64().Counter(QueriesMetricName); err == nil {
+		h.queries = counter
+	}
+	if histogram, err := meter.SyncFloat64().Histogram(DurationMetricName); err == nil {
+		h.duration = histogram
+	}
+	return h
+}
+
+func (h *metricsHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if len(m.Question) == 0 {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	start := clock.FromContext(ctx).Now()
+	r := new(record)
+	w := &responseWriter{ResponseWriter: rw}
+	next.Handler(ctx).ServeDNS(withRecord(ctx, r), w, m)
+
+	attributes := []attribute.KeyValue{
+		attribute.String(searchDomainAttribute, searchDomain(m.Question[0].Name, searches.SearchDomains(ctx))),
+		attribute.String(upstreamAttribute, upstream(ctx)),
+		attribute.String(rcodeAttribute, w.rcode()),
+		attribute.Bool(cacheHitAttribute, r.isCacheHit()),
+	}
+	if h.queries != nil {
+		h.queries.Add(ctx, 1, attributes...)
+	}
+	if h.duration != nil {
+		h.duration.Record(ctx, clock.FromContext(ctx).Since(start).Seconds(), attributes...)
+	}
+}
+
+// searchDomain returns the search domain the name belongs to
+func searchDomain(name string, domains []string) string {
+	for _, d := range domains {
+		d = dns.Fqdn(d)
+		if d != "." && dns.IsSubDomain(d, dns.Fqdn(name)) {
+			return d
+		}
+	}
+	return ""
+}
+
+// upstream returns the sorted client URLs joined with ","
+func upstream(ctx context.Context) string {
+	var urls []string
+	for _, u := range clienturlctx.ClientURLs(ctx) {
+		urls = append(urls, u.String())
+	}
+	sort.Strings(urls)
+	return strings.Join(urls, ",")
+}
+
+type responseWriter struct {
+	dns.ResponseWriter
+	response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.response = m
+	return r.ResponseWriter.WriteMsg(m)
+}
+
+func (r *responseWriter) rcode() string {
+	if r.response == nil {
+		return "NONE"
+	}
+	return dns.RcodeToString[r.response.Rcode]
+}
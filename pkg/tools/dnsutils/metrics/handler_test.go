@@ -0,0 +1,113 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/sdk/metric/metrictest"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clienturlctx"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/cache"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/metrics"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/searches"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+func TestMetricsHandler(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	provider, exporter := metrictest.NewTestMeterProvider()
+	global.SetMeterProvider(provider)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	records := new(memory.Map)
+	records.Store("nsc.my.domain.", []net.IP{net.ParseIP("1.1.1.1")})
+
+	handler := chain.NewDNSHandler(
+		searches.NewDNSHandler(),
+		metrics.NewDNSHandler(),
+		cache.NewDNSHandler(),
+		memory.NewDNSHandler(records),
+	)
+
+	ctx = clienturlctx.WithClientURLs(ctx, []url.URL{{Scheme: "udp", Host: "10.0.0.1:53"}})
+	ctx = searches.WithSearchDomains(ctx, []string{"my.domain"})
+
+	for i := 0; i < 2; i++ {
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion("nsc.", dns.TypeA))
+		require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode)
+	}
+	require.NoError(t, exporter.Collect(ctx))
+
+	attributes := func(cacheHit bool) []attribute.KeyValue {
+		return []attribute.KeyValue{
+			attribute.String("search_domain", "my.domain."),
+			attribute.String("upstream", "udp://10.0.0.1:53"),
+			attribute.String("rcode", "NOERROR"),
+			attribute.Bool("cache_hit", cacheHit),
+		}
+	}
+
+	miss, err := exporter.GetByNameAndAttributes(metrics.QueriesMetricName, attributes(false))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), miss.Sum.AsInt64())
+
+	hit, err := exporter.GetByNameAndAttributes(metrics.QueriesMetricName, attributes(true))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), hit.Sum.AsInt64())
+
+	duration, err := exporter.GetByNameAndAttributes(metrics.DurationMetricName, attributes(false))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), duration.Count)
+
+	// Both "unknown.my.domain." and "unknown." fail
+	handler.ServeDNS(ctx, &responseWriter{}, new(dns.Msg).SetQuestion("unknown.", dns.TypeA))
+	require.NoError(t, exporter.Collect(ctx))
+
+	for _, domain := range []string{"my.domain.", ""} {
+		failed, getErr := exporter.GetByNameAndAttributes(metrics.QueriesMetricName, []attribute.KeyValue{
+			attribute.String("search_domain", domain),
+			attribute.String("rcode", "SERVFAIL"),
+		})
+		require.NoError(t, getErr)
+		require.Equal(t, int64(1), failed.Sum.AsInt64())
+	}
+}
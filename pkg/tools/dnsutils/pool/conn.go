@@ -0,0 +1,151 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type upstreamConn struct {
+	conn        *dns.Conn
+	idleTimeout time.Duration
+	onClose     func(c *upstreamConn)
+
+	writeMu sync.Mutex
+
+	mu        sync.Mutex
+	pending   map[uint16]chan *dns.Msg
+	nextID    uint16
+	idleTimer clock.Timer
+	err       error
+	done      chan struct{}
+}
+
+func newUpstreamConn(clk clock.Clock, conn *dns.Conn, idleTimeout time.Duration, onClose func(c *upstreamConn)) *upstreamConn {
+	c := &upstreamConn{
+		conn:        conn,
+		idleTimeout: idleTimeout,
+		onClose:     onClose,
+		pending:     make(map[uint16]chan *dns.Msg),
+		nextID:      dns.Id(),
+		done:        make(chan struct{}),
+	}
+	c.idleTimer = clk.AfterFunc(idleTimeout, func() {
+		c.mu.Lock()
+		idle := len(c.pending) == 0
+		c.mu.Unlock()
+		if idle {
+			c.close(errors.New("connection is idle"))
+		}
+	})
+	go c.readLoop()
+	return c
+}
+
+func (c *upstreamConn) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	// The queries of the different clients can have the same ID, so the ID is replaced with a unique per connection one
+	query := msg.Copy()
+	respCh := make(chan *dns.Msg, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return nil, err
+	}
+	for {
+		c.nextID++
+		if _, ok := c.pending[c.nextID]; !ok {
+			break
+		}
+	}
+	query.Id = c.nextID
+	c.pending[query.Id] = respCh
+	c.idleTimer.Stop()
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, query.Id)
+		if len(c.pending) == 0 && c.err == nil {
+			c.idleTimer.Reset(c.idleTimeout)
+		}
+		c.mu.Unlock()
+	}()
+
+	c.writeMu.Lock()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetWriteDeadline(deadline)
+	}
+	err := c.conn.WriteMsg(query)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.close(err)
+		return nil, errors.Wrap(err, "failed to write query")
+	}
+
+	select {
+	case resp := <-respCh:
+		resp.Id = msg.Id
+		return resp, nil
+	case <-c.done:
+		return nil, errors.Wrap(c.err, "connection is closed")
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "no response received")
+	}
+}
+
+func (c *upstreamConn) readLoop() {
+	for {
+		resp, err := c.conn.ReadMsg()
+		if err != nil {
+			c.close(err)
+			return
+		}
+
+		c.mu.Lock()
+		respCh, ok := c.pending[resp.Id]
+		delete(c.pending, resp.Id)
+		c.mu.Unlock()
+
+		if ok {
+			respCh <- resp
+		}
+	}
+}
+
+func (c *upstreamConn) close(err error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.err = err
+	c.idleTimer.Stop()
+	close(c.done)
+	c.mu.Unlock()
+
+	_ = c.conn.Close()
+	c.onClose(c)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+// Option is an option pattern for NewPool
+type Option func(p *Pool)
+
+// WithIdleTimeout sets the duration after which the idle connections are closed
+// By default 10 seconds
+func WithIdleTimeout(idleTimeout time.Duration) Option {
+	return func(p *Pool) {
+		p.idleTimeout = idleTimeout
+	}
+}
+
+// WithTLSConfig sets the TLS config for the "tcp-tls" upstreams
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(p *Pool) {
+		p.tlsConfig = tlsConfig
+	}
+}
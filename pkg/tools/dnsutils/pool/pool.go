@@ -0,0 +1,115 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pool provides persistent TCP and TLS connections to the upstream DNS servers. Queries to the same upstream
+// are pipelined over a single connection and matched with the responses by the message ID, so the handshake is not
+// repeated per query.
+package pool
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const defaultIdleTimeout = 10 * time.Second
+
+type upstreamKey struct {
+	network string
+	address string
+}
+
+// Pool keeps a persistent connection per upstream, connections are closed after being idle for the idle timeout
+type Pool struct {
+	idleTimeout time.Duration
+	tlsConfig   *tls.Config
+
+	conns  map[upstreamKey]*upstreamConn
+	closed bool
+	mu     sync.Mutex
+}
+
+// NewPool creates a new Pool
+func NewPool(opts ...Option) *Pool {
+	p := &Pool{
+		idleTimeout: defaultIdleTimeout,
+		conns:       make(map[upstreamKey]*upstreamConn),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Exchange sends the query to the upstream over the persistent connection and returns the response. network is "tcp"
+// or "tcp-tls".
+func (p *Pool) Exchange(ctx context.Context, msg *dns.Msg, network, address string) (*dns.Msg, error) {
+	c, err := p.conn(ctx, upstreamKey{network: network, address: address})
+	if err != nil {
+		return nil, err
+	}
+	return c.exchange(ctx, msg)
+}
+
+// Close closes all the connections, the pool can't be used after it
+func (p *Pool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	conns := p.conns
+	p.conns = make(map[upstreamKey]*upstreamConn)
+	p.mu.Unlock()
+
+	for _, c := range conns {
+		c.close(errors.New("pool is closed"))
+	}
+}
+
+func (p *Pool) conn(ctx context.Context, key upstreamKey) (*upstreamConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, errors.New("pool is closed")
+	}
+	if c, ok := p.conns[key]; ok {
+		return c, nil
+	}
+
+	client := &dns.Client{
+		Net:       key.network,
+		TLSConfig: p.tlsConfig,
+	}
+	conn, err := client.DialContext(ctx, key.address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s://%s", key.network, key.address)
+	}
+
+	c := newUpstreamConn(clock.FromContext(ctx), conn, p.idleTimeout, func(c *upstreamConn) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.conns[key] == c {
+			delete(p.conns, key)
+		}
+	})
+	p.conns[key] = c
+	return c, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/pool"
+)
+
+type countingListener struct {
+	net.Listener
+	accepted int32
+	active   int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt32(&l.accepted, 1)
+	atomic.AddInt32(&l.active, 1)
+	return &countingConn{Conn: conn, l: l}, nil
+}
+
+type countingConn struct {
+	net.Conn
+	l    *countingListener
+	once sync.Once
+}
+
+func (c *countingConn) Close() error {
+	c.once.Do(func() { atomic.AddInt32(&c.l.active, -1) })
+	return c.Conn.Close()
+}
+
+func startServer(t *testing.T) (address string, l *countingListener) {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	l = &countingListener{Listener: tcpListener}
+
+	server := &dns.Server{
+		Listener: l,
+		Handler: dns.HandlerFunc(func(rw dns.ResponseWriter, msg *dns.Msg) {
+			resp := new(dns.Msg).SetReply(msg)
+			resp.Answer = append(resp.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: msg.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET},
+				Txt: []string{msg.Question[0].Name},
+			})
+			_ = rw.WriteMsg(resp)
+		}),
+	}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return tcpListener.Addr().String(), l
+}
+
+func TestPool_ReusesConnection(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	address, l := startServer(t)
+
+	p := pool.NewPool()
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		name := dns.Fqdn(string(rune('a'+i)) + ".example.com")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			msg := new(dns.Msg).SetQuestion(name, dns.TypeTXT)
+			// All the queries have the same ID
+			msg.Id = 1
+
+			resp, err := p.Exchange(ctx, msg, "tcp", address)
+			require.NoError(t, err)
+			require.Equal(t, uint16(1), resp.Id)
+			require.Len(t, resp.Answer, 1)
+			require.Equal(t, []string{name}, resp.Answer[0].(*dns.TXT).Txt)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&l.accepted))
+}
+
+func TestPool_IdleTimeout(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	address, l := startServer(t)
+
+	p := pool.NewPool(pool.WithIdleTimeout(time.Millisecond * 100))
+	defer p.Close()
+
+	_, err := p.Exchange(ctx, new(dns.Msg).SetQuestion("example.com.", dns.TypeTXT), "tcp", address)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&l.active))
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&l.active) == 0
+	}, time.Second, time.Millisecond*10)
+
+	_, err = p.Exchange(ctx, new(dns.Msg).SetQuestion("example.com.", dns.TypeTXT), "tcp", address)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&l.accepted))
+}
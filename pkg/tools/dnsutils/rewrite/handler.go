@@ -0,0 +1,50 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rewrite rewrites A/AAAA answers according to the address translation rules, so the addresses unreachable
+// over the NSM datapath (e.g. service VIPs returned by the upstream DNS) are replaced with the reachable ones.
+package rewrite
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+)
+
+type rewriteHandler struct {
+	getRules func() []*Rule
+}
+
+// NewDNSHandler creates a new dns handler rewriting the addresses in A/AAAA answers
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	h := &rewriteHandler{
+		getRules: func() []*Rule { return nil },
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *rewriteHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	next.Handler(ctx).ServeDNS(ctx, &responseWriter{
+		ResponseWriter: rw,
+		rules:          h.getRules(),
+	}, m)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewrite_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/rewrite"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	Response *dns.Msg
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+type answerHandler struct {
+	answer []dns.RR
+	last   *dns.Msg
+}
+
+func (h *answerHandler) ServeDNS(_ context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	resp := new(dns.Msg).SetReply(m)
+	resp.Answer = h.answer
+	h.last = resp
+	_ = rw.WriteMsg(resp)
+}
+
+func newRR(t *testing.T, s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+	return rr
+}
+
+func TestRewriteHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	vips, err := rewrite.ParseRule("10.96.0.0/16=172.16.0.0/16")
+	require.NoError(t, err)
+	vips6, err := rewrite.ParseRule("fd00:10:96::/64=fd00:172:16::/64")
+	require.NoError(t, err)
+
+	upstream := &answerHandler{
+		answer: []dns.RR{
+			newRR(t, "svc.cluster.local. 60 IN A 10.96.1.2"),
+			newRR(t, "svc.cluster.local. 60 IN A 192.168.0.1"),
+			newRR(t, "svc.cluster.local. 60 IN AAAA fd00:10:96::5"),
+			newRR(t, "svc.cluster.local. 60 IN TXT \"10.96.1.2\""),
+		},
+	}
+	handler := chain.NewDNSHandler(
+		rewrite.NewDNSHandler(rewrite.WithRules(vips, vips6)),
+		upstream,
+	)
+
+	m := new(dns.Msg).SetQuestion("svc.cluster.local.", dns.TypeANY)
+	rw := new(responseWriter)
+	handler.ServeDNS(ctx, rw, m)
+
+	require.NotNil(t, rw.Response)
+	require.Len(t, rw.Response.Answer, 4)
+	require.Equal(t, "172.16.1.2", rw.Response.Answer[0].(*dns.A).A.String())
+	require.Equal(t, "192.168.0.1", rw.Response.Answer[1].(*dns.A).A.String())
+	require.Equal(t, "fd00:172:16::5", rw.Response.Answer[2].(*dns.AAAA).AAAA.String())
+
+	// The upstream message is not modified
+	require.Equal(t, "10.96.1.2", upstream.last.Answer[0].(*dns.A).A.String())
+}
+
+func TestRewriteHandler_RulesFunc(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var rules []*rewrite.Rule
+	handler := chain.NewDNSHandler(
+		rewrite.NewDNSHandler(rewrite.WithRulesFunc(func() []*rewrite.Rule { return rules })),
+		&answerHandler{answer: []dns.RR{newRR(t, "svc. 60 IN A 10.96.1.2")}},
+	)
+
+	rw := new(responseWriter)
+	handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion("svc.", dns.TypeA))
+	require.Equal(t, "10.96.1.2", rw.Response.Answer[0].(*dns.A).A.String())
+
+	rules = []*rewrite.Rule{{
+		From: &net.IPNet{IP: net.ParseIP("10.96.1.2").To4(), Mask: net.CIDRMask(32, 32)},
+		To:   &net.IPNet{IP: net.ParseIP("172.16.0.10").To4(), Mask: net.CIDRMask(32, 32)},
+	}}
+	handler.ServeDNS(ctx, rw, new(dns.Msg).SetQuestion("svc.", dns.TypeA))
+	require.Equal(t, "172.16.0.10", rw.Response.Answer[0].(*dns.A).A.String())
+}
+
+func TestParseRule(t *testing.T) {
+	_, err := rewrite.ParseRule("10.96.0.0/16")
+	require.Error(t, err)
+	_, err = rewrite.ParseRule("10.96.0.0/16=172.16.0.0/24")
+	require.Error(t, err)
+	_, err = rewrite.ParseRule("10.96.0.0/16=fd00::/112")
+	require.Error(t, err)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewrite
+
+// Option is an option pattern for NewDNSHandler
+type Option func(h *rewriteHandler)
+
+// WithRules sets the static address translation rules, the first matching rule is applied
+func WithRules(rules ...*Rule) Option {
+	return func(h *rewriteHandler) {
+		h.getRules = func() []*Rule {
+			return rules
+		}
+	}
+}
+
+// WithRulesFunc sets the function returning the current address translation rules, it should be used if the rules
+// change at runtime, e.g. depend on the addresses of the NSM connection
+func WithRulesFunc(getRules func() []*Rule) Option {
+	return func(h *rewriteHandler) {
+		h.getRules = getRules
+	}
+}
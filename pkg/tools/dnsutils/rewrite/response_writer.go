@@ -0,0 +1,76 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewrite
+
+import (
+	"net"
+
+	"github.com/miekg/dns"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	rules []*Rule
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	if m == nil || len(r.rules) == 0 {
+		return r.ResponseWriter.WriteMsg(m)
+	}
+
+	// The message can be shared with the other handlers (e.g. cache), so it is copied before rewriting
+	var rewritten *dns.Msg
+	for i, rr := range m.Answer {
+		var ip net.IP
+		switch v := rr.(type) {
+		case *dns.A:
+			ip = v.A
+		case *dns.AAAA:
+			ip = v.AAAA
+		default:
+			continue
+		}
+
+		translated := r.translate(ip)
+		if translated == nil {
+			continue
+		}
+		if rewritten == nil {
+			rewritten = m.Copy()
+		}
+		switch v := rewritten.Answer[i].(type) {
+		case *dns.A:
+			v.A = translated
+		case *dns.AAAA:
+			v.AAAA = translated
+		}
+	}
+
+	if rewritten == nil {
+		return r.ResponseWriter.WriteMsg(m)
+	}
+	return r.ResponseWriter.WriteMsg(rewritten)
+}
+
+func (r *responseWriter) translate(ip net.IP) net.IP {
+	for _, rule := range r.rules {
+		if translated := rule.Translate(ip); translated != nil {
+			return translated
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rewrite
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Rule translates the addresses of the From network to the addresses of the To network with the same host part
+type Rule struct {
+	From *net.IPNet
+	To   *net.IPNet
+}
+
+// ParseRule parses the rule in the "from=to" form, e.g. "10.96.0.0/16=172.16.0.0/16". Both networks must be of the same
+// family and size.
+func ParseRule(s string) (*Rule, error) {
+	parts := strings.Split(s, "=")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("rule should be in the from=to form: %s", s)
+	}
+	_, from, err := net.ParseCIDR(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse rule %s", s)
+	}
+	_, to, err := net.ParseCIDR(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse rule %s", s)
+	}
+
+	fromOnes, fromBits := from.Mask.Size()
+	toOnes, toBits := to.Mask.Size()
+	if fromOnes != toOnes || fromBits != toBits {
+		return nil, errors.Errorf("networks of rule %s should be of the same family and size", s)
+	}
+
+	return &Rule{
+		From: from,
+		To:   to,
+	}, nil
+}
+
+// Translate returns the translated ip or nil if the rule doesn't match the ip
+func (r *Rule) Translate(ip net.IP) net.IP {
+	if !r.From.Contains(ip) {
+		return nil
+	}
+	if v4 := ip.To4(); v4 != nil && len(r.From.IP) == net.IPv4len {
+		ip = v4
+	}
+	if len(ip) != len(r.To.IP) {
+		return nil
+	}
+
+	rv := make(net.IP, len(ip))
+	for i := range ip {
+		rv[i] = r.To.IP[i] | (ip[i] &^ r.To.Mask[i])
+	}
+	return rv
+}
@@ -24,6 +24,7 @@ import (
 
 const (
 	searchDomainsKey contextKeyType = "SearchDomains"
+	expandedKey      contextKeyType = "SearchDomainsExpanded"
 )
 
 type contextKeyType string
@@ -44,3 +45,12 @@ func SearchDomains(ctx context.Context) []string {
 	}
 	return nil
 }
+
+func withExpanded(parent context.Context) context.Context {
+	return context.WithValue(parent, expandedKey, true)
+}
+
+func isExpanded(ctx context.Context) bool {
+	_, ok := ctx.Value(expandedKey).(bool)
+	return ok
+}
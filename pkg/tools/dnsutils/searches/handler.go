@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package searches makes requests to all subdomains received from DNS configs
+// Package searches resolves names with the search domains received from DNS configs
 package searches
 
 import (
@@ -23,54 +23,120 @@ import (
 
 	"github.com/miekg/dns"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
 const (
-	timeout = 5 * time.Second
+	timeout              = 5 * time.Second
+	defaultNdots         = 1
+	defaultMaxExpansions = 6
 )
 
 type searchDomainsHandler struct {
+	ndots         int
+	maxExpansions int
 }
 
 func (h *searchDomainsHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
-	domains := SearchDomains(ctx)
-
-	r := &responseWriter{
-		ResponseWriter: rw,
-		Responses:      make([]*dns.Msg, len(domains)+1),
-		index:          0,
+	if isExpanded(ctx) || len(m.Question) == 0 {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := clock.FromContext(ctx).WithTimeout(withExpanded(ctx), timeout)
 	defer cancel()
 
-	next.Handler(ctx).ServeDNS(ctx, r, m)
-
-	for _, d := range SearchDomains(ctx) {
+	var nodata, last *dns.Msg
+	for _, name := range h.candidates(m.Question[0].Name, SearchDomains(ctx)) {
 		newMsg := m.Copy()
-		newMsg.Question[0].Name = dns.Fqdn(newMsg.Question[0].Name + d)
+		newMsg.Question[0].Name = name
+
+		r := &responseWriter{ResponseWriter: rw}
 		next.Handler(ctx).ServeDNS(ctx, r, newMsg)
-	}
 
-	for _, resp := range r.Responses {
-		if resp != nil && resp.Rcode == dns.RcodeSuccess {
-			resp.Question = m.Question
-			if err := rw.WriteMsg(resp); err != nil {
-				log.FromContext(ctx).WithField("searchDomainsHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
-				dns.HandleFailed(rw, resp)
-				return
-			}
+		resp := r.Response
+		if resp == nil {
+			continue
+		}
+		last = resp
+		if resp.Rcode != dns.RcodeSuccess {
+			continue
+		}
+		if len(resp.Answer) > 0 {
+			h.write(ctx, rw, m, resp)
 			return
 		}
+		if nodata == nil {
+			nodata = resp
+		}
+	}
+
+	switch {
+	case nodata != nil:
+		h.write(ctx, rw, m, nodata)
+	case last != nil:
+		h.write(ctx, rw, m, last)
+	default:
+		dns.HandleFailed(rw, m)
+	}
+}
+
+// candidates returns names to query in the resolv.conf order: names with at least ndots dots are tried as is first,
+// other names are tried with the search domains first. Names already belonging to one of the search domains (e.g.
+// CNAME targets) are not expanded again.
+func (h *searchDomainsHandler) candidates(name string, domains []string) []string {
+	name = dns.Fqdn(name)
+
+	var expansions []string
+	visited := map[string]struct{}{name: {}}
+	for _, d := range domains {
+		d = dns.Fqdn(d)
+		if d == "." {
+			continue
+		}
+		if dns.IsSubDomain(d, name) {
+			return []string{name}
+		}
+		if len(expansions) == h.maxExpansions {
+			continue
+		}
+		expanded := name + d
+		if _, ok := visited[expanded]; ok {
+			continue
+		}
+		visited[expanded] = struct{}{}
+		expansions = append(expansions, expanded)
+	}
+
+	if dns.CountLabel(name)-1 >= h.ndots {
+		return append([]string{name}, expansions...)
 	}
+	return append(expansions, name)
+}
 
-	dns.HandleFailed(rw, m)
+func (h *searchDomainsHandler) write(ctx context.Context, rw dns.ResponseWriter, m, resp *dns.Msg) {
+	resp.Question = m.Question
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("searchDomainsHandler", "ServeDNS").Warnf("got an error during write the message: %v", err.Error())
+		dns.HandleFailed(rw, resp)
+	}
 }
 
-// NewDNSHandler creates a new dns handler that makes requests to all subdomains received from dns configs
-func NewDNSHandler() dnsutils.Handler {
-	return new(searchDomainsHandler)
+// NewDNSHandler creates a new dns handler that resolves names with the search domains received from dns configs
+// following the resolv.conf ndots semantics
+func NewDNSHandler(opts ...Option) dnsutils.Handler {
+	o := &options{
+		ndots:         defaultNdots,
+		maxExpansions: defaultMaxExpansions,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &searchDomainsHandler{
+		ndots:         o.ndots,
+		maxExpansions: o.maxExpansions,
+	}
 }
@@ -27,6 +27,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsconfig"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/dnsconfigs"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
 	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
@@ -45,13 +46,53 @@ func (r *responseWriter) WriteMsg(m *dns.Msg) error {
 
 type checkHandler struct {
 	Count int
+	Names []string
 }
 
 func (h *checkHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
 	h.Count++
+	h.Names = append(h.Names, m.Question[0].Name)
 	next.Handler(ctx).ServeDNS(ctx, rw, m)
 }
 
+// cnameHandler answers with a CNAME and resolves its target through the whole chain once more
+type cnameHandler struct {
+	chain  dnsutils.Handler
+	target string
+}
+
+func (h *cnameHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if m.Question[0].Name == h.target {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	targetMsg := m.Copy()
+	targetMsg.Question[0].Name = h.target
+
+	r := &responseWriter{}
+	h.chain.ServeDNS(ctx, r, targetMsg)
+	if r.Response == nil {
+		dns.HandleFailed(rw, m)
+		return
+	}
+
+	resp := r.Response.Copy()
+	resp.Answer = append([]dns.RR{&dns.CNAME{
+		Hdr:    dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET},
+		Target: h.target,
+	}}, resp.Answer...)
+	_ = rw.WriteMsg(resp)
+}
+
+func newConfigs(domains ...string) *dnsconfig.Map {
+	configs := new(dnsconfig.Map)
+	configs.Store("1", []*networkservice.DNSConfig{
+		{SearchDomains: domains, DnsServerIps: []string{"8.8.4.4"}},
+	})
+	return configs
+}
+
 func TestDomainSearches(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -80,8 +121,96 @@ func TestDomainSearches(t *testing.T) {
 	handler.ServeDNS(ctx, rw, m)
 
 	resp := rw.Response.Copy()
-	require.Equal(t, check.Count, 4)
+	require.Equal(t, check.Count, 1)
 	require.Equal(t, resp.MsgHdr.Rcode, dns.RcodeSuccess)
 	require.NotNil(t, resp.Answer)
 	require.Equal(t, resp.Answer[0].(*dns.A).A.String(), "1.1.1.1")
 }
+
+func TestDomainSearches_Ndots(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	records := new(memory.Map)
+	records.Store("my.svc.", []net.IP{net.ParseIP("1.1.1.1")})
+	records.Store("my.svc.cluster.", []net.IP{net.ParseIP("2.2.2.2")})
+
+	for _, sample := range []struct {
+		ndots int
+		names []string
+		ip    string
+	}{
+		{ndots: 1, names: []string{"my.svc."}, ip: "1.1.1.1"},
+		{ndots: 2, names: []string{"my.svc.cluster."}, ip: "2.2.2.2"},
+	} {
+		check := &checkHandler{}
+		handler := next.NewDNSHandler(
+			dnsconfigs.NewDNSHandler(newConfigs("cluster")),
+			searches.NewDNSHandler(searches.WithNdots(sample.ndots)),
+			check,
+			memory.NewDNSHandler(records),
+		)
+
+		m := &dns.Msg{}
+		m.SetQuestion("my.svc.", dns.TypeA)
+
+		rw := &responseWriter{}
+		handler.ServeDNS(ctx, rw, m)
+
+		require.Equal(t, sample.names, check.Names)
+		require.Equal(t, m.Question, rw.Response.Question)
+		require.Equal(t, sample.ip, rw.Response.Answer[0].(*dns.A).A.String())
+	}
+}
+
+func TestDomainSearches_MaxExpansions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	check := &checkHandler{}
+	handler := next.NewDNSHandler(
+		dnsconfigs.NewDNSHandler(newConfigs("a", "b", "a", "c", "d")),
+		searches.NewDNSHandler(searches.WithMaxExpansions(2)),
+		check,
+		memory.NewDNSHandler(new(memory.Map)),
+	)
+
+	m := &dns.Msg{}
+	m.SetQuestion("unknown.", dns.TypeA)
+
+	rw := &responseWriter{}
+	handler.ServeDNS(ctx, rw, m)
+
+	require.Equal(t, []string{"unknown.a.", "unknown.b.", "unknown."}, check.Names)
+	require.NotEqual(t, dns.RcodeSuccess, rw.Response.Rcode)
+}
+
+func TestDomainSearches_CNAMELoop(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	records := new(memory.Map)
+	records.Store("target.svc.", []net.IP{net.ParseIP("1.1.1.1")})
+
+	check := &checkHandler{}
+	cname := &cnameHandler{target: "target.svc."}
+	handler := next.NewDNSHandler(
+		dnsconfigs.NewDNSHandler(newConfigs("svc")),
+		searches.NewDNSHandler(),
+		check,
+		cname,
+		memory.NewDNSHandler(records),
+	)
+	cname.chain = handler
+
+	m := &dns.Msg{}
+	m.SetQuestion("alias.", dns.TypeA)
+
+	rw := &responseWriter{}
+	handler.ServeDNS(ctx, rw, m)
+
+	// CNAME target is resolved as is without the search domains being appended again
+	require.Equal(t, []string{"alias.svc.", "target.svc."}, check.Names)
+	require.Len(t, rw.Response.Answer, 2)
+	require.Equal(t, "1.1.1.1", rw.Response.Answer[1].(*dns.A).A.String())
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searches
+
+type options struct {
+	ndots         int
+	maxExpansions int
+}
+
+// Option is an option pattern for NewDNSHandler
+type Option func(o *options)
+
+// WithNdots sets the number of dots a name must have to be queried as is before the search domains are tried.
+// Default: 1
+func WithNdots(ndots int) Option {
+	return func(o *options) {
+		o.ndots = ndots
+	}
+}
+
+// WithMaxExpansions sets the maximum number of search domains tried per query. Default: 6
+func WithMaxExpansions(maxExpansions int) Option {
+	return func(o *options) {
+		o.maxExpansions = maxExpansions
+	}
+}
@@ -22,12 +22,10 @@ import (
 
 type responseWriter struct {
 	dns.ResponseWriter
-	Responses []*dns.Msg
-	index     int
+	Response *dns.Msg
 }
 
 func (r *responseWriter) WriteMsg(m *dns.Msg) error {
-	r.Responses[r.index] = m
-	r.index++
+	r.Response = m
 	return nil
 }
@@ -0,0 +1,160 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package switchable provides a dnsutils.Handler wrapping a chain of handlers which can be inspected, replaced and
+// reordered at runtime without restarting the DNS server
+package switchable
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/chain"
+)
+
+// Entry is a named handler of the switchable chain
+type Entry struct {
+	Name    string
+	Handler dnsutils.Handler
+}
+
+// Named returns a new Entry with the given name
+func Named(name string, handler dnsutils.Handler) Entry {
+	return Entry{Name: name, Handler: handler}
+}
+
+type snapshot struct {
+	entries []Entry
+	handler dnsutils.Handler
+}
+
+// Handler is a dnsutils.Handler serving queries with the current chain of handlers
+type Handler struct {
+	current atomic.Value
+	mu      sync.Mutex
+}
+
+// NewDNSHandler creates a new switchable Handler with the given entries. Entries without name are named by the handler
+// type name.
+func NewDNSHandler(entries ...Entry) *Handler {
+	h := new(Handler)
+	h.store(entries)
+	return h
+}
+
+// ServeDNS serves the query with the current chain. Changes don't affect the queries already in progress.
+func (h *Handler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	h.load().handler.ServeDNS(ctx, rw, m)
+}
+
+// Names returns the names of the current chain handlers in order
+func (h *Handler) Names() []string {
+	entries := h.load().entries
+	rv := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		rv = append(rv, entry.Name)
+	}
+	return rv
+}
+
+// Set replaces the whole chain
+func (h *Handler) Set(entries ...Entry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.store(entries)
+}
+
+// Replace replaces the handler with the given name
+func (h *Handler) Replace(name string, handler dnsutils.Handler) error {
+	return h.update(name, func(entries []Entry, i int) []Entry {
+		entries[i].Handler = handler
+		return entries
+	})
+}
+
+// Remove removes the handler with the given name
+func (h *Handler) Remove(name string) error {
+	return h.update(name, func(entries []Entry, i int) []Entry {
+		return append(entries[:i], entries[i+1:]...)
+	})
+}
+
+// Move moves the handler with the given name to the index position
+func (h *Handler) Move(name string, index int) error {
+	if index < 0 || index >= len(h.load().entries) {
+		return errors.Errorf("index %d is out of range", index)
+	}
+	return h.update(name, func(entries []Entry, i int) []Entry {
+		entry := entries[i]
+		entries = append(entries[:i], entries[i+1:]...)
+		return append(entries[:index], append([]Entry{entry}, entries[index:]...)...)
+	})
+}
+
+// Insert inserts the entry to the index position
+func (h *Handler) Insert(index int, entry Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := h.load().entries
+	if index < 0 || index > len(entries) {
+		return errors.Errorf("index %d is out of range", index)
+	}
+	rv := append(append(append([]Entry(nil), entries[:index]...), entry), entries[index:]...)
+	h.store(rv)
+	return nil
+}
+
+func (h *Handler) update(name string, modify func(entries []Entry, i int) []Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := append([]Entry(nil), h.load().entries...)
+	for i := range entries {
+		if entries[i].Name == name {
+			h.store(modify(entries, i))
+			return nil
+		}
+	}
+	return errors.Errorf("handler %s is not found", name)
+}
+
+func (h *Handler) load() *snapshot {
+	return h.current.Load().(*snapshot)
+}
+
+func (h *Handler) store(entries []Entry) {
+	s := &snapshot{
+		entries: make([]Entry, 0, len(entries)),
+	}
+	handlers := make([]dnsutils.Handler, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name == "" {
+			entry.Name = reflect.Indirect(reflect.ValueOf(entry.Handler)).Type().Name()
+		}
+		s.entries = append(s.entries, entry)
+		handlers = append(handlers, entry.Handler)
+	}
+	s.handler = chain.NewDNSHandler(handlers...)
+	h.current.Store(s)
+}
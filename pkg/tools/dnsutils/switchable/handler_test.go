@@ -0,0 +1,74 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package switchable_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/switchable"
+)
+
+type orderHandler struct {
+	name  string
+	order *[]string
+}
+
+func (h *orderHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	*h.order = append(*h.order, h.name)
+	next.Handler(ctx).ServeDNS(ctx, rw, m)
+}
+
+func TestSwitchableHandler(t *testing.T) {
+	var order []string
+	handler := switchable.NewDNSHandler(
+		switchable.Named("a", &orderHandler{name: "a", order: &order}),
+		switchable.Named("b", &orderHandler{name: "b", order: &order}),
+		switchable.Named("c", &orderHandler{name: "c", order: &order}),
+	)
+
+	serve := func() []string {
+		order = nil
+		handler.ServeDNS(context.Background(), nil, new(dns.Msg))
+		return order
+	}
+
+	require.Equal(t, []string{"a", "b", "c"}, handler.Names())
+	require.Equal(t, []string{"a", "b", "c"}, serve())
+
+	require.NoError(t, handler.Move("c", 0))
+	require.Equal(t, []string{"c", "a", "b"}, serve())
+
+	require.NoError(t, handler.Replace("a", &orderHandler{name: "a2", order: &order}))
+	require.Equal(t, []string{"c", "a2", "b"}, serve())
+
+	require.NoError(t, handler.Remove("c"))
+	require.NoError(t, handler.Insert(2, switchable.Named("d", &orderHandler{name: "d", order: &order})))
+	require.Equal(t, []string{"a", "b", "d"}, handler.Names())
+	require.Equal(t, []string{"a2", "b", "d"}, serve())
+
+	require.Error(t, handler.Remove("unknown"))
+	require.Error(t, handler.Move("a", 3))
+
+	handler.Set(switchable.Entry{Handler: &orderHandler{name: "e", order: &order}})
+	require.Equal(t, []string{"orderHandler"}, handler.Names())
+	require.Equal(t, []string{"e"}, serve())
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zonetransfer provides a dns handler answering AXFR/IXFR requests for the zones stored in memory.Map, so
+// external DNS servers can act as secondaries for the NSM-managed zones
+package zonetransfer
+
+import (
+	"context"
+	"hash/fnv"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	defaultTTL = 3600
+	soaRefresh = 60
+	soaRetry   = 30
+	soaExpire  = 3600
+)
+
+type zoneTransferHandler struct {
+	records         *memory.Map
+	zones           map[string]struct{}
+	allowedNetworks []*net.IPNet
+	requireTSIG     bool
+}
+
+// NewDNSHandler creates a new dns handler answering zone transfer requests for the zones with the records. IXFR
+// requests are answered with the full zone transfer. Other requests are passed to the next handler.
+func NewDNSHandler(records *memory.Map, zones []string, opts ...Option) dnsutils.Handler {
+	if records == nil {
+		panic("records cannot be nil")
+	}
+
+	o := &options{
+		allowedNetworks: []*net.IPNet{
+			{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+			{IP: net.IPv6loopback, Mask: net.CIDRMask(128, 128)},
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &zoneTransferHandler{
+		records:         records,
+		zones:           make(map[string]struct{}),
+		allowedNetworks: o.allowedNetworks,
+		requireTSIG:     o.requireTSIG,
+	}
+	for _, zone := range zones {
+		h.zones[dns.CanonicalName(zone)] = struct{}{}
+	}
+	return h
+}
+
+func (h *zoneTransferHandler) ServeDNS(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg) {
+	if m == nil || len(m.Question) == 0 || (m.Question[0].Qtype != dns.TypeAXFR && m.Question[0].Qtype != dns.TypeIXFR) {
+		next.Handler(ctx).ServeDNS(ctx, rw, m)
+		return
+	}
+
+	zone := dns.CanonicalName(m.Question[0].Name)
+	if _, ok := h.zones[zone]; !ok {
+		h.refuse(ctx, rw, m, "not authoritative for "+zone)
+		return
+	}
+	if !h.allowed(rw) {
+		h.refuse(ctx, rw, m, "transfer is not allowed for "+rw.RemoteAddr().String())
+		return
+	}
+	if h.requireTSIG && (m.IsTsig() == nil || rw.TsigStatus() != nil) {
+		h.refuse(ctx, rw, m, "transfer request is not signed")
+		return
+	}
+
+	resp := new(dns.Msg)
+	resp.SetReply(m)
+	resp.Authoritative = true
+
+	soa := h.soa(zone)
+	resp.Answer = append(append([]dns.RR{soa}, h.zoneRecords(zone)...), soa)
+
+	if tsig := m.IsTsig(); tsig != nil {
+		resp.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, int64(tsig.TimeSigned))
+	}
+	if err := rw.WriteMsg(resp); err != nil {
+		log.FromContext(ctx).WithField("zoneTransferHandler", "ServeDNS").Errorf("failed to write zone transfer: %v", err.Error())
+	}
+}
+
+func (h *zoneTransferHandler) refuse(ctx context.Context, rw dns.ResponseWriter, m *dns.Msg, reason string) {
+	log.FromContext(ctx).WithField("zoneTransferHandler", "ServeDNS").Warnf("refused zone transfer: %s", reason)
+
+	resp := new(dns.Msg)
+	resp.SetRcode(m, dns.RcodeRefused)
+	_ = rw.WriteMsg(resp)
+}
+
+func (h *zoneTransferHandler) allowed(rw dns.ResponseWriter) bool {
+	var ip net.IP
+	switch addr := rw.RemoteAddr().(type) {
+	case *net.TCPAddr:
+		ip = addr.IP
+	case *net.UDPAddr:
+		ip = addr.IP
+	default:
+		return false
+	}
+	for _, network := range h.allowedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneRecords returns the zone A/AAAA records sorted by name
+func (h *zoneTransferHandler) zoneRecords(zone string) []dns.RR {
+	var names []string
+	ips := make(map[string][]net.IP)
+	h.records.Range(func(name string, value []net.IP) bool {
+		if name = dns.CanonicalName(name); dns.IsSubDomain(zone, name) {
+			names = append(names, name)
+			ips[name] = value
+		}
+		return true
+	})
+	sort.Strings(names)
+
+	var rv []dns.RR
+	for _, name := range names {
+		for _, ip := range ips[name] {
+			hdr := dns.RR_Header{Name: name, Class: dns.ClassINET, Ttl: defaultTTL}
+			if ip4 := ip.To4(); ip4 != nil {
+				hdr.Rrtype = dns.TypeA
+				rv = append(rv, &dns.A{Hdr: hdr, A: ip4})
+				continue
+			}
+			hdr.Rrtype = dns.TypeAAAA
+			rv = append(rv, &dns.AAAA{Hdr: hdr, AAAA: ip})
+		}
+	}
+	return rv
+}
+
+// soa returns the zone SOA record. Serial is a hash of the zone records, so it changes only with the zone contents.
+func (h *zoneTransferHandler) soa(zone string) *dns.SOA {
+	hash := fnv.New32a()
+	for _, rr := range h.zoneRecords(zone) {
+		_, _ = hash.Write([]byte(rr.String()))
+	}
+
+	return &dns.SOA{
+		Hdr:     dns.RR_Header{Name: zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: defaultTTL},
+		Ns:      "ns." + zone,
+		Mbox:    "hostmaster." + strings.TrimPrefix(zone, "."),
+		Serial:  hash.Sum32(),
+		Refresh: soaRefresh,
+		Retry:   soaRetry,
+		Expire:  soaExpire,
+		Minttl:  defaultTTL,
+	}
+}
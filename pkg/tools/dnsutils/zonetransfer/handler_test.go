@@ -0,0 +1,134 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zonetransfer_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/zonetransfer"
+)
+
+type responseWriter struct {
+	dns.ResponseWriter
+	remoteAddr net.Addr
+	tsigStatus error
+	Response   *dns.Msg
+}
+
+func (r *responseWriter) RemoteAddr() net.Addr {
+	return r.remoteAddr
+}
+
+func (r *responseWriter) TsigStatus() error {
+	return r.tsigStatus
+}
+
+func (r *responseWriter) WriteMsg(m *dns.Msg) error {
+	r.Response = m
+	return nil
+}
+
+func newResponseWriter(ip string) *responseWriter {
+	return &responseWriter{remoteAddr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 53}}
+}
+
+func transfer(zone string) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zone))
+	return m
+}
+
+func newRecords() *memory.Map {
+	records := new(memory.Map)
+	records.Store("nsc.vl3.", []net.IP{net.ParseIP("10.0.0.1")})
+	records.Store("nse.vl3.", []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("fe80::1")})
+	records.Store("other.zone.", []net.IP{net.ParseIP("10.0.0.3")})
+	return records
+}
+
+func TestZoneTransferHandler_AXFR(t *testing.T) {
+	records := newRecords()
+	handler := zonetransfer.NewDNSHandler(records, []string{"vl3"})
+
+	rw := newResponseWriter("127.0.0.1")
+	handler.ServeDNS(context.Background(), rw, transfer("vl3"))
+	require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode)
+
+	answer := rw.Response.Answer
+	require.Len(t, answer, 5)
+	require.Equal(t, dns.TypeSOA, answer[0].Header().Rrtype)
+	require.Equal(t, answer[0].String(), answer[4].String())
+	require.Equal(t, "10.0.0.1", answer[1].(*dns.A).A.String())
+	require.Equal(t, "10.0.0.2", answer[2].(*dns.A).A.String())
+	require.Equal(t, "fe80::1", answer[3].(*dns.AAAA).AAAA.String())
+
+	// Serial changes only with the zone contents
+	serial := answer[0].(*dns.SOA).Serial
+	rw = newResponseWriter("127.0.0.1")
+	handler.ServeDNS(context.Background(), rw, transfer("vl3"))
+	require.Equal(t, serial, rw.Response.Answer[0].(*dns.SOA).Serial)
+
+	records.Store("nsc-2.vl3.", []net.IP{net.ParseIP("10.0.0.4")})
+	rw = newResponseWriter("127.0.0.1")
+	handler.ServeDNS(context.Background(), rw, transfer("vl3"))
+	require.NotEqual(t, serial, rw.Response.Answer[0].(*dns.SOA).Serial)
+}
+
+func TestZoneTransferHandler_Refused(t *testing.T) {
+	_, allowed, err := net.ParseCIDR("192.168.0.0/16")
+	require.NoError(t, err)
+
+	handler := zonetransfer.NewDNSHandler(newRecords(), []string{"vl3"},
+		zonetransfer.WithAllowedNetworks(allowed),
+		zonetransfer.WithTSIG(),
+	)
+
+	// Not served zone
+	rw := newResponseWriter("192.168.0.1")
+	handler.ServeDNS(context.Background(), rw, transfer("other.zone"))
+	require.Equal(t, dns.RcodeRefused, rw.Response.Rcode)
+
+	// Not allowed network
+	rw = newResponseWriter("127.0.0.1")
+	handler.ServeDNS(context.Background(), rw, transfer("vl3"))
+	require.Equal(t, dns.RcodeRefused, rw.Response.Rcode)
+
+	// Not signed
+	rw = newResponseWriter("192.168.0.1")
+	handler.ServeDNS(context.Background(), rw, transfer("vl3"))
+	require.Equal(t, dns.RcodeRefused, rw.Response.Rcode)
+
+	// Signed with a bad key
+	m := transfer("vl3")
+	m.SetTsig("axfr.", dns.HmacSHA256, 300, 0)
+	rw = newResponseWriter("192.168.0.1")
+	rw.tsigStatus = dns.ErrSig
+	handler.ServeDNS(context.Background(), rw, m)
+	require.Equal(t, dns.RcodeRefused, rw.Response.Rcode)
+
+	// Signed
+	rw = newResponseWriter("192.168.0.1")
+	handler.ServeDNS(context.Background(), rw, m)
+	require.Equal(t, dns.RcodeSuccess, rw.Response.Rcode)
+	require.NotNil(t, rw.Response.IsTsig())
+}
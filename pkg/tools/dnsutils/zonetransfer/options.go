@@ -0,0 +1,43 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zonetransfer
+
+import "net"
+
+type options struct {
+	allowedNetworks []*net.IPNet
+	requireTSIG     bool
+}
+
+// Option is an option pattern for NewDNSHandler
+type Option func(o *options)
+
+// WithAllowedNetworks sets networks allowed to request zone transfers. By default transfers are allowed only from the
+// loopback addresses.
+func WithAllowedNetworks(networks ...*net.IPNet) Option {
+	return func(o *options) {
+		o.allowedNetworks = networks
+	}
+}
+
+// WithTSIG requires zone transfer requests to be signed with TSIG. The DNS server should be started with
+// dnsutils.WithTsigSecret to verify requests and sign responses.
+func WithTSIG() Option {
+	return func(o *options) {
+		o.requireTSIG = true
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drain provides helpers to avoid selecting the endpoints which are going to disappear soon: the endpoints
+// cordoned by the CordonLabel and the endpoints with the pending unregistration known by the Tracker.
+package drain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	// CordonLabel is a network service label marking the endpoint as cordoned: it keeps serving the existing
+	// connections, but should not be selected for the new ones
+	CordonLabel = "cordoned"
+
+	defaultTTL = 30 * time.Second
+)
+
+// Cordon sets the CordonLabel for all the network services of the endpoint
+func Cordon(nse *registry.NetworkServiceEndpoint) {
+	if nse.NetworkServiceLabels == nil {
+		nse.NetworkServiceLabels = make(map[string]*registry.NetworkServiceLabels)
+	}
+	for _, ns := range nse.GetNetworkServiceNames() {
+		labels, ok := nse.NetworkServiceLabels[ns]
+		if !ok || labels == nil {
+			labels = new(registry.NetworkServiceLabels)
+			nse.NetworkServiceLabels[ns] = labels
+		}
+		if labels.Labels == nil {
+			labels.Labels = make(map[string]string)
+		}
+		labels.Labels[CordonLabel] = "true"
+	}
+}
+
+// IsCordoned returns true if the endpoint has the CordonLabel set for any of its network services
+func IsCordoned(nse *registry.NetworkServiceEndpoint) bool {
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if labels.GetLabels()[CordonLabel] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// Tracker tracks the endpoints with the pending unregistration. The endpoints are tracked until they are registered
+// again or the TTL expires, so the stale registry entries (e.g. cached Find results) are not selected as well.
+type Tracker struct {
+	ctx   context.Context
+	ttl   time.Duration
+	mu    sync.Mutex
+	names map[string]time.Time
+}
+
+// Option is an option pattern for NewTracker
+type Option func(t *Tracker)
+
+// WithTTL sets how long the endpoint is tracked after the unregistration has started
+// Default: 30 seconds
+func WithTTL(ttl time.Duration) Option {
+	return func(t *Tracker) {
+		t.ttl = ttl
+	}
+}
+
+// NewTracker creates a new Tracker, ctx is used to get the clock
+func NewTracker(ctx context.Context, opts ...Option) *Tracker {
+	t := &Tracker{
+		ctx:   ctx,
+		ttl:   defaultTTL,
+		names: make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Unregistering marks the endpoint as having the pending unregistration
+func (t *Tracker) Unregistering(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.names[name] = clock.FromContext(t.ctx).Now().Add(t.ttl)
+}
+
+// Registered removes the pending unregistration mark of the endpoint
+func (t *Tracker) Registered(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.names, name)
+}
+
+// IsUnregistering returns true if the endpoint has the pending unregistration
+func (t *Tracker) IsUnregistering(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expiresAt, ok := t.names[name]
+	if !ok {
+		return false
+	}
+	if !clock.FromContext(t.ctx).Now().Before(expiresAt) {
+		delete(t.names, name)
+		return false
+	}
+	return true
+}
+
+// Available returns the endpoints which are neither cordoned nor have the pending unregistration, tracker can be nil.
+// If all the endpoints are draining, they are returned as is: trying a draining endpoint is better than failing.
+func Available(tracker *Tracker, nses []*registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	var available []*registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if IsCordoned(nse) || (tracker != nil && tracker.IsUnregistering(nse.GetName())) {
+			continue
+		}
+		available = append(available, nse)
+	}
+	if len(available) == 0 {
+		return nses
+	}
+	return available
+}
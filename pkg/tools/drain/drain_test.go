@@ -0,0 +1,80 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/drain"
+)
+
+func TestCordon(t *testing.T) {
+	nse := &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns-1", "ns-2"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns-1": {Labels: map[string]string{"app": "firewall"}},
+		},
+	}
+	require.False(t, drain.IsCordoned(nse))
+
+	drain.Cordon(nse)
+	require.True(t, drain.IsCordoned(nse))
+	require.Equal(t, map[string]string{"app": "firewall", drain.CordonLabel: "true"}, nse.GetNetworkServiceLabels()["ns-1"].GetLabels())
+	require.Equal(t, map[string]string{drain.CordonLabel: "true"}, nse.GetNetworkServiceLabels()["ns-2"].GetLabels())
+}
+
+func TestTracker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	tracker := drain.NewTracker(ctx, drain.WithTTL(time.Minute))
+	require.False(t, tracker.IsUnregistering("nse"))
+
+	tracker.Unregistering("nse")
+	require.True(t, tracker.IsUnregistering("nse"))
+
+	tracker.Registered("nse")
+	require.False(t, tracker.IsUnregistering("nse"))
+
+	tracker.Unregistering("nse")
+	clockMock.Add(time.Minute)
+	require.False(t, tracker.IsUnregistering("nse"))
+}
+
+func TestAvailable(t *testing.T) {
+	tracker := drain.NewTracker(context.Background())
+	tracker.Unregistering("nse-2")
+
+	cordoned := &registry.NetworkServiceEndpoint{Name: "nse-1", NetworkServiceNames: []string{"ns"}}
+	drain.Cordon(cordoned)
+	nses := []*registry.NetworkServiceEndpoint{cordoned, {Name: "nse-2"}, {Name: "nse-3"}}
+
+	require.Equal(t, nses[2:], drain.Available(tracker, nses))
+	require.Equal(t, nses[1:], drain.Available(nil, nses))
+	require.Equal(t, nses[:2], drain.Available(tracker, nses[:2]))
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fips provides a FIPS-compatible crypto mode toggle and validation helpers restricting the algorithms used
+// for token signing, TLS configuration and mechanisms to a FIPS-approved set.
+// The mode is enabled by default if the binary is built with the "fips" build tag and can be changed at runtime with
+// Enable. Applications should call ValidateStartup on startup, token generation also validates the own SVID key on
+// every call because SVIDs are rotated.
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+)
+
+const minRSAKeySize = 2048
+
+var (
+	isSet     int32 = 0
+	isEnabled int32 = 0
+)
+
+// Enabled returns true if FIPS mode is enabled
+func Enabled() bool {
+	if atomic.LoadInt32(&isSet) == 0 {
+		return enabledByDefault
+	}
+	return atomic.LoadInt32(&isEnabled) != 0
+}
+
+// Enable enables/disables FIPS mode
+func Enable(enable bool) {
+	if enable {
+		atomic.StoreInt32(&isEnabled, 1)
+	} else {
+		atomic.StoreInt32(&isEnabled, 0)
+	}
+	atomic.StoreInt32(&isSet, 1)
+}
+
+// approvedSigningMethods are JWT signing methods approved by FIPS 186-4
+var approvedSigningMethods = map[string]struct{}{
+	jwt.SigningMethodES256.Alg(): {},
+	jwt.SigningMethodES384.Alg(): {},
+	jwt.SigningMethodES512.Alg(): {},
+	jwt.SigningMethodRS256.Alg(): {},
+	jwt.SigningMethodRS384.Alg(): {},
+	jwt.SigningMethodRS512.Alg(): {},
+	jwt.SigningMethodPS256.Alg(): {},
+	jwt.SigningMethodPS384.Alg(): {},
+	jwt.SigningMethodPS512.Alg(): {},
+}
+
+// approvedCipherSuites are TLS 1.2 cipher suites approved by NIST SP 800-52r2
+var approvedCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// approvedCurves are TLS curves approved by NIST SP 800-52r2
+var approvedCurves = []tls.CurveID{
+	tls.CurveP256,
+	tls.CurveP384,
+	tls.CurveP521,
+}
+
+// unapprovedMechanisms are mechanisms relying on the algorithms outside of the FIPS-approved set
+var unapprovedMechanisms = map[string]string{
+	"WIREGUARD": "Curve25519 and ChaCha20-Poly1305 are not FIPS-approved",
+}
+
+// ValidateSigningMethod returns an error if FIPS mode is enabled and the method is not FIPS-approved
+func ValidateSigningMethod(method jwt.SigningMethod) error {
+	if !Enabled() {
+		return nil
+	}
+	if _, ok := approvedSigningMethods[method.Alg()]; !ok {
+		return errors.Errorf("fips: signing method %s is not FIPS-approved", method.Alg())
+	}
+	return nil
+}
+
+// ValidateKey returns an error if FIPS mode is enabled and the key is not FIPS-approved
+func ValidateKey(key crypto.PublicKey) error {
+	if !Enabled() {
+		return nil
+	}
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+			return nil
+		}
+		return errors.Errorf("fips: ECDSA curve %s is not FIPS-approved", k.Curve.Params().Name)
+	case *rsa.PublicKey:
+		if k.Size()*8 < minRSAKeySize {
+			return errors.Errorf("fips: RSA key size %d is less than %d bits", k.Size()*8, minRSAKeySize)
+		}
+		return nil
+	default:
+		return errors.Errorf("fips: key type %T is not FIPS-approved", key)
+	}
+}
+
+// ValidateMechanism returns an error if FIPS mode is enabled and the mechanism type relies on not FIPS-approved
+// algorithms
+func ValidateMechanism(mechanismType string) error {
+	if !Enabled() {
+		return nil
+	}
+	if reason, ok := unapprovedMechanisms[mechanismType]; ok {
+		return errors.Errorf("fips: mechanism %s is not allowed: %s", mechanismType, reason)
+	}
+	return nil
+}
+
+// TLSConfig returns a copy of the config restricted to the FIPS-approved TLS versions, cipher suites and curves if
+// FIPS mode is enabled
+func TLSConfig(config *tls.Config) *tls.Config {
+	if config == nil {
+		config = new(tls.Config)
+	}
+	rv := config.Clone()
+	if !Enabled() {
+		return rv
+	}
+	if rv.MinVersion < tls.VersionTLS12 {
+		rv.MinVersion = tls.VersionTLS12
+	}
+	// CipherSuites are not configurable for TLS 1.3, so TLS_CHACHA20_POLY1305_SHA256 could be negotiated
+	rv.MaxVersion = tls.VersionTLS12
+	rv.CipherSuites = append([]uint16(nil), approvedCipherSuites...)
+	rv.CurvePreferences = append([]tls.CurveID(nil), approvedCurves...)
+	return rv
+}
+
+// ValidateTLSConfig returns an error if FIPS mode is enabled and the config allows not FIPS-approved TLS versions,
+// cipher suites or curves
+func ValidateTLSConfig(config *tls.Config) error {
+	if !Enabled() {
+		return nil
+	}
+	if config == nil {
+		return errors.New("fips: TLS config is not set")
+	}
+	if config.MinVersion < tls.VersionTLS12 {
+		return errors.New("fips: TLS versions prior to 1.2 are not FIPS-approved")
+	}
+	if config.MaxVersion == 0 || config.MaxVersion > tls.VersionTLS12 {
+		return errors.New("fips: TLS 1.3 cipher suites can't be restricted, max TLS version must be 1.2")
+	}
+	if len(config.CipherSuites) == 0 {
+		return errors.New("fips: TLS cipher suites must be explicitly restricted")
+	}
+	for _, suite := range config.CipherSuites {
+		if !containsCipherSuite(suite) {
+			return errors.Errorf("fips: TLS cipher suite %s is not FIPS-approved", tls.CipherSuiteName(suite))
+		}
+	}
+	if len(config.CurvePreferences) == 0 {
+		return errors.New("fips: TLS curves must be explicitly restricted")
+	}
+	for _, curve := range config.CurvePreferences {
+		if !containsCurve(curve) {
+			return errors.Errorf("fips: TLS curve %v is not FIPS-approved", curve)
+		}
+	}
+	return nil
+}
+
+// ValidateStartup returns an error if FIPS mode is enabled and the own SVID key or any of the TLS configs are not
+// FIPS-approved. It should be called on the application startup, so not FIPS-compatible setups fail fast instead of
+// failing on the first token generation or handshake.
+func ValidateStartup(source x509svid.Source, tlsConfigs ...*tls.Config) error {
+	if !Enabled() {
+		return nil
+	}
+	if source != nil {
+		svid, err := source.GetX509SVID()
+		if err != nil {
+			return errors.Wrap(err, "fips: failed to get X509 SVID")
+		}
+		if err := ValidateKey(svid.PrivateKey.Public()); err != nil {
+			return err
+		}
+	}
+	for _, config := range tlsConfigs {
+		if err := ValidateTLSConfig(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsCipherSuite(suite uint16) bool {
+	for _, approved := range approvedCipherSuites {
+		if suite == approved {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCurve(curve tls.CurveID) bool {
+	for _, approved := range approvedCurves {
+		if curve == approved {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fips_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/fips"
+)
+
+func TestFIPS_Disabled(t *testing.T) {
+	fips.Enable(false)
+
+	require.NoError(t, fips.ValidateSigningMethod(jwt.SigningMethodHS256))
+	require.NoError(t, fips.ValidateMechanism("WIREGUARD"))
+	require.NoError(t, fips.ValidateTLSConfig(&tls.Config{MinVersion: tls.VersionTLS10}))
+}
+
+func TestFIPS_Enabled(t *testing.T) {
+	fips.Enable(true)
+	t.Cleanup(func() { fips.Enable(false) })
+
+	require.NoError(t, fips.ValidateSigningMethod(jwt.SigningMethodES256))
+	require.Error(t, fips.ValidateSigningMethod(jwt.SigningMethodHS256))
+	require.Error(t, fips.ValidateSigningMethod(jwt.SigningMethodEdDSA))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, fips.ValidateKey(ecKey.Public()))
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.Error(t, fips.ValidateKey(edKey))
+
+	require.NoError(t, fips.ValidateMechanism("KERNEL"))
+	require.Error(t, fips.ValidateMechanism("WIREGUARD"))
+
+	require.Error(t, fips.ValidateTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	require.NoError(t, fips.ValidateTLSConfig(fips.TLSConfig(&tls.Config{MinVersion: tls.VersionTLS10})))
+	require.Equal(t, uint16(tls.VersionTLS12), fips.TLSConfig(nil).MaxVersion)
+	require.Error(t, fips.ValidateTLSConfig(&tls.Config{
+		MinVersion:       tls.VersionTLS12,
+		CipherSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		CurvePreferences: []tls.CurveID{tls.CurveP256},
+	}))
+	require.Error(t, fips.ValidateTLSConfig(&tls.Config{
+		MinVersion:       tls.VersionTLS12,
+		CipherSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256},
+		CurvePreferences: []tls.CurveID{tls.CurveP256},
+	}))
+	require.Error(t, fips.ValidateTLSConfig(&tls.Config{
+		MinVersion:       tls.VersionTLS12,
+		CipherSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		CurvePreferences: []tls.CurveID{tls.X25519},
+	}))
+}
+
+type svidSource struct {
+	svid *x509svid.SVID
+}
+
+func (s *svidSource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func TestFIPS_ValidateStartup(t *testing.T) {
+	fips.Enable(true)
+	t.Cleanup(func() { fips.Enable(false) })
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, fips.ValidateStartup(&svidSource{svid: &x509svid.SVID{PrivateKey: ecKey}}, fips.TLSConfig(nil)))
+	require.Error(t, fips.ValidateStartup(&svidSource{svid: &x509svid.SVID{PrivateKey: edKey}}))
+	require.Error(t, fips.ValidateStartup(nil, &tls.Config{}))
+}
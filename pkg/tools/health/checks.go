@@ -0,0 +1,114 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+// Gauge is a numeric value reported in the status
+type Gauge struct {
+	value int64
+}
+
+// Inc increments the gauge
+func (g *Gauge) Inc() {
+	atomic.AddInt64(&g.value, 1)
+}
+
+// Dec decrements the gauge
+func (g *Gauge) Dec() {
+	atomic.AddInt64(&g.value, -1)
+}
+
+// Set sets the gauge value
+func (g *Gauge) Set(value int64) {
+	atomic.StoreInt64(&g.value, value)
+}
+
+// Value returns the gauge value
+func (g *Gauge) Value() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+// Heartbeat tracks the last time some periodic action (e.g. registration refresh) has succeeded
+type Heartbeat struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Beat records a successful action
+func (h *Heartbeat) Beat(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = clock.FromContext(ctx).Now()
+}
+
+// Check returns a Check failing if there were no successful actions during the maxAge
+func (h *Heartbeat) Check(maxAge time.Duration) Check {
+	return func(ctx context.Context) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if h.last.IsZero() {
+			return errors.New("no heartbeats yet")
+		}
+		if age := clock.FromContext(ctx).Since(h.last); age > maxAge {
+			return errors.Errorf("last heartbeat was %s ago", age)
+		}
+		return nil
+	}
+}
+
+// ClientConnCheck returns a Check failing if the cc is not connected, e.g. the registry is not reachable
+func ClientConnCheck(cc *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		switch state := cc.GetState(); state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			cc.Connect()
+			fallthrough
+		default:
+			return errors.Errorf("%s is %s", cc.Target(), state)
+		}
+	}
+}
+
+// DNSCheck returns a Check failing if the DNS server listening on the addr doesn't respond to the query for the name
+func DNSCheck(addr, name string) Check {
+	return func(ctx context.Context) error {
+		m := new(dns.Msg)
+		m.SetQuestion(dns.Fqdn(name), dns.TypeA)
+
+		if _, _, err := new(dns.Client).ExchangeContext(ctx, m, addr); err != nil {
+			return errors.Wrapf(err, "DNS server %s is not serving", addr)
+		}
+		return nil
+	}
+}
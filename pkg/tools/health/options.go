@@ -0,0 +1,41 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import "time"
+
+type options struct {
+	interval     time.Duration
+	checkTimeout time.Duration
+}
+
+// Option is an option pattern for NewServer
+type Option func(o *options)
+
+// WithInterval sets the interval between health checks. Default: 5s
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithCheckTimeout sets the timeout for a single check. Default: 3s
+func WithCheckTimeout(checkTimeout time.Duration) Option {
+	return func(o *options) {
+		o.checkTimeout = checkTimeout
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health aggregates health of the application components into a single status served over HTTP and the gRPC
+// health checking protocol, so it can be consumed by any orchestrator and not only by Kubernetes probes
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	defaultInterval     = 5 * time.Second
+	defaultCheckTimeout = 3 * time.Second
+)
+
+// Check returns an error if the component is not healthy
+type Check func(ctx context.Context) error
+
+// ComponentStatus is a health status of a single component
+type ComponentStatus struct {
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Status is an aggregated health status
+type Status struct {
+	Healthy    bool                        `json:"healthy"`
+	Components map[string]*ComponentStatus `json:"components"`
+	Gauges     map[string]int64            `json:"gauges,omitempty"`
+	CheckedAt  time.Time                   `json:"checkedAt"`
+}
+
+// Server periodically runs registered checks and serves the last aggregated status
+type Server struct {
+	ctx          context.Context
+	interval     time.Duration
+	checkTimeout time.Duration
+	grpcHealth   *grpchealth.Server
+
+	mu     sync.Mutex
+	checks map[string]Check
+	gauges map[string]*Gauge
+	status *Status
+}
+
+// NewServer creates a new health Server. Checks run until the ctx is done.
+func NewServer(ctx context.Context, opts ...Option) *Server {
+	o := &options{
+		interval:     defaultInterval,
+		checkTimeout: defaultCheckTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &Server{
+		ctx:          ctx,
+		interval:     o.interval,
+		checkTimeout: o.checkTimeout,
+		grpcHealth:   grpchealth.NewServer(),
+		checks:       make(map[string]Check),
+		gauges:       make(map[string]*Gauge),
+		status:       &Status{Healthy: true, Components: map[string]*ComponentStatus{}},
+	}
+
+	go func() {
+		timeClock := clock.FromContext(ctx)
+		for {
+			s.Refresh(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.grpcHealth.Shutdown()
+				return
+			case <-timeClock.After(s.interval):
+			}
+		}
+	}()
+
+	return s
+}
+
+// Register registers the component check
+func (s *Server) Register(name string, check Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checks[name] = check
+}
+
+// Gauge returns the gauge with the name reported in the status, e.g. a number of active connections
+func (s *Server) Gauge(name string) *Gauge {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gauge, ok := s.gauges[name]
+	if !ok {
+		gauge = new(Gauge)
+		s.gauges[name] = gauge
+	}
+	return gauge
+}
+
+// Refresh runs all the checks and updates the status
+func (s *Server) Refresh(ctx context.Context) *Status {
+	s.mu.Lock()
+	checks := make(map[string]Check, len(s.checks))
+	for name, check := range s.checks {
+		checks[name] = check
+	}
+	gauges := make(map[string]int64, len(s.gauges))
+	for name, gauge := range s.gauges {
+		gauges[name] = gauge.Value()
+	}
+	s.mu.Unlock()
+
+	status := &Status{
+		Healthy:    true,
+		Components: make(map[string]*ComponentStatus, len(checks)),
+		Gauges:     gauges,
+		CheckedAt:  clock.FromContext(ctx).Now(),
+	}
+
+	var wg sync.WaitGroup
+	var statusMu sync.Mutex
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := clock.FromContext(ctx).WithTimeout(ctx, s.checkTimeout)
+			defer cancel()
+
+			component := &ComponentStatus{Healthy: true}
+			if err := check(checkCtx); err != nil {
+				component.Healthy = false
+				component.Error = err.Error()
+			}
+
+			statusMu.Lock()
+			defer statusMu.Unlock()
+
+			status.Components[name] = component
+		}(name, check)
+	}
+	wg.Wait()
+
+	names := make([]string, 0, len(status.Components))
+	for name := range status.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		component := status.Components[name]
+		if !component.Healthy {
+			status.Healthy = false
+			log.FromContext(ctx).WithField("health", "Refresh").Warnf("%s is not healthy: %s", name, component.Error)
+		}
+		s.grpcHealth.SetServingStatus(name, servingStatus(component.Healthy))
+	}
+	s.grpcHealth.SetServingStatus("", servingStatus(status.Healthy))
+
+	s.mu.Lock()
+	s.status = status
+	s.mu.Unlock()
+
+	return status
+}
+
+// Status returns the last aggregated status
+func (s *Server) Status() *Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+// ServeHTTP writes the last aggregated status as JSON. Responds with 503 if any of the components is not healthy.
+func (s *Server) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status := s.Status()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.FromContext(s.ctx).WithField("health", "ServeHTTP").Errorf("failed to write status: %s", err.Error())
+	}
+}
+
+// RegisterGRPC registers the gRPC health checking service on the server. Components are served with their names, the
+// aggregated status is served with the empty service name.
+func (s *Server) RegisterGRPC(server *grpc.Server) {
+	grpc_health_v1.RegisterHealthServer(server, s.grpcHealth)
+}
+
+func servingStatus(healthy bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
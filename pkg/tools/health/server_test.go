@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/health"
+)
+
+func TestHealthServer(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	s := health.NewServer(ctx, health.WithInterval(time.Hour))
+
+	heartbeat := new(health.Heartbeat)
+	heartbeat.Beat(ctx)
+	s.Register("registration", heartbeat.Check(time.Minute))
+
+	var dnsServing int32 = 1
+	s.Register("dns", func(context.Context) error {
+		if atomic.LoadInt32(&dnsServing) == 0 {
+			return errors.New("not serving")
+		}
+		return nil
+	})
+	s.Gauge("connections").Inc()
+
+	status := s.Refresh(ctx)
+	require.True(t, status.Healthy)
+	require.Equal(t, int64(1), status.Gauges["connections"])
+
+	// HTTP
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	atomic.StoreInt32(&dnsServing, 0)
+	clockMock.Add(2 * time.Minute)
+	status = s.Refresh(ctx)
+	require.False(t, status.Healthy)
+	require.False(t, status.Components["registration"].Healthy)
+	require.Equal(t, "not serving", status.Components["dns"].Error)
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var decoded health.Status
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&decoded))
+	require.False(t, decoded.Components["dns"].Healthy)
+
+	// gRPC
+	grpcServer := grpc.NewServer()
+	s.RegisterGRPC(grpcServer)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = grpcServer.Serve(l) }()
+	defer grpcServer.Stop()
+
+	cc, err := grpc.DialContext(ctx, l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	healthClient := grpc_health_v1.NewHealthClient(cc)
+	resp, err := healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	atomic.StoreInt32(&dnsServing, 1)
+	heartbeat.Beat(ctx)
+	s.Refresh(ctx)
+
+	resp, err = healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "dns"})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+}
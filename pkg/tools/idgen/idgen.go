@@ -0,0 +1,106 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package idgen provides the strategies generating the connection and path segment IDs
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Generator generates the ID of the new path segment named segmentName following the prev path segment. Prev is nil
+// for the first path segment.
+type Generator func(prev *networkservice.PathSegment, segmentName string) string
+
+// UUID returns a Generator generating random UUIDs, it is the default one
+func UUID() Generator {
+	return func(_ *networkservice.PathSegment, _ string) string {
+		return uuid.New().String()
+	}
+}
+
+// UUIDv7 returns a Generator generating time-ordered UUIDs version 7, so the IDs generated by the same Generator are
+// sorted in the generation order and the IDs generated by the different ones are sorted up to the millisecond
+func UUIDv7() Generator {
+	var mu sync.Mutex
+	var lastMs int64
+	var seq uint16
+
+	return func(_ *networkservice.PathSegment, _ string) string {
+		mu.Lock()
+		ms := time.Now().UnixMilli()
+		switch {
+		case ms > lastMs:
+			lastMs = ms
+			seq = randUint16() & 0x7ff
+		case seq < 0xfff:
+			seq++
+		default:
+			// The sequence is exhausted in the millisecond, borrow the next one
+			lastMs++
+			seq = 0
+		}
+		ms, s := lastMs, seq
+		mu.Unlock()
+
+		var id uuid.UUID
+		_, _ = rand.Read(id[8:])
+		id[0] = byte(ms >> 40)
+		id[1] = byte(ms >> 32)
+		id[2] = byte(ms >> 24)
+		id[3] = byte(ms >> 16)
+		id[4] = byte(ms >> 8)
+		id[5] = byte(ms)
+		id[6] = 0x70 | byte(s>>8)
+		id[7] = byte(s)
+		id[8] = 0x80 | id[8]&0x3f
+		return id.String()
+	}
+}
+
+// Hash returns a Generator generating UUIDs version 5 of the namespace and the previous path segment ID with the
+// segment name, so the same request passing the same path always gets the same IDs. The first path segment ID is
+// generated by fallback, or UUID if fallback is nil.
+func Hash(namespace uuid.UUID, fallback Generator) Generator {
+	if fallback == nil {
+		fallback = UUID()
+	}
+	return func(prev *networkservice.PathSegment, segmentName string) string {
+		if prev.GetId() == "" {
+			return fallback(prev, segmentName)
+		}
+		return uuid.NewSHA1(namespace, []byte(prev.GetId()+"/"+segmentName)).String()
+	}
+}
+
+// WithPrefix returns a Generator prefixing the IDs generated by gen with prefix, e.g. the site name with a separator
+func WithPrefix(prefix string, gen Generator) Generator {
+	return func(prev *networkservice.PathSegment, segmentName string) string {
+		return prefix + gen(prev, segmentName)
+	}
+}
+
+func randUint16() uint16 {
+	var b [2]byte
+	_, _ = rand.Read(b[:])
+	return binary.BigEndian.Uint16(b[:])
+}
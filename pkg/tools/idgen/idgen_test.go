@@ -0,0 +1,62 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idgen_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/idgen"
+)
+
+func TestUUIDv7(t *testing.T) {
+	gen := idgen.UUIDv7()
+
+	ids := make([]string, 10000)
+	for i := range ids {
+		ids[i] = gen(nil, "nsc")
+	}
+	require.True(t, sort.StringsAreSorted(ids))
+
+	for _, id := range ids[:10] {
+		parsed, err := uuid.Parse(id)
+		require.NoError(t, err)
+		require.Equal(t, uuid.Version(7), parsed.Version())
+		require.Equal(t, uuid.RFC4122, parsed.Variant())
+	}
+}
+
+func TestHash(t *testing.T) {
+	gen := idgen.Hash(uuid.NameSpaceURL, idgen.WithPrefix("first-", idgen.UUID()))
+
+	first := gen(nil, "nsc")
+	require.Contains(t, first, "first-")
+	require.NotEqual(t, first, gen(nil, "nsc"))
+
+	prev := &networkservice.PathSegment{Id: first, Name: "nsc"}
+	require.Equal(t, gen(prev, "nsmgr"), gen(prev, "nsmgr"))
+	require.NotEqual(t, gen(prev, "nsmgr"), gen(prev, "forwarder"))
+}
+
+func TestWithPrefix(t *testing.T) {
+	id := idgen.WithPrefix("site-a/", idgen.UUID())(nil, "nsc")
+	require.Regexp(t, "^site-a/[0-9a-f-]{36}$", id)
+}
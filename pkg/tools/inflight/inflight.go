@@ -0,0 +1,81 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inflight provides tools for tracking chain elements currently processing a request
+package inflight
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKeyType struct{}
+
+// Stack is a stack of chain elements currently processing a request
+type Stack struct {
+	mu       sync.Mutex
+	elements []string
+}
+
+// WithStack returns a new context with a new Stack
+func WithStack(parent context.Context) (ctx context.Context, stack *Stack) {
+	stack = new(Stack)
+	return context.WithValue(parent, contextKeyType{}, stack), stack
+}
+
+// Enter pushes the element to the Stack stored in the context and returns a function popping it. Does nothing if
+// there is no Stack in the context.
+func Enter(ctx context.Context, element string) (exit func()) {
+	stack, ok := ctx.Value(contextKeyType{}).(*Stack)
+	if !ok {
+		return func() {}
+	}
+
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+
+	stack.elements = append(stack.elements, element)
+	return func() {
+		stack.mu.Lock()
+		defer stack.mu.Unlock()
+
+		for i := len(stack.elements) - 1; i >= 0; i-- {
+			if stack.elements[i] == element {
+				stack.elements = append(stack.elements[:i], stack.elements[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Deepest returns the deepest pending element or "" if there are no pending elements
+func (s *Stack) Deepest() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.elements) == 0 {
+		return ""
+	}
+	return s.elements[len(s.elements)-1]
+}
+
+// Elements returns a copy of all pending elements from the outermost to the deepest one
+func (s *Stack) Elements() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.elements...)
+}
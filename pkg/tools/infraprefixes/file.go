@@ -0,0 +1,68 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infraprefixes
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+type fileProvider struct {
+	path string
+}
+
+// NewFileProvider creates a Provider reading the prefixes from the yaml file with the same format as the excluded
+// prefixes config:
+//
+//	prefixes:
+//	- 10.96.0.0/12
+//	- 10.244.0.0/16
+//
+// The file is read on every call, missing file means no prefixes.
+func NewFileProvider(path string) Provider {
+	return &fileProvider{
+		path: path,
+	}
+}
+
+func (p *fileProvider) Prefixes(_ context.Context) ([]string, error) {
+	bytes, err := ioutil.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read prefixes file %s", p.path)
+	}
+
+	source := struct {
+		Prefixes []string
+	}{}
+	if unmarshalErr := yaml.Unmarshal(bytes, &source); unmarshalErr != nil {
+		return nil, errors.Wrapf(unmarshalErr, "failed to unmarshal prefixes file %s", p.path)
+	}
+	for _, prefix := range source.Prefixes {
+		if _, _, parseErr := net.ParseCIDR(prefix); parseErr != nil {
+			return nil, errors.Wrapf(parseErr, "invalid prefix in file %s", p.path)
+		}
+	}
+	return source.Prefixes, nil
+}
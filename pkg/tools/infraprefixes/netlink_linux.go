@@ -0,0 +1,76 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package infraprefixes
+
+import (
+	"context"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+type netlinkProvider struct{}
+
+// NewNetlinkProvider creates a Provider returning the prefixes of all the addresses assigned to the host interfaces.
+// The addresses are dumped with the RTM_GETADDR netlink request in the network namespace of the calling thread.
+func NewNetlinkProvider() Provider {
+	return &netlinkProvider{}
+}
+
+func (p *netlinkProvider) Prefixes(_ context.Context) ([]string, error) {
+	rib, err := syscall.NetlinkRIB(syscall.RTM_GETADDR, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, errors.Wrap(os.NewSyscallError("netlinkrib", err), "failed to dump interface addresses")
+	}
+	msgs, err := syscall.ParseNetlinkMessage(rib)
+	if err != nil {
+		return nil, errors.Wrap(os.NewSyscallError("parsenetlinkmessage", err), "failed to parse interface addresses")
+	}
+
+	var prefixes []string
+	for i := range msgs {
+		if msgs[i].Header.Type == syscall.NLMSG_DONE {
+			break
+		}
+		if msgs[i].Header.Type != syscall.RTM_NEWADDR || len(msgs[i].Data) < syscall.SizeofIfAddrmsg {
+			continue
+		}
+		// struct ifaddrmsg { u8 ifa_family; u8 ifa_prefixlen; ... }
+		prefixLen := int(msgs[i].Data[1])
+
+		attrs, parseErr := syscall.ParseNetlinkRouteAttr(&msgs[i])
+		if parseErr != nil {
+			return nil, errors.Wrap(os.NewSyscallError("parsenetlinkrouteattr", parseErr), "failed to parse interface address")
+		}
+		for _, attr := range attrs {
+			if attr.Attr.Type != syscall.IFA_ADDRESS {
+				continue
+			}
+			prefix, prefixErr := networkPrefix(net.IP(attr.Value), prefixLen)
+			if prefixErr != nil {
+				return nil, prefixErr
+			}
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes, nil
+}
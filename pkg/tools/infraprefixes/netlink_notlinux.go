@@ -0,0 +1,57 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package infraprefixes
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+type netlinkProvider struct{}
+
+// NewNetlinkProvider creates a Provider returning the prefixes of all the addresses assigned to the host interfaces.
+// There is no netlink on this platform, so the addresses are listed with the net package.
+func NewNetlinkProvider() Provider {
+	return &netlinkProvider{}
+}
+
+func (p *netlinkProvider) Prefixes(_ context.Context) ([]string, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list interface addresses")
+	}
+
+	var prefixes []string
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		prefixLen, _ := ipNet.Mask.Size()
+		prefix, prefixErr := networkPrefix(ipNet.IP, prefixLen)
+		if prefixErr != nil {
+			return nil, prefixErr
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package infraprefixes provides the sources of the node and cluster infrastructure prefixes which should never be
+// allocated by NSM IPAM
+package infraprefixes
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// WellKnownPrefixes are the prefixes reserved for the special use which can't be allocated for the connections
+var WellKnownPrefixes = []string{
+	"0.0.0.0/8",      // "this" network
+	"127.0.0.0/8",    // loopback
+	"169.254.0.0/16", // link-local
+	"224.0.0.0/4",    // multicast
+	"240.0.0.0/4",    // reserved
+	"::1/128",        // loopback
+	"fe80::/10",      // link-local
+	"ff00::/8",       // multicast
+}
+
+// Provider provides the list of the infrastructure prefixes
+type Provider interface {
+	Prefixes(ctx context.Context) ([]string, error)
+}
+
+// ProviderFunc is a function adapter for the Provider
+type ProviderFunc func(ctx context.Context) ([]string, error)
+
+// Prefixes calls f(ctx)
+func (f ProviderFunc) Prefixes(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// NewStaticProvider creates a Provider always returning the given prefixes, e.g. the cluster Pod and Service CIDRs or
+// the WellKnownPrefixes
+func NewStaticProvider(prefixes ...string) Provider {
+	return ProviderFunc(func(_ context.Context) ([]string, error) {
+		return prefixes, nil
+	})
+}
+
+// NewWellKnownProvider creates a Provider returning the WellKnownPrefixes
+func NewWellKnownProvider() Provider {
+	return NewStaticProvider(WellKnownPrefixes...)
+}
+
+// Collect returns the prefixes provided by all the providers. On error it stops and returns the prefixes collected so
+// far.
+func Collect(ctx context.Context, providers ...Provider) ([]string, error) {
+	var result []string
+	for _, provider := range providers {
+		prefixes, err := provider.Prefixes(ctx)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, prefixes...)
+	}
+	return result, nil
+}
+
+func networkPrefix(ip net.IP, prefixLen int) (string, error) {
+	bits := net.IPv6len * 8
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, net.IPv4len*8
+	}
+	if prefixLen < 0 || prefixLen > bits {
+		return "", errors.Errorf("invalid prefix length %d for %s", prefixLen, ip)
+	}
+	mask := net.CIDRMask(prefixLen, bits)
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String(), nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infraprefixes_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/infraprefixes"
+)
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefixes.yaml")
+
+	provider := infraprefixes.NewFileProvider(path)
+
+	prefixes, err := provider.Prefixes(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, prefixes)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("prefixes:\n- 10.96.0.0/12\n- 10.244.0.0/16\n"), os.ModePerm))
+
+	prefixes, err = provider.Prefixes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.96.0.0/12", "10.244.0.0/16"}, prefixes)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("prefixes:\n- 10.96.0.0\n"), os.ModePerm))
+
+	_, err = provider.Prefixes(context.Background())
+	require.Error(t, err)
+}
+
+func TestNetlinkProvider(t *testing.T) {
+	prefixes, err := infraprefixes.NewNetlinkProvider().Prefixes(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, prefixes, "127.0.0.0/8")
+}
+
+func TestCollect(t *testing.T) {
+	prefixes, err := infraprefixes.Collect(context.Background(),
+		infraprefixes.NewWellKnownProvider(),
+		infraprefixes.NewStaticProvider("10.96.0.0/12"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, append(append([]string(nil), infraprefixes.WellKnownPrefixes...), "10.96.0.0/12"), prefixes)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inspect provides helpers for the troubleshooting tools inspecting the live NSM components: dialing NSMgr and
+// registry, listing connections and registrations, watching connection events and printing them in a human readable
+// form. The helpers are independent of the CLI framework, so they can back cobra commands as well as any other tool.
+package inspect
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/reconnect"
+)
+
+// Dial dials the NSM component (NSMgr, registry, endpoint, ...) serving on u
+func Dial(ctx context.Context, u *url.URL, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s", u.String())
+	}
+	return cc, nil
+}
+
+// ListConnections returns the connections matching the selector. nil selector matches all the connections.
+func ListConnections(ctx context.Context, cc grpc.ClientConnInterface, selector *networkservice.MonitorScopeSelector) (map[string]*networkservice.Connection, error) {
+	if selector == nil {
+		selector = new(networkservice.MonitorScopeSelector)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := networkservice.NewMonitorConnectionClient(cc).MonitorConnections(ctx, selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to monitor connections")
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive connections")
+	}
+	if event.GetType() != networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER {
+		return nil, errors.Errorf("expected initial state transfer, received: %s", event.GetType())
+	}
+	return event.GetConnections(), nil
+}
+
+// WatchEvents calls handle for every connection event matching the selector until ctx is done or handle returns an
+// error. Broken streams are re-established, the changes missed during the gap are passed as the synthetic events.
+// nil selector matches all the connections.
+func WatchEvents(ctx context.Context, cc grpc.ClientConnInterface, selector *networkservice.MonitorScopeSelector, handle func(*networkservice.ConnectionEvent) error) error {
+	if selector == nil {
+		selector = new(networkservice.MonitorScopeSelector)
+	}
+
+	stream, err := reconnect.NewClient(networkservice.NewMonitorConnectionClient(cc)).MonitorConnections(ctx, selector)
+	if err != nil {
+		return errors.Wrap(err, "failed to monitor connections")
+	}
+	for {
+		event, recvErr := stream.Recv()
+		if recvErr != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return recvErr
+		}
+		if err = handle(event); err != nil {
+			return err
+		}
+	}
+}
+
+// ListEndpoints returns the registered endpoints matching the query endpoint. nil endpoint matches all the endpoints.
+func ListEndpoints(ctx context.Context, cc grpc.ClientConnInterface, nse *registry.NetworkServiceEndpoint) ([]*registry.NetworkServiceEndpoint, error) {
+	if nse == nil {
+		nse = new(registry.NetworkServiceEndpoint)
+	}
+
+	stream, err := registry.NewNetworkServiceEndpointRegistryClient(cc).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: nse,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find endpoints")
+	}
+	return registry.ReadNetworkServiceEndpointList(stream), nil
+}
+
+// ListNetworkServices returns the registered network services matching the query network service. nil network service
+// matches all the network services.
+func ListNetworkServices(ctx context.Context, cc grpc.ClientConnInterface, ns *registry.NetworkService) ([]*registry.NetworkService, error) {
+	if ns == nil {
+		ns = new(registry.NetworkService)
+	}
+
+	stream, err := registry.NewNetworkServiceRegistryClient(cc).Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: ns,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find network services")
+	}
+	return registry.ReadNetworkServiceList(stream), nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/inspect"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func TestInspect(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetRegistryProxySupplier(nil).
+		SetNSMgrProxySupplier(nil).
+		Build()
+
+	nsRegistryClient := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken)
+	_, err := nsRegistryClient.Register(ctx, &registry.NetworkService{Name: "ns"})
+	require.NoError(t, err)
+
+	domain.Nodes[0].NewEndpoint(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{"ns"},
+	}, sandbox.GenerateTestToken)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+	conn, err := nsc.Request(ctx, &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.LOCAL, Type: kernelmech.MECHANISM},
+		},
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: "ns",
+		},
+	})
+	require.NoError(t, err)
+
+	cc, err := inspect.Dial(ctx, domain.Nodes[0].NSMgr.URL, sandbox.DialOptions()...)
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	conns, err := inspect.ListConnections(ctx, cc, nil)
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+
+	out := new(bytes.Buffer)
+	require.NoError(t, inspect.PrintConnections(out, conns))
+	require.Contains(t, out.String(), "ns")
+	require.Contains(t, out.String(), inspect.FormatPath(conns[conn.GetPath().GetPathSegments()[1].GetId()].GetPath()))
+
+	nses, err := inspect.ListEndpoints(ctx, cc, &registry.NetworkServiceEndpoint{Name: "nse"})
+	require.NoError(t, err)
+	require.Len(t, nses, 1)
+
+	out.Reset()
+	require.NoError(t, inspect.PrintEndpoints(out, nses))
+	require.Contains(t, out.String(), "nse")
+
+	nss, err := inspect.ListNetworkServices(ctx, cc, &registry.NetworkService{Name: "ns"})
+	require.NoError(t, err)
+	require.Len(t, nss, 1)
+
+	watchCtx, watchCancel := context.WithCancel(ctx)
+	defer watchCancel()
+	require.NoError(t, inspect.WatchEvents(watchCtx, cc, nil, func(event *networkservice.ConnectionEvent) error {
+		require.Equal(t, networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, event.GetType())
+		out.Reset()
+		require.NoError(t, inspect.PrintEvent(out, event))
+		require.Contains(t, out.String(), "INITIAL_STATE_TRANSFER")
+		watchCancel()
+		return nil
+	}))
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "nsc(1) -> *nsmgr(2)", inspect.FormatPath(&networkservice.Path{
+		Index: 1,
+		PathSegments: []*networkservice.PathSegment{
+			{Name: "nsc", Id: "1"},
+			{Name: "nsmgr", Id: "2"},
+		},
+	}))
+	require.Equal(t, "LOCAL/KERNEL a=1,b=2", inspect.FormatMechanism(&networkservice.Mechanism{
+		Cls:        cls.LOCAL,
+		Type:       kernelmech.MECHANISM,
+		Parameters: map[string]string{"b": "2", "a": "1"},
+	}))
+	require.Equal(t, "-", inspect.FormatMechanism(nil))
+}
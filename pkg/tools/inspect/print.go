@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inspect
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// FormatPath returns the path as "name1(id1) -> name2(id2) -> ...", the current path segment is marked with "*"
+func FormatPath(path *networkservice.Path) string {
+	segments := make([]string, 0, len(path.GetPathSegments()))
+	for i, segment := range path.GetPathSegments() {
+		s := fmt.Sprintf("%s(%s)", segment.GetName(), segment.GetId())
+		if i == int(path.GetIndex()) {
+			s = "*" + s
+		}
+		segments = append(segments, s)
+	}
+	return strings.Join(segments, " -> ")
+}
+
+// FormatMechanism returns the mechanism as "CLS/TYPE key1=value1,key2=value2"
+func FormatMechanism(mechanism *networkservice.Mechanism) string {
+	if mechanism == nil {
+		return "-"
+	}
+	rv := mechanism.GetCls() + "/" + mechanism.GetType()
+	if params := formatMap(mechanism.GetParameters()); params != "" {
+		rv += " " + params
+	}
+	return rv
+}
+
+// PrintConnections prints the connections sorted by ID as a table
+func PrintConnections(w io.Writer, conns map[string]*networkservice.Connection) error {
+	ids := make([]string, 0, len(conns))
+	for id := range conns {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNETWORK SERVICE\tENDPOINT\tSTATE\tMECHANISM\tPATH")
+	for _, id := range ids {
+		conn := conns[id]
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			id, conn.GetNetworkService(), conn.GetNetworkServiceEndpointName(), conn.GetState(),
+			FormatMechanism(conn.GetMechanism()), FormatPath(conn.GetPath()))
+	}
+	return tw.Flush()
+}
+
+// PrintEvent prints the connection event, one connection per line
+func PrintEvent(w io.Writer, event *networkservice.ConnectionEvent) error {
+	ids := make([]string, 0, len(event.GetConnections()))
+	for id := range event.GetConnections() {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		conn := event.GetConnections()[id]
+		if _, err := fmt.Fprintf(w, "%s %s %s %s\n", event.GetType(), id, conn.GetState(), FormatPath(conn.GetPath())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintEndpoints prints the endpoints as a table
+func PrintEndpoints(w io.Writer, nses []*registry.NetworkServiceEndpoint) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tNETWORK SERVICES\tURL\tEXPIRES")
+	for _, nse := range nses {
+		expires := "-"
+		if nse.GetExpirationTime() != nil {
+			expires = nse.GetExpirationTime().AsTime().Local().String()
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			nse.GetName(), strings.Join(nse.GetNetworkServiceNames(), ","), nse.GetUrl(), expires)
+	}
+	return tw.Flush()
+}
+
+func formatMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+m[k])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipbinding provides the store of the connection ID to IP addresses bindings used by the IPAM chain elements
+// to give the same addresses to the clients reconnecting after the NSE restart.
+package ipbinding
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultRestoreGracePeriod is the default time the IPAM chain elements keep the restored binding for the connection to
+// be requested again. It matches the default token lifetime, so the client that hasn't refreshed the connection during
+// it is considered gone.
+const DefaultRestoreGracePeriod = 10 * time.Minute
+
+// Binding is the p2p addresses allocated for the connection, SrcAddrs[i] and DstAddrs[i] are the i-th pair
+type Binding struct {
+	SrcAddrs []string `json:"src_addrs"`
+	DstAddrs []string `json:"dst_addrs"`
+}
+
+// Store stores the bindings. The external stores (e.g. the Kubernetes ConfigMap) can be plugged into the IPAM chain
+// elements by implementing it.
+type Store interface {
+	// Load returns the binding of the connection
+	Load(id string) (*Binding, bool)
+	// Store stores the binding of the connection
+	Store(id string, binding *Binding) error
+	// Delete deletes the binding of the connection
+	Delete(id string) error
+	// Range calls f for each binding until f returns false
+	Range(f func(id string, binding *Binding) bool)
+}
+
+// MapStore is the in-memory Store optionally persisted to the file
+type MapStore struct {
+	path     string
+	bindings map[string]*Binding
+	mu       sync.Mutex
+}
+
+// Option is an option pattern for NewStore
+type Option func(s *MapStore)
+
+// WithPersistence makes the store save the bindings in the file at path and restore them on creation, so the bindings
+// survive the restart
+func WithPersistence(path string) Option {
+	return func(s *MapStore) {
+		s.path = path
+	}
+}
+
+// NewStore creates a new MapStore
+func NewStore(opts ...Option) (*MapStore, error) {
+	s := &MapStore{
+		bindings: make(map[string]*Binding),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load returns the binding of the connection
+func (s *MapStore) Load(id string) (*Binding, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	binding, ok := s.bindings[id]
+	return binding, ok
+}
+
+// Store stores the binding of the connection
+func (s *MapStore) Store(id string, binding *Binding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.bindings[id]
+	s.bindings[id] = binding
+	if err := s.save(); err != nil {
+		if ok {
+			s.bindings[id] = prev
+		} else {
+			delete(s.bindings, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete deletes the binding of the connection
+func (s *MapStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.bindings[id]; !ok {
+		return nil
+	}
+	delete(s.bindings, id)
+	return s.save()
+}
+
+// Range calls f for each binding until f returns false
+func (s *MapStore) Range(f func(id string, binding *Binding) bool) {
+	s.mu.Lock()
+	bindings := make(map[string]*Binding, len(s.bindings))
+	for id, binding := range s.bindings {
+		bindings[id] = binding
+	}
+	s.mu.Unlock()
+
+	for id, binding := range bindings {
+		if !f(id, binding) {
+			return
+		}
+	}
+}
+
+func (s *MapStore) load() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", s.path)
+	}
+	if err = json.Unmarshal(data, &s.bindings); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", s.path)
+	}
+	if s.bindings == nil {
+		s.bindings = make(map[string]*Binding)
+	}
+	return nil
+}
+
+func (s *MapStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.bindings)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal IP bindings")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for %s", s.path)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to write %s", tmp.Name())
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmp.Name())
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), s.path), "failed to replace %s", s.path)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipbinding_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/ipbinding"
+)
+
+func TestStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ipam.json")
+	binding := &ipbinding.Binding{
+		SrcAddrs: []string{"10.0.0.1/32"},
+		DstAddrs: []string{"10.0.0.0/32"},
+	}
+
+	s, err := ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+	require.NoError(t, s.Store("conn-1", binding))
+	require.NoError(t, s.Store("conn-2", binding))
+
+	// Bindings survive restart
+	s, err = ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+	loaded, ok := s.Load("conn-1")
+	require.True(t, ok)
+	require.Equal(t, binding, loaded)
+
+	require.NoError(t, s.Delete("conn-1"))
+	s, err = ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.NoError(t, err)
+	_, ok = s.Load("conn-1")
+	require.False(t, ok)
+
+	var ids []string
+	s.Range(func(id string, _ *ipbinding.Binding) bool {
+		ids = append(ids, id)
+		return true
+	})
+	require.Equal(t, []string{"conn-2"}, ids)
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	_, err = ipbinding.NewStore(ipbinding.WithPersistence(path))
+	require.Error(t, err)
+}
@@ -20,10 +20,11 @@
 package ippool
 
 import (
-	"errors"
 	"math"
 	"net"
 	"sync"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 type color bool
@@ -225,7 +226,7 @@ func (tree *IPPool) Pull() (net.IP, error) {
 
 	ip := tree.pull()
 	if ip == nil {
-		return nil, errors.New("IPPool is empty")
+		return nil, nsmerrors.New(nsmerrors.IPAMExhausted, "IPPool is empty")
 	}
 	return ipFromIPAddress(ip, tree.ipLength), nil
 }
@@ -243,12 +244,12 @@ func (tree *IPPool) PullP2PAddrs(exclude ...*IPPool) (srcNet, dstNet *net.IPNet,
 
 	srcIP := clone.pull()
 	if srcIP == nil {
-		return nil, nil, errors.New("IPPool is empty")
+		return nil, nil, nsmerrors.New(nsmerrors.IPAMExhausted, "IPPool is empty")
 	}
 
 	dstIP := clone.pull()
 	if dstIP == nil {
-		return nil, nil, errors.New("IPPool is empty")
+		return nil, nil, nsmerrors.New(nsmerrors.IPAMExhausted, "IPPool is empty")
 	}
 
 	tree.deleteRange(&ipRange{
@@ -273,6 +274,28 @@ func (tree *IPPool) PullP2PAddrs(exclude ...*IPPool) (srcNet, dstNet *net.IPNet,
 	return srcNet, dstNet, nil
 }
 
+// PullIP - removes the given IP address from the pool, returns an error if the pool doesn't contain it
+func (tree *IPPool) PullIP(ip net.IP) error {
+	if ip == nil || tree.ipLength != len(ip) {
+		return nsmerrors.Errorf(nsmerrors.IPAMExhausted, "IPPool doesn't contain %v", ip)
+	}
+
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+
+	ipAddr := ipAddressFromIP(ip)
+	if tree.lookup(ipAddr) == nil {
+		return nsmerrors.Errorf(nsmerrors.IPAMExhausted, "IPPool doesn't contain %v", ip)
+	}
+
+	tree.deleteRange(&ipRange{
+		start: ipAddr.Clone(),
+		end:   ipAddr.Clone(),
+	})
+
+	return nil
+}
+
 // GetPrefixes returns the list of saved prefixes
 func (tree *IPPool) GetPrefixes() []string {
 	tree.lock.Lock()
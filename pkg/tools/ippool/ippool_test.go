@@ -196,6 +196,23 @@ func TestIPPoolTool_Pull(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestIPPoolTool_PullIP(t *testing.T) {
+	ipPool := NewWithNetString("192.0.0.0/30")
+	require.NotNil(t, ipPool)
+
+	require.NoError(t, ipPool.PullIP(net.ParseIP("192.0.0.1").To4()))
+	require.Error(t, ipPool.PullIP(net.ParseIP("192.0.0.1").To4()))
+	require.Error(t, ipPool.PullIP(net.ParseIP("192.0.0.4").To4()))
+	require.Error(t, ipPool.PullIP(net.ParseIP("fe80::1")))
+
+	ip, err := ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, ip.String(), "192.0.0.0")
+	ip, err = ipPool.Pull()
+	require.NoError(t, err)
+	require.Equal(t, ip.String(), "192.0.0.2")
+}
+
 //nolint:dupl
 func TestIPPoolTool_GetPrefixes(t *testing.T) {
 	ipPool := NewWithNetString("192.0.0.0/16")
@@ -0,0 +1,240 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchutils
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type operator int
+
+const (
+	opEquals operator = iota
+	opNotEquals
+	opIn
+	opNotIn
+	opExists
+	opNotExists
+)
+
+type requirement struct {
+	key    string
+	op     operator
+	values []string
+}
+
+func (r *requirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+	switch r.op {
+	case opEquals:
+		return ok && value == r.values[0]
+	case opNotEquals:
+		return !ok || value != r.values[0]
+	case opIn:
+		return ok && containsString(r.values, value)
+	case opNotIn:
+		return !ok || !containsString(r.values, value)
+	case opExists:
+		return ok
+	default:
+		return !ok
+	}
+}
+
+// LabelSelector is a parsed label selector expression. Similar to Kubernetes, the expression is a comma separated
+// list of the requirements all of which must be met:
+//
+//	key=value, key==value   - the label is set to the value
+//	key!=value              - the label is not set or is set to another value
+//	key in (v1,v2)          - the label is set to one of the values
+//	key notin (v1,v2)       - the label is not set or is set to none of the values
+//	key                     - the label is set
+//	!key                    - the label is not set
+//
+// Empty expression matches everything.
+type LabelSelector struct {
+	requirements []*requirement
+}
+
+// ParseLabelSelector parses the label selector expression
+func ParseLabelSelector(expr string) (*LabelSelector, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range expr {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth < 0 {
+				return nil, errors.Errorf("unexpected ')' in label selector: %s", expr)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, errors.Errorf("unclosed '(' in label selector: %s", expr)
+	}
+	parts = append(parts, expr[start:])
+
+	s := new(LabelSelector)
+	if len(parts) == 1 && strings.TrimSpace(parts[0]) == "" {
+		return s, nil
+	}
+	for _, part := range parts {
+		r, err := parseRequirement(strings.TrimSpace(part))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid label selector: %s", expr)
+		}
+		s.requirements = append(s.requirements, r)
+	}
+	return s, nil
+}
+
+// Matches returns true if the labels meet all the requirements of the selector
+func (s *LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s.requirements {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchNetworkServiceEndpointLabels returns true if the labels of any of the services of the NSE match the selector.
+// If services are empty, all the NSE services are checked. Nil selector matches every NSE.
+func MatchNetworkServiceEndpointLabels(selector *LabelSelector, services []string, nse *registry.NetworkServiceEndpoint) bool {
+	if selector == nil {
+		return true
+	}
+	if len(services) == 0 {
+		for service := range nse.GetNetworkServiceLabels() {
+			services = append(services, service)
+		}
+		if len(services) == 0 {
+			return selector.Matches(nil)
+		}
+	}
+	for _, service := range services {
+		if selector.Matches(nse.GetNetworkServiceLabels()[service].GetLabels()) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRequirement(s string) (*requirement, error) {
+	if i := strings.Index(s, "("); i >= 0 {
+		return parseSetRequirement(s[:i], s[i:])
+	}
+	for _, op := range []struct {
+		token string
+		op    operator
+	}{
+		{"!=", opNotEquals},
+		{"==", opEquals},
+		{"=", opEquals},
+	} {
+		if i := strings.Index(s, op.token); i >= 0 {
+			key, value := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(op.token):])
+			if err := validateKey(key); err != nil {
+				return nil, err
+			}
+			if err := validateValue(value); err != nil {
+				return nil, err
+			}
+			return &requirement{key: key, op: op.op, values: []string{value}}, nil
+		}
+	}
+	if strings.HasPrefix(s, "!") {
+		key := strings.TrimSpace(s[1:])
+		if err := validateKey(key); err != nil {
+			return nil, err
+		}
+		return &requirement{key: key, op: opNotExists}, nil
+	}
+	if err := validateKey(s); err != nil {
+		return nil, err
+	}
+	return &requirement{key: s, op: opExists}, nil
+}
+
+func parseSetRequirement(head, set string) (*requirement, error) {
+	fields := strings.Fields(head)
+	if len(fields) != 2 {
+		return nil, errors.Errorf("expected 'key in (...)' or 'key notin (...)': %s", head+set)
+	}
+	r := &requirement{key: fields[0]}
+	switch fields[1] {
+	case "in":
+		r.op = opIn
+	case "notin":
+		r.op = opNotIn
+	default:
+		return nil, errors.Errorf("unknown set operator: %s", fields[1])
+	}
+	if err := validateKey(r.key); err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(set, ")") {
+		return nil, errors.Errorf("unexpected characters after ')': %s", set)
+	}
+	for _, value := range strings.Split(set[1:len(set)-1], ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, errors.Errorf("empty value in the set: %s", set)
+		}
+		if err := validateValue(value); err != nil {
+			return nil, err
+		}
+		r.values = append(r.values, value)
+	}
+	return r, nil
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("empty label key")
+	}
+	if strings.ContainsAny(key, " \t!=(),") {
+		return errors.Errorf("invalid label key: %q", key)
+	}
+	return nil
+}
+
+func validateValue(value string) error {
+	if strings.ContainsAny(value, " \t!=(),") {
+		return errors.Errorf("invalid label value: %q", value)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matchutils_test
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{
+		"app":  "firewall",
+		"zone": "east",
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "", want: true},
+		{expr: "app=firewall", want: true},
+		{expr: "app == firewall", want: true},
+		{expr: "app=vpn", want: false},
+		{expr: "app!=vpn", want: true},
+		{expr: "tier!=gold", want: true},
+		{expr: "zone in (east, west)", want: true},
+		{expr: "zone in (west)", want: false},
+		{expr: "zone notin (west)", want: true},
+		{expr: "tier notin (gold)", want: true},
+		{expr: "app", want: true},
+		{expr: "tier", want: false},
+		{expr: "!tier", want: true},
+		{expr: "!app", want: false},
+		{expr: "app=firewall, zone in (east), !canary", want: true},
+		{expr: "app=firewall,zone notin (east)", want: false},
+	}
+
+	for _, tc := range tests {
+		selector, err := matchutils.ParseLabelSelector(tc.expr)
+		require.NoError(t, err, tc.expr)
+		require.Equal(t, tc.want, selector.Matches(labels), tc.expr)
+	}
+}
+
+func TestLabelSelector_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"app=firewall,",
+		"=firewall",
+		"zone in (east",
+		"zone in east)",
+		"zone in (east,)",
+		"zone inside (east)",
+		"zone in (east) x",
+		"!",
+		"app=fire wall",
+	} {
+		_, err := matchutils.ParseLabelSelector(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestMatchNetworkServiceEndpointLabels(t *testing.T) {
+	nse := &registry.NetworkServiceEndpoint{
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns-1": {Labels: map[string]string{"app": "firewall"}},
+			"ns-2": {Labels: map[string]string{"app": "vpn"}},
+		},
+	}
+
+	selector, err := matchutils.ParseLabelSelector("app=vpn")
+	require.NoError(t, err)
+
+	require.True(t, matchutils.MatchNetworkServiceEndpointLabels(nil, nil, nse))
+	require.True(t, matchutils.MatchNetworkServiceEndpointLabels(selector, nil, nse))
+	require.True(t, matchutils.MatchNetworkServiceEndpointLabels(selector, []string{"ns-2"}, nse))
+	require.False(t, matchutils.MatchNetworkServiceEndpointLabels(selector, []string{"ns-1"}, nse))
+	require.False(t, matchutils.MatchNetworkServiceEndpointLabels(selector, nil, new(registry.NetworkServiceEndpoint)))
+}
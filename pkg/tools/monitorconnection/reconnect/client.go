@@ -0,0 +1,187 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reconnect provides a MonitorConnectionClient transparently re-establishing the broken monitor streams. The
+// changes missed during the gap are detected by comparing the new initial state transfer with the cached state and are
+// sent as the synthetic DELETE and UPDATE events.
+package reconnect
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultRetryInterval = time.Second
+
+type reconnectClient struct {
+	client        networkservice.MonitorConnectionClient
+	retryInterval time.Duration
+}
+
+// NewClient wraps the client, so the returned streams are re-established with the same selector until their context
+// is done
+func NewClient(client networkservice.MonitorConnectionClient, opts ...Option) networkservice.MonitorConnectionClient {
+	c := &reconnectClient{
+		client:        client,
+		retryInterval: defaultRetryInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *reconnectClient) MonitorConnections(ctx context.Context, selector *networkservice.MonitorScopeSelector, opts ...grpc.CallOption) (networkservice.MonitorConnection_MonitorConnectionsClient, error) {
+	stream, err := c.client.MonitorConnections(ctx, selector, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &reconnectStream{
+		reconnectClient: c,
+		ctx:             ctx,
+		selector:        selector,
+		opts:            opts,
+		stream:          stream,
+		connections:     make(map[string]*networkservice.Connection),
+	}, nil
+}
+
+type reconnectStream struct {
+	grpc.ClientStream
+	*reconnectClient
+
+	ctx      context.Context
+	selector *networkservice.MonitorScopeSelector
+	opts     []grpc.CallOption
+
+	stream networkservice.MonitorConnection_MonitorConnectionsClient
+	// synced is set after the first initial state transfer is received
+	synced      bool
+	connections map[string]*networkservice.Connection
+	queue       []*networkservice.ConnectionEvent
+}
+
+func (s *reconnectStream) Recv() (*networkservice.ConnectionEvent, error) {
+	for len(s.queue) == 0 {
+		if s.stream == nil {
+			if err := s.reconnect(); err != nil {
+				return nil, err
+			}
+		}
+
+		event, err := s.stream.Recv()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return nil, err
+			}
+			log.FromContext(s.ctx).WithField("reconnectStream", "Recv").Warnf("monitor stream is broken, reconnecting: %s", err.Error())
+			s.stream = nil
+			continue
+		}
+		s.handle(event)
+	}
+
+	event := s.queue[0]
+	s.queue = s.queue[1:]
+	return event, nil
+}
+
+func (s *reconnectStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *reconnectStream) reconnect() error {
+	clockTime := clock.FromContext(s.ctx)
+	for {
+		select {
+		case <-s.ctx.Done():
+			return errors.Wrap(s.ctx.Err(), "failed to reconnect monitor stream")
+		case <-clockTime.After(s.retryInterval):
+		}
+
+		stream, err := s.client.MonitorConnections(s.ctx, s.selector, s.opts...)
+		if err == nil {
+			s.stream = stream
+			return nil
+		}
+		log.FromContext(s.ctx).WithField("reconnectStream", "reconnect").Warnf("failed to reconnect monitor stream: %s", err.Error())
+	}
+}
+
+func (s *reconnectStream) handle(event *networkservice.ConnectionEvent) {
+	switch event.GetType() {
+	case networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER:
+		if s.synced {
+			s.queue = append(s.queue, s.diff(event.GetConnections())...)
+		} else {
+			s.synced = true
+			s.queue = append(s.queue, event)
+		}
+		s.connections = make(map[string]*networkservice.Connection, len(event.GetConnections()))
+		for id, conn := range event.GetConnections() {
+			s.connections[id] = conn.Clone()
+		}
+	case networkservice.ConnectionEventType_UPDATE:
+		for id, conn := range event.GetConnections() {
+			s.connections[id] = conn.Clone()
+		}
+		s.queue = append(s.queue, event)
+	case networkservice.ConnectionEventType_DELETE:
+		for id := range event.GetConnections() {
+			delete(s.connections, id)
+		}
+		s.queue = append(s.queue, event)
+	}
+}
+
+// diff returns the DELETE and UPDATE events for the changes between the cached state and the new state
+func (s *reconnectStream) diff(connections map[string]*networkservice.Connection) []*networkservice.ConnectionEvent {
+	deleted := make(map[string]*networkservice.Connection)
+	for id, conn := range s.connections {
+		if _, ok := connections[id]; !ok {
+			deleted[id] = conn
+		}
+	}
+	updated := make(map[string]*networkservice.Connection)
+	for id, conn := range connections {
+		if cached, ok := s.connections[id]; !ok || !proto.Equal(cached, conn) {
+			updated[id] = conn
+		}
+	}
+
+	var events []*networkservice.ConnectionEvent
+	if len(deleted) > 0 {
+		events = append(events, &networkservice.ConnectionEvent{
+			Type:        networkservice.ConnectionEventType_DELETE,
+			Connections: deleted,
+		})
+	}
+	if len(updated) > 0 {
+		events = append(events, &networkservice.ConnectionEvent{
+			Type:        networkservice.ConnectionEventType_UPDATE,
+			Connections: updated,
+		})
+	}
+	return events
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconnect_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/reconnect"
+)
+
+type testStream struct {
+	grpc.ClientStream
+	events []*networkservice.ConnectionEvent
+}
+
+func (s *testStream) Recv() (*networkservice.ConnectionEvent, error) {
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	event := s.events[0]
+	s.events = s.events[1:]
+	return event, nil
+}
+
+// testClient returns the streams one by one, nil stream means the connection failure
+type testClient struct {
+	streams   []*testStream
+	selectors []*networkservice.MonitorScopeSelector
+}
+
+func (c *testClient) MonitorConnections(ctx context.Context, selector *networkservice.MonitorScopeSelector, _ ...grpc.CallOption) (networkservice.MonitorConnection_MonitorConnectionsClient, error) {
+	c.selectors = append(c.selectors, selector)
+	if len(c.streams) == 0 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	stream := c.streams[0]
+	c.streams = c.streams[1:]
+	if stream == nil {
+		return nil, errors.New("connection refused")
+	}
+	return stream, nil
+}
+
+func event(eventType networkservice.ConnectionEventType, conns ...*networkservice.Connection) *networkservice.ConnectionEvent {
+	rv := &networkservice.ConnectionEvent{
+		Type:        eventType,
+		Connections: make(map[string]*networkservice.Connection),
+	}
+	for _, conn := range conns {
+		rv.Connections[conn.GetId()] = conn
+	}
+	return rv
+}
+
+func TestReconnectClient_GapDetection(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	a := &networkservice.Connection{Id: "a", State: networkservice.State_UP}
+	a2 := &networkservice.Connection{Id: "a", State: networkservice.State_DOWN}
+	b := &networkservice.Connection{Id: "b", State: networkservice.State_UP}
+	b2 := &networkservice.Connection{Id: "b", State: networkservice.State_DOWN}
+	c := &networkservice.Connection{Id: "c", State: networkservice.State_UP}
+
+	client := &testClient{
+		streams: []*testStream{
+			{events: []*networkservice.ConnectionEvent{
+				event(networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, a, b),
+				event(networkservice.ConnectionEventType_UPDATE, a2),
+			}},
+			nil,
+			{events: []*networkservice.ConnectionEvent{
+				event(networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, b2, c),
+				event(networkservice.ConnectionEventType_DELETE, c),
+			}},
+		},
+	}
+
+	selector := &networkservice.MonitorScopeSelector{
+		PathSegments: []*networkservice.PathSegment{{Name: "nsmgr"}},
+	}
+	stream, err := reconnect.NewClient(client, reconnect.WithRetryInterval(time.Millisecond)).MonitorConnections(ctx, selector)
+	require.NoError(t, err)
+
+	for _, expected := range []*networkservice.ConnectionEvent{
+		event(networkservice.ConnectionEventType_INITIAL_STATE_TRANSFER, a, b),
+		event(networkservice.ConnectionEventType_UPDATE, a2),
+		// Synthetic events for the changes missed during the gap
+		event(networkservice.ConnectionEventType_DELETE, a2),
+		event(networkservice.ConnectionEventType_UPDATE, b2, c),
+		event(networkservice.ConnectionEventType_DELETE, c),
+	} {
+		actual, recvErr := stream.Recv()
+		require.NoError(t, recvErr)
+		require.Equal(t, expected.String(), actual.String())
+	}
+
+	require.Len(t, client.selectors, 3)
+	for _, s := range client.selectors {
+		require.Equal(t, selector, s)
+	}
+
+	cancel()
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
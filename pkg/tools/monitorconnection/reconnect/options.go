@@ -0,0 +1,30 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconnect
+
+import "time"
+
+// Option is an option pattern for NewClient
+type Option func(c *reconnectClient)
+
+// WithRetryInterval sets the interval between the reconnection attempts
+// By default 1 second
+func WithRetryInterval(retryInterval time.Duration) Option {
+	return func(c *reconnectClient) {
+		c.retryInterval = retryInterval
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netns provides utilities validating and watching the network namespaces referenced by the mechanism netns
+// URLs, so dangling references are detected early and the connections using them can be healed or cleaned up.
+package netns
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+const (
+	fileScheme  = "file"
+	inodeScheme = "inode"
+)
+
+// Validate returns an error if the netns URL is malformed or the file referenced by the file:// URL doesn't exist or is
+// not a network namespace. inode://${dev}/${ino} URLs can't be validated locally, so only their format is checked.
+func Validate(netnsURL string) error {
+	u, err := url.Parse(netnsURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse netns URL %s", netnsURL)
+	}
+	switch u.Scheme {
+	case fileScheme:
+		if _, err = os.Stat(u.Path); err != nil {
+			return errors.Wrapf(err, "network namespace %s doesn't exist", netnsURL)
+		}
+		return checkNetNS(u.Path)
+	case inodeScheme:
+		if _, err = strconv.ParseUint(u.Host, 10, 64); err != nil {
+			return errors.Wrapf(err, "invalid device of netns URL %s", netnsURL)
+		}
+		if _, err = strconv.ParseUint(strings.TrimPrefix(u.Path, "/"), 10, 64); err != nil {
+			return errors.Wrapf(err, "invalid inode of netns URL %s", netnsURL)
+		}
+		return nil
+	default:
+		return errors.Errorf("unsupported scheme of netns URL %s", netnsURL)
+	}
+}
+
+// Watch returns a channel closed when the network namespace referenced by the file:// netns URL disappears or is
+// replaced by another one. The file is checked every interval until ctx is done.
+func Watch(ctx context.Context, netnsURL string, interval time.Duration) (<-chan struct{}, error) {
+	u, err := url.Parse(netnsURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse netns URL %s", netnsURL)
+	}
+	if u.Scheme != fileScheme {
+		return nil, errors.Errorf("only file:// netns URLs can be watched: %s", netnsURL)
+	}
+	info, err := os.Stat(u.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "network namespace %s doesn't exist", netnsURL)
+	}
+
+	goneCh := make(chan struct{})
+	ticker := clock.FromContext(ctx).Ticker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				if current, statErr := os.Stat(u.Path); statErr != nil || !os.SameFile(info, current) {
+					close(goneCh)
+					return
+				}
+			}
+		}
+	}()
+	return goneCh, nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netns
+
+import (
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// nsfsMagic is the filesystem type of the namespace files
+const nsfsMagic = 0x6e736673
+
+func checkNetNS(path string) error {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return errors.Wrapf(err, "failed to statfs %s", path)
+	}
+	if uint64(stat.Type) != nsfsMagic {
+		return errors.Errorf("%s is not a namespace file", path)
+	}
+	return nil
+}
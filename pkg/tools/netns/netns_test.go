@@ -0,0 +1,92 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netns_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/netns"
+)
+
+func fileURL(path string) string {
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, netns.Validate(fileURL("/proc/self/ns/net")))
+	require.NoError(t, netns.Validate("inode://4/4026531992"))
+
+	require.Error(t, netns.Validate(fileURL(filepath.Join(t.TempDir(), "missing"))))
+	require.Error(t, netns.Validate(fileURL("/proc/self/status")))
+	require.Error(t, netns.Validate("inode://4/net"))
+	require.Error(t, netns.Validate("unix:///proc/self/ns/net"))
+}
+
+func TestResolve(t *testing.T) {
+	dev, ino, err := netns.Resolve(fileURL("/proc/self/ns/net"))
+	require.NoError(t, err)
+	require.NotZero(t, ino)
+
+	inodeDev, inodeIno, err := netns.Resolve(fmt.Sprintf("inode://%d/%d", dev, ino))
+	require.NoError(t, err)
+	require.Equal(t, dev, inodeDev)
+	require.Equal(t, ino, inodeIno)
+}
+
+func TestWatch(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "net")
+	require.NoError(t, os.Symlink("/proc/self/ns/net", path))
+
+	goneCh, err := netns.Watch(ctx, fileURL(path), time.Millisecond)
+	require.NoError(t, err)
+
+	select {
+	case <-goneCh:
+		require.FailNow(t, "namespace is not gone yet")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	require.NoError(t, os.Remove(path))
+	require.Eventually(t, func() bool {
+		select {
+		case <-goneCh:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	_, err = netns.Watch(ctx, "inode://4/4026531992", time.Millisecond)
+	require.Error(t, err)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package netns
+
+import (
+	"net/url"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/pkg/errors"
+)
+
+// Resolve returns the device and the inode of the network namespace referenced by the netns URL
+func Resolve(netnsURL string) (dev, ino uint64, err error) {
+	if err = Validate(netnsURL); err != nil {
+		return 0, 0, err
+	}
+	u, err := url.Parse(netnsURL)
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	if u.Scheme == fileScheme {
+		if u, err = grpcfd.FilenameToURL(u.Path); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to resolve netns URL %s", netnsURL)
+		}
+	}
+	return grpcfd.URLToDevIno(u)
+}
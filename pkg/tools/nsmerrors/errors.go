@@ -0,0 +1,204 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nsmerrors provides typed errors for the common NSM failure conditions. Each error belongs to a Class mapped
+// to a gRPC status code and carries retriability metadata, so the class survives the gRPC boundary and clients can
+// program against error classes instead of matching error strings.
+package nsmerrors
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the errdetails.ErrorInfo domain used for the NSM errors
+const Domain = "networkservicemesh.io"
+
+// RetriableKey is the errdetails.ErrorInfo metadata key storing the retriability of the error
+const RetriableKey = "retriable"
+
+// Class is an error class
+type Class string
+
+const (
+	// Unknown is a class of the errors not created by this package
+	Unknown Class = ""
+	// IPAMExhausted - there are no free addresses left in the IP pool
+	IPAMExhausted Class = "IPAM_EXHAUSTED"
+	// NoCandidates - there are no Network Service Endpoints or forwarders able to serve the request
+	NoCandidates Class = "NO_CANDIDATES"
+	// MechanismUnsupported - none of the requested mechanisms are supported
+	MechanismUnsupported Class = "MECHANISM_UNSUPPORTED"
+	// PolicyDenied - the request is denied by the policy
+	PolicyDenied Class = "POLICY_DENIED"
+	// DatapathFailure - the datapath failed to setup the connection
+	DatapathFailure Class = "DATAPATH_FAILURE"
+	// ContextTooLarge - the connection context exceeds the size limit
+	ContextTooLarge Class = "CONTEXT_TOO_LARGE"
+)
+
+type classInfo struct {
+	code      codes.Code
+	retriable bool
+}
+
+var classes = map[Class]classInfo{
+	IPAMExhausted:        {code: codes.ResourceExhausted, retriable: true},
+	NoCandidates:         {code: codes.Unavailable, retriable: true},
+	MechanismUnsupported: {code: codes.FailedPrecondition, retriable: false},
+	PolicyDenied:         {code: codes.PermissionDenied, retriable: false},
+	DatapathFailure:      {code: codes.Internal, retriable: true},
+	ContextTooLarge:      {code: codes.ResourceExhausted, retriable: false},
+}
+
+// Code returns the gRPC status code of the class
+func (c Class) Code() codes.Code {
+	if info, ok := classes[c]; ok {
+		return info.code
+	}
+	return codes.Unknown
+}
+
+// Retriable returns true if the request failed with the class error can be retried
+func (c Class) Retriable() bool {
+	return classes[c].retriable
+}
+
+// Error is an error of some Class
+type Error struct {
+	class Class
+	msg   string
+	cause error
+}
+
+// New returns a new class error with the message
+func New(class Class, msg string) error {
+	return &Error{class: class, msg: msg}
+}
+
+// Errorf returns a new class error with the formatted message
+func Errorf(class Class, format string, args ...interface{}) error {
+	return &Error{class: class, msg: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns a new class error with the message caused by err
+func Wrap(err error, class Class, msg string) error {
+	return &Error{class: class, msg: msg, cause: err}
+}
+
+// WithClass returns a new class error with the same message as err. It is useful to make the class error the
+// outermost one, so it is not lost when err is converted to the gRPC status.
+func WithClass(err error, class Class) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{class: class, cause: err}
+}
+
+// Error returns the error message
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.msg
+	case e.msg == "":
+		return e.cause.Error()
+	}
+	return e.msg + ": " + e.cause.Error()
+}
+
+// Class returns the error class
+func (e *Error) Class() Class {
+	return e.class
+}
+
+// Cause returns the error cause
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+// Unwrap returns the error cause
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// GRPCStatus returns the gRPC status of the error with the class and retriability stored in the errdetails.ErrorInfo
+func (e *Error) GRPCStatus() *status.Status {
+	s := status.New(e.class.Code(), e.Error())
+	if withDetails, err := s.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(e.class),
+		Domain: Domain,
+		Metadata: map[string]string{
+			RetriableKey: strconv.FormatBool(e.class.Retriable()),
+		},
+	}); err == nil {
+		return withDetails
+	}
+	return s
+}
+
+// ClassOf returns the class of err. It works for both the local errors and the errors received over gRPC.
+func ClassOf(err error) Class {
+	if err == nil {
+		return Unknown
+	}
+	var classErr *Error
+	if errors.As(err, &classErr) {
+		return classErr.class
+	}
+	if info := errorInfo(err); info != nil {
+		return Class(info.GetReason())
+	}
+	return Unknown
+}
+
+// Is returns true if err is of the class
+func Is(err error, class Class) bool {
+	return err != nil && ClassOf(err) == class
+}
+
+// IsRetriable returns true if err is a class error that can be retried
+func IsRetriable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var classErr *Error
+	if errors.As(err, &classErr) {
+		return classErr.class.Retriable()
+	}
+	if info := errorInfo(err); info != nil {
+		retriable, _ := strconv.ParseBool(info.GetMetadata()[RetriableKey])
+		return retriable
+	}
+	return false
+}
+
+func errorInfo(err error) *errdetails.ErrorInfo {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return nil
+	}
+	for _, detail := range grpcErr.GRPCStatus().Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+			return info
+		}
+	}
+	return nil
+}
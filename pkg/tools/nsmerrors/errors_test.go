@@ -0,0 +1,65 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmerrors_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
+)
+
+func TestError_Local(t *testing.T) {
+	err := errors.Wrap(nsmerrors.New(nsmerrors.IPAMExhausted, "IPPool is empty"), "failed to allocate IP")
+
+	require.Equal(t, "failed to allocate IP: IPPool is empty", err.Error())
+	require.Equal(t, nsmerrors.IPAMExhausted, nsmerrors.ClassOf(err))
+	require.True(t, nsmerrors.Is(err, nsmerrors.IPAMExhausted))
+	require.True(t, nsmerrors.IsRetriable(err))
+
+	require.Equal(t, nsmerrors.Unknown, nsmerrors.ClassOf(errors.New("error")))
+	require.False(t, nsmerrors.IsRetriable(errors.New("error")))
+	require.False(t, nsmerrors.Is(nil, nsmerrors.Unknown))
+}
+
+func TestError_GRPCStatus(t *testing.T) {
+	cause := errors.New("cause")
+	err := nsmerrors.WithClass(errors.Wrap(cause, "policy"), nsmerrors.PolicyDenied)
+
+	require.Equal(t, "policy: cause", err.Error())
+	require.Equal(t, cause, errors.Cause(err))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Emulate the error received over gRPC
+	remoteErr := status.FromProto(status.Convert(err).Proto()).Err()
+
+	require.Equal(t, "rpc error: code = PermissionDenied desc = policy: cause", remoteErr.Error())
+	require.Equal(t, nsmerrors.PolicyDenied, nsmerrors.ClassOf(remoteErr))
+	require.False(t, nsmerrors.IsRetriable(remoteErr))
+
+	remoteErr = status.Convert(nsmerrors.Errorf(nsmerrors.NoCandidates, "no candidates for %s", "ns")).Err()
+
+	require.Equal(t, codes.Unavailable, status.Code(remoteErr))
+	require.True(t, nsmerrors.Is(errors.WithStack(remoteErr), nsmerrors.NoCandidates))
+	require.True(t, nsmerrors.IsRetriable(remoteErr))
+
+	require.Equal(t, nsmerrors.Unknown, nsmerrors.ClassOf(status.Error(codes.Unavailable, "unavailable")))
+}
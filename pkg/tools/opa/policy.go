@@ -27,6 +27,8 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/nsmerrors"
 )
 
 // CheckAccessFunc checks rego result. Returns bool flag that means access. Returns error if something was wrong
@@ -93,6 +95,7 @@ type AuthorizationPolicy struct {
 	evalQuery      *rego.PreparedEvalQuery
 	checker        CheckAccessFunc
 	once           sync.Once
+	mu             sync.RWMutex
 }
 
 // Check returns nil if passed tokens are valid
@@ -104,7 +107,10 @@ func (d *AuthorizationPolicy) Check(ctx context.Context, model interface{}) erro
 	if intErr := d.init(); intErr != nil {
 		return intErr
 	}
-	rs, err := d.evalQuery.Eval(ctx, rego.EvalInput(input))
+	d.mu.RLock()
+	evalQuery := d.evalQuery
+	d.mu.RUnlock()
+	rs, err := evalQuery.Eval(ctx, rego.EvalInput(input))
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -113,13 +119,46 @@ func (d *AuthorizationPolicy) Check(ctx context.Context, model interface{}) erro
 		return status.Error(codes.Internal, err.Error())
 	}
 	if !hasAccess {
-		return status.Error(codes.PermissionDenied, "no sufficient privileges")
+		return nsmerrors.New(nsmerrors.PolicyDenied, "no sufficient privileges")
+	}
+	return nil
+}
+
+// FilePath returns the policy source file path, it is empty for the policies created from the source
+func (d *AuthorizationPolicy) FilePath() string {
+	return d.policyFilePath
+}
+
+// Reload replaces the policy source, e.g. on the policy file change. The current policy is kept if the new source
+// is invalid.
+func (d *AuthorizationPolicy) Reload(source string) error {
+	_ = d.init()
+
+	source = strings.TrimSpace(source)
+	pkg, err := packageOf(source)
+	if err != nil {
+		return err
+	}
+	evalQuery, err := prepare(pkg, d.query, source)
+	if err != nil {
+		return err
 	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.policySource = source
+	d.pkg = pkg
+	d.evalQuery = evalQuery
+	d.initErr = nil
 	return nil
 }
 
 func (d *AuthorizationPolicy) init() error {
 	d.once.Do(func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
 		if d.query == "" {
 			d.query = strings.TrimSuffix(filepath.Base(d.policyFilePath), filepath.Ext(d.policyFilePath))
 		}
@@ -129,15 +168,12 @@ func (d *AuthorizationPolicy) init() error {
 		if d.initErr = d.checkModule(); d.initErr != nil {
 			return
 		}
-		var r rego.PreparedEvalQuery
-		r, d.initErr = rego.New(
-			rego.Query(strings.Join([]string{"data", d.pkg, d.query}, ".")),
-			rego.Module(d.pkg, d.policySource)).PrepareForEval(context.Background())
-		if d.initErr != nil {
-			return
-		}
-		d.evalQuery = &r
+		d.evalQuery, d.initErr = prepare(d.pkg, d.query, d.policySource)
 	})
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
 	if d.initErr != nil {
 		return d.initErr
 	}
@@ -160,17 +196,31 @@ func (d *AuthorizationPolicy) loadSource() error {
 	return nil
 }
 
-func (d *AuthorizationPolicy) checkModule() error {
+func (d *AuthorizationPolicy) checkModule() (err error) {
 	if d.pkg != "" {
 		return nil
 	}
+	d.pkg, err = packageOf(d.policySource)
+	return err
+}
+
+func packageOf(source string) (string, error) {
 	const pkg = "package"
-	lines := strings.Split(d.policySource, "\n")
+	lines := strings.Split(source, "\n")
 	for i := 0; i < len(lines); i++ {
 		if strings.HasPrefix(lines[i], pkg) {
-			d.pkg = strings.TrimSpace(lines[i][len(pkg):])
-			return nil
+			return strings.TrimSpace(lines[i][len(pkg):]), nil
 		}
 	}
-	return errors.New("missed package")
+	return "", errors.New("missed package")
+}
+
+func prepare(pkg, query, source string) (*rego.PreparedEvalQuery, error) {
+	r, err := rego.New(
+		rego.Query(strings.Join([]string{"data", pkg, query}, ".")),
+		rego.Module(pkg, source)).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
 }
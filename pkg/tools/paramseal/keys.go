@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramseal
+
+import (
+	"crypto/rand"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// KeySize is the size of the keys generated by RandomKey
+const KeySize = 32
+
+// KeyProvider provides the process-level AES key (16, 24 or 32 bytes)
+type KeyProvider interface {
+	Key() ([]byte, error)
+}
+
+// KeyProviderFunc is a function adapter for KeyProvider
+type KeyProviderFunc func() ([]byte, error)
+
+// Key calls f()
+func (f KeyProviderFunc) Key() ([]byte, error) {
+	return f()
+}
+
+// StaticKey returns a KeyProvider providing the key
+func StaticKey(key []byte) KeyProvider {
+	return KeyProviderFunc(func() ([]byte, error) {
+		return key, nil
+	})
+}
+
+// FileKey returns a KeyProvider reading the key from the file, e.g. from a mounted Kubernetes secret. The file is
+// read on every call, so the secret can be rotated, but the data sealed with the previous key can't be opened then.
+func FileKey(path string) KeyProvider {
+	return KeyProviderFunc(func() ([]byte, error) {
+		key, err := os.ReadFile(path) // #nosec G304 - the path is set by the application
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read key file %s", path)
+		}
+		return key, nil
+	})
+}
+
+// RandomKey returns a KeyProvider providing the key randomly generated on the first call. The data sealed with it can
+// be opened only by the same process, so it fits the per-connection metadata but not the persisted state.
+func RandomKey() KeyProvider {
+	var once sync.Once
+	var key []byte
+	var err error
+	return KeyProviderFunc(func() ([]byte, error) {
+		once.Do(func() {
+			key = make([]byte, KeySize)
+			if _, err = io.ReadFull(rand.Reader, key); err != nil {
+				err = errors.Wrap(err, "failed to generate key")
+			}
+		})
+		return key, err
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramseal
+
+import "strings"
+
+// Option is an option pattern for NewSealer
+type Option func(s *Sealer)
+
+// WithSensitiveParameters sets the substrings of the mechanism parameter keys which values should be sealed, matching
+// is case insensitive
+func WithSensitiveParameters(substrings ...string) Option {
+	return func(s *Sealer) {
+		s.parameterKeys = nil
+		for _, substr := range substrings {
+			s.parameterKeys = append(s.parameterKeys, strings.ToLower(substr))
+		}
+	}
+}
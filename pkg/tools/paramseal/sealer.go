@@ -0,0 +1,149 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package paramseal provides encryption at rest of the sensitive mechanism parameters (keys, tokens) and other
+// secrets stored in the per-connection metadata or in the persisted state, limiting the exposure if state dumps or
+// checkpoints leak. The data is encrypted with AES-GCM using the process-level key from the KeyProvider.
+package paramseal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+)
+
+// Prefix is the prefix of the sealed parameter values
+const Prefix = "sealed:v1:"
+
+// Sealer encrypts and decrypts the secrets
+type Sealer struct {
+	keyProvider   KeyProvider
+	parameterKeys []string
+}
+
+// NewSealer creates a new Sealer using the key from the keyProvider. By default it seals all mechanism parameters
+// which keys contain "private", "secret" or "psk" (such as Wireguard private data).
+func NewSealer(keyProvider KeyProvider, opts ...Option) *Sealer {
+	s := &Sealer{
+		keyProvider:   keyProvider,
+		parameterKeys: []string{"private", "secret", "psk"},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Seal encrypts the data, the additionalData is authenticated but not encrypted and should be passed to Open as is
+func (s *Sealer) Seal(data, additionalData []byte) ([]byte, error) {
+	aead, err := s.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
+
+// Open decrypts the data encrypted by Seal
+func (s *Sealer) Open(sealed, additionalData []byte) ([]byte, error) {
+	aead, err := s.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed data is too short")
+	}
+	data, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open sealed data")
+	}
+	return data, nil
+}
+
+// SealParameters encrypts the sensitive parameters in place, the already sealed ones are skipped
+func (s *Sealer) SealParameters(parameters map[string]string) error {
+	for k, v := range parameters {
+		if !s.isSensitive(k) || strings.HasPrefix(v, Prefix) {
+			continue
+		}
+		sealed, err := s.Seal([]byte(v), []byte(k))
+		if err != nil {
+			return errors.Wrapf(err, "failed to seal parameter %s", k)
+		}
+		parameters[k] = Prefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return nil
+}
+
+// OpenParameters decrypts the parameters sealed by SealParameters in place
+func (s *Sealer) OpenParameters(parameters map[string]string) error {
+	for k, v := range parameters {
+		if !strings.HasPrefix(v, Prefix) {
+			continue
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(v, Prefix))
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode parameter %s", k)
+		}
+		data, err := s.Open(sealed, []byte(k))
+		if err != nil {
+			return errors.Wrapf(err, "failed to open parameter %s", k)
+		}
+		parameters[k] = string(data)
+	}
+	return nil
+}
+
+// SealConnection encrypts the sensitive parameters of the connection mechanism in place
+func (s *Sealer) SealConnection(conn *networkservice.Connection) error {
+	return s.SealParameters(conn.GetMechanism().GetParameters())
+}
+
+// OpenConnection decrypts the parameters of the connection mechanism sealed by SealConnection in place
+func (s *Sealer) OpenConnection(conn *networkservice.Connection) error {
+	return s.OpenParameters(conn.GetMechanism().GetParameters())
+}
+
+func (s *Sealer) isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, substr := range s.parameterKeys {
+		if strings.Contains(key, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sealer) aead() (cipher.AEAD, error) {
+	key, err := s.keyProvider.Key()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sealing key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid sealing key")
+	}
+	return cipher.NewGCM(block)
+}
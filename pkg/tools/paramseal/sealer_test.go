@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paramseal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/paramseal"
+)
+
+func TestSealer_Parameters(t *testing.T) {
+	sealer := paramseal.NewSealer(paramseal.RandomKey())
+
+	parameters := map[string]string{
+		"privateKey": "private",
+		"peerPSK":    "psk",
+		"name":       "nsm-1",
+	}
+	require.NoError(t, sealer.SealParameters(parameters))
+	require.True(t, strings.HasPrefix(parameters["privateKey"], paramseal.Prefix))
+	require.True(t, strings.HasPrefix(parameters["peerPSK"], paramseal.Prefix))
+	require.Equal(t, "nsm-1", parameters["name"])
+
+	// Sealed parameters are not sealed twice
+	sealed := parameters["privateKey"]
+	require.NoError(t, sealer.SealParameters(parameters))
+	require.Equal(t, sealed, parameters["privateKey"])
+
+	// Sealed values are bound to the parameter keys
+	swapped := map[string]string{"peerPSK": sealed}
+	require.Error(t, sealer.OpenParameters(swapped))
+
+	require.NoError(t, sealer.OpenParameters(parameters))
+	require.Equal(t, map[string]string{
+		"privateKey": "private",
+		"peerPSK":    "psk",
+		"name":       "nsm-1",
+	}, parameters)
+}
+
+func TestSealer_Keys(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("0123456789abcdef0123456789abcdef"), 0o600))
+
+	sealer := paramseal.NewSealer(paramseal.FileKey(keyFile))
+	sealed, err := sealer.Seal([]byte("data"), []byte("ad"))
+	require.NoError(t, err)
+
+	data, err := paramseal.NewSealer(paramseal.StaticKey([]byte("0123456789abcdef0123456789abcdef"))).Open(sealed, []byte("ad"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+
+	_, err = sealer.Open(sealed, []byte("another ad"))
+	require.Error(t, err)
+	_, err = paramseal.NewSealer(paramseal.RandomKey()).Open(sealed, []byte("ad"))
+	require.Error(t, err)
+	_, err = paramseal.NewSealer(paramseal.StaticKey([]byte("short"))).Seal([]byte("data"), nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package payloadtypes provides a registry of the payload types supported by the deployment with their validators,
+// so specialized deployments (MPLS, raw L2TP, ...) can use custom payload identifiers instead of abusing the
+// Ethernet payload.
+package payloadtypes
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var payloadTypePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_.-]*$`)
+
+// Validator validates the connection with the payload type
+type Validator func(ctx context.Context, conn *networkservice.Connection) error
+
+// Registry is a registry of the supported payload types
+type Registry struct {
+	mu         sync.RWMutex
+	validators map[string]Validator
+}
+
+// NewRegistry creates a new Registry supporting the IP and Ethernet payload types
+func NewRegistry() *Registry {
+	return &Registry{
+		validators: map[string]Validator{
+			payload.IP:       nil,
+			payload.Ethernet: nil,
+		},
+	}
+}
+
+// Register registers the payload type with the validator, the validator can be nil. Payload types are upper case
+// identifiers, e.g. "MPLS".
+func (r *Registry) Register(payloadType string, validator Validator) error {
+	if !payloadTypePattern.MatchString(payloadType) {
+		return errors.Errorf("payload type %q doesn't match %s", payloadType, payloadTypePattern.String())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.validators[payloadType] = validator
+	return nil
+}
+
+// IsKnown returns true if the payload type is registered
+func (r *Registry) IsKnown(payloadType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.validators[payloadType]
+	return ok
+}
+
+// Types returns the sorted registered payload types
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.validators))
+	for payloadType := range r.validators {
+		types = append(types, payloadType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Validate returns InvalidArgument error if the connection payload type is not registered or the connection is
+// rejected by the payload type validator. Connections with the empty payload are not validated.
+func (r *Registry) Validate(ctx context.Context, conn *networkservice.Connection) error {
+	payloadType := conn.GetPayload()
+	if payloadType == "" {
+		return nil
+	}
+
+	r.mu.RLock()
+	validator, ok := r.validators[payloadType]
+	r.mu.RUnlock()
+
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "payload type %q is not supported", payloadType)
+	}
+	if validator == nil {
+		return nil
+	}
+	if err := validator(ctx, conn); err != nil {
+		return status.Errorf(codes.InvalidArgument, "connection %s is not valid for the payload type %q: %s", conn.GetId(), payloadType, err.Error())
+	}
+	return nil
+}
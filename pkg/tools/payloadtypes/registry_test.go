@@ -0,0 +1,57 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadtypes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/payloadtypes"
+)
+
+func TestRegistry(t *testing.T) {
+	types := payloadtypes.NewRegistry()
+
+	require.Equal(t, []string{payload.Ethernet, payload.IP}, types.Types())
+	require.Error(t, types.Register("mpls", nil))
+	require.NoError(t, types.Register("MPLS", func(_ context.Context, conn *networkservice.Connection) error {
+		if conn.GetLabels()["mpls-label"] == "" {
+			return errors.New("mpls-label is not set")
+		}
+		return nil
+	}))
+	require.True(t, types.IsKnown("MPLS"))
+	require.False(t, types.IsKnown("L2TP"))
+
+	ctx := context.Background()
+
+	require.NoError(t, types.Validate(ctx, &networkservice.Connection{}))
+	require.NoError(t, types.Validate(ctx, &networkservice.Connection{Payload: payload.IP}))
+	require.NoError(t, types.Validate(ctx, &networkservice.Connection{
+		Payload: "MPLS",
+		Labels:  map[string]string{"mpls-label": "100"},
+	}))
+	require.Equal(t, codes.InvalidArgument, status.Code(types.Validate(ctx, &networkservice.Connection{Payload: "MPLS"})))
+	require.Equal(t, codes.InvalidArgument, status.Code(types.Validate(ctx, &networkservice.Connection{Payload: "L2TP"})))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provenance provides errors enriched with the chain element, operation and hop index they were returned from
+package provenance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Domain is an ErrorInfo domain used for encoding provenance into gRPC status details
+	Domain = "provenance.networkservicemesh.io"
+
+	elementKey   = "element"
+	operationKey = "operation"
+	hopKey       = "hop"
+)
+
+var enabled int32
+
+// Enable - enables/disables errors enrichment in the trace chain elements
+func Enable(enable bool) {
+	if enable {
+		atomic.StoreInt32(&enabled, 1)
+		return
+	}
+	atomic.StoreInt32(&enabled, 0)
+}
+
+// IsEnabled - checks if errors enrichment is enabled
+func IsEnabled() bool {
+	return atomic.LoadInt32(&enabled) != 0
+}
+
+// Error is an error returned from the chain element
+type Error struct {
+	// Element is a name of the chain element originated the error
+	Element string
+	// Operation is a name of the failed operation (Request, Close)
+	Operation string
+	// Hop is a path index of the failed connection
+	Hop uint32
+	// Err is the original error
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error() + e.suffix()
+}
+
+func (e *Error) suffix() string {
+	return fmt.Sprintf(" (element: %s, operation: %s, hop: %d)", e.Element, e.Operation, e.Hop)
+}
+
+// Unwrap returns the original error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StackTrace returns the stack trace of the original error. Implementing it keeps the trace elements from wrapping
+// Error with another stack, so Error reaches the gRPC server as is and GRPCStatus encodes the provenance on the wire.
+func (e *Error) StackTrace() errors.StackTrace {
+	var st interface{ StackTrace() errors.StackTrace }
+	if errors.As(e.Err, &st) {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+// GRPCStatus returns a status with the original error code and provenance encoded as ErrorInfo details
+func (e *Error) GRPCStatus() *status.Status {
+	code, msg := codes.Unknown, e.Err.Error()
+	var grpcStatus interface{ GRPCStatus() *status.Status }
+	if errors.As(e.Err, &grpcStatus) {
+		code, msg = grpcStatus.GRPCStatus().Code(), grpcStatus.GRPCStatus().Message()
+	}
+
+	s := status.New(code, msg+e.suffix())
+	if sd, err := s.WithDetails(&errdetails.ErrorInfo{
+		Reason: code.String(),
+		Domain: Domain,
+		Metadata: map[string]string{
+			elementKey:   e.Element,
+			operationKey: e.Operation,
+			hopKey:       strconv.FormatUint(uint64(e.Hop), 10),
+		},
+	}); err == nil {
+		return sd
+	}
+	return s
+}
+
+// Wrap - wraps err with the provenance if it is enabled and err doesn't have it yet. If err is a gRPC status error
+// with provenance details from the remote side, the remote provenance is used.
+func Wrap(err error, element, operation string, hop uint32) error {
+	if err == nil || !IsEnabled() {
+		return err
+	}
+	if _, ok := FromError(err); ok {
+		return err
+	}
+	if remote, ok := fromStatus(err); ok {
+		return remote
+	}
+	return &Error{
+		Element:   element,
+		Operation: operation,
+		Hop:       hop,
+		Err:       withStack(err),
+	}
+}
+
+func withStack(err error) error {
+	if _, ok := err.(interface{ StackTrace() errors.StackTrace }); ok {
+		return err
+	}
+	return errors.WithStack(err)
+}
+
+// FromError returns the provenance Error from the err chain
+func FromError(err error) (*Error, bool) {
+	var rv *Error
+	if errors.As(err, &rv) {
+		return rv, true
+	}
+	return nil, false
+}
+
+func fromStatus(err error) (*Error, bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != Domain {
+			continue
+		}
+		hop, _ := strconv.ParseUint(info.GetMetadata()[hopKey], 10, 32)
+		rv := &Error{
+			Element:   info.GetMetadata()[elementKey],
+			Operation: info.GetMetadata()[operationKey],
+			Hop:       uint32(hop),
+		}
+		rv.Err = errors.WithStack(status.Error(s.Code(), strings.TrimSuffix(s.Message(), rv.suffix())))
+		return rv, true
+	}
+	return nil, false
+}
+
+// UnaryServerInterceptor returns a server interceptor encoding the provenance into gRPC status details of the errors
+// returned from the handlers. Errors returned from the trace elements are encoded without it, the interceptor is needed
+// only if some element wraps Error with another error.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if rv, ok := FromError(err); ok {
+			return resp, rv.GRPCStatus().Err()
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/inject/injecterror"
+	"github.com/networkservicemesh/sdk/pkg/tools/provenance"
+)
+
+func TestProvenance_Chain(t *testing.T) {
+	provenance.Enable(true)
+	defer provenance.Enable(false)
+
+	server := chain.NewNetworkServiceServer(
+		new(count.Server),
+		injecterror.NewServer(injecterror.WithError(status.Error(codes.Unavailable, "connection refused"))),
+	)
+
+	_, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{Index: 1},
+		},
+	})
+	require.Error(t, err)
+
+	rv, ok := provenance.FromError(err)
+	require.True(t, ok)
+	require.True(t, strings.HasSuffix(rv.Element, "injecterror/injectErrorServer"), rv.Element)
+	require.Equal(t, "Request", rv.Operation)
+	require.Equal(t, uint32(1), rv.Hop)
+	require.Equal(t, codes.Unavailable, status.Code(rv))
+}
+
+func TestProvenance_GRPC(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	provenance.Enable(true)
+	defer provenance.Enable(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	networkservice.RegisterNetworkServiceServer(server, chain.NewNetworkServiceServer(
+		new(count.Server),
+		injecterror.NewServer(injecterror.WithError(status.Error(codes.Unavailable, "connection refused"))),
+	))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	cc, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := chain.NewNetworkServiceClient(networkservice.NewNetworkServiceClient(cc))
+
+	_, err = client.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Path: &networkservice.Path{Index: 1},
+		},
+	})
+	require.Error(t, err)
+
+	rv, ok := provenance.FromError(err)
+	require.True(t, ok)
+	require.True(t, strings.HasSuffix(rv.Element, "injecterror/injectErrorServer"), rv.Element)
+	require.Equal(t, "Request", rv.Operation)
+	require.Equal(t, uint32(1), rv.Hop)
+	require.Equal(t, codes.Unavailable, status.Code(rv))
+	require.Equal(t, "rpc error: code = Unavailable desc = connection refused"+
+		" (element: "+rv.Element+", operation: Request, hop: 1)", rv.Error())
+}
+
+func TestProvenance_Disabled(t *testing.T) {
+	err := status.Error(codes.Unavailable, "connection refused")
+	require.Equal(t, err, provenance.Wrap(err, "element", "Request", 0))
+}
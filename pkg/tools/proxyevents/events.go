@@ -0,0 +1,123 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxyevents provides structured machine-readable events describing the decisions of the interdomain proxy
+// registries, so interdomain connectivity failures can be monitored and alerted on.
+// The JSON schema of Event is stable: fields may be added, but existing fields are never renamed or removed without
+// bumping SchemaVersion.
+package proxyevents
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// SchemaVersion is the version of the Event JSON schema
+const SchemaVersion = "v1"
+
+// Type is an event type
+type Type string
+
+const (
+	// DomainResolved is emitted when the domain is resolved to the service URL, Error is set if the resolution failed
+	DomainResolved Type = "domain_resolved"
+	// ProxyHopChosen is emitted when the URL the request is forwarded to is chosen
+	ProxyHopChosen Type = "proxy_hop_chosen"
+	// RemoteError is emitted when the remote registry returns an error
+	RemoteError Type = "remote_error"
+)
+
+// Resource types
+const (
+	ResourceNS  = "ns"
+	ResourceNSE = "nse"
+)
+
+// Operations
+const (
+	OperationRegister   = "register"
+	OperationFind       = "find"
+	OperationUnregister = "unregister"
+)
+
+// Event is a structured proxy registry event
+type Event struct {
+	Version   string    `json:"version"`
+	Type      Type      `json:"type"`
+	Time      time.Time `json:"time"`
+	Resource  string    `json:"resource"`
+	Operation string    `json:"operation"`
+	Name      string    `json:"name"`
+	Domain    string    `json:"domain"`
+	Service   string    `json:"service,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Sink receives the events
+type Sink interface {
+	Emit(ctx context.Context, event *Event)
+}
+
+// SinkFunc is a function adapter for Sink
+type SinkFunc func(ctx context.Context, event *Event)
+
+// Emit calls f(ctx, event)
+func (f SinkFunc) Emit(ctx context.Context, event *Event) {
+	f(ctx, event)
+}
+
+type logSink struct{}
+
+// NewLogSink returns a Sink writing the events as JSON to the logger from ctx: RemoteError and failed DomainResolved
+// events are logged as warnings, other events as infos
+func NewLogSink() Sink {
+	return new(logSink)
+}
+
+func (s *logSink) Emit(ctx context.Context, event *Event) {
+	logger := log.FromContext(ctx).WithField("proxyEvent", string(event.Type))
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("failed to marshal event: %s", err.Error())
+		return
+	}
+
+	if event.Error != "" {
+		logger.Warnf("%s", data)
+		return
+	}
+	logger.Infof("%s", data)
+}
+
+type sinkKey struct{}
+
+// WithSink returns a new context carrying the sink
+func WithSink(ctx context.Context, sink Sink) context.Context {
+	return context.WithValue(ctx, sinkKey{}, sink)
+}
+
+// FromContext returns the sink from ctx or the log sink if there is no such
+func FromContext(ctx context.Context) Sink {
+	if sink, ok := ctx.Value(sinkKey{}).(Sink); ok {
+		return sink
+	}
+	return NewLogSink()
+}
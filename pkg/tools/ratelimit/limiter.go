@@ -0,0 +1,206 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides a token bucket Limiter with the global and per-identity limits rejecting the operations
+// over the limits with RESOURCE_EXHAUSTED and the retry-after hint
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+)
+
+// IdentityFunc returns the identity of the peer in ctx the per-identity limit is applied to
+type IdentityFunc func(ctx context.Context) string
+
+// PeerIdentity is the default IdentityFunc returning the peer SPIFFE ID or the peer host if the peer is not
+// authenticated, empty identity is returned for the unknown peers
+func PeerIdentity(ctx context.Context) string {
+	if id, err := spire.SpiffeIDFromContext(ctx); err == nil {
+		return id.String()
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// Limiter limits the rate of the operations globally and per peer identity using the token buckets. The operation is
+// admitted only if both the global and the identity buckets have a token, so the rejected operations don't consume
+// the tokens of the other bucket.
+type Limiter struct {
+	global       limit
+	identity     limit
+	identityFunc IdentityFunc
+
+	mu         sync.Mutex
+	globalB    *bucket
+	identities map[string]*bucket
+	lastSweep  time.Time
+}
+
+// NewLimiter creates a new Limiter. With no options it admits all the operations.
+func NewLimiter(opts ...Option) *Limiter {
+	l := &Limiter{
+		identityFunc: PeerIdentity,
+		identities:   make(map[string]*bucket),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Allow admits the operation or returns RESOURCE_EXHAUSTED error with the errdetails.RetryInfo storing the time
+// after which the operation can be admitted
+func (l *Limiter) Allow(ctx context.Context) error {
+	now := clock.FromContext(ctx).Now()
+
+	var identity string
+	if l.identity.enabled() {
+		identity = l.identityFunc(ctx)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	var globalB, identityB *bucket
+	var retryAfter time.Duration
+	if l.global.enabled() {
+		if l.globalB == nil {
+			l.globalB = newBucket(l.global, now)
+		}
+		globalB = l.globalB
+		retryAfter = globalB.wait(l.global, now)
+	}
+	if l.identity.enabled() {
+		identityB = l.identities[identity]
+		if identityB == nil {
+			identityB = newBucket(l.identity, now)
+			l.identities[identity] = identityB
+		}
+		if wait := identityB.wait(l.identity, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return retryError(identity, retryAfter)
+	}
+
+	if globalB != nil {
+		globalB.tokens--
+	}
+	if identityB != nil {
+		identityB.tokens--
+	}
+	return nil
+}
+
+// sweep removes the identities buckets which are full, they are no different from the new ones
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for identity, b := range l.identities {
+		b.refill(l.identity, now)
+		if b.tokens >= float64(l.identity.burst) {
+			delete(l.identities, identity)
+		}
+	}
+}
+
+// RetryAfter returns the retry-after hint stored in the RESOURCE_EXHAUSTED error returned by Limiter. It works for
+// both the local errors and the errors received over gRPC.
+func RetryAfter(err error) (time.Duration, bool) {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) || grpcErr.GRPCStatus().Code() != codes.ResourceExhausted {
+		return 0, false
+	}
+	for _, detail := range grpcErr.GRPCStatus().Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+func retryError(identity string, retryAfter time.Duration) error {
+	s := status.Newf(codes.ResourceExhausted, "rate limit exceeded for %q, retry after %s", identity, retryAfter)
+	if withDetails, err := s.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	}); err == nil {
+		s = withDetails
+	}
+	return s.Err()
+}
+
+type limit struct {
+	rate  float64
+	burst int
+}
+
+func (l limit) enabled() bool {
+	return l.rate > 0
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l limit, now time.Time) *bucket {
+	return &bucket{
+		tokens: float64(l.burst),
+		last:   now,
+	}
+}
+
+func (b *bucket) refill(l limit, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(l.burst), b.tokens+elapsed.Seconds()*l.rate)
+		b.last = now
+	}
+}
+
+// wait refills the bucket and returns the time until it has a token
+func (b *bucket) wait(l limit, now time.Time) time.Duration {
+	b.refill(l, now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / l.rate * float64(time.Second)))
+}
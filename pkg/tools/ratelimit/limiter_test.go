@@ -0,0 +1,120 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/ratelimit"
+)
+
+type identityKey struct{}
+
+func withIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func identityFunc(ctx context.Context) string {
+	identity, _ := ctx.Value(identityKey{}).(string)
+	return identity
+}
+
+func TestLimiter_Identity(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	limiter := ratelimit.NewLimiter(
+		ratelimit.WithIdentityLimit(1, 2),
+		ratelimit.WithIdentityFunc(identityFunc),
+	)
+
+	aCtx, bCtx := withIdentity(ctx, "a"), withIdentity(ctx, "b")
+
+	require.NoError(t, limiter.Allow(aCtx))
+	require.NoError(t, limiter.Allow(aCtx))
+
+	err := limiter.Allow(aCtx)
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	retryAfter, ok := ratelimit.RetryAfter(err)
+	require.True(t, ok)
+	require.Equal(t, time.Second, retryAfter)
+
+	// Other identity has its own bucket
+	require.NoError(t, limiter.Allow(bCtx))
+
+	clockMock.Add(time.Second / 2)
+	retryAfter, _ = ratelimit.RetryAfter(limiter.Allow(aCtx))
+	require.Equal(t, time.Second/2, retryAfter)
+
+	clockMock.Add(time.Second / 2)
+	require.NoError(t, limiter.Allow(aCtx))
+	require.Error(t, limiter.Allow(aCtx))
+}
+
+func TestLimiter_Global(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	limiter := ratelimit.NewLimiter(
+		ratelimit.WithGlobalLimit(10, 3),
+		ratelimit.WithIdentityLimit(1, 1),
+		ratelimit.WithIdentityFunc(identityFunc),
+	)
+
+	require.NoError(t, limiter.Allow(withIdentity(ctx, "a")))
+	// Rejected by the identity limit, the global token is not consumed
+	require.Error(t, limiter.Allow(withIdentity(ctx, "a")))
+	require.NoError(t, limiter.Allow(withIdentity(ctx, "b")))
+	require.NoError(t, limiter.Allow(withIdentity(ctx, "c")))
+
+	err := limiter.Allow(withIdentity(ctx, "d"))
+	retryAfter, ok := ratelimit.RetryAfter(err)
+	require.True(t, ok)
+	require.Equal(t, time.Second/10, retryAfter)
+
+	clockMock.Add(time.Second / 10)
+	require.NoError(t, limiter.Allow(withIdentity(ctx, "d")))
+}
+
+func TestLimiter_NoLimits(t *testing.T) {
+	limiter := ratelimit.NewLimiter()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Allow(context.Background()))
+	}
+	_, ok := ratelimit.RetryAfter(status.Error(codes.ResourceExhausted, "quota"))
+	require.False(t, ok)
+}
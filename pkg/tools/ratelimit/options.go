@@ -0,0 +1,57 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import "time"
+
+const sweepInterval = time.Minute
+
+// Option is an option pattern for NewLimiter
+type Option func(l *Limiter)
+
+// WithGlobalLimit sets the limit of rate operations per second with the burst for all the peers. Rate <= 0 disables
+// the global limit.
+func WithGlobalLimit(rate float64, burst int) Option {
+	return func(l *Limiter) {
+		l.global = newLimit(rate, burst)
+	}
+}
+
+// WithIdentityLimit sets the limit of rate operations per second with the burst for each peer identity. Rate <= 0
+// disables the per-identity limit.
+func WithIdentityLimit(rate float64, burst int) Option {
+	return func(l *Limiter) {
+		l.identity = newLimit(rate, burst)
+	}
+}
+
+// WithIdentityFunc sets the func returning the peer identity. Default is PeerIdentity.
+func WithIdentityFunc(identityFunc IdentityFunc) Option {
+	return func(l *Limiter) {
+		l.identityFunc = identityFunc
+	}
+}
+
+func newLimit(rate float64, burst int) limit {
+	if burst < 1 {
+		burst = 1
+	}
+	return limit{
+		rate:  rate,
+		burst: burst,
+	}
+}
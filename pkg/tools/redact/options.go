@@ -0,0 +1,49 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import "strings"
+
+// Option is an option pattern for New
+type Option func(p *Policy)
+
+// WithTokens sets if path segment tokens should be masked
+func WithTokens(redactTokens bool) Option {
+	return func(p *Policy) {
+		p.tokens = redactTokens
+	}
+}
+
+// WithMechanismParameters sets case-insensitive substrings of the mechanism parameter keys which values should be masked
+func WithMechanismParameters(keys ...string) Option {
+	return func(p *Policy) {
+		p.parameterKeys = nil
+		for _, key := range keys {
+			p.parameterKeys = append(p.parameterKeys, strings.ToLower(key))
+		}
+	}
+}
+
+// WithLabels sets label keys which values should be masked
+func WithLabels(keys ...string) Option {
+	return func(p *Policy) {
+		p.labelKeys = make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			p.labelKeys[key] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact provides a policy for masking sensitive data (tokens, secret mechanism parameters, selected labels)
+// in protobuf messages before they are written to logs or traces.
+package redact
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// Mask is a value used instead of the redacted data
+	Mask = "<redacted>"
+
+	tokenFieldName      protoreflect.Name = "token"
+	parametersFieldName protoreflect.Name = "parameters"
+	labelsFieldName     protoreflect.Name = "labels"
+)
+
+var (
+	builtinPolicy = New()
+	defaultPolicy atomic.Value
+)
+
+// Policy describes which parts of the protobuf messages should be masked
+type Policy struct {
+	tokens        bool
+	parameterKeys []string
+	labelKeys     map[string]struct{}
+}
+
+// New creates a new redaction Policy. By default it masks all path tokens and all mechanism parameters which keys
+// contain "private", "secret" or "psk" (such as Wireguard private data).
+func New(opts ...Option) *Policy {
+	p := &Policy{
+		tokens:        true,
+		parameterKeys: []string{"private", "secret", "psk"},
+		labelKeys:     make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// SetDefault sets the Policy used by the trace elements. nil restores the built-in New() Policy.
+func SetDefault(p *Policy) {
+	if p == nil {
+		p = builtinPolicy
+	}
+	defaultPolicy.Store(p)
+}
+
+// Default returns the Policy used by the trace elements
+func Default() *Policy {
+	if p, ok := defaultPolicy.Load().(*Policy); ok {
+		return p
+	}
+	return builtinPolicy
+}
+
+// Apply returns a redacted copy of the msg. The original msg is never modified.
+func (p *Policy) Apply(msg proto.Message) proto.Message {
+	if msg == nil {
+		return nil
+	}
+	rv := proto.Clone(msg)
+	if rv == nil {
+		return nil
+	}
+	p.redact(rv.ProtoReflect())
+	return rv
+}
+
+func (p *Policy) redact(m protoreflect.Message) {
+	if !m.IsValid() {
+		return
+	}
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	for _, fd := range fields {
+		switch {
+		case fd.IsMap():
+			p.redactMap(fd, m.Mutable(fd).Map())
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind {
+				list := m.Get(fd).List()
+				for i := 0; i < list.Len(); i++ {
+					p.redact(list.Get(i).Message())
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			p.redact(m.Get(fd).Message())
+		case fd.Kind() == protoreflect.StringKind && fd.Name() == tokenFieldName && p.tokens:
+			m.Set(fd, protoreflect.ValueOfString(Mask))
+		}
+	}
+}
+
+func (p *Policy) redactMap(fd protoreflect.FieldDescriptor, m protoreflect.Map) {
+	if fd.MapValue().Kind() == protoreflect.MessageKind {
+		m.Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			p.redact(v.Message())
+			return true
+		})
+		return
+	}
+	if fd.MapKey().Kind() != protoreflect.StringKind || fd.MapValue().Kind() != protoreflect.StringKind {
+		return
+	}
+	var keys []protoreflect.MapKey
+	m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		switch fd.Name() {
+		case parametersFieldName:
+			if p.isSecretParameter(k.String()) {
+				keys = append(keys, k)
+			}
+		case labelsFieldName:
+			if _, ok := p.labelKeys[k.String()]; ok {
+				keys = append(keys, k)
+			}
+		}
+		return true
+	})
+	for _, k := range keys {
+		m.Set(k, protoreflect.ValueOfString(Mask))
+	}
+}
+
+func (p *Policy) isSecretParameter(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range p.parameterKeys {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+	return false
+}
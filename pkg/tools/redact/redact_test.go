@@ -0,0 +1,118 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact_test
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/redact"
+)
+
+func newRequest() *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:     "id",
+			Labels: map[string]string{"app": "nsc", "secret-label": "value"},
+			Path: &networkservice.Path{
+				PathSegments: []*networkservice.PathSegment{
+					{Id: "id", Token: "token"},
+				},
+			},
+			Mechanism: &networkservice.Mechanism{
+				Type: "WIREGUARD",
+				Parameters: map[string]string{
+					"src_public_key":  "public",
+					"src_private_key": "private",
+				},
+			},
+		},
+	}
+}
+
+func TestPolicy_Default(t *testing.T) {
+	request := newRequest()
+
+	redacted := redact.New().Apply(request).(*networkservice.NetworkServiceRequest)
+
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetPath().GetPathSegments()[0].GetToken())
+	require.Equal(t, "public", redacted.GetConnection().GetMechanism().GetParameters()["src_public_key"])
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetMechanism().GetParameters()["src_private_key"])
+	require.Equal(t, "value", redacted.GetConnection().GetLabels()["secret-label"])
+
+	require.Equal(t, newRequest().String(), request.String())
+}
+
+func TestPolicy_Options(t *testing.T) {
+	redacted := redact.New(
+		redact.WithTokens(false),
+		redact.WithMechanismParameters("PUBLIC"),
+		redact.WithLabels("secret-label"),
+	).Apply(newRequest()).(*networkservice.NetworkServiceRequest)
+
+	require.Equal(t, "token", redacted.GetConnection().GetPath().GetPathSegments()[0].GetToken())
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetMechanism().GetParameters()["src_public_key"])
+	require.Equal(t, "private", redacted.GetConnection().GetMechanism().GetParameters()["src_private_key"])
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetLabels()["secret-label"])
+	require.Equal(t, "nsc", redacted.GetConnection().GetLabels()["app"])
+}
+
+func TestPolicy_RegistryLabels(t *testing.T) {
+	nse := &registry.NetworkServiceEndpoint{
+		Name: "nse",
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"ns": {Labels: map[string]string{"secret-label": "value"}},
+		},
+	}
+
+	redacted := redact.New(redact.WithLabels("secret-label")).Apply(nse).(*registry.NetworkServiceEndpoint)
+
+	require.Equal(t, redact.Mask, redacted.GetNetworkServiceLabels()["ns"].GetLabels()["secret-label"])
+	require.Equal(t, "value", nse.GetNetworkServiceLabels()["ns"].GetLabels()["secret-label"])
+}
+
+func TestPolicy_OptionsReplace(t *testing.T) {
+	redacted := redact.New(
+		redact.WithMechanismParameters("public"),
+		redact.WithMechanismParameters("private"),
+		redact.WithLabels("app"),
+		redact.WithLabels("secret-label"),
+	).Apply(newRequest()).(*networkservice.NetworkServiceRequest)
+
+	require.Equal(t, "public", redacted.GetConnection().GetMechanism().GetParameters()["src_public_key"])
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetMechanism().GetParameters()["src_private_key"])
+	require.Equal(t, "nsc", redacted.GetConnection().GetLabels()["app"])
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetLabels()["secret-label"])
+}
+
+func TestPolicy_SetDefault(t *testing.T) {
+	builtin := redact.Default()
+	require.Same(t, builtin, redact.Default())
+
+	custom := redact.New(redact.WithTokens(false))
+	redact.SetDefault(custom)
+	require.Same(t, custom, redact.Default())
+
+	redact.SetDefault(nil)
+	require.Same(t, builtin, redact.Default())
+
+	redacted := redact.Default().Apply(newRequest()).(*networkservice.NetworkServiceRequest)
+	require.Equal(t, redact.Mask, redacted.GetConnection().GetPath().GetPathSegments()[0].GetToken())
+}
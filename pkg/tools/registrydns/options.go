@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrydns
+
+import (
+	"time"
+)
+
+// Option is an option pattern for Publish
+type Option func(p *publisher)
+
+// WithDomain sets the domain appended to the network service and endpoint names. By default, the records are
+// published for the bare names.
+func WithDomain(domain string) Option {
+	return func(p *publisher) {
+		p.domain = domain
+	}
+}
+
+// WithRetryInterval sets the interval between the attempts to watch the registry after failures. Default value = 5
+// seconds.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(p *publisher) {
+		p.retryInterval = interval
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registrydns provides a bridge publishing the registry contents as DNS records into the dnsutils memory
+// records, so workloads can resolve the network service and endpoint names through the NSM DNS proxy without an
+// external DNS operator.
+// Each network service endpoint with an IP URL gets an A/AAAA record "<nse-name>.<domain>.", each registered network
+// service gets a record "<ns-name>.<domain>." resolving to all its endpoints. The records are removed on the
+// unregistration or expiration of the registry entries.
+package registrydns
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultRetryInterval = 5 * time.Second
+
+type nseEntry struct {
+	nse   *registry.NetworkServiceEndpoint
+	timer clock.Timer
+}
+
+func (e *nseEntry) stop() {
+	if e.timer != nil {
+		e.timer.Stop()
+	}
+}
+
+type publisher struct {
+	ctx           context.Context
+	records       *memory.Map
+	domain        string
+	retryInterval time.Duration
+	clock         clock.Clock
+
+	mu        sync.Mutex
+	services  map[string]struct{}
+	nses      map[string]*nseEntry
+	published map[string]struct{}
+}
+
+// Publish starts watching the registry and publishing its contents into the records until the ctx is done
+func Publish(ctx context.Context, records *memory.Map,
+	nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient, opts ...Option) {
+	if records == nil {
+		panic("records cannot be nil")
+	}
+
+	p := &publisher{
+		ctx:           ctx,
+		records:       records,
+		retryInterval: defaultRetryInterval,
+		clock:         clock.FromContext(ctx),
+		services:      make(map[string]struct{}),
+		nses:          make(map[string]*nseEntry),
+		published:     make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	go p.watch(ctx, "network services", func() error { return p.syncServices(ctx, nsClient) })
+	go p.watch(ctx, "network service endpoints", func() error { return p.syncEndpoints(ctx, nseClient) })
+	go func() {
+		<-ctx.Done()
+		p.unpublishAll()
+	}()
+}
+
+// watch runs sync until the ctx is done, restarting it after failures
+func (p *publisher) watch(ctx context.Context, what string, sync func() error) {
+	logger := log.FromContext(ctx).WithField("registrydns", "watch")
+	for ctx.Err() == nil {
+		err := sync()
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warnf("failed to watch %s, retrying in %s: %v", what, p.retryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.clock.After(p.retryInterval):
+		}
+	}
+}
+
+// syncServices lists the current network services to drop the ones deleted while the watch was down, and then
+// watches for the changes
+func (p *publisher) syncServices(ctx context.Context, client registry.NetworkServiceRegistryClient) error {
+	query := &registry.NetworkServiceQuery{NetworkService: new(registry.NetworkService)}
+
+	stream, err := client.Find(ctx, query)
+	if err != nil {
+		return err
+	}
+	p.resetServices(registry.ReadNetworkServiceList(stream))
+
+	query.Watch = true
+	if stream, err = client.Find(ctx, query); err != nil {
+		return err
+	}
+	for {
+		resp, recvErr := stream.Recv()
+		if recvErr != nil {
+			return recvErr
+		}
+		p.updateService(resp)
+	}
+}
+
+// syncEndpoints lists the current network service endpoints to drop the ones deleted while the watch was down, and
+// then watches for the changes
+func (p *publisher) syncEndpoints(ctx context.Context, client registry.NetworkServiceEndpointRegistryClient) error {
+	query := &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)}
+
+	stream, err := client.Find(ctx, query)
+	if err != nil {
+		return err
+	}
+	p.resetEndpoints(registry.ReadNetworkServiceEndpointList(stream))
+
+	query.Watch = true
+	if stream, err = client.Find(ctx, query); err != nil {
+		return err
+	}
+	for {
+		resp, recvErr := stream.Recv()
+		if recvErr != nil {
+			return recvErr
+		}
+		p.updateEndpoint(resp)
+	}
+}
+
+func (p *publisher) resetServices(nss []*registry.NetworkService) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.services = make(map[string]struct{})
+	for _, ns := range nss {
+		p.services[ns.GetName()] = struct{}{}
+	}
+	p.publish()
+}
+
+func (p *publisher) updateService(resp *registry.NetworkServiceResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if resp.GetDeleted() {
+		delete(p.services, resp.GetNetworkService().GetName())
+	} else {
+		p.services[resp.GetNetworkService().GetName()] = struct{}{}
+	}
+	p.publish()
+}
+
+func (p *publisher) resetEndpoints(nses []*registry.NetworkServiceEndpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name, entry := range p.nses {
+		entry.stop()
+		delete(p.nses, name)
+	}
+	for _, nse := range nses {
+		p.storeEndpoint(nse)
+	}
+	p.publish()
+}
+
+func (p *publisher) updateEndpoint(resp *registry.NetworkServiceEndpointResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nse := resp.GetNetworkServiceEndpoint()
+	if resp.GetDeleted() {
+		if entry, ok := p.nses[nse.GetName()]; ok {
+			entry.stop()
+			delete(p.nses, nse.GetName())
+		}
+	} else {
+		p.storeEndpoint(nse)
+	}
+	p.publish()
+}
+
+// storeEndpoint stores the NSE and starts its expiration timer, so the records are removed even if the registry
+// doesn't send the delete event
+func (p *publisher) storeEndpoint(nse *registry.NetworkServiceEndpoint) {
+	if entry, ok := p.nses[nse.GetName()]; ok {
+		entry.stop()
+	}
+
+	entry := &nseEntry{nse: nse}
+	if nse.GetExpirationTime() != nil {
+		entry.timer = p.clock.AfterFunc(p.clock.Until(nse.GetExpirationTime().AsTime()), func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			if p.nses[nse.GetName()] == entry {
+				delete(p.nses, nse.GetName())
+				p.publish()
+			}
+		})
+	}
+	p.nses[nse.GetName()] = entry
+}
+
+// publish updates the records according to the current state, it must be called under the lock
+func (p *publisher) publish() {
+	if p.ctx.Err() != nil {
+		return
+	}
+
+	records := make(map[string][]net.IP)
+	for _, entry := range p.nses {
+		ip := endpointIP(entry.nse)
+		if ip == nil {
+			continue
+		}
+		if name, ok := p.recordName(entry.nse.GetName()); ok {
+			records[name] = append(records[name], ip)
+		}
+		for _, ns := range entry.nse.GetNetworkServiceNames() {
+			if _, ok := p.services[ns]; !ok {
+				continue
+			}
+			if name, ok := p.recordName(ns); ok {
+				records[name] = append(records[name], ip)
+			}
+		}
+	}
+
+	for name, ips := range records {
+		sort.Slice(ips, func(i, j int) bool { return ips[i].String() < ips[j].String() })
+		p.records.Store(name, ips)
+	}
+	for name := range p.published {
+		if _, ok := records[name]; !ok {
+			p.records.Delete(name)
+			delete(p.published, name)
+		}
+	}
+	for name := range records {
+		p.published[name] = struct{}{}
+	}
+}
+
+func (p *publisher) unpublishAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, entry := range p.nses {
+		entry.stop()
+	}
+	for name := range p.published {
+		p.records.Delete(name)
+	}
+	p.published = make(map[string]struct{})
+}
+
+func (p *publisher) recordName(name string) (string, bool) {
+	if p.domain != "" {
+		name += "." + p.domain
+	}
+	name = dns.Fqdn(name)
+	if _, ok := dns.IsDomainName(name); !ok {
+		return "", false
+	}
+	return name, true
+}
+
+func endpointIP(nse *registry.NetworkServiceEndpoint) net.IP {
+	u, err := url.Parse(nse.GetUrl())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(u.Hostname())
+}
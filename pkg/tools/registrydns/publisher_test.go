@@ -0,0 +1,133 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrydns_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	dnsmemory "github.com/networkservicemesh/sdk/pkg/tools/dnsutils/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/registrydns"
+)
+
+func requireRecord(t *testing.T, records *dnsmemory.Map, name string, ips ...string) {
+	require.Eventually(t, func() bool {
+		actual, ok := records.Load(name)
+		if !ok || len(actual) != len(ips) {
+			return false
+		}
+		for i := range ips {
+			if !actual[i].Equal(net.ParseIP(ips[i])) {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+}
+
+func requireNoRecord(t *testing.T, records *dnsmemory.Map, name string) {
+	require.Eventually(t, func() bool {
+		_, ok := records.Load(name)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPublish(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	nsServer := memory.NewNetworkServiceRegistryServer()
+	nseServer := memory.NewNetworkServiceEndpointRegistryServer()
+
+	_, err := nsServer.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+	_, err = nseServer.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		Url:                 "tcp://10.0.0.1:5001",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	require.NoError(t, err)
+
+	records := new(dnsmemory.Map)
+	publishCtx, publishCancel := context.WithCancel(ctx)
+	registrydns.Publish(publishCtx, records,
+		adapters.NetworkServiceServerToClient(nsServer),
+		adapters.NetworkServiceEndpointServerToClient(nseServer),
+		registrydns.WithDomain("nsm"),
+	)
+
+	requireRecord(t, records, "nse-1.nsm.", "10.0.0.1")
+	requireRecord(t, records, "ns-1.nsm.", "10.0.0.1")
+
+	nse2, err := nseServer.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-2",
+		Url:                 "tcp://10.0.0.2:5001",
+		NetworkServiceNames: []string{"ns-1", "ns-2"},
+		ExpirationTime:      timestamppb.New(clockMock.Now().Add(time.Minute)),
+	})
+	require.NoError(t, err)
+
+	requireRecord(t, records, "nse-2.nsm.", "10.0.0.2")
+	requireRecord(t, records, "ns-1.nsm.", "10.0.0.1", "10.0.0.2")
+	// ns-2 is not registered
+	requireNoRecord(t, records, "ns-2.nsm.")
+
+	_, err = nsServer.Register(ctx, &registry.NetworkService{Name: "ns-2"})
+	require.NoError(t, err)
+	requireRecord(t, records, "ns-2.nsm.", "10.0.0.2")
+
+	// Unregistration
+	_, err = nseServer.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	requireNoRecord(t, records, "nse-1.nsm.")
+	requireRecord(t, records, "ns-1.nsm.", "10.0.0.2")
+
+	// Expiration
+	clockMock.Add(2 * time.Minute)
+	requireNoRecord(t, records, "nse-2.nsm.")
+	requireNoRecord(t, records, "ns-1.nsm.")
+	requireNoRecord(t, records, "ns-2.nsm.")
+
+	// Records are removed once the publishing stops
+	_, err = nseServer.Register(ctx, nse2)
+	require.NoError(t, err)
+	_, err = nseServer.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name: "nse-3",
+		Url:  "tcp://10.0.0.3:5001",
+	})
+	require.NoError(t, err)
+	requireRecord(t, records, "nse-3.nsm.", "10.0.0.3")
+
+	publishCancel()
+	requireNoRecord(t, records, "nse-3.nsm.")
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reloadconfig
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Config is a set of settings which can be changed without restarting the application
+type Config struct {
+	// LogLevel is a logrus log level
+	LogLevel string `json:"logLevel,omitempty"`
+	// PolicyPaths are paths to the authorization policies
+	PolicyPaths []string `json:"policyPaths,omitempty"`
+	// ExcludedPrefixes are CIDRs which must not be used for the connections
+	ExcludedPrefixes []string `json:"excludedPrefixes,omitempty"`
+	// Timeouts are named timeouts in the time.ParseDuration format, e.g. "dial: 100ms"
+	Timeouts map[string]string `json:"timeouts,omitempty"`
+}
+
+// Timeout returns the named timeout or defaultTimeout if it is not set
+func (c *Config) Timeout(name string, defaultTimeout time.Duration) time.Duration {
+	if timeout, err := time.ParseDuration(c.Timeouts[name]); err == nil {
+		return timeout
+	}
+	return defaultTimeout
+}
+
+func (c *Config) validate() error {
+	if c.LogLevel != "" {
+		if _, err := logrus.ParseLevel(c.LogLevel); err != nil {
+			return errors.Wrapf(err, "invalid log level %s", c.LogLevel)
+		}
+	}
+	for _, prefix := range c.ExcludedPrefixes {
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			return errors.Wrapf(err, "invalid excluded prefix %s", prefix)
+		}
+	}
+	for name, timeout := range c.Timeouts {
+		if _, err := time.ParseDuration(timeout); err != nil {
+			return errors.Wrapf(err, "invalid timeout %s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reloadconfig provides a manager for the settings of long-running chains which can be reloaded from the file
+// on SIGHUP or with an authenticated HTTP request. Chain elements subscribe for the changes instead of requiring
+// the application restart.
+package reloadconfig
+
+import (
+	"context"
+	"crypto/subtle"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Manager holds the last successfully loaded Config and notifies subscribers on its changes
+type Manager struct {
+	ctx        context.Context
+	path       string
+	adminToken string
+
+	mu          sync.Mutex
+	config      *Config
+	subscribers map[chan *Config]struct{}
+}
+
+// NewManager loads the config from the path and returns a new Manager reloading it until the ctx is done
+func NewManager(ctx context.Context, path string, opts ...Option) (*Manager, error) {
+	o := &options{
+		sighup: true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	m := &Manager{
+		ctx:         ctx,
+		path:        path,
+		adminToken:  o.adminToken,
+		subscribers: make(map[chan *Config]struct{}),
+	}
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	if o.sighup {
+		signalCh := make(chan os.Signal, 1)
+		signal.Notify(signalCh, syscall.SIGHUP)
+		go func() {
+			defer signal.Stop(signalCh)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-signalCh:
+					if err := m.Reload(); err != nil {
+						log.FromContext(ctx).WithField("reloadconfig", "SIGHUP").Errorf("failed to reload config: %s", err.Error())
+					}
+				}
+			}
+		}()
+	}
+
+	return m, nil
+}
+
+// Config returns the current config
+func (m *Manager) Config() *Config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.config
+}
+
+// Subscribe returns a channel receiving the current config and then all its changes. Only the latest not received
+// config is kept in the channel. The channel is closed when the ctx is done.
+func (m *Manager) Subscribe(ctx context.Context) <-chan *Config {
+	ch := make(chan *Config, 1)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch <- m.config
+	m.subscribers[ch] = struct{}{}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-m.ctx.Done():
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		delete(m.subscribers, ch)
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Reload loads the config from the file. The current config is kept if the file can't be read or is not valid.
+func (m *Manager) Reload() error {
+	data, err := ioutil.ReadFile(m.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read config %s", m.path)
+	}
+
+	config := new(Config)
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return errors.Wrapf(err, "failed to parse config %s", m.path)
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if reflect.DeepEqual(m.config, config) {
+		return nil
+	}
+	m.config = config
+
+	if config.LogLevel != "" {
+		level, _ := logrus.ParseLevel(config.LogLevel)
+		logrus.SetLevel(level)
+	}
+	log.FromContext(m.ctx).WithField("reloadconfig", "Reload").Infof("config reloaded: %+v", *config)
+
+	for ch := range m.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- config
+	}
+	return nil
+}
+
+// ServeHTTP reloads the config on POST requests authenticated with the admin bearer token
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if m.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.adminToken)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := m.Reload(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
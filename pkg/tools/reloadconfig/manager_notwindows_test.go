@@ -0,0 +1,67 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package reloadconfig_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/reloadconfig"
+)
+
+func TestManager_SIGHUP(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "policyPaths:\n- a.rego\n")
+
+	m, err := reloadconfig.NewManager(ctx, path)
+	require.NoError(t, err)
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	ch := m.Subscribe(subCtx)
+	require.Equal(t, []string{"a.rego"}, (<-ch).PolicyPaths)
+
+	writeConfig(t, path, "policyPaths:\n- b.rego\n")
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	select {
+	case config := <-ch:
+		require.Equal(t, []string{"b.rego"}, config.PolicyPaths)
+	case <-time.After(time.Second):
+		require.FailNow(t, "config is not reloaded")
+	}
+
+	subCancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
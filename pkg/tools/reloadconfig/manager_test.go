@@ -0,0 +1,78 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reloadconfig_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/reloadconfig"
+)
+
+func writeConfig(t *testing.T, path, config string) {
+	require.NoError(t, ioutil.WriteFile(path, []byte(config), os.ModePerm))
+}
+
+func TestManager_HTTP(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "timeouts:\n  dial: 100ms\n")
+
+	m, err := reloadconfig.NewManager(ctx, path, reloadconfig.WithAdminToken("secret"), reloadconfig.WithoutSIGHUP())
+	require.NoError(t, err)
+
+	ch := m.Subscribe(ctx)
+	require.Equal(t, 100*time.Millisecond, (<-ch).Timeout("dial", time.Second))
+
+	writeConfig(t, path, "timeouts:\n  dial: 200ms\nexcludedPrefixes:\n- 10.0.0.0/8\n")
+
+	// Not authenticated
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, 100*time.Millisecond, m.Config().Timeout("dial", time.Second))
+
+	req := httptest.NewRequest(http.MethodPost, "/reload", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	config := <-ch
+	require.Equal(t, 200*time.Millisecond, config.Timeout("dial", time.Second))
+	require.Equal(t, []string{"10.0.0.0/8"}, config.ExcludedPrefixes)
+
+	// Invalid config is not applied
+	writeConfig(t, path, "excludedPrefixes:\n- invalid\n")
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, config, m.Config())
+}
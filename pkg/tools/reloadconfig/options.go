@@ -0,0 +1,40 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reloadconfig
+
+type options struct {
+	adminToken string
+	sighup     bool
+}
+
+// Option is an option pattern for NewManager
+type Option func(o *options)
+
+// WithAdminToken sets the bearer token required by the reload HTTP endpoint. The endpoint refuses all requests if the
+// token is not set.
+func WithAdminToken(token string) Option {
+	return func(o *options) {
+		o.adminToken = token
+	}
+}
+
+// WithoutSIGHUP disables reloading the config on SIGHUP
+func WithoutSIGHUP() Option {
+	return func(o *options) {
+		o.sighup = false
+	}
+}
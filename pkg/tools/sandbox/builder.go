@@ -56,6 +56,7 @@ type Builder struct {
 	dnsResolver            dnsresolve.Resolver
 	generateTokenFunc      token.GeneratorFunc
 	registryExpiryDuration time.Duration
+	faultInjector          *FaultInjector
 
 	useUnixSockets bool
 
@@ -76,6 +77,7 @@ func NewBuilder(ctx context.Context, t *testing.T) *Builder {
 		dnsResolver:            new(FakeDNSResolver),
 		generateTokenFunc:      GenerateTestToken,
 		registryExpiryDuration: time.Minute,
+		faultInjector:          NewFaultInjector(),
 	}
 
 	b.setupNode = func(ctx context.Context, node *Node, _ int) {
@@ -147,6 +149,12 @@ func (b *Builder) SetRegistryExpiryDuration(registryExpiryDuration time.Duration
 	return b
 }
 
+// SetFaultInjector replaces default fault injector for the traffic between the domain components
+func (b *Builder) SetFaultInjector(f *FaultInjector) *Builder {
+	b.faultInjector = f
+	return b
+}
+
 // UseUnixSockets sets 1 node and mark it to use unix socket to listen on.
 func (b *Builder) UseUnixSockets() *Builder {
 	require.NotEqual(b.t, "windows", runtime.GOOS, "Unix sockets are not available for windows")
@@ -162,8 +170,9 @@ func (b *Builder) UseUnixSockets() *Builder {
 // Build builds Domain and Supplier
 func (b *Builder) Build() *Domain {
 	b.domain = &Domain{
-		Name:        b.name,
-		DNSResolver: b.dnsResolver,
+		Name:          b.name,
+		DNSResolver:   b.dnsResolver,
+		FaultInjector: b.faultInjector,
 	}
 
 	if b.useUnixSockets {
@@ -237,7 +246,7 @@ func (b *Builder) newRegistryProxy() *RegistryEntry {
 		entry.Registry = b.supplyRegistryProxy(
 			ctx,
 			b.dnsResolver,
-			DialOptions(WithTokenGenerator(b.generateTokenFunc))...,
+			DialOptions(WithTokenGenerator(b.generateTokenFunc), WithFaultInjector(b.faultInjector, entry.URL))...,
 		)
 		serve(ctx, b.t, entry.URL, entry.Register)
 
@@ -265,7 +274,7 @@ func (b *Builder) newRegistry() *RegistryEntry {
 			ctx,
 			b.registryExpiryDuration,
 			nsmgrProxyURL,
-			DialOptions(WithTokenGenerator(b.generateTokenFunc))...,
+			DialOptions(WithTokenGenerator(b.generateTokenFunc), WithFaultInjector(b.faultInjector, entry.URL))...,
 		)
 		serve(ctx, b.t, entry.URL, entry.Register)
 
@@ -285,7 +294,7 @@ func (b *Builder) newNSMgrProxy() *NSMgrEntry {
 		URL:  b.domain.NSMgrProxy.URL,
 	}
 	entry.restartableServer = newRestartableServer(b.ctx, b.t, entry.URL, func(ctx context.Context) {
-		dialOptions := DialOptions(WithTokenGenerator(b.generateTokenFunc))
+		dialOptions := DialOptions(WithTokenGenerator(b.generateTokenFunc), WithFaultInjector(b.faultInjector, entry.URL))
 		entry.Nsmgr = b.supplyNSMgrProxy(ctx,
 			CloneURL(b.domain.Registry.URL),
 			CloneURL(b.domain.RegistryProxy.URL),
@@ -17,6 +17,8 @@
 package sandbox
 
 import (
+	"net/url"
+
 	"github.com/edwarnicke/grpcfd"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -27,6 +29,8 @@ import (
 
 type dialOpts struct {
 	tokenGenerator token.GeneratorFunc
+	faultInjector  *FaultInjector
+	source         *url.URL
 }
 
 // DialOption is an option pattern for DialOptions
@@ -39,6 +43,14 @@ func WithTokenGenerator(tokenGenerator token.GeneratorFunc) DialOption {
 	}
 }
 
+// WithFaultInjector sets faultInjector for DialOptions, the traffic is identified as sent from the source
+func WithFaultInjector(faultInjector *FaultInjector, source *url.URL) DialOption {
+	return func(opts *dialOpts) {
+		opts.faultInjector = faultInjector
+		opts.source = source
+	}
+}
+
 // DialOptions is a helper method for building []grpc.DialOption for testing
 func DialOptions(options ...DialOption) []grpc.DialOption {
 	tokenResetCh := make(chan struct{})
@@ -51,7 +63,7 @@ func DialOptions(options ...DialOption) []grpc.DialOption {
 		o(opts)
 	}
 
-	return append([]grpc.DialOption{
+	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(
 			grpcfdTransportCredentials(insecure.NewCredentials()),
 		),
@@ -62,5 +74,10 @@ func DialOptions(options ...DialOption) []grpc.DialOption {
 		grpcfd.WithChainUnaryInterceptor(),
 		WithInsecureRPCCredentials(),
 		WithInsecureStreamRPCCredentials(),
-	}, tracing.WithTracingDial()...)
+	}
+	if opts.faultInjector != nil {
+		dialOptions = append(dialOptions, opts.faultInjector.dialOptions(opts.source)...)
+	}
+
+	return append(dialOptions, tracing.WithTracingDial()...)
 }
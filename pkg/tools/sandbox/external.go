@@ -0,0 +1,82 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+// ExternalConfig describes the NSM components running outside of the test process
+type ExternalConfig struct {
+	// Name is the domain name, it is used in the interdomain queries
+	Name string
+	// RegistryURL is the registry URL, if not set NSMgr is used as the registry
+	RegistryURL *url.URL
+	// NSMgrURLs are the node NSMgr URLs, each one makes a node
+	NSMgrURLs []*url.URL
+	// ListenOn is the host the in-process components (NSEs, forwarders) serve on, it should be reachable from the
+	// external components. Default is 127.0.0.1.
+	ListenOn string
+}
+
+// NewExternalDomain creates a Domain for the externally running NSMgrs and registry. The in-process NSEs, forwarders
+// and clients can be started on the domain nodes the same way as on the built ones, but the external components
+// can't be restarted.
+func NewExternalDomain(t *testing.T, config *ExternalConfig) *Domain {
+	listenOn := config.ListenOn
+	if listenOn == "" {
+		listenOn = "127.0.0.1"
+	}
+
+	domain := &Domain{
+		Name:          config.Name,
+		FaultInjector: NewFaultInjector(),
+		supplyURL: func(_ string) *url.URL {
+			l, err := net.Listen("tcp", net.JoinHostPort(listenOn, "0"))
+			require.NoError(t, err)
+			defer func() { _ = l.Close() }()
+
+			return grpcutils.AddressToURL(l.Addr())
+		},
+	}
+
+	if config.RegistryURL != nil {
+		domain.Registry = &RegistryEntry{
+			URL: CloneURL(config.RegistryURL),
+		}
+	}
+
+	for _, u := range config.NSMgrURLs {
+		domain.Nodes = append(domain.Nodes, &Node{
+			t:      t,
+			domain: domain,
+			NSMgr: &NSMgrEntry{
+				Name: u.Host,
+				URL:  CloneURL(u),
+			},
+			Forwarders: make(map[string]*EndpointEntry),
+		})
+	}
+
+	return domain
+}
@@ -24,18 +24,93 @@ import (
 	"net/url"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/networkservicemesh/sdk/pkg/registry/common/dnsresolve"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 )
 
+// FakeDNSFailure is a failure injected into the FakeDNSResolver lookup
+type FakeDNSFailure int
+
+const (
+	// FakeDNSNoFailure - lookup is resolved normally
+	FakeDNSNoFailure FakeDNSFailure = iota
+	// FakeDNSServFail - lookup fails with the temporary SERVFAIL error
+	FakeDNSServFail
+	// FakeDNSNXDomain - lookup fails with the NXDOMAIN error
+	FakeDNSNXDomain
+)
+
+// FakeDNSStep scripts a single lookup of the name
+type FakeDNSStep struct {
+	// Latency is the lookup delay measured by the clock from the lookup context
+	Latency time.Duration
+	Failure FakeDNSFailure
+}
+
 // FakeDNSResolver implements dnsresolve.Resolver interface and can be used for logic DNS testing
 type FakeDNSResolver struct {
 	sync.Mutex
-	ports map[string]string
+	ports   map[string]string
+	scripts map[string][]FakeDNSStep
+	lookups map[string]int
+}
+
+// Script appends steps for the following lookups of the name: each lookup consumes one step, lookups after the last
+// step are resolved normally without delay
+func (f *FakeDNSResolver) Script(name string, steps ...FakeDNSStep) {
+	f.Lock()
+	defer f.Unlock()
+	if f.scripts == nil {
+		f.scripts = map[string][]FakeDNSStep{}
+	}
+	f.scripts[name] = append(f.scripts[name], steps...)
+}
+
+// LookupCount returns the number of lookups of the name
+func (f *FakeDNSResolver) LookupCount(name string) int {
+	f.Lock()
+	defer f.Unlock()
+	return f.lookups[name]
+}
+
+// runScript counts the lookup and runs the next scripted step for the name
+func (f *FakeDNSResolver) runScript(ctx context.Context, name string) error {
+	f.Lock()
+	if f.lookups == nil {
+		f.lookups = map[string]int{}
+	}
+	f.lookups[name]++
+	var step FakeDNSStep
+	if steps := f.scripts[name]; len(steps) > 0 {
+		step, f.scripts[name] = steps[0], steps[1:]
+	}
+	f.Unlock()
+
+	if step.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return &net.DNSError{Err: ctx.Err().Error(), Name: name, IsTimeout: true}
+		case <-clock.FromContext(ctx).After(step.Latency):
+		}
+	}
+
+	switch step.Failure {
+	case FakeDNSServFail:
+		return &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	case FakeDNSNXDomain:
+		return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	default:
+		return nil
+	}
 }
 
 // LookupSRV lookups DNS SRV record
 func (f *FakeDNSResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if err := f.runScript(ctx, name); err != nil {
+		return "", nil, err
+	}
 	f.Lock()
 	defer f.Unlock()
 	if f.ports == nil {
@@ -55,7 +130,10 @@ func (f *FakeDNSResolver) LookupSRV(ctx context.Context, service, proto, name st
 }
 
 // LookupIPAddr lookups IP address by host
-func (f *FakeDNSResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+func (f *FakeDNSResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if err := f.runScript(ctx, host); err != nil {
+		return nil, err
+	}
 	f.Lock()
 	defer f.Unlock()
 	if f.ports == nil {
@@ -0,0 +1,196 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+type faultKey struct {
+	source string
+	target string
+}
+
+type fault struct {
+	disconnected bool
+	latency      time.Duration
+}
+
+// FaultInjector injects faults into the gRPC traffic between the sandbox components. Components are identified by
+// their serve URLs, the node components (NSMgr, forwarders, endpoints and clients) are identified by the node NSMgr URL.
+type FaultInjector struct {
+	mu      sync.Mutex
+	faults  map[faultKey]*fault
+	streams map[faultKey]map[*context.CancelFunc]struct{}
+}
+
+// NewFaultInjector creates a new FaultInjector
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{
+		faults:  make(map[faultKey]*fault),
+		streams: make(map[faultKey]map[*context.CancelFunc]struct{}),
+	}
+}
+
+// Disconnect drops the traffic from the source to the target: new calls fail with Unavailable, open streams are closed
+func (f *FaultInjector) Disconnect(source, target *url.URL) {
+	key := newFaultKey(source, target)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fault(key).disconnected = true
+	for cancel := range f.streams[key] {
+		(*cancel)()
+	}
+	delete(f.streams, key)
+}
+
+// SetLatency delays the calls and the streams opening from the source to the target by the clock from the call
+// context
+func (f *FaultInjector) SetLatency(source, target *url.URL, latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fault(newFaultKey(source, target)).latency = latency
+}
+
+// Reset removes the faults injected into the traffic from the source to the target
+func (f *FaultInjector) Reset(source, target *url.URL) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.faults, newFaultKey(source, target))
+}
+
+func (f *FaultInjector) fault(key faultKey) *fault {
+	flt, ok := f.faults[key]
+	if !ok {
+		flt = new(fault)
+		f.faults[key] = flt
+	}
+	return flt
+}
+
+func newFaultKey(source, target *url.URL) faultKey {
+	return faultKey{
+		source: source.String(),
+		target: grpcutils.URLToTarget(target),
+	}
+}
+
+// inject applies the fault to the call from the source to the target
+func (f *FaultInjector) inject(ctx context.Context, key faultKey) error {
+	f.mu.Lock()
+	var flt fault
+	if v, ok := f.faults[key]; ok {
+		flt = *v
+	}
+	f.mu.Unlock()
+
+	if flt.latency > 0 {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-clock.FromContext(ctx).After(flt.latency):
+		}
+	}
+	if flt.disconnected {
+		return status.Errorf(codes.Unavailable, "sandbox: %s is disconnected from %s", key.source, key.target)
+	}
+	return nil
+}
+
+// openStream registers the stream cancel, so the stream is closed on Disconnect
+func (f *FaultInjector) openStream(key faultKey, cancel *context.CancelFunc) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if flt, ok := f.faults[key]; ok && flt.disconnected {
+		return status.Errorf(codes.Unavailable, "sandbox: %s is disconnected from %s", key.source, key.target)
+	}
+	if f.streams[key] == nil {
+		f.streams[key] = make(map[*context.CancelFunc]struct{})
+	}
+	f.streams[key][cancel] = struct{}{}
+	return nil
+}
+
+func (f *FaultInjector) closeStream(key faultKey, cancel *context.CancelFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.streams[key], cancel)
+	(*cancel)()
+}
+
+func (f *FaultInjector) dialOptions(source *url.URL) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if err := f.inject(ctx, faultKey{source: source.String(), target: cc.Target()}); err != nil {
+				return err
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			key := faultKey{source: source.String(), target: cc.Target()}
+			if err := f.inject(ctx, key); err != nil {
+				return nil, err
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			if err := f.openStream(key, &cancel); err != nil {
+				cancel()
+				return nil, err
+			}
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				f.closeStream(key, &cancel)
+				return nil, err
+			}
+			return &faultClientStream{
+				ClientStream: stream,
+				close: func() {
+					f.closeStream(key, &cancel)
+				},
+			}, nil
+		}),
+	}
+}
+
+type faultClientStream struct {
+	grpc.ClientStream
+	close func()
+}
+
+func (s *faultClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.close()
+	}
+	return err
+}
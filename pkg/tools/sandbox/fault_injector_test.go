@@ -0,0 +1,128 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	registryclient "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func newFaultRequest(nsName string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		MechanismPreferences: []*networkservice.Mechanism{
+			{Cls: cls.LOCAL, Type: kernelmech.MECHANISM},
+		},
+		Connection: &networkservice.Connection{
+			Id:             "1",
+			NetworkService: nsName,
+			Context:        &networkservice.ConnectionContext{},
+			Labels:         make(map[string]string),
+		},
+	}
+}
+
+func TestFaultInjector_DisconnectFrom(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsReg, err := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken).Register(ctx, &registry.NetworkService{
+		Name: "ns",
+	})
+	require.NoError(t, err)
+
+	nse := domain.Nodes[0].NewEndpoint(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{nsReg.Name},
+	}, sandbox.GenerateTestToken)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken)
+
+	domain.Nodes[0].DisconnectFrom(nse.URL)
+
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+
+	_, err = nsc.Request(requestCtx, newFaultRequest(nsReg.Name))
+	require.Error(t, err)
+
+	domain.Nodes[0].ReconnectTo(nse.URL)
+
+	conn, err := nsc.Request(ctx, newFaultRequest(nsReg.Name))
+	require.NoError(t, err)
+
+	_, err = nsc.Close(ctx, conn)
+	require.NoError(t, err)
+}
+
+func TestFaultInjector_SetLatency(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	faultInjector := sandbox.NewFaultInjector()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		SetFaultInjector(faultInjector).
+		Build()
+	require.Equal(t, faultInjector, domain.FaultInjector)
+
+	nseRegistryClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx,
+		registryclient.WithClientURL(sandbox.CloneURL(domain.Nodes[0].NSMgr.URL)),
+		registryclient.WithDialOptions(sandbox.DialOptions()...))
+
+	faultInjector.SetLatency(domain.Nodes[0].NSMgr.URL, domain.Registry.URL, 2*time.Second)
+
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+
+	_, err := nseRegistryClient.Register(requestCtx, &registry.NetworkServiceEndpoint{
+		Name: "nse",
+		Url:  "tcp://127.0.0.1:5000",
+	})
+	require.Error(t, err)
+
+	faultInjector.Reset(domain.Nodes[0].NSMgr.URL, domain.Registry.URL)
+
+	_, err = nseRegistryClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name: "nse",
+		Url:  "tcp://127.0.0.1:5000",
+	})
+	require.NoError(t, err)
+}
@@ -26,15 +26,17 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/tracing"
 )
 
 func serve(ctx context.Context, t *testing.T, u *url.URL, register func(server *grpc.Server)) {
-	server := grpc.NewServer(append([]grpc.ServerOption{
+	// The chain elements use the clock from the Builder context, so the tests can inject the fake one
+	server := grpc.NewServer(append(append([]grpc.ServerOption{
 		grpc.Creds(grpcfdTransportCredentials(insecure.NewCredentials())),
-	}, tracing.WithTracing()...)...)
+	}, clock.WithServerClock(clock.FromContext(ctx))...), tracing.WithTracing()...)...)
 	register(server)
 
 	errCh := grpcutils.ListenAndServe(ctx, u, server)
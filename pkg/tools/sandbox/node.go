@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
@@ -44,7 +45,8 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
-// Node is a NSMgr with Forwarder, NSE registry clients
+// Node is a NSMgr with Forwarder, NSE registry clients. All the node components send the traffic as the node NSMgr, so
+// the node faults are injected with the NSMgr URL as a source.
 type Node struct {
 	t      *testing.T
 	domain *Domain
@@ -65,7 +67,7 @@ func (n *Node) NewNSMgr(
 		serveURL = n.domain.supplyURL("nsmgr")
 	}
 
-	dialOptions := DialOptions(WithTokenGenerator(generatorFunc))
+	dialOptions := DialOptions(WithTokenGenerator(generatorFunc), WithFaultInjector(n.domain.FaultInjector, serveURL))
 
 	options := []nsmgr.Option{
 		nsmgr.WithName(name),
@@ -117,7 +119,7 @@ func (n *Node) NewForwarder(
 	}
 
 	nseClone := nse.Clone()
-	dialOptions := DialOptions(WithTokenGenerator(generatorFunc))
+	dialOptions := DialOptions(WithTokenGenerator(generatorFunc), WithFaultInjector(n.domain.FaultInjector, n.NSMgr.URL))
 
 	entry := &EndpointEntry{
 		Name: nse.Name,
@@ -193,7 +195,7 @@ func (n *Node) NewEndpoint(
 	}
 
 	nseClone := nse.Clone()
-	dialOptions := DialOptions(WithTokenGenerator(generatorFunc))
+	dialOptions := DialOptions(WithTokenGenerator(generatorFunc), WithFaultInjector(n.domain.FaultInjector, n.NSMgr.URL))
 
 	entry := &EndpointEntry{
 		Name: nse.Name,
@@ -243,7 +245,7 @@ func (n *Node) NewClient(
 ) networkservice.NetworkServiceClient {
 	opts := []client.Option{
 		client.WithClientURL(CloneURL(n.NSMgr.URL)),
-		client.WithDialOptions(DialOptions(WithTokenGenerator(generatorFunc))...),
+		client.WithDialOptions(DialOptions(WithTokenGenerator(generatorFunc), WithFaultInjector(n.domain.FaultInjector, n.NSMgr.URL))...),
 		client.WithAuthorizeClient(authorize.NewClient(authorize.Any())),
 		client.WithHealClient(heal.NewClient(ctx)),
 		client.WithDialTimeout(DialTimeout),
@@ -255,3 +257,18 @@ func (n *Node) NewClient(
 		opts...,
 	))
 }
+
+// DisconnectFrom drops the traffic from the node components to the target
+func (n *Node) DisconnectFrom(target *url.URL) {
+	n.domain.FaultInjector.Disconnect(n.NSMgr.URL, target)
+}
+
+// SetLatencyTo delays the traffic from the node components to the target
+func (n *Node) SetLatencyTo(target *url.URL, latency time.Duration) {
+	n.domain.FaultInjector.SetLatency(n.NSMgr.URL, target, latency)
+}
+
+// ReconnectTo removes all the faults injected into the traffic from the node components to the target
+func (n *Node) ReconnectTo(target *url.URL) {
+	n.domain.FaultInjector.Reset(n.NSMgr.URL, target)
+}
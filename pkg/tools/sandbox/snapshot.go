@@ -0,0 +1,151 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	registryapi "github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+
+	registryclient "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/tools/inspect"
+)
+
+// Snapshot is a state of the Domain: network services and endpoints registered in the domain registry and
+// connections requested by the clients of the domain nodes
+type Snapshot struct {
+	NetworkServices         []*registryapi.NetworkService
+	NetworkServiceEndpoints []*registryapi.NetworkServiceEndpoint
+	// Connections are the client connections indexed the same way as Domain.Nodes
+	Connections [][]*networkservice.Connection
+}
+
+// Snapshot captures the Domain state
+func (d *Domain) Snapshot(ctx context.Context) (*Snapshot, error) {
+	snapshot := new(Snapshot)
+
+	if registryURL := d.registryURL(); registryURL != nil {
+		cc, err := inspect.Dial(ctx, registryURL, DialOptions()...)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = cc.Close() }()
+
+		if snapshot.NetworkServices, err = inspect.ListNetworkServices(ctx, cc, nil); err != nil {
+			return nil, err
+		}
+		if snapshot.NetworkServiceEndpoints, err = inspect.ListEndpoints(ctx, cc, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, node := range d.Nodes {
+		conns, err := node.clientConnections(ctx)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Connections = append(snapshot.Connections, conns)
+	}
+
+	return snapshot, nil
+}
+
+// Restore re-creates the snapshot state in the Domain: registers the network services and endpoints and requests the
+// connections by the new clients of the same nodes keeping the connection IDs. The registrations are refreshed and
+// the connections are healed until ctx is done.
+func (d *Domain) Restore(ctx context.Context, snapshot *Snapshot) error {
+	if registryURL := d.registryURL(); registryURL != nil {
+		nsClient := registryclient.NewNetworkServiceRegistryClient(ctx,
+			registryclient.WithClientURL(registryURL),
+			registryclient.WithDialOptions(DialOptions()...))
+		for _, ns := range snapshot.NetworkServices {
+			if _, err := nsClient.Register(ctx, ns.Clone()); err != nil {
+				return errors.Wrapf(err, "failed to restore network service %s", ns.GetName())
+			}
+		}
+
+		nseClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx,
+			registryclient.WithClientURL(CloneURL(registryURL)),
+			registryclient.WithDialOptions(DialOptions()...))
+		for _, nse := range snapshot.NetworkServiceEndpoints {
+			nse = nse.Clone()
+			nse.ExpirationTime = nil
+			if _, err := nseClient.Register(ctx, nse); err != nil {
+				return errors.Wrapf(err, "failed to restore network service endpoint %s", nse.GetName())
+			}
+		}
+	}
+
+	for i, conns := range snapshot.Connections {
+		if len(conns) == 0 {
+			continue
+		}
+		if i >= len(d.Nodes) {
+			return errors.Errorf("failed to restore connections: node %d doesn't exist", i)
+		}
+		nsc := d.Nodes[i].NewClient(ctx, GenerateTestToken)
+		for _, conn := range conns {
+			if _, err := nsc.Request(ctx, restoreRequest(conn)); err != nil {
+				return errors.Wrapf(err, "failed to restore connection %s", conn.GetId())
+			}
+		}
+	}
+
+	return nil
+}
+
+// clientConnections returns the connections requested by the node clients, the connections requested by the remote
+// NSMgrs are restored by their own nodes
+func (n *Node) clientConnections(ctx context.Context) ([]*networkservice.Connection, error) {
+	cc, err := inspect.Dial(ctx, n.NSMgr.URL, DialOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cc.Close() }()
+
+	conns, err := inspect.ListConnections(ctx, cc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rv []*networkservice.Connection
+	for _, conn := range conns {
+		if conn.GetPath().GetIndex() == 1 {
+			rv = append(rv, conn.Clone())
+		}
+	}
+	return rv, nil
+}
+
+func restoreRequest(conn *networkservice.Connection) *networkservice.NetworkServiceRequest {
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:                         conn.GetPath().GetPathSegments()[0].GetId(),
+			NetworkService:             conn.GetNetworkService(),
+			NetworkServiceEndpointName: conn.GetNetworkServiceEndpointName(),
+			Payload:                    conn.GetPayload(),
+			Context:                    conn.GetContext(),
+			Labels:                     conn.GetLabels(),
+		},
+	}
+	if conn.GetMechanism() != nil {
+		request.MechanismPreferences = []*networkservice.Mechanism{conn.GetMechanism()}
+	}
+	return request
+}
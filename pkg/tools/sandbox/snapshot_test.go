@@ -0,0 +1,90 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/chains/client"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func TestDomain_SnapshotRestore(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	domain := sandbox.NewBuilder(ctx, t).
+		SetNodesCount(1).
+		SetNSMgrProxySupplier(nil).
+		SetRegistryProxySupplier(nil).
+		Build()
+
+	nsReg, err := domain.NewNSRegistryClient(ctx, sandbox.GenerateTestToken).Register(ctx, &registry.NetworkService{
+		Name: "ns",
+	})
+	require.NoError(t, err)
+
+	domain.Nodes[0].NewEndpoint(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse",
+		NetworkServiceNames: []string{nsReg.Name},
+	}, sandbox.GenerateTestToken)
+
+	nsc := domain.Nodes[0].NewClient(ctx, sandbox.GenerateTestToken, client.WithHealClient(null.NewClient()))
+
+	conn, err := nsc.Request(ctx, newFaultRequest(nsReg.Name))
+	require.NoError(t, err)
+
+	snapshot, err := domain.Snapshot(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, snapshot.NetworkServices, 1)
+	require.Equal(t, nsReg.Name, snapshot.NetworkServices[0].Name)
+	var nseNames []string
+	for _, nse := range snapshot.NetworkServiceEndpoints {
+		nseNames = append(nseNames, nse.Name)
+	}
+	require.Contains(t, nseNames, "nse")
+	require.Len(t, snapshot.Connections, 1)
+	require.Len(t, snapshot.Connections[0], 1)
+	require.Equal(t, conn.GetId(), snapshot.Connections[0][0].GetPath().GetPathSegments()[0].GetId())
+
+	// Registry and NSMgr lose their state on restart
+	domain.Registry.Restart()
+	domain.Nodes[0].NSMgr.Restart()
+
+	restored, err := domain.Snapshot(ctx)
+	require.NoError(t, err)
+	require.Empty(t, restored.NetworkServices)
+	require.Empty(t, restored.Connections[0])
+
+	require.NoError(t, domain.Restore(ctx, snapshot))
+
+	restored, err = domain.Snapshot(ctx)
+	require.NoError(t, err)
+	require.Len(t, restored.NetworkServices, 1)
+	require.Len(t, restored.Connections[0], 1)
+	require.Equal(t, conn.GetId(), restored.Connections[0][0].GetPath().GetPathSegments()[0].GetId())
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/adapters"
+	registryadapters "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+)
+
+// Transport is a transport the tested chain is called over
+type Transport string
+
+const (
+	// TransportUnix calls the chain over gRPC on the unix socket, file descriptors are passed
+	TransportUnix Transport = "unix"
+	// TransportTCP calls the chain over gRPC on the TCP socket
+	TransportTCP Transport = "tcp"
+	// TransportInProcess calls the chain directly with the adapters
+	TransportInProcess Transport = "inprocess"
+)
+
+// Transports returns all the transports available on the current OS
+func Transports() []Transport {
+	if runtime.GOOS == "windows" {
+		return []Transport{TransportTCP, TransportInProcess}
+	}
+	return []Transport{TransportUnix, TransportTCP, TransportInProcess}
+}
+
+// RunTransports runs f as a subtest for each of the Transports, so the same scenario is tested over all of them
+func RunTransports(t *testing.T, f func(t *testing.T, transport Transport)) {
+	for _, transport := range Transports() {
+		transport := transport
+		t.Run(string(transport), func(t *testing.T) {
+			f(t, transport)
+		})
+	}
+}
+
+// NewClient returns a client calling the server over the transport. gRPC server and connection are closed on ctx done.
+func (tr Transport) NewClient(ctx context.Context, t *testing.T, server networkservice.NetworkServiceServer, opts ...grpc.DialOption) networkservice.NetworkServiceClient {
+	if tr == TransportInProcess {
+		return adapters.NewServerToClient(server)
+	}
+	cc := tr.serveAndDial(ctx, t, func(s *grpc.Server) {
+		networkservice.RegisterNetworkServiceServer(s, server)
+	}, opts...)
+	return networkservice.NewNetworkServiceClient(cc)
+}
+
+// NewNSERegistryClient returns a registry client calling the server over the transport. gRPC server and connection
+// are closed on ctx done.
+func (tr Transport) NewNSERegistryClient(ctx context.Context, t *testing.T, server registry.NetworkServiceEndpointRegistryServer, opts ...grpc.DialOption) registry.NetworkServiceEndpointRegistryClient {
+	if tr == TransportInProcess {
+		return registryadapters.NetworkServiceEndpointServerToClient(server)
+	}
+	cc := tr.serveAndDial(ctx, t, func(s *grpc.Server) {
+		registry.RegisterNetworkServiceEndpointRegistryServer(s, server)
+	}, opts...)
+	return registry.NewNetworkServiceEndpointRegistryClient(cc)
+}
+
+func (tr Transport) serveAndDial(ctx context.Context, t *testing.T, register func(s *grpc.Server), opts ...grpc.DialOption) *grpc.ClientConn {
+	u := tr.supplyURL(ctx, t)
+	serve(ctx, t, u, register)
+
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), append(DialOptions(), append(opts, grpc.WithBlock())...)...)
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		_ = cc.Close()
+	}()
+
+	return cc
+}
+
+func (tr Transport) supplyURL(ctx context.Context, t *testing.T) *url.URL {
+	switch tr {
+	case TransportUnix:
+		// t.TempDir() can exceed the unix socket path length limit
+		sockPath, err := os.MkdirTemp("", "nsm-transport")
+		require.NoError(t, err)
+		go func() {
+			<-ctx.Done()
+			_ = os.RemoveAll(sockPath)
+		}()
+		return &url.URL{Scheme: "unix", Path: filepath.Join(sockPath, "server.sock")}
+	case TransportTCP:
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { _ = l.Close() }()
+		return grpcutils.AddressToURL(l.Addr())
+	default:
+		require.FailNowf(t, "unknown transport", "%s", tr)
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+)
+
+func TestRunTransports(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	var transports []sandbox.Transport
+	sandbox.RunTransports(t, func(t *testing.T, transport sandbox.Transport) {
+		transports = append(transports, transport)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		counter := new(count.Server)
+		client := transport.NewClient(ctx, t, counter)
+
+		conn, err := client.Request(ctx, &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "id"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "id", conn.GetId())
+
+		_, err = client.Close(ctx, conn)
+		require.NoError(t, err)
+		require.Equal(t, 1, counter.Requests())
+		require.Equal(t, 1, counter.Closes())
+
+		registryClient := transport.NewNSERegistryClient(ctx, t, memory.NewNetworkServiceEndpointRegistryServer())
+
+		_, err = registryClient.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse"})
+		require.NoError(t, err)
+
+		stream, err := registryClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		})
+		require.NoError(t, err)
+		require.Len(t, registry.ReadNetworkServiceEndpointList(stream), 1)
+	})
+	require.Equal(t, sandbox.Transports(), transports)
+}
@@ -82,21 +82,17 @@ type Domain struct {
 	Registry      *RegistryEntry
 	RegistryProxy *RegistryEntry
 
-	DNSResolver dnsresolve.Resolver
-	Name        string
+	DNSResolver   dnsresolve.Resolver
+	FaultInjector *FaultInjector
+	Name          string
 
 	supplyURL func(prefix string) *url.URL
 }
 
 // NewNSRegistryClient creates new NS registry client for the domain
 func (d *Domain) NewNSRegistryClient(ctx context.Context, generatorFunc token.GeneratorFunc) registryapi.NetworkServiceRegistryClient {
-	var registryURL *url.URL
-	switch {
-	case d.Registry != nil:
-		registryURL = CloneURL(d.Registry.URL)
-	case len(d.Nodes) != 0:
-		registryURL = CloneURL(d.Nodes[0].NSMgr.URL)
-	default:
+	registryURL := d.registryURL()
+	if registryURL == nil {
 		return nil
 	}
 
@@ -104,3 +100,14 @@ func (d *Domain) NewNSRegistryClient(ctx context.Context, generatorFunc token.Ge
 		registryclient.WithClientURL(registryURL),
 		registryclient.WithDialOptions(DialOptions(WithTokenGenerator(generatorFunc))...))
 }
+
+func (d *Domain) registryURL() *url.URL {
+	switch {
+	case d.Registry != nil:
+		return CloneURL(d.Registry.URL)
+	case len(d.Nodes) != 0:
+		return CloneURL(d.Nodes[0].NSMgr.URL)
+	default:
+		return nil
+	}
+}
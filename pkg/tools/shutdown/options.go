@@ -0,0 +1,46 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shutdown
+
+import "time"
+
+// Option is an option pattern for New
+type Option func(c *Cleanup)
+
+// WithTimeout sets the timeout of each operation attempt
+// By default 15 seconds
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Cleanup) {
+		c.timeout = timeout
+	}
+}
+
+// WithAttempts sets the maximum number of each operation attempts
+// By default 3
+func WithAttempts(attempts int) Option {
+	return func(c *Cleanup) {
+		c.attempts = attempts
+	}
+}
+
+// WithRetryInterval sets the interval between the operation attempts
+// By default 200 milliseconds
+func WithRetryInterval(retryInterval time.Duration) Option {
+	return func(c *Cleanup) {
+		c.retryInterval = retryInterval
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shutdown provides a helper collecting the pending cleanup operations (closing connections, unregistering
+// NSEs) and executing them on shutdown with fresh contexts detached from the canceled main context, bounded retries
+// and a final report, so the cleanup is not aborted mid-way leaving stale state behind.
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/extend"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	defaultTimeout       = 15 * time.Second
+	defaultAttempts      = 3
+	defaultRetryInterval = 200 * time.Millisecond
+)
+
+// Operation is a cleanup operation
+type Operation func(ctx context.Context) error
+
+// Result is the result of a cleanup operation
+type Result struct {
+	Name     string
+	Attempts int
+	Err      error
+}
+
+// Report is the result of all the cleanup operations sorted by name
+type Report struct {
+	Results []*Result
+}
+
+// Err returns the error combining the errors of the failed operations or nil if all of them succeeded
+func (r *Report) Err() error {
+	var failed []string
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.Name, result.Err.Error()))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Errorf("%d of %d cleanup operations failed: %s", len(failed), len(r.Results), strings.Join(failed, "; "))
+}
+
+// Cleanup collects the pending cleanup operations
+type Cleanup struct {
+	timeout       time.Duration
+	attempts      int
+	retryInterval time.Duration
+
+	operations map[string]Operation
+	mu         sync.Mutex
+}
+
+// New creates a new Cleanup
+func New(opts ...Option) *Cleanup {
+	c := &Cleanup{
+		timeout:       defaultTimeout,
+		attempts:      defaultAttempts,
+		retryInterval: defaultRetryInterval,
+		operations:    make(map[string]Operation),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Add adds the operation replacing the previously added one with the same name. The returned function removes the
+// operation, it should be called when the cleanup is done by the application itself.
+func (c *Cleanup) Add(name string, operation Operation) (remove func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.operations[name] = operation
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		delete(c.operations, name)
+	}
+}
+
+// AddClose adds the operation closing the connection with the client
+func (c *Cleanup) AddClose(client networkservice.NetworkServiceClient, conn *networkservice.Connection) (remove func()) {
+	conn = conn.Clone()
+	return c.Add("close connection "+conn.GetId(), func(ctx context.Context) error {
+		_, err := client.Close(ctx, conn)
+		return err
+	})
+}
+
+// AddUnregister adds the operation unregistering the NSE with the client
+func (c *Cleanup) AddUnregister(client registry.NetworkServiceEndpointRegistryClient, nse *registry.NetworkServiceEndpoint) (remove func()) {
+	nse = nse.Clone()
+	return c.Add("unregister nse "+nse.GetName(), func(ctx context.Context) error {
+		_, err := client.Unregister(ctx, nse)
+		return err
+	})
+}
+
+// Run executes all the pending operations concurrently and removes them. Each operation attempt gets a fresh context
+// having the values of ctx, but not its cancellation, with the timeout.
+func (c *Cleanup) Run(ctx context.Context) *Report {
+	c.mu.Lock()
+	operations := c.operations
+	c.operations = make(map[string]Operation)
+	c.mu.Unlock()
+
+	report := new(Report)
+	var wg sync.WaitGroup
+	for name, operation := range operations {
+		result := &Result{Name: name}
+		report.Results = append(report.Results, result)
+
+		wg.Add(1)
+		go func(operation Operation) {
+			defer wg.Done()
+			c.run(ctx, operation, result)
+		}(operation)
+	}
+	wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool { return report.Results[i].Name < report.Results[j].Name })
+
+	logger := log.FromContext(ctx).WithField("shutdown", "Run")
+	for _, result := range report.Results {
+		if result.Err != nil {
+			logger.Errorf("%s failed after %d attempts: %s", result.Name, result.Attempts, result.Err.Error())
+		}
+	}
+
+	return report
+}
+
+// RunOnDone runs the operations when ctx is done and sends the report to the returned channel
+func (c *Cleanup) RunOnDone(ctx context.Context) <-chan *Report {
+	reportCh := make(chan *Report, 1)
+	go func() {
+		<-ctx.Done()
+		reportCh <- c.Run(ctx)
+		close(reportCh)
+	}()
+	return reportCh
+}
+
+func (c *Cleanup) run(ctx context.Context, operation Operation, result *Result) {
+	clockTime := clock.FromContext(ctx)
+	for result.Attempts < c.attempts {
+		if result.Attempts > 0 {
+			clockTime.Sleep(c.retryInterval)
+		}
+		result.Attempts++
+
+		attemptCtx, cancel := clockTime.WithTimeout(extend.WithValuesFromContext(context.Background(), ctx), c.timeout)
+		result.Err = operation(attemptCtx)
+		cancel()
+
+		if result.Err == nil {
+			return
+		}
+	}
+}
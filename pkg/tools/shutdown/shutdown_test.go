@@ -0,0 +1,87 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shutdown_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/checks/checkcontext"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/count"
+	"github.com/networkservicemesh/sdk/pkg/tools/shutdown"
+)
+
+func TestCleanup_RunOnDone(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	counter := new(count.Client)
+	client := chain.NewNetworkServiceClient(
+		checkcontext.NewClient(t, func(t *testing.T, ctx context.Context) {
+			require.NoError(t, ctx.Err())
+		}),
+		counter,
+	)
+
+	cleanup := shutdown.New(shutdown.WithRetryInterval(time.Millisecond))
+	cleanup.AddClose(client, &networkservice.Connection{Id: "1"})
+	remove := cleanup.AddClose(client, &networkservice.Connection{Id: "2"})
+	remove()
+
+	var flakyAttempts int
+	cleanup.Add("flaky", func(ctx context.Context) error {
+		if flakyAttempts++; flakyAttempts < 3 {
+			return errors.New("flaky error")
+		}
+		return nil
+	})
+	cleanup.Add("failing", func(ctx context.Context) error {
+		return errors.New("failing error")
+	})
+
+	reportCh := cleanup.RunOnDone(ctx)
+	cancel()
+
+	report := <-reportCh
+	require.Equal(t, 1, counter.Closes())
+	require.Len(t, report.Results, 3)
+
+	require.Equal(t, "close connection 1", report.Results[0].Name)
+	require.Equal(t, 1, report.Results[0].Attempts)
+	require.NoError(t, report.Results[0].Err)
+
+	require.Equal(t, "failing", report.Results[1].Name)
+	require.Equal(t, 3, report.Results[1].Attempts)
+	require.Error(t, report.Results[1].Err)
+
+	require.Equal(t, "flaky", report.Results[2].Name)
+	require.Equal(t, 3, report.Results[2].Attempts)
+	require.NoError(t, report.Results[2].Err)
+
+	require.EqualError(t, report.Err(), "1 of 3 cleanup operations failed: failing: failing error")
+
+	// The operations are executed once
+	require.Empty(t, cleanup.Run(ctx).Results)
+}
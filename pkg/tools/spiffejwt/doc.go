@@ -14,5 +14,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package spiffejwt provides a token.GeneratorFunc for spiffe jwt tokens signed by x509vids
+// Package spiffejwt provides a token.GeneratorFunc for spiffe jwt tokens signed by x509vids and authorization
+// policies binding these tokens to the audience, checking their expiration and protecting them from replay.
+//
+// AudiencePolicy, ExpirationPolicy and ReplayPolicy are not part of the default authorize policies, they should be explicitly added with
+// authorize.WithPolicies on the servers requiring them.
 package spiffejwt
@@ -0,0 +1,155 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockskew"
+)
+
+// AudiencePolicy checks that the previous path segment token is issued for the current component (audience claim
+// contains own SPIFFE ID).
+type AudiencePolicy struct {
+	source x509svid.Source
+}
+
+// NewAudiencePolicy returns a new AudiencePolicy. It can be used as authorize.Policy.
+func NewAudiencePolicy(source x509svid.Source) *AudiencePolicy {
+	return &AudiencePolicy{
+		source: source,
+	}
+}
+
+// Check returns nil if the previous path segment token is issued for the current component
+func (p *AudiencePolicy) Check(_ context.Context, input interface{}) error {
+	claims, err := prevTokenClaims(input)
+	if err != nil {
+		return err
+	}
+
+	ownSVID, err := p.source.GetX509SVID()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !claims.VerifyAudience(ownSVID.ID.String(), true) {
+		return status.Errorf(codes.PermissionDenied, "token is not issued for %s", ownSVID.ID.String())
+	}
+	return nil
+}
+
+// ExpirationPolicy checks that the previous path segment token is not expired taking the max clock skew between
+// components into account. It also warns if the token issue time implies the issuer clock is ahead.
+type ExpirationPolicy struct{}
+
+// NewExpirationPolicy returns a new ExpirationPolicy. It can be used as authorize.Policy.
+func NewExpirationPolicy() *ExpirationPolicy {
+	return new(ExpirationPolicy)
+}
+
+// Check returns nil if the previous path segment token is not expired
+func (p *ExpirationPolicy) Check(ctx context.Context, input interface{}) error {
+	claims, err := prevTokenClaims(input)
+	if err != nil {
+		return err
+	}
+	if claims.IssuedAt != nil {
+		clockskew.CheckIssuedAt(ctx, claims.Subject, claims.IssuedAt.Time)
+	}
+	if claims.ExpiresAt != nil && clockskew.Expired(ctx, claims.ExpiresAt.Time) {
+		return status.Errorf(codes.PermissionDenied, "token expired at %s", claims.ExpiresAt.Time)
+	}
+	return nil
+}
+
+// ReplayPolicy checks that the previous path segment token has never been seen before. Token IDs are kept in the
+// cache until the token expiration, tokens without expiration are rejected.
+type ReplayPolicy struct {
+	clock  clock.Clock
+	nonces map[string]time.Time
+	mu     sync.Mutex
+}
+
+// NewReplayPolicy returns a new ReplayPolicy. It can be used as authorize.Policy.
+func NewReplayPolicy(ctx context.Context) *ReplayPolicy {
+	return &ReplayPolicy{
+		clock:  clock.FromContext(ctx),
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// Check returns nil if the previous path segment token has an ID which has never been seen before
+func (p *ReplayPolicy) Check(_ context.Context, input interface{}) error {
+	claims, err := prevTokenClaims(input)
+	if err != nil {
+		return err
+	}
+	if claims.ID == "" {
+		return status.Error(codes.PermissionDenied, "token has no ID")
+	}
+	// Nonces are kept until the token expiration, so tokens without expiration could be replayed after the eviction
+	if claims.ExpiresAt == nil {
+		return status.Error(codes.PermissionDenied, "token has no expiration time")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	for id, expireTime := range p.nonces {
+		if !expireTime.After(now) {
+			delete(p.nonces, id)
+		}
+	}
+
+	if _, ok := p.nonces[claims.ID]; ok {
+		return status.Errorf(codes.PermissionDenied, "token %s has been already used", claims.ID)
+	}
+
+	// Token is accepted by the peers with the clock behind until the expiration extended by the max skew
+	p.nonces[claims.ID] = claims.ExpiresAt.Time.Add(clockskew.MaxSkew())
+
+	return nil
+}
+
+func prevTokenClaims(input interface{}) (*jwt.RegisteredClaims, error) {
+	path, ok := input.(*networkservice.Path)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected input type: %T", input)
+	}
+
+	index := int(path.GetIndex()) - 1
+	if index < 0 || index >= len(path.GetPathSegments()) {
+		return nil, status.Error(codes.PermissionDenied, "no previous path segment")
+	}
+
+	claims := new(jwt.RegisteredClaims)
+	if _, _, err := new(jwt.Parser).ParseUnverified(path.GetPathSegments()[index].GetToken(), claims); err != nil {
+		return nil, status.Error(codes.PermissionDenied, errors.Wrap(err, "failed to parse token").Error())
+	}
+	return claims, nil
+}
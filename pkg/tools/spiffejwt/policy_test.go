@@ -0,0 +1,120 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffejwt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockmock"
+	"github.com/networkservicemesh/sdk/pkg/tools/clockskew"
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+)
+
+type svidSource struct {
+	id spiffeid.ID
+}
+
+func (s *svidSource) GetX509SVID() (*x509svid.SVID, error) {
+	return &x509svid.SVID{ID: s.id}, nil
+}
+
+func pathWithToken(t *testing.T, claims *jwt.RegisteredClaims) *networkservice.Path {
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("key"))
+	require.NoError(t, err)
+	return &networkservice.Path{
+		Index: 1,
+		PathSegments: []*networkservice.PathSegment{
+			{Token: tok},
+			{},
+		},
+	}
+}
+
+func TestAudiencePolicy(t *testing.T) {
+	policy := spiffejwt.NewAudiencePolicy(&svidSource{id: spiffeid.RequireFromString("spiffe://test.com/nsmgr")})
+
+	require.NoError(t, policy.Check(context.Background(), pathWithToken(t, &jwt.RegisteredClaims{
+		Audience: []string{"spiffe://test.com/nsmgr"},
+	})))
+	require.Error(t, policy.Check(context.Background(), pathWithToken(t, &jwt.RegisteredClaims{
+		Audience: []string{"spiffe://test.com/forwarder"},
+	})))
+	require.Error(t, policy.Check(context.Background(), pathWithToken(t, &jwt.RegisteredClaims{})))
+}
+
+func TestReplayPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	policy := spiffejwt.NewReplayPolicy(ctx)
+
+	path := pathWithToken(t, &jwt.RegisteredClaims{
+		ID:        "nonce-1",
+		ExpiresAt: jwt.NewNumericDate(clockMock.Now().Add(time.Minute)),
+	})
+
+	require.NoError(t, policy.Check(ctx, path))
+	require.Error(t, policy.Check(ctx, path))
+
+	require.NoError(t, policy.Check(ctx, pathWithToken(t, &jwt.RegisteredClaims{
+		ID:        "nonce-2",
+		ExpiresAt: jwt.NewNumericDate(clockMock.Now().Add(time.Minute)),
+	})))
+	require.Error(t, policy.Check(ctx, pathWithToken(t, &jwt.RegisteredClaims{})))
+	require.Error(t, policy.Check(ctx, pathWithToken(t, &jwt.RegisteredClaims{ID: "nonce-3"})))
+
+	clockMock.Add(time.Hour)
+	require.NoError(t, policy.Check(ctx, path))
+}
+
+func TestExpirationPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clockMock := clockmock.New(ctx)
+	ctx = clock.WithClock(ctx, clockMock)
+
+	policy := spiffejwt.NewExpirationPolicy()
+
+	path := pathWithToken(t, &jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(clockMock.Now()),
+		ExpiresAt: jwt.NewNumericDate(clockMock.Now().Add(time.Minute)),
+	})
+	require.NoError(t, policy.Check(ctx, path))
+
+	clockMock.Add(time.Minute + time.Second)
+	require.Error(t, policy.Check(ctx, path))
+
+	clockskew.SetMaxSkew(5 * time.Second)
+	t.Cleanup(func() { clockskew.SetMaxSkew(0) })
+	require.NoError(t, policy.Check(ctx, path))
+
+	clockMock.Add(5 * time.Second)
+	require.Error(t, policy.Check(ctx, path))
+}
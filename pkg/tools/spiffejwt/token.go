@@ -20,22 +20,29 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/networkservicemesh/sdk/pkg/tools/fips"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 )
 
 // TokenGeneratorFunc - creates a token.TokenGeneratorFunc that creates spiffe JWT tokens from the cert returned by getCert()
+// Each token has an unique ID (nonce) and is bound to the peer SPIFFE ID (audience) if the peer is known.
 func TokenGeneratorFunc(source x509svid.Source, maxTokenLifeTime time.Duration) token.GeneratorFunc {
 	return func(authInfo credentials.AuthInfo) (string, time.Time, error) {
 		ownSVID, err := source.GetX509SVID()
 		if err != nil {
 			return "", time.Time{}, errors.Wrap(err, "Error creating Token")
 		}
+		if err = fips.ValidateKey(ownSVID.PrivateKey.Public()); err != nil {
+			return "", time.Time{}, err
+		}
 
-		expireTime := time.Now().Add(maxTokenLifeTime)
+		now := time.Now()
+		expireTime := now.Add(maxTokenLifeTime)
 		if ownSVID.Certificates[0].NotAfter.Before(expireTime) {
 			expireTime = ownSVID.Certificates[0].NotAfter
 		}
@@ -43,7 +50,9 @@ func TokenGeneratorFunc(source x509svid.Source, maxTokenLifeTime time.Duration)
 			return "", time.Time{}, errors.Wrap(err, "Error creating Token")
 		}
 		claims := jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Subject:   ownSVID.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expireTime),
 		}
 		if authInfo != nil {
@@ -0,0 +1,186 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package switchablecreds provides grpc transport credentials for the components starting before their SVID is
+// available. The credentials start in the insecure mode and are atomically switched to the SPIFFE mTLS ones when the
+// SVID arrives, so there is no startup ordering dependency between SPIRE and NSM components:
+//
+//	creds := switchablecreds.New()
+//	server := grpc.NewServer(append(switchablecreds.ServerOptions(creds), opts...)...)
+//	go func() {
+//		source, err := workloadapi.NewX509Source(ctx)
+//		...
+//		creds.Switch(credentials.NewTLS(tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny())))
+//	}()
+//
+// The server options allow only the registration calls on the insecure connections, so the component can register
+// itself while waiting for the SVID. All the insecure connections are closed on switch, so the peers reconnect with
+// mTLS.
+package switchablecreds
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/edwarnicke/grpcfd"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Credentials is grpc transport credentials switchable from the insecure mode to the secure ones
+type Credentials struct {
+	insecure credentials.TransportCredentials
+
+	mu       sync.RWMutex
+	secure   credentials.TransportCredentials
+	conns    map[*trackedConn]struct{}
+	switched chan struct{}
+}
+
+// New creates new Credentials in the insecure mode
+func New() *Credentials {
+	return &Credentials{
+		insecure: insecure.NewCredentials(),
+		conns:    make(map[*trackedConn]struct{}),
+		switched: make(chan struct{}),
+	}
+}
+
+// Switch atomically switches c to the secure creds. The following handshakes use creds, all the insecure connections
+// are closed. Switch can be called again to rotate the secure creds, established connections are not affected then.
+func (c *Credentials) Switch(creds credentials.TransportCredentials) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secure == nil {
+		close(c.switched)
+	}
+	c.secure = creds
+
+	for conn := range c.conns {
+		_ = conn.Conn.Close()
+	}
+	c.conns = make(map[*trackedConn]struct{})
+}
+
+// Secure returns true if c is switched to the secure creds
+func (c *Credentials) Secure() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.secure != nil
+}
+
+// Switched returns a channel closed when c is switched to the secure creds
+func (c *Credentials) Switched() <-chan struct{} {
+	return c.switched
+}
+
+func (c *Credentials) current() (creds credentials.TransportCredentials, secure bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.secure != nil {
+		return c.secure, true
+	}
+	return c.insecure, false
+}
+
+// ClientHandshake does the handshake with the current creds
+func (c *Credentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	creds, secure := c.current()
+	conn, authInfo, err := creds.ClientHandshake(ctx, authority, rawConn)
+	if err != nil || secure {
+		return conn, authInfo, err
+	}
+	if conn, err = c.track(conn); err != nil {
+		return nil, nil, err
+	}
+	return conn, authInfo, nil
+}
+
+// ServerHandshake does the handshake with the current creds
+func (c *Credentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	creds, secure := c.current()
+	conn, authInfo, err := creds.ServerHandshake(rawConn)
+	if err != nil || secure {
+		return conn, authInfo, err
+	}
+	if conn, err = c.track(conn); err != nil {
+		return nil, nil, err
+	}
+	return conn, authInfo, nil
+}
+
+// Info returns the protocol info of the current creds
+func (c *Credentials) Info() credentials.ProtocolInfo {
+	creds, _ := c.current()
+	return creds.Info()
+}
+
+// Clone returns c itself, because all the copies should be switched together
+func (c *Credentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+// OverrideServerName overrides the server name of the current creds
+//
+// Deprecated: use grpc.WithAuthority instead.
+func (c *Credentials) OverrideServerName(serverName string) error {
+	creds, _ := c.current()
+	//nolint:staticcheck
+	return creds.OverrideServerName(serverName)
+}
+
+func (c *Credentials) track(conn net.Conn) (net.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// c has been switched during the handshake
+	if c.secure != nil {
+		_ = conn.Close()
+		return nil, errors.New("credentials have been switched to the secure ones during the insecure handshake")
+	}
+
+	tracked := &trackedConn{Conn: conn, c: c}
+	c.conns[tracked] = struct{}{}
+
+	// Keep the fd passing capability of the grpcfd wrapped connections
+	if transceiver, ok := conn.(grpcfd.FDTransceiver); ok {
+		return &trackedFDConn{trackedConn: tracked, FDTransceiver: transceiver}, nil
+	}
+	return tracked, nil
+}
+
+type trackedConn struct {
+	net.Conn
+	c *Credentials
+}
+
+func (t *trackedConn) Close() error {
+	t.c.mu.Lock()
+	delete(t.c.conns, t)
+	t.c.mu.Unlock()
+
+	return t.Conn.Close()
+}
+
+type trackedFDConn struct {
+	*trackedConn
+	grpcfd.FDTransceiver
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package switchablecreds_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/tools/switchablecreds"
+)
+
+func newTLSConfigs(t *testing.T) (server, client *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	server = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+	client = &tls.Config{
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+	}
+	return server, client
+}
+
+func TestSwitchableCredentials(t *testing.T) {
+	t.Cleanup(func() { goleak.VerifyNone(t) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	serverCreds := switchablecreds.New()
+	server := grpc.NewServer(switchablecreds.ServerOptions(serverCreds)...)
+	registry.RegisterNetworkServiceEndpointRegistryServer(server, memory.NewNetworkServiceEndpointRegistryServer())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	clientCreds := switchablecreds.New()
+	cc, err := grpc.DialContext(ctx, l.Addr().String(), grpc.WithTransportCredentials(clientCreds))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	client := registry.NewNetworkServiceEndpointRegistryClient(cc)
+	find := func() error {
+		stream, findErr := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse"},
+		}, grpc.WaitForReady(true))
+		if findErr != nil {
+			return findErr
+		}
+		_, findErr = stream.Recv()
+		return findErr
+	}
+
+	// Insecure mode: only the registration is allowed
+	require.False(t, clientCreds.Secure())
+	_, err = client.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse"}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Code(find()))
+
+	serverTLSConfig, clientTLSConfig := newTLSConfigs(t)
+	serverCreds.Switch(credentials.NewTLS(serverTLSConfig))
+	clientCreds.Switch(credentials.NewTLS(clientTLSConfig))
+
+	select {
+	case <-clientCreds.Switched():
+	default:
+		require.FailNow(t, "client credentials are not switched")
+	}
+	require.True(t, serverCreds.Secure())
+	require.Equal(t, "tls", clientCreds.Info().SecurityProtocol)
+
+	// The insecure connection is closed, the client reconnects with TLS
+	require.Eventually(t, func() bool {
+		return find() == nil
+	}, time.Second*5, time.Millisecond*50)
+}
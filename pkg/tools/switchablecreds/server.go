@@ -0,0 +1,85 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package switchablecreds
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultAllowedMethods are the registration methods allowed on the insecure connections by default
+var DefaultAllowedMethods = []string{
+	"/registry.NetworkServiceEndpointRegistry/Register",
+	"/registry.NetworkServiceRegistry/Register",
+}
+
+// UnaryServerInterceptor returns a server interceptor rejecting the unary calls not from the allowedMethods (or
+// DefaultAllowedMethods if not set) on the insecure connections
+func UnaryServerInterceptor(allowedMethods ...string) grpc.UnaryServerInterceptor {
+	if len(allowedMethods) == 0 {
+		allowedMethods = DefaultAllowedMethods
+	}
+	allowed := make(map[string]struct{}, len(allowedMethods))
+	for _, method := range allowedMethods {
+		allowed[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := allowed[info.FullMethod]; !ok && isInsecure(ctx) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed on the insecure connection", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor rejecting all the streams on the insecure connections
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isInsecure(ss.Context()) {
+			return status.Errorf(codes.PermissionDenied, "%s is not allowed on the insecure connection", info.FullMethod)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// ServerOptions returns server options serving with creds and restricting the insecure connections to the
+// allowedMethods (or DefaultAllowedMethods if not set)
+func ServerOptions(creds *Credentials, allowedMethods ...string) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(allowedMethods...)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor()),
+	}
+}
+
+func isInsecure(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return true
+	}
+	if commonAuthInfo, ok := p.AuthInfo.(interface {
+		GetCommonAuthInfo() credentials.CommonAuthInfo
+	}); ok && commonAuthInfo.GetCommonAuthInfo().SecurityLevel != credentials.InvalidSecurityLevel {
+		return commonAuthInfo.GetCommonAuthInfo().SecurityLevel == credentials.NoSecurity
+	}
+	return p.AuthInfo.AuthType() == "insecure"
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenancy provides the tenant context shared by the chain elements partitioning the resources (IPAM pools,
+// tunnel IDs, registry storage) per tenant, the tenant derivation from the peer identity or labels and the per-tenant
+// quotas.
+package tenancy
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefaultTenant is the tenant of the requests with no tenant derived
+	DefaultTenant = ""
+	// LabelKey is the default connection label key of the tenant
+	LabelKey = "tenant"
+	// MetadataKey is the grpc metadata key of the tenant used by the registry clients
+	MetadataKey = "nsm-tenant"
+)
+
+type contextKeyType string
+
+const tenantKey contextKeyType = "Tenant"
+
+// WithTenant returns a new context with the tenant
+func WithTenant(parent context.Context, tenant string) context.Context {
+	if parent == nil {
+		panic("cannot create context from nil parent")
+	}
+	return context.WithValue(parent, tenantKey, tenant)
+}
+
+// FromContext returns the tenant from ctx or DefaultTenant if it is not set
+func FromContext(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey).(string); ok {
+		return tenant
+	}
+	return DefaultTenant
+}
+
+// WithOutgoingTenant returns a new context sending the tenant in the outgoing grpc metadata, so the server can derive
+// the tenant from it if it is not derived from the identity
+func WithOutgoingTenant(ctx context.Context, tenant string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, tenant)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrQuotaExceeded is returned when the tenant has no free resources left
+var ErrQuotaExceeded = errors.New("tenant quota exceeded")
+
+// Quota limits the number of resources allocated per tenant
+type Quota struct {
+	defaultLimit int
+	limits       map[string]int
+
+	mu   sync.Mutex
+	used map[string]int
+}
+
+// QuotaOption is an option pattern for NewQuota
+type QuotaOption func(q *Quota)
+
+// WithDefaultLimit sets the limit of the tenants with no own limit. Limit <= 0 means no limit, default is no limit.
+func WithDefaultLimit(limit int) QuotaOption {
+	return func(q *Quota) {
+		q.defaultLimit = limit
+	}
+}
+
+// WithLimit sets the limit of the tenant. Limit <= 0 means no limit.
+func WithLimit(tenant string, limit int) QuotaOption {
+	return func(q *Quota) {
+		q.limits[tenant] = limit
+	}
+}
+
+// NewQuota creates a new Quota
+func NewQuota(opts ...QuotaOption) *Quota {
+	q := &Quota{
+		limits: make(map[string]int),
+		used:   make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// Acquire allocates a resource for the tenant. It returns ErrQuotaExceeded if the tenant limit is reached.
+func (q *Quota) Acquire(tenant string) error {
+	if q == nil {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	limit, ok := q.limits[tenant]
+	if !ok {
+		limit = q.defaultLimit
+	}
+	if limit > 0 && q.used[tenant] >= limit {
+		return errors.Wrapf(ErrQuotaExceeded, "tenant %q has reached the limit of %d", tenant, limit)
+	}
+	q.used[tenant]++
+	return nil
+}
+
+// Release frees a resource allocated for the tenant
+func (q *Quota) Release(tenant string) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.used[tenant] <= 1 {
+		delete(q.used, tenant)
+		return
+	}
+	q.used[tenant]--
+}
+
+// Used returns the number of resources allocated for the tenant
+func (q *Quota) Used(tenant string) int {
+	if q == nil {
+		return 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.used[tenant]
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spire"
+)
+
+// IdentityFunc returns the tenant of the SPIFFE ID or DefaultTenant if the ID doesn't belong to any tenant
+type IdentityFunc func(id spiffeid.ID) string
+
+// FirstPathSegment is an IdentityFunc returning the first SPIFFE ID path segment, e.g. "tenant-a" for
+// "spiffe://example.org/tenant-a/nsc"
+func FirstPathSegment(id spiffeid.ID) string {
+	segments := strings.SplitN(strings.TrimPrefix(id.Path(), "/"), "/", 2)
+	if len(segments) < 2 {
+		return DefaultTenant
+	}
+	return segments[0]
+}
+
+// Resolver derives the tenant from the peer identity or labels. The identity is authenticated, so if the identity
+// func is set and returns the tenant, labels are not used.
+type Resolver struct {
+	identityFunc IdentityFunc
+	labelKey     string
+}
+
+// ResolverOption is an option pattern for NewResolver
+type ResolverOption func(r *Resolver)
+
+// WithIdentity sets the func deriving the tenant from the peer SPIFFE ID
+func WithIdentity(identityFunc IdentityFunc) ResolverOption {
+	return func(r *Resolver) {
+		r.identityFunc = identityFunc
+	}
+}
+
+// WithLabelKey sets the label key of the tenant. Default is LabelKey, empty key disables the labels.
+func WithLabelKey(labelKey string) ResolverOption {
+	return func(r *Resolver) {
+		r.labelKey = labelKey
+	}
+}
+
+// NewResolver creates a new Resolver
+func NewResolver(opts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		labelKey: LabelKey,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the tenant derived from the peer identity in ctx or from labels
+func (r *Resolver) Resolve(ctx context.Context, labels map[string]string) string {
+	if r.identityFunc != nil {
+		if id, err := spire.SpiffeIDFromContext(ctx); err == nil {
+			if tenant := r.identityFunc(id); tenant != DefaultTenant {
+				return tenant
+			}
+		}
+	}
+	if r.labelKey != "" {
+		return labels[r.labelKey]
+	}
+	return DefaultTenant
+}
+
+// ResolveIncoming returns the tenant derived from the peer identity in ctx or from the incoming grpc metadata
+func (r *Resolver) ResolveIncoming(ctx context.Context) string {
+	var labels map[string]string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			labels = map[string]string{r.labelKey: values[0]}
+		}
+	}
+	return r.Resolve(ctx, labels)
+}
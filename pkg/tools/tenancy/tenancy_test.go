@@ -0,0 +1,73 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/tenancy"
+)
+
+func TestQuota(t *testing.T) {
+	quota := tenancy.NewQuota(tenancy.WithDefaultLimit(1), tenancy.WithLimit("tenant-a", 2), tenancy.WithLimit("tenant-b", 0))
+
+	require.NoError(t, quota.Acquire("tenant-a"))
+	require.NoError(t, quota.Acquire("tenant-a"))
+	require.True(t, errors.Is(quota.Acquire("tenant-a"), tenancy.ErrQuotaExceeded))
+
+	require.NoError(t, quota.Acquire("tenant-c"))
+	require.True(t, errors.Is(quota.Acquire("tenant-c"), tenancy.ErrQuotaExceeded))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, quota.Acquire("tenant-b"))
+	}
+
+	quota.Release("tenant-a")
+	require.Equal(t, 1, quota.Used("tenant-a"))
+	require.NoError(t, quota.Acquire("tenant-a"))
+
+	var nilQuota *tenancy.Quota
+	require.NoError(t, nilQuota.Acquire("tenant-a"))
+}
+
+func TestFirstPathSegment(t *testing.T) {
+	require.Equal(t, "tenant-a", tenancy.FirstPathSegment(spiffeid.RequireFromString("spiffe://example.org/tenant-a/nsc")))
+	require.Equal(t, tenancy.DefaultTenant, tenancy.FirstPathSegment(spiffeid.RequireFromString("spiffe://example.org/nsmgr")))
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+
+	require.Equal(t, "tenant-a", tenancy.NewResolver().Resolve(ctx, map[string]string{tenancy.LabelKey: "tenant-a"}))
+	require.Equal(t, tenancy.DefaultTenant, tenancy.NewResolver(tenancy.WithLabelKey("")).Resolve(ctx, map[string]string{tenancy.LabelKey: "tenant-a"}))
+	require.Equal(t, "tenant-b", tenancy.NewResolver(tenancy.WithLabelKey("team")).Resolve(ctx, map[string]string{"team": "tenant-b"}))
+
+	// No peer identity: the labels are used
+	resolver := tenancy.NewResolver(tenancy.WithIdentity(tenancy.FirstPathSegment))
+	incomingCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(tenancy.MetadataKey, "tenant-c"))
+	require.Equal(t, "tenant-c", resolver.ResolveIncoming(incomingCtx))
+	require.Equal(t, tenancy.DefaultTenant, resolver.ResolveIncoming(ctx))
+
+	outgoing, _ := metadata.FromOutgoingContext(tenancy.WithOutgoingTenant(ctx, "tenant-d"))
+	require.Equal(t, []string{"tenant-d"}, outgoing.Get(tenancy.MetadataKey))
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tunnelid provides an allocator for the tunnel IDs (VXLAN VNIs, Geneve VNIs, GRE keys) scoped per remote
+// tunnel IP, with conflict detection and optional persistence, shared by the mechanism chain elements.
+package tunnelid
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const maxAllocateAttempts = 1000
+
+// Kind describes the tunnel ID space
+type Kind struct {
+	Name string
+	Min  uint32
+	Max  uint32
+}
+
+var (
+	// VXLAN - VXLAN VNIs, 24 bits
+	VXLAN = Kind{Name: "vxlan", Min: 1, Max: 1<<24 - 1}
+	// Geneve - Geneve VNIs, 24 bits
+	Geneve = Kind{Name: "geneve", Min: 1, Max: 1<<24 - 1}
+	// GRE - GRE keys, 32 bits
+	GRE = Kind{Name: "gre", Min: 1, Max: 1<<32 - 1}
+)
+
+// ErrConflict is returned when the tunnel ID is already allocated for the remote IP by another owner
+var ErrConflict = errors.New("tunnel ID is already allocated by another owner")
+
+type scope struct {
+	remote string
+	id     uint32
+}
+
+// Allocator allocates the tunnel IDs unique per remote IP
+type Allocator struct {
+	kind   Kind
+	path   string
+	owners map[scope]string
+	mu     sync.Mutex
+}
+
+// Option is an option pattern for NewAllocator
+type Option func(a *Allocator)
+
+// WithPersistence makes the allocator store the allocations in the file at path and restore them on creation, so
+// the tunnel IDs of the existing tunnels are not reallocated after the restart
+func WithPersistence(path string) Option {
+	return func(a *Allocator) {
+		a.path = path
+	}
+}
+
+// NewAllocator creates a new Allocator for the tunnel IDs of the kind
+func NewAllocator(kind Kind, opts ...Option) (*Allocator, error) {
+	a := &Allocator{
+		kind:   kind,
+		owners: make(map[scope]string),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Allocate allocates a random free tunnel ID for the remote IP accepted by accept (nil accepts any ID)
+func (a *Allocator) Allocate(remote net.IP, owner string, accept func(id uint32) bool) (uint32, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := 0; i < maxAllocateAttempts; i++ {
+		// #nosec
+		id := a.kind.Min + uint32(rand.Int63n(int64(a.kind.Max)-int64(a.kind.Min)+1))
+		if accept != nil && !accept(id) {
+			continue
+		}
+		k := scope{remote: remote.String(), id: id}
+		if _, ok := a.owners[k]; ok {
+			continue
+		}
+		a.owners[k] = owner
+		if err := a.save(); err != nil {
+			delete(a.owners, k)
+			return 0, err
+		}
+		return id, nil
+	}
+	return 0, errors.Errorf("failed to allocate %s tunnel ID for %s", a.kind.Name, remote)
+}
+
+// Reserve reserves the tunnel ID chosen by the other side for the remote IP. It returns ErrConflict if the ID is
+// already allocated by another owner, repeated reservations by the same owner are allowed.
+func (a *Allocator) Reserve(remote net.IP, id uint32, owner string) error {
+	if id < a.kind.Min || id > a.kind.Max {
+		return errors.Errorf("%s tunnel ID %d is out of range [%d, %d]", a.kind.Name, id, a.kind.Min, a.kind.Max)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := scope{remote: remote.String(), id: id}
+	if current, ok := a.owners[k]; ok {
+		if current != owner {
+			return errors.Wrapf(ErrConflict, "%s tunnel ID %d for %s", a.kind.Name, id, remote)
+		}
+		return nil
+	}
+	a.owners[k] = owner
+	if err := a.save(); err != nil {
+		delete(a.owners, k)
+		return err
+	}
+	return nil
+}
+
+// Release releases the tunnel ID allocated for the remote IP if it is owned by the owner
+func (a *Allocator) Release(remote net.IP, id uint32, owner string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := scope{remote: remote.String(), id: id}
+	if current, ok := a.owners[k]; !ok || current != owner {
+		return nil
+	}
+	delete(a.owners, k)
+	return a.save()
+}
+
+type record struct {
+	Remote string `json:"remote"`
+	ID     uint32 `json:"id"`
+	Owner  string `json:"owner"`
+}
+
+type state struct {
+	Kind    string    `json:"kind"`
+	Records []*record `json:"records"`
+}
+
+func (a *Allocator) load() error {
+	if a.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(a.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", a.path)
+	}
+	s := new(state)
+	if err = json.Unmarshal(data, s); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", a.path)
+	}
+	if s.Kind != a.kind.Name {
+		return errors.Errorf("%s contains %s tunnel IDs, expected %s", a.path, s.Kind, a.kind.Name)
+	}
+	for _, r := range s.Records {
+		k := scope{remote: r.Remote, id: r.ID}
+		if current, ok := a.owners[k]; ok && current != r.Owner {
+			return errors.Wrapf(ErrConflict, "%s contains %s tunnel ID %d for %s twice", a.path, a.kind.Name, r.ID, r.Remote)
+		}
+		a.owners[k] = r.Owner
+	}
+	return nil
+}
+
+func (a *Allocator) save() error {
+	if a.path == "" {
+		return nil
+	}
+	s := &state{Kind: a.kind.Name}
+	for k, owner := range a.owners {
+		s.Records = append(s.Records, &record{Remote: k.remote, ID: k.id, Owner: owner})
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal tunnel IDs")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(a.path), filepath.Base(a.path)+".*")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for %s", a.path)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to write %s", tmp.Name())
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmp.Name())
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), a.path), "failed to replace %s", a.path)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2022 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnelid_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/tunnelid"
+)
+
+func TestAllocator_ScopedPerRemote(t *testing.T) {
+	a, err := tunnelid.NewAllocator(tunnelid.Kind{Name: "test", Min: 1, Max: 2})
+	require.NoError(t, err)
+
+	remote1, remote2 := net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2")
+
+	id1, err := a.Allocate(remote1, "conn-1", nil)
+	require.NoError(t, err)
+	id2, err := a.Allocate(remote1, "conn-2", nil)
+	require.NoError(t, err)
+	require.NotEqual(t, id1, id2)
+
+	_, err = a.Allocate(remote1, "conn-3", nil)
+	require.Error(t, err)
+
+	// Other remote has its own space
+	_, err = a.Allocate(remote2, "conn-3", func(id uint32) bool { return id == id1 })
+	require.NoError(t, err)
+
+	// Only the owner releases the ID
+	require.NoError(t, a.Release(remote1, id1, "conn-2"))
+	require.True(t, errors.Is(a.Reserve(remote1, id1, "conn-3"), tunnelid.ErrConflict))
+	require.NoError(t, a.Reserve(remote1, id1, "conn-1"))
+
+	require.NoError(t, a.Release(remote1, id1, "conn-1"))
+	require.NoError(t, a.Reserve(remote1, id1, "conn-3"))
+
+	require.Error(t, a.Reserve(remote1, 3, "conn-4"))
+}
+
+func TestAllocator_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vni.json")
+	remote := net.ParseIP("192.0.2.1")
+
+	a, err := tunnelid.NewAllocator(tunnelid.VXLAN, tunnelid.WithPersistence(path))
+	require.NoError(t, err)
+	id, err := a.Allocate(remote, "conn-1", nil)
+	require.NoError(t, err)
+
+	// Allocation survives restart
+	a, err = tunnelid.NewAllocator(tunnelid.VXLAN, tunnelid.WithPersistence(path))
+	require.NoError(t, err)
+	require.True(t, errors.Is(a.Reserve(remote, id, "conn-2"), tunnelid.ErrConflict))
+
+	require.NoError(t, a.Release(remote, id, "conn-1"))
+	a, err = tunnelid.NewAllocator(tunnelid.VXLAN, tunnelid.WithPersistence(path))
+	require.NoError(t, err)
+	require.NoError(t, a.Reserve(remote, id, "conn-2"))
+
+	_, err = tunnelid.NewAllocator(tunnelid.GRE, tunnelid.WithPersistence(path))
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = tunnelid.NewAllocator(tunnelid.VXLAN, tunnelid.WithPersistence(path))
+	require.Error(t, err)
+}